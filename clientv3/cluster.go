@@ -25,6 +25,9 @@ type (
 	MemberAddResponse    pb.MemberAddResponse
 	MemberRemoveResponse pb.MemberRemoveResponse
 	MemberUpdateResponse pb.MemberUpdateResponse
+
+	MemberPromoteResponse     pb.MemberPromoteResponse
+	MemberReconfigureResponse pb.MemberReconfigureResponse
 )
 
 type Cluster interface {
@@ -34,11 +37,27 @@ type Cluster interface {
 	// MemberAdd adds a new member into the cluster.
 	MemberAdd(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
 
+	// MemberAddAsLearner adds a new learner member into the cluster. The
+	// learner does not vote until it is promoted.
+	MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
+
+	// MemberAddAsWitness adds a new witness member into the cluster. The
+	// witness votes but keeps no application state.
+	MemberAddAsWitness(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
+
 	// MemberRemove removes an existing member from the cluster.
 	MemberRemove(ctx context.Context, id uint64) (*MemberRemoveResponse, error)
 
 	// MemberUpdate updates the peer addresses of the member.
 	MemberUpdate(ctx context.Context, id uint64, peerAddrs []string) (*MemberUpdateResponse, error)
+
+	// MemberPromote promotes a learner member to a voting member.
+	MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error)
+
+	// MemberReconfigure adds a member for each of the given lists of peer
+	// addresses and removes the given members in a single change of the
+	// cluster configuration.
+	MemberReconfigure(ctx context.Context, addPeerAddrs [][]string, removeIDs []uint64) (*MemberReconfigureResponse, error)
 }
 
 type cluster struct {
@@ -50,7 +69,18 @@ func NewCluster(c *Client) Cluster {
 }
 
 func (c *cluster) MemberAdd(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
-	r := &pb.MemberAddRequest{PeerURLs: peerAddrs}
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs})
+}
+
+func (c *cluster) MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs, IsLearner: true})
+}
+
+func (c *cluster) MemberAddAsWitness(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs, IsWitness: true})
+}
+
+func (c *cluster) memberAdd(ctx context.Context, r *pb.MemberAddRequest) (*MemberAddResponse, error) {
 	resp, err := c.remote.MemberAdd(ctx, r)
 	if err == nil {
 		return (*MemberAddResponse)(resp), nil
@@ -87,6 +117,27 @@ func (c *cluster) MemberUpdate(ctx context.Context, id uint64, peerAddrs []strin
 	}
 }
 
+func (c *cluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error) {
+	r := &pb.MemberPromoteRequest{ID: id}
+	resp, err := c.remote.MemberPromote(ctx, r)
+	if err == nil {
+		return (*MemberPromoteResponse)(resp), nil
+	}
+	return nil, toErr(ctx, err)
+}
+
+func (c *cluster) MemberReconfigure(ctx context.Context, addPeerAddrs [][]string, removeIDs []uint64) (*MemberReconfigureResponse, error) {
+	r := &pb.MemberReconfigureRequest{Remove: removeIDs}
+	for _, peerAddrs := range addPeerAddrs {
+		r.Add = append(r.Add, &pb.MemberAddRequest{PeerURLs: peerAddrs})
+	}
+	resp, err := c.remote.MemberReconfigure(ctx, r)
+	if err == nil {
+		return (*MemberReconfigureResponse)(resp), nil
+	}
+	return nil, toErr(ctx, err)
+}
+
 func (c *cluster) MemberList(ctx context.Context) (*MemberListResponse, error) {
 	// it is safe to retry on list.
 	for {
@@ -15,6 +15,7 @@
 package integration
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -453,3 +454,93 @@ func TestLeaseKeepAliveTTLTimeout(t *testing.T) {
 
 	clus.Members[0].Restart(t)
 }
+
+// TestLeaseTimeToLive tests TimeToLive is served by every member, forwarding
+// to the leader when needed, and reports the attached keys on request.
+func TestLeaseTimeToLive(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lapi := clientv3.NewLease(clus.RandClient())
+	defer lapi.Close()
+
+	resp, err := lapi.Grant(context.Background(), 10)
+	if err != nil {
+		t.Errorf("failed to create lease %v", err)
+	}
+
+	kv := clientv3.NewKV(clus.RandClient())
+	keys := []string{"foo1", "foo2"}
+	for i := range keys {
+		if _, err = kv.Put(context.TODO(), keys[i], "bar", clientv3.WithLease(resp.ID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		le := clientv3.NewLease(clus.Client(i))
+		lresp, lerr := le.TimeToLive(context.Background(), resp.ID, clientv3.WithAttachedKeys())
+		le.Close()
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+		if lresp.ID != resp.ID {
+			t.Fatalf("leaseID expected %d, got %d", resp.ID, lresp.ID)
+		}
+		if lresp.GrantedTTL != int64(10) {
+			t.Fatalf("GrantedTTL expected %d, got %d", 10, lresp.GrantedTTL)
+		}
+		if lresp.TTL == 0 || lresp.TTL > lresp.GrantedTTL {
+			t.Fatalf("unexpected TTL %d (granted %d)", lresp.TTL, lresp.GrantedTTL)
+		}
+		ks := make([]string, len(lresp.Keys))
+		for j := range lresp.Keys {
+			ks[j] = string(lresp.Keys[j])
+		}
+		if !reflect.DeepEqual(ks, keys) {
+			t.Fatalf("keys expected %v, got %v", keys, ks)
+		}
+	}
+
+	if _, err = lapi.TimeToLive(context.Background(), clientv3.LeaseID(500)); err != rpctypes.ErrLeaseNotFound {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrLeaseNotFound, err)
+	}
+}
+
+func TestLeaseLeases(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lapi := clientv3.NewLease(clus.RandClient())
+	defer lapi.Close()
+
+	ids := []clientv3.LeaseID{}
+	for i := 0; i < 5; i++ {
+		resp, err := lapi.Grant(context.Background(), 10)
+		if err != nil {
+			t.Errorf("failed to create lease %v", err)
+		}
+		ids = append(ids, resp.ID)
+	}
+
+	resp, err := lapi.Leases(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Leases) != 5 {
+		t.Fatalf("len(resp.Leases) expected 5, got %d", len(resp.Leases))
+	}
+	found := make(map[clientv3.LeaseID]bool)
+	for _, l := range resp.Leases {
+		found[l.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			t.Fatalf("lease %x not listed", id)
+		}
+	}
+}
@@ -152,3 +152,51 @@ func TestTxnSuccess(t *testing.T) {
 		t.Fatalf("unexpected Get response %v", resp)
 	}
 }
+
+func TestTxnNested(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clientv3.NewKV(clus.Client(0))
+	ctx := context.TODO()
+
+	if _, err := kv.Put(ctx, "foo1", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kv.Put(ctx, "foo2", "bar"); err != nil {
+		t.Fatal(err)
+	}
+
+	nested := clientv3.OpTxn(
+		[]clientv3.Cmp{clientv3.Compare(clientv3.Value("foo").WithPrefix(), "=", "bar")},
+		[]clientv3.Op{clientv3.OpPut("foo3", "bar")},
+		[]clientv3.Op{clientv3.OpPut("foo3", "baz")},
+	)
+	tresp, err := kv.Txn(ctx).If(
+		clientv3.Compare(clientv3.Version("foo").WithPrefix(), "=", 1),
+	).Then(nested, clientv3.OpGet("foo", clientv3.WithPrefix())).Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tresp.Succeeded || len(tresp.Responses) != 2 {
+		t.Fatalf("unexpected txn response %+v", tresp)
+	}
+	if nresp := tresp.Responses[0].GetResponseTxn(); nresp == nil || !nresp.Succeeded {
+		t.Fatalf("unexpected nested txn response %+v", nresp)
+	}
+	// the get in the same txn sees the put of the nested txn
+	if gresp := tresp.Responses[1].GetResponseRange(); gresp == nil || len(gresp.Kvs) != 3 {
+		t.Fatalf("unexpected get response %+v", gresp)
+	}
+
+	// a txn op can also be issued on its own
+	dresp, err := kv.Do(ctx, nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dresp.Txn().Succeeded {
+		t.Fatalf("unexpected nested txn response %+v", dresp.Txn())
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+// LeaseOp represents a lease operation.
+type LeaseOp struct {
+	id LeaseID
+
+	// for TimeToLive
+	attachedKeys bool
+}
+
+// LeaseOption configures lease operation.
+type LeaseOption func(*LeaseOp)
+
+func (op *LeaseOp) applyLeaseOpts(opts []LeaseOption) {
+	for _, opt := range opts {
+		opt(op)
+	}
+}
+
+// OpLease wraps slice LeaseOption to create a LeaseOp.
+func OpLease(id LeaseID, opts ...LeaseOption) LeaseOp {
+	ret := LeaseOp{id: id}
+	ret.applyLeaseOpts(opts)
+	return ret
+}
+
+func (op LeaseOp) toTimeToLiveRequest() *pb.LeaseTimeToLiveRequest {
+	return &pb.LeaseTimeToLiveRequest{ID: int64(op.id), Keys: op.attachedKeys}
+}
+
+// WithAttachedKeys makes TimeToLive also return the keys attached
+// to the lease.
+func WithAttachedKeys() LeaseOption {
+	return func(op *LeaseOp) { op.attachedKeys = true }
+}
@@ -26,6 +26,7 @@ type (
 	AlarmResponse      pb.AlarmResponse
 	AlarmMember        pb.AlarmMember
 	StatusResponse     pb.StatusResponse
+	MoveLeaderResponse pb.MoveLeaderResponse
 )
 
 type Maintenance interface {
@@ -49,6 +50,11 @@ type Maintenance interface {
 
 	// Snapshot provides a reader for a snapshot of a backend.
 	Snapshot(ctx context.Context) (io.ReadCloser, error)
+
+	// MoveLeader requests the member with given endpoint, which must be the
+	// leader, to transfer its leadership to the member with transfereeID.
+	// It returns once the transferee is elected.
+	MoveLeader(ctx context.Context, endpoint string, transfereeID uint64) (*MoveLeaderResponse, error)
 }
 
 type maintenance struct {
@@ -135,6 +141,20 @@ func (m *maintenance) Status(ctx context.Context, endpoint string) (*StatusRespo
 	return (*StatusResponse)(resp), nil
 }
 
+func (m *maintenance) MoveLeader(ctx context.Context, endpoint string, transfereeID uint64) (*MoveLeaderResponse, error) {
+	conn, err := m.c.Dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer conn.Close()
+	remote := pb.NewMaintenanceClient(conn)
+	resp, err := remote.MoveLeader(ctx, &pb.MoveLeaderRequest{TargetID: transfereeID})
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*MoveLeaderResponse)(resp), nil
+}
+
 func (m *maintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	ss, err := m.remote.Snapshot(ctx, &pb.SnapshotRequest{})
 	if err != nil {
@@ -0,0 +1,57 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestGetOpFilters(t *testing.T) {
+	req1 := OpGet("foo", WithPrefix(), WithMinModRev(2), WithMaxModRev(5), WithMinCreateRev(1), WithMaxCreateRev(3), WithContinue([]byte("tok"))).toRangeRequest()
+	req2 := &etcdserverpb.RangeRequest{
+		Key:               []byte("foo"),
+		RangeEnd:          []byte("fop"),
+		MinModRevision:    2,
+		MaxModRevision:    5,
+		MinCreateRevision: 1,
+		MaxCreateRevision: 3,
+		ContinueToken:     []byte("tok"),
+	}
+	if !reflect.DeepEqual(req1, req2) {
+		t.Fatalf("expected %+v, got %+v", req2, req1)
+	}
+}
+
+func TestTxnOp(t *testing.T) {
+	cmp := Compare(ModRevision("foo").WithPrefix(), ">", 1)
+	req1 := OpTxn([]Cmp{cmp}, []Op{OpPut("foo", "bar")}, nil).toRequestOp()
+	req2 := &etcdserverpb.RequestOp{Request: &etcdserverpb.RequestOp_RequestTxn{RequestTxn: &etcdserverpb.TxnRequest{
+		Compare: []*etcdserverpb.Compare{{
+			Result:      etcdserverpb.Compare_GREATER,
+			Target:      etcdserverpb.Compare_MOD,
+			Key:         []byte("foo"),
+			RangeEnd:    []byte("fop"),
+			TargetUnion: &etcdserverpb.Compare_ModRevision{ModRevision: 1},
+		}},
+		Success: []*etcdserverpb.RequestOp{{Request: &etcdserverpb.RequestOp_RequestPut{RequestPut: &etcdserverpb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}}}},
+		Failure: []*etcdserverpb.RequestOp{},
+	}}}
+	if !reflect.DeepEqual(req1, req2) {
+		t.Fatalf("expected %+v, got %+v", req2, req1)
+	}
+}
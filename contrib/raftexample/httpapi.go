@@ -20,10 +20,16 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
 )
 
+// barrierTimeout bounds how long a consistent GET waits for its barrier.
+const barrierTimeout = 5 * time.Second
+
 // Handler for a http based key-value store backed by raft
 type httpKVAPI struct {
 	store       *kvstore
@@ -47,6 +53,18 @@ func (h *httpKVAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		// committed so a subsequent GET on the key may return old value
 		w.WriteHeader(http.StatusNoContent)
 	case r.Method == "GET":
+		if r.URL.RawQuery == "consistent" {
+			// wait for the updates committed before the GET to be applied
+			key = strings.TrimSuffix(key, "?consistent")
+			ctx, cancel := context.WithTimeout(context.Background(), barrierTimeout)
+			err := h.store.Barrier(ctx)
+			cancel()
+			if err != nil {
+				log.Printf("Failed to apply barrier on GET (%v)\n", err)
+				http.Error(w, "Failed to GET", http.StatusServiceUnavailable)
+				return
+			}
+		}
 		if v, ok := h.store.Lookup(key); ok {
 			w.Write([]byte(v))
 		} else {
@@ -103,7 +121,7 @@ func (h *httpKVAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // serveHttpKVAPI starts a key-value server with a GET/PUT API and listens.
 func serveHttpKVAPI(port int, proposeC chan<- string, confChangeC chan<- raftpb.ConfChange,
-	commitC <-chan *string, errorC <-chan error) {
+	barrierC chan<- chan struct{}, commitC <-chan *commit, errorC <-chan error) {
 
 	// exit when raft goes down
 	go func() {
@@ -116,7 +134,7 @@ func serveHttpKVAPI(port int, proposeC chan<- string, confChangeC chan<- raftpb.
 	srv := http.Server{
 		Addr: ":" + strconv.Itoa(port),
 		Handler: &httpKVAPI{
-			store:       newKVStore(proposeC, commitC, errorC),
+			store:       newKVStore(proposeC, barrierC, commitC, errorC),
 			confChangeC: confChangeC,
 		},
 	}
@@ -19,11 +19,14 @@ import (
 	"encoding/gob"
 	"log"
 	"sync"
+
+	"golang.org/x/net/context"
 )
 
 // a key-value store backed by raft
 type kvstore struct {
-	proposeC chan<- string // channel for proposing updates
+	proposeC chan<- string        // channel for proposing updates
+	barrierC chan<- chan struct{} // channel for proposing barriers
 	mu       sync.RWMutex
 	kvStore  map[string]string // current committed key-value pairs
 }
@@ -33,8 +36,8 @@ type kv struct {
 	Val string
 }
 
-func newKVStore(proposeC chan<- string, commitC <-chan *string, errorC <-chan error) *kvstore {
-	s := &kvstore{proposeC: proposeC, kvStore: make(map[string]string)}
+func newKVStore(proposeC chan<- string, barrierC chan<- chan struct{}, commitC <-chan *commit, errorC <-chan error) *kvstore {
+	s := &kvstore{proposeC: proposeC, barrierC: barrierC, kvStore: make(map[string]string)}
 	// replay log into key-value map
 	s.readCommits(commitC, errorC)
 	// read commits from raft into kvStore map until error
@@ -57,15 +60,37 @@ func (s *kvstore) Propose(k string, v string) {
 	s.proposeC <- string(buf.Bytes())
 }
 
-func (s *kvstore) readCommits(commitC <-chan *string, errorC <-chan error) {
-	for data := range commitC {
-		if data == nil {
+// Barrier waits until every update proposed through the store before it is
+// applied, or until ctx is done.
+func (s *kvstore) Barrier(ctx context.Context) error {
+	appliedC := make(chan struct{})
+	select {
+	case s.barrierC <- appliedC:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-appliedC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *kvstore) readCommits(commitC <-chan *commit, errorC <-chan error) {
+	for c := range commitC {
+		if c == nil {
 			// done replaying log; new data incoming
 			return
 		}
+		if c.appliedC != nil {
+			// the commits before the barrier are applied.
+			close(c.appliedC)
+			continue
+		}
 
 		var dataKv kv
-		dec := gob.NewDecoder(bytes.NewBufferString(*data))
+		dec := gob.NewDecoder(bytes.NewBufferString(c.data))
 		if err := dec.Decode(&dataKv); err != nil {
 			log.Fatalf("raftexample: could not decode message (%v)", err)
 		}
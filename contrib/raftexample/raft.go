@@ -15,46 +15,87 @@
 package main
 
 import (
+	"encoding/binary"
 	"fmt"
 	"log"
-	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"net/http"
 	"net/url"
 
 	"github.com/coreos/etcd/etcdserver/stats"
+	"github.com/coreos/etcd/pkg/idutil"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/raft/walstorage"
 	"github.com/coreos/etcd/rafthttp"
-	"github.com/coreos/etcd/wal"
-	"github.com/coreos/etcd/wal/walpb"
 	"golang.org/x/net/context"
 )
 
+// entryBarrier is the application entry type of the barriers proposed to
+// wait until every update proposed before them is applied. Its entries carry
+// the id of the barrier.
+const entryBarrier = raft.FirstApplicationEntryType
+
+// barrierCodec encodes the ids of barriers.
+type barrierCodec struct{}
+
+func (barrierCodec) Encode(v interface{}) ([]byte, error) {
+	id, ok := v.(uint64)
+	if !ok {
+		return nil, fmt.Errorf("barrier id must be an uint64, got %T", v)
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return b, nil
+}
+
+func (barrierCodec) Decode(data []byte) (interface{}, error) {
+	if len(data) != 8 {
+		return nil, fmt.Errorf("bad barrier id length %d", len(data))
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// commit is published over the commit channel for each committed update,
+// with its data, and for each committed barrier proposed by the node, with
+// the channel to close once every update before it is applied.
+type commit struct {
+	data     string
+	appliedC chan struct{}
+}
+
 // A key-value stream backed by raft
 type raftNode struct {
 	proposeC    <-chan string            // proposed messages (k,v)
 	confChangeC <-chan raftpb.ConfChange // proposed cluster config changes
-	commitC     chan *string             // entries committed to log (k,v)
+	barrierC    <-chan chan struct{}     // proposed barriers
+	commitC     chan *commit             // entries committed to log (k,v)
 	errorC      chan error               // errors from raft session
 
 	id        int      // client ID for raft session
 	peers     []string // raft peer URLs
 	join      bool     // node is joining an existing cluster
-	waldir    string   // path to WAL directory
+	datadir   string   // path to raft storage directory
 	lastIndex uint64   // index of log at start
 
 	// raft backing for the commit/error channel
 	node        raft.Node
-	raftStorage *raft.MemoryStorage
-	wal         *wal.WAL
+	raftStorage *walstorage.Storage
 	transport   *rafthttp.Transport
 	stopc       chan struct{} // signals proposal channel closed
 	httpstopc   chan struct{} // signals http server to shutdown
 	httpdonec   chan struct{} // signals http server shutdown complete
+
+	entryTypes *raft.EntryTypes
+	barrierIDs *idutil.Generator
+	// barriers are the barriers proposed by the node waiting to be
+	// committed, by id.
+	mu       sync.Mutex
+	barriers map[uint64]chan struct{}
 }
 
 // newRaftNode initiates a raft instance and returns a committed log entry
@@ -62,23 +103,33 @@ type raftNode struct {
 // provided the proposal channel. All log entries are replayed over the
 // commit channel, followed by a nil message (to indicate the channel is
 // current), then new log entries. To shutdown, close proposeC and read errorC.
+// The channels sent over barrierC are published over the commit channel once
+// the barriers they were proposed for are committed.
 func newRaftNode(id int, peers []string, join bool, proposeC <-chan string,
-	confChangeC <-chan raftpb.ConfChange) (<-chan *string, <-chan error) {
+	confChangeC <-chan raftpb.ConfChange, barrierC <-chan chan struct{}) (<-chan *commit, <-chan error) {
+
+	entryTypes := raft.NewEntryTypes()
+	if err := entryTypes.Register(entryBarrier, "barrier", barrierCodec{}); err != nil {
+		log.Fatalf("raftexample: register barrier entry type error (%v)", err)
+	}
 
 	rc := &raftNode{
 		proposeC:    proposeC,
 		confChangeC: confChangeC,
-		commitC:     make(chan *string),
+		barrierC:    barrierC,
+		commitC:     make(chan *commit),
 		errorC:      make(chan error),
 		id:          id,
 		peers:       peers,
 		join:        join,
-		waldir:      fmt.Sprintf("raftexample-%d", id),
-		raftStorage: raft.NewMemoryStorage(),
+		datadir:     fmt.Sprintf("raftexample-%d", id),
 		stopc:       make(chan struct{}),
 		httpstopc:   make(chan struct{}),
 		httpdonec:   make(chan struct{}),
-		// rest of structure populated after WAL replay
+		entryTypes:  entryTypes,
+		barrierIDs:  idutil.NewGenerator(uint16(id), time.Now()),
+		barriers:    make(map[uint64]chan struct{}),
+		// rest of structure populated after storage is opened
 	}
 	go rc.startRaft()
 	return rc.commitC, rc.errorC
@@ -94,9 +145,8 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 				// ignore empty messages
 				break
 			}
-			s := string(ents[i].Data)
 			select {
-			case rc.commitC <- &s:
+			case rc.commitC <- &commit{data: string(ents[i].Data)}:
 			case <-rc.stopc:
 				return false
 			}
@@ -117,6 +167,31 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 				}
 				rc.transport.RemovePeer(types.ID(cc.NodeID))
 			}
+
+		case entryBarrier:
+			v, err := rc.entryTypes.Decode(ents[i])
+			if err != nil {
+				log.Fatalf("raftexample: could not decode barrier (%v)", err)
+			}
+			id := v.(uint64)
+			rc.mu.Lock()
+			appliedC, ok := rc.barriers[id]
+			delete(rc.barriers, id)
+			rc.mu.Unlock()
+			if !ok {
+				// proposed by another node.
+				break
+			}
+			select {
+			case rc.commitC <- &commit{appliedC: appliedC}:
+			case <-rc.stopc:
+				return false
+			}
+
+		default:
+			if raft.IsApplicationEntryType(ents[i].Type) {
+				log.Printf("raftexample: ignoring %s", rc.entryTypes.Describe(ents[i]))
+			}
 		}
 
 		// special nil commit to signal replay has finished
@@ -131,45 +206,35 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 	return true
 }
 
-// openWAL returns a WAL ready for reading.
-func (rc *raftNode) openWAL() *wal.WAL {
-	if !wal.Exist(rc.waldir) {
-		if err := os.Mkdir(rc.waldir, 0750); err != nil {
-			log.Fatalf("raftexample: cannot create dir for wal (%v)", err)
-		}
-
-		w, err := wal.Create(rc.waldir, nil)
+// openStorage opens the raft storage, creating it if there is none, and
+// returns whether it already existed.
+func (rc *raftNode) openStorage() bool {
+	if !walstorage.Exist(rc.datadir) {
+		s, err := walstorage.Create(rc.datadir, nil)
 		if err != nil {
-			log.Fatalf("raftexample: create wal error (%v)", err)
+			log.Fatalf("raftexample: create storage error (%v)", err)
 		}
-		w.Close()
+		rc.raftStorage = s
+		rc.commitC <- nil
+		return false
 	}
 
-	w, err := wal.Open(rc.waldir, walpb.Snapshot{})
+	s, err := walstorage.Open(rc.datadir)
 	if err != nil {
-		log.Fatalf("raftexample: error loading wal (%v)", err)
+		log.Fatalf("raftexample: error loading storage (%v)", err)
 	}
-
-	return w
-}
-
-// replayWAL replays WAL entries into the raft instance.
-func (rc *raftNode) replayWAL() *wal.WAL {
-	w := rc.openWAL()
-	_, st, ents, err := w.ReadAll()
+	rc.raftStorage = s
+	// send nil once lastIndex is published so client knows commit channel is current
+	li, err := s.LastIndex()
 	if err != nil {
-		log.Fatalf("raftexample: failed to read WAL (%v)", err)
+		log.Fatalf("raftexample: failed to read storage (%v)", err)
 	}
-	// append to storage so raft starts at the right place in log
-	rc.raftStorage.Append(ents)
-	// send nil once lastIndex is published so client knows commit channel is current
-	if len(ents) > 0 {
-		rc.lastIndex = ents[len(ents)-1].Index
+	if li > 0 {
+		rc.lastIndex = li
 	} else {
 		rc.commitC <- nil
 	}
-	rc.raftStorage.SetHardState(st)
-	return w
+	return true
 }
 
 func (rc *raftNode) writeError(err error) {
@@ -181,8 +246,7 @@ func (rc *raftNode) writeError(err error) {
 }
 
 func (rc *raftNode) startRaft() {
-	oldwal := wal.Exist(rc.waldir)
-	rc.wal = rc.replayWAL()
+	oldstorage := rc.openStorage()
 
 	rpeers := make([]raft.Peer, len(rc.peers))
 	for i := range rpeers {
@@ -197,7 +261,7 @@ func (rc *raftNode) startRaft() {
 		MaxInflightMsgs: 256,
 	}
 
-	if oldwal {
+	if oldstorage {
 		rc.node = raft.RestartNode(c)
 	} else {
 		startPeers := rpeers
@@ -245,7 +309,7 @@ func (rc *raftNode) stopHTTP() {
 }
 
 func (rc *raftNode) serveChannels() {
-	defer rc.wal.Close()
+	defer rc.raftStorage.Close()
 
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -272,6 +336,21 @@ func (rc *raftNode) serveChannels() {
 					cc.ID = confChangeCount
 					rc.node.ProposeConfChange(context.TODO(), cc)
 				}
+
+			case appliedC := <-rc.barrierC:
+				id := rc.barrierIDs.Next()
+				e, err := rc.entryTypes.Encode(entryBarrier, id)
+				if err != nil {
+					log.Fatalf("raftexample: could not encode barrier (%v)", err)
+				}
+				rc.mu.Lock()
+				rc.barriers[id] = appliedC
+				rc.mu.Unlock()
+				if err := rc.node.ProposeEntry(context.TODO(), e.Type, e.Data); err != nil {
+					rc.mu.Lock()
+					delete(rc.barriers, id)
+					rc.mu.Unlock()
+				}
 			}
 		}
 		// client closed channel; shutdown raft if not already
@@ -284,10 +363,9 @@ func (rc *raftNode) serveChannels() {
 		case <-ticker.C:
 			rc.node.Tick()
 
-		// store raft entries to wal, then publish over commit channel
+		// store raft entries to storage, then publish over commit channel
 		case rd := <-rc.node.Ready():
-			rc.wal.Save(rd.HardState, rd.Entries)
-			rc.raftStorage.Append(rd.Entries)
+			rc.raftStorage.Save(rd.HardState, rd.Entries)
 			rc.transport.Send(rd.Messages)
 			if ok := rc.publishEntries(rd.CommittedEntries); !ok {
 				rc.stop()
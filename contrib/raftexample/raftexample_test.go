@@ -24,10 +24,11 @@ import (
 
 type cluster struct {
 	peers       []string
-	commitC     []<-chan *string
+	commitC     []<-chan *commit
 	errorC      []<-chan error
 	proposeC    []chan string
 	confChangeC []chan raftpb.ConfChange
+	barrierC    []chan chan struct{}
 }
 
 // newCluster creates a cluster of n nodes
@@ -39,17 +40,19 @@ func newCluster(n int) *cluster {
 
 	clus := &cluster{
 		peers:       peers,
-		commitC:     make([]<-chan *string, len(peers)),
+		commitC:     make([]<-chan *commit, len(peers)),
 		errorC:      make([]<-chan error, len(peers)),
 		proposeC:    make([]chan string, len(peers)),
 		confChangeC: make([]chan raftpb.ConfChange, len(peers)),
+		barrierC:    make([]chan chan struct{}, len(peers)),
 	}
 
 	for i := range clus.peers {
 		os.RemoveAll(fmt.Sprintf("raftexample-%d", i+1))
 		clus.proposeC[i] = make(chan string, 1)
 		clus.confChangeC[i] = make(chan raftpb.ConfChange, 1)
-		clus.commitC[i], clus.errorC[i] = newRaftNode(i+1, clus.peers, false, clus.proposeC[i], clus.confChangeC[i])
+		clus.barrierC[i] = make(chan chan struct{}, 1)
+		clus.commitC[i], clus.errorC[i] = newRaftNode(i+1, clus.peers, false, clus.proposeC[i], clus.confChangeC[i], clus.barrierC[i])
 	}
 
 	return clus
@@ -100,14 +103,14 @@ func TestProposeOnCommit(t *testing.T) {
 	donec := make(chan struct{})
 	for i := range clus.peers {
 		// feedback for "n" committed entries, then update donec
-		go func(pC chan<- string, cC <-chan *string, eC <-chan error) {
+		go func(pC chan<- string, cC <-chan *commit, eC <-chan error) {
 			for n := 0; n < 100; n++ {
 				s, ok := <-cC
 				if !ok {
 					pC = nil
 				}
 				select {
-				case pC <- *s:
+				case pC <- s.data:
 					continue
 				case err, _ := <-eC:
 					t.Fatalf("eC message (%v)", err)
@@ -151,7 +154,27 @@ func TestCloseProposerInflight(t *testing.T) {
 	}()
 
 	// wait for one message
-	if c, ok := <-clus.commitC[0]; *c != "foo" || !ok {
+	if c, ok := <-clus.commitC[0]; !ok || c.data != "foo" {
 		t.Fatalf("Commit failed")
 	}
 }
+
+// TestBarrierAfterCommit tests a barrier is published after the updates
+// proposed before it.
+func TestBarrierAfterCommit(t *testing.T) {
+	clus := newCluster(1)
+	defer clus.closeNoErrors(t)
+
+	clus.sinkReplay()
+
+	clus.proposeC[0] <- "foo"
+	appliedC := make(chan struct{})
+	clus.barrierC[0] <- appliedC
+
+	if c, ok := <-clus.commitC[0]; !ok || c.data != "foo" {
+		t.Fatalf("Commit failed")
+	}
+	if c, ok := <-clus.commitC[0]; !ok || c.appliedC != appliedC {
+		t.Fatalf("Barrier failed")
+	}
+}
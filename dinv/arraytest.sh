@@ -1,8 +1,8 @@
 #!/bin/bash
-assertOP[0]="NONE"
-assertOP[1]="STRONGLEADER"
-assertOP[2]="LOGMATCHING"
-assertOP[3]="LEADERAGREEMENT"
+assertOP[0]="none"
+assertOP[1]="strong-leadership"
+assertOP[2]="log-matching"
+assertOP[3]="leader-agreement"
 
 leaderOP[0]="true"
 leaderOP[1]="false"
@@ -27,23 +27,20 @@ echo $CLUSTERSTRING
 fuser -k 2380/tcp
 #remove old databases
 rm -r *[0-9].etcd
+#remove old bug logs
+rm -f *.bugs.jsonl *.shiviz.log
 #install etcd
 sudo -E go install ../
 
-#hard coded enviornment variables for testing dinv assertions
+#hard coded flags for testing dinv assertions
 #they type of assert to be made
-ASSERTTYPE="STRONGLEADER"
+INVARIANTS="strong-leadership"
 #if true only leader asserts
 LEADER="false"
 #assert with probability 1/SAMPLE
 SAMPLE="0"
 #true if bugs should be run
 DINVBUG="false"
-#export assert macros
-export LEADER
-export ASSERTTYPE
-export SAMPLE
-export DINVBUG
 
 
 #itterativly launch the cluster
@@ -64,5 +61,12 @@ do
       --advertise-client-urls http://127.0.0.$i:2379 \
       --initial-cluster-token etcd-cluster-1 \
       --initial-cluster $CLUSTERSTRING \
-      --initial-cluster-state new &
+      --initial-cluster-state new \
+      --dinv-invariants $INVARIANTS \
+      --dinv-leader-only=$LEADER \
+      --dinv-sample $SAMPLE \
+      --dinv-inject-bugs=$DINVBUG \
+      --dinv-bug-log $infra.bugs.jsonl \
+      --dinv-shiviz-log $infra.shiviz.log \
+      --dinv-dtrace $infra.dtrace &
 done
@@ -31,6 +31,9 @@ func TestCtlV3TxnInteractiveSuccessPeerTLS(t *testing.T) {
 func TestCtlV3TxnInteractiveFail(t *testing.T) {
 	testCtl(t, txnTestFail, withInteractive())
 }
+func TestCtlV3TxnInteractiveNested(t *testing.T) {
+	testCtl(t, txnTestNested, withInteractive())
+}
 
 func txnTestSuccess(cx ctlCtx) {
 	if err := ctlV3Put(cx, "key1", "value1", ""); err != nil {
@@ -63,6 +66,33 @@ func txnTestFail(cx ctlCtx) {
 	}
 }
 
+func txnTestNested(cx ctlCtx) {
+	if err := ctlV3Put(cx, "key1", "value1", ""); err != nil {
+		cx.t.Fatalf("txnTestNested ctlV3Put error (%v)", err)
+	}
+	if err := ctlV3Put(cx, "key2", "value2", ""); err != nil {
+		cx.t.Fatalf("txnTestNested ctlV3Put error (%v)", err)
+	}
+
+	rqs := txnRequests{
+		compare: []string{`version("key1", "key3") = "1"`},
+		ifSucess: []string{
+			"txn",
+			`val("key1") = "value1"`,
+			"",
+			"get key2",
+			"",
+			`put key2 "fail"`,
+			"",
+		},
+		ifFail:  []string{`put key1 "fail"`},
+		results: []string{"SUCCESS", "SUCCESS", "key2", "value2"},
+	}
+	if err := ctlV3Txn(cx, rqs); err != nil {
+		cx.t.Fatal(err)
+	}
+}
+
 type txnRequests struct {
 	compare  []string
 	ifSucess []string
@@ -93,7 +123,7 @@ func ctlV3Txn(cx ctlCtx, rqs txnRequests) error {
 		return err
 	}
 
-	_, err = proc.Expect("success requests (get, put, delete):")
+	_, err = proc.Expect("success requests (get, put, delete, txn):")
 	if err != nil {
 		return err
 	}
@@ -106,7 +136,7 @@ func ctlV3Txn(cx ctlCtx, rqs txnRequests) error {
 		return err
 	}
 
-	_, err = proc.Expect("failure requests (get, put, delete):")
+	_, err = proc.Expect("failure requests (get, put, delete, txn):")
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@
 package command
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -31,6 +32,11 @@ var (
 	getFromKey     bool
 	getRev         int64
 	getKeysOnly    bool
+	getMinModRev   int64
+	getMaxModRev   int64
+	getMinCreate   int64
+	getMaxCreate   int64
+	getContinue    string
 )
 
 // NewGetCommand returns the cobra command for "get".
@@ -49,6 +55,11 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&getFromKey, "from-key", false, "Get keys that are greater than or equal to the given key")
 	cmd.Flags().Int64Var(&getRev, "rev", 0, "Specify the kv revision")
 	cmd.Flags().BoolVar(&getKeysOnly, "keys-only", false, "Get only the keys")
+	cmd.Flags().Int64Var(&getMinModRev, "min-mod-rev", 0, "Get only the keys modified at or after the given revision")
+	cmd.Flags().Int64Var(&getMaxModRev, "max-mod-rev", 0, "Get only the keys modified at or before the given revision")
+	cmd.Flags().Int64Var(&getMinCreate, "min-create-rev", 0, "Get only the keys created at or after the given revision")
+	cmd.Flags().Int64Var(&getMaxCreate, "max-create-rev", 0, "Get only the keys created at or before the given revision")
+	cmd.Flags().StringVar(&getContinue, "continue", "", "Continue a limited get from the base64 continue token of the previous response")
 	return cmd
 }
 
@@ -150,5 +161,18 @@ func getGetOp(cmd *cobra.Command, args []string) (string, []clientv3.OpOption) {
 		opts = append(opts, clientv3.WithKeysOnly())
 	}
 
+	opts = append(opts, clientv3.WithMinModRev(getMinModRev))
+	opts = append(opts, clientv3.WithMaxModRev(getMaxModRev))
+	opts = append(opts, clientv3.WithMinCreateRev(getMinCreate))
+	opts = append(opts, clientv3.WithMaxCreateRev(getMaxCreate))
+
+	if len(getContinue) != 0 {
+		tok, err := base64.StdEncoding.DecodeString(getContinue)
+		if err != nil {
+			ExitWithError(ExitBadArgs, fmt.Errorf("bad continue token %q (%v)", getContinue, err))
+		}
+		opts = append(opts, clientv3.WithContinue(tok))
+	}
+
 	return key, opts
 }
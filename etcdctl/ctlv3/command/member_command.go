@@ -19,10 +19,31 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/coreos/etcd/clientv3"
 	"github.com/spf13/cobra"
 )
 
-var memberPeerURLs string
+var (
+	memberPeerURLs string
+	isLearner      bool
+	isWitness      bool
+
+	reconfigureAdd    peerURLsList
+	reconfigureRemove []string
+)
+
+// peerURLsList is a flag value collecting the comma separated peer URLs of
+// every occurrence of the flag.
+type peerURLsList [][]string
+
+func (l *peerURLsList) String() string { return fmt.Sprint(*l) }
+
+func (l *peerURLsList) Set(s string) error {
+	*l = append(*l, strings.Split(s, ","))
+	return nil
+}
+
+func (l *peerURLsList) Type() string { return "peerURLs" }
 
 // NewMemberCommand returns the cobra command for "member".
 func NewMemberCommand() *cobra.Command {
@@ -35,6 +56,8 @@ func NewMemberCommand() *cobra.Command {
 	mc.AddCommand(NewMemberRemoveCommand())
 	mc.AddCommand(NewMemberUpdateCommand())
 	mc.AddCommand(NewMemberListCommand())
+	mc.AddCommand(NewMemberPromoteCommand())
+	mc.AddCommand(NewMemberReconfigureCommand())
 
 	return mc
 }
@@ -49,6 +72,8 @@ func NewMemberAddCommand() *cobra.Command {
 	}
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
+	cc.Flags().BoolVar(&isLearner, "learner", false, "indicates if the new member is a non-voting learner.")
+	cc.Flags().BoolVar(&isWitness, "witness", false, "indicates if the new member is a witness, which votes but keeps no application state.")
 
 	return cc
 }
@@ -85,7 +110,7 @@ func NewMemberListCommand() *cobra.Command {
 		Use:   "list",
 		Short: "Lists all members in the cluster",
 		Long: `When --write-out is set to simple, this command prints out comma-separated member lists for each endpoint.
-The items in the lists are ID, Status, Name, Peer Addrs, Client Addrs.
+The items in the lists are ID, Status, Name, Peer Addrs, Client Addrs, Is Learner.
 `,
 
 		Run: memberListCommandFunc,
@@ -94,6 +119,40 @@ The items in the lists are ID, Status, Name, Peer Addrs, Client Addrs.
 	return cc
 }
 
+// NewMemberPromoteCommand returns the cobra command for "member promote".
+func NewMemberPromoteCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "promote <memberID>",
+		Short: "Promotes a learner member in the cluster to a voting member",
+		Long: `The promotion is rejected unless the endpoint serving it is the leader and the
+learner has caught up with the log of the leader.
+`,
+
+		Run: memberPromoteCommandFunc,
+	}
+
+	return cc
+}
+
+// NewMemberReconfigureCommand returns the cobra command for "member reconfigure".
+func NewMemberReconfigureCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "reconfigure",
+		Short: "Adds and removes several members of the cluster at once",
+		Long: `The members are added and removed in a single change of the cluster
+configuration through joint consensus: until the change completes, decisions
+need a quorum of both the old and the new set of voting members.
+`,
+
+		Run: memberReconfigureCommandFunc,
+	}
+
+	cc.Flags().Var(&reconfigureAdd, "add", "comma separated peer URLs of a member to add; may be given once per member.")
+	cc.Flags().StringSliceVar(&reconfigureRemove, "remove", nil, "comma separated IDs of the members to remove.")
+
+	return cc
+}
+
 // memberAddCommandFunc executes the "member add" command.
 func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
@@ -104,14 +163,37 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 		ExitWithError(ExitBadArgs, fmt.Errorf("member peer urls not provided."))
 	}
 
+	if isLearner && isWitness {
+		ExitWithError(ExitBadArgs, fmt.Errorf("a member cannot be both a learner and a witness."))
+	}
+
 	urls := strings.Split(memberPeerURLs, ",")
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberAdd(ctx, urls)
+	var (
+		resp *clientv3.MemberAddResponse
+		err  error
+	)
+	switch {
+	case isLearner:
+		resp, err = mustClientFromCmd(cmd).MemberAddAsLearner(ctx, urls)
+	case isWitness:
+		resp, err = mustClientFromCmd(cmd).MemberAddAsWitness(ctx, urls)
+	default:
+		resp, err = mustClientFromCmd(cmd).MemberAdd(ctx, urls)
+	}
 	cancel()
 	if err != nil {
 		ExitWithError(ExitError, err)
 	}
 
+	if isLearner {
+		fmt.Printf("Member %16x added to cluster %16x as a learner\n", resp.Member.ID, resp.Header.ClusterId)
+		return
+	}
+	if isWitness {
+		fmt.Printf("Member %16x added to cluster %16x as a witness\n", resp.Member.ID, resp.Header.ClusterId)
+		return
+	}
 	fmt.Printf("Member %16x added to cluster %16x\n", resp.Member.ID, resp.Header.ClusterId)
 }
 
@@ -163,6 +245,57 @@ func memberUpdateCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Printf("Member %16x updated in cluster %16x\n", id, resp.Header.ClusterId)
 }
 
+// memberPromoteCommandFunc executes the "member promote" command.
+func memberPromoteCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("member ID is not provided"))
+	}
+
+	id, err := strconv.ParseUint(args[0], 16, 64)
+	if err != nil {
+		ExitWithError(ExitBadArgs, fmt.Errorf("bad member ID arg (%v), expecting ID in Hex", err))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).MemberPromote(ctx, id)
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+
+	fmt.Printf("Member %16x promoted in cluster %16x\n", id, resp.Header.ClusterId)
+}
+
+// memberReconfigureCommandFunc executes the "member reconfigure" command.
+func memberReconfigureCommandFunc(cmd *cobra.Command, args []string) {
+	if len(reconfigureAdd)+len(reconfigureRemove) == 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("no member to add or remove provided"))
+	}
+
+	ids := make([]uint64, len(reconfigureRemove))
+	for i, s := range reconfigureRemove {
+		id, err := strconv.ParseUint(s, 16, 64)
+		if err != nil {
+			ExitWithError(ExitBadArgs, fmt.Errorf("bad member ID arg (%v), expecting ID in Hex", err))
+		}
+		ids[i] = id
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).MemberReconfigure(ctx, reconfigureAdd, ids)
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+
+	for _, m := range resp.Added {
+		fmt.Printf("Member %16x added to cluster %16x\n", m.ID, resp.Header.ClusterId)
+	}
+	for _, id := range ids {
+		fmt.Printf("Member %16x removed from cluster %16x\n", id, resp.Header.ClusterId)
+	}
+}
+
 // memberListCommandFunc executes the "member list" command.
 func memberListCommandFunc(cmd *cobra.Command, args []string) {
 	ctx, cancel := commandCtx(cmd)
@@ -35,6 +35,9 @@ type printer interface {
 	Txn(v3.TxnResponse)
 	Watch(v3.WatchResponse)
 
+	TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool)
+	Leases(v3.LeaseLeasesResponse)
+
 	MemberList(v3.MemberListResponse)
 
 	EndpointStatus([]epStatus)
@@ -58,7 +61,7 @@ func NewPrinter(printerType string, isHex bool) printer {
 }
 
 func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string) {
-	hdr = []string{"ID", "Status", "Name", "Peer Addrs", "Client Addrs"}
+	hdr = []string{"ID", "Status", "Name", "Peer Addrs", "Client Addrs", "Is Learner", "Is Witness"}
 	for _, m := range r.Members {
 		status := "started"
 		if len(m.Name) == 0 {
@@ -70,6 +73,8 @@ func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string
 			m.Name,
 			strings.Join(m.PeerURLs, ","),
 			strings.Join(m.ClientURLs, ","),
+			fmt.Sprint(m.IsLearner),
+			fmt.Sprint(m.IsWitness),
 		})
 	}
 	return
@@ -142,6 +147,8 @@ func (s *simplePrinter) Txn(resp v3.TxnResponse) {
 			s.Put((v3.PutResponse)(*v.ResponsePut))
 		case *pb.ResponseOp_ResponseRange:
 			s.Get(((v3.GetResponse)(*v.ResponseRange)))
+		case *pb.ResponseOp_ResponseTxn:
+			s.Txn((v3.TxnResponse)(*v.ResponseTxn))
 		default:
 			fmt.Printf("unexpected response %+v\n", r)
 		}
@@ -164,6 +171,25 @@ func (s *simplePrinter) Alarm(resp v3.AlarmResponse) {
 	}
 }
 
+func (s *simplePrinter) TimeToLive(resp v3.LeaseTimeToLiveResponse, keys bool) {
+	txt := fmt.Sprintf("lease %016x granted with TTL(%ds), remaining(%ds)", resp.ID, resp.GrantedTTL, resp.TTL)
+	if keys {
+		ks := make([]string, len(resp.Keys))
+		for i := range resp.Keys {
+			ks[i] = string(resp.Keys[i])
+		}
+		txt += fmt.Sprintf(", attached keys(%v)", ks)
+	}
+	fmt.Println(txt)
+}
+
+func (s *simplePrinter) Leases(resp v3.LeaseLeasesResponse) {
+	fmt.Printf("found %d leases\n", len(resp.Leases))
+	for _, item := range resp.Leases {
+		fmt.Printf("%016x\n", item.ID)
+	}
+}
+
 func (s *simplePrinter) MemberList(resp v3.MemberListResponse) {
 	_, rows := makeMemberListTable(resp)
 	for _, row := range rows {
@@ -205,6 +231,12 @@ func (tp *tablePrinter) Watch(r v3.WatchResponse) {
 func (tp *tablePrinter) Alarm(r v3.AlarmResponse) {
 	ExitWithError(ExitBadFeature, errors.New("table is not supported as output format"))
 }
+func (tp *tablePrinter) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) {
+	ExitWithError(ExitBadFeature, errors.New("table is not supported as output format"))
+}
+func (tp *tablePrinter) Leases(r v3.LeaseLeasesResponse) {
+	ExitWithError(ExitBadFeature, errors.New("table is not supported as output format"))
+}
 func (tp *tablePrinter) MemberList(r v3.MemberListResponse) {
 	hdr, rows := makeMemberListTable(r)
 	table := tablewriter.NewWriter(os.Stdout)
@@ -245,6 +277,9 @@ func (p *jsonPrinter) MemberList(r v3.MemberListResponse) { printJSON(r) }
 func (p *jsonPrinter) EndpointStatus(r []epStatus)        { printJSON(r) }
 func (p *jsonPrinter) DBStatus(r dbstatus)                { printJSON(r) }
 
+func (p *jsonPrinter) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) { printJSON(r) }
+func (p *jsonPrinter) Leases(r v3.LeaseLeasesResponse)                    { printJSON(r) }
+
 func printJSON(v interface{}) {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -286,6 +321,14 @@ func (p *pbPrinter) Alarm(r v3.AlarmResponse) {
 	printPB((*pb.AlarmResponse)(&r))
 }
 
+func (p *pbPrinter) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) {
+	ExitWithError(ExitBadFeature, errors.New("only support simple or json as output format"))
+}
+
+func (p *pbPrinter) Leases(r v3.LeaseLeasesResponse) {
+	ExitWithError(ExitBadFeature, errors.New("only support simple or json as output format"))
+}
+
 func (p *pbPrinter) MemberList(r v3.MemberListResponse) {
 	printPB((*pb.MemberListResponse)(&r))
 }
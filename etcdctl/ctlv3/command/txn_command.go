@@ -52,9 +52,9 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 	txn := mustClientFromCmd(cmd).Txn(context.Background())
 	promptInteractive("compares:")
 	txn.If(readCompares(reader)...)
-	promptInteractive("success requests (get, put, delete):")
+	promptInteractive("success requests (get, put, delete, txn):")
 	txn.Then(readOps(reader)...)
-	promptInteractive("failure requests (get, put, delete):")
+	promptInteractive("failure requests (get, put, delete, txn):")
 	txn.Else(readOps(reader)...)
 
 	resp, err := txn.Commit()
@@ -105,6 +105,10 @@ func readOps(r *bufio.Reader) (ops []clientv3.Op) {
 
 		// remove trialling \n
 		line = line[:len(line)-1]
+		if strings.TrimSpace(line) == "txn" {
+			ops = append(ops, readTxnOp(r))
+			continue
+		}
 		op, err := parseRequestUnion(line)
 		if err != nil {
 			ExitWithError(ExitInvalidInput, err)
@@ -115,6 +119,18 @@ func readOps(r *bufio.Reader) (ops []clientv3.Op) {
 	return ops
 }
 
+// readTxnOp reads a nested txn, which is given like the txn itself: its
+// compares, success requests and failure requests, each ended by an empty line.
+func readTxnOp(r *bufio.Reader) clientv3.Op {
+	promptInteractive("nested txn compares:")
+	cmps := readCompares(r)
+	promptInteractive("nested txn success requests (get, put, delete, txn):")
+	thenOps := readOps(r)
+	promptInteractive("nested txn failure requests (get, put, delete, txn):")
+	elseOps := readOps(r)
+	return clientv3.OpTxn(cmps, thenOps, elseOps)
+}
+
 func parseRequestUnion(line string) (*clientv3.Op, error) {
 	args := argify(line)
 	if len(args) < 2 {
@@ -153,6 +169,7 @@ func parseRequestUnion(line string) (*clientv3.Op, error) {
 func parseCompare(line string) (*clientv3.Cmp, error) {
 	var (
 		key string
+		end string
 		op  string
 		val string
 	)
@@ -163,9 +180,14 @@ func parseCompare(line string) (*clientv3.Cmp, error) {
 	}
 
 	target := lparenSplit[0]
-	n, serr := fmt.Sscanf(lparenSplit[1], "%q) %s %q", &key, &op, &val)
-	if n != 3 {
-		return nil, fmt.Errorf("malformed comparison: %s; got %s(%q) %s %q", line, target, key, op, val)
+	n, serr := fmt.Sscanf(lparenSplit[1], "%q,%q) %s %q", &key, &end, &op, &val)
+	if n != 4 {
+		// no range end; compare a single key
+		key, end = "", ""
+		n, serr = fmt.Sscanf(lparenSplit[1], "%q) %s %q", &key, &op, &val)
+		if n != 3 {
+			return nil, fmt.Errorf("malformed comparison: %s; got %s(%q) %s %q", line, target, key, op, val)
+		}
 	}
 	if serr != nil {
 		return nil, fmt.Errorf("malformed comparison: %s (%v)", line, serr)
@@ -198,6 +220,9 @@ func parseCompare(line string) (*clientv3.Cmp, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid txn compare request: %s", line)
 	}
+	if len(end) != 0 {
+		cmp = cmp.WithRange(end)
+	}
 
 	return &cmp, nil
 }
@@ -67,6 +67,7 @@ func init() {
 		command.NewCompactionCommand(),
 		command.NewAlarmCommand(),
 		command.NewDefragCommand(),
+		command.NewMoveLeaderCommand(),
 		command.NewEndpointCommand(),
 		command.NewWatchCommand(),
 		command.NewVersionCommand(),
@@ -30,6 +30,7 @@ import (
 	"github.com/coreos/etcd/pkg/flags"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/version"
 	"github.com/ghodss/yaml"
 )
@@ -111,6 +112,11 @@ type config struct {
 	InitialCluster      string `json:"initial-cluster"`
 	InitialClusterToken string `json:"initial-cluster-token"`
 	StrictReconfigCheck bool   `json:"strict-reconfig-check"`
+	PreVote             bool   `json:"pre-vote"`
+	AsyncFsync          bool   `json:"async-fsync"`
+	BatchProposals      bool   `json:"batch-proposals"`
+	Witness             bool   `json:"witness"`
+	LeaseCheckpointSec  uint   `json:"lease-checkpoint-interval"`
 	ApurlsCfgFile       string `json:"initial-advertise-peer-urls"`
 	AcurlsCfgFile       string `json:"advertise-client-urls"`
 	ClusterStateCfgFile string `json:"initial-cluster-state"`
@@ -139,6 +145,19 @@ type config struct {
 	// ForceNewCluster is unsafe
 	ForceNewCluster bool `json:"force-new-cluster"`
 
+	// dinv
+	DinvInvariants string `json:"dinv-invariants"`
+	DinvLeaderOnly bool   `json:"dinv-leader-only"`
+	DinvSample     int    `json:"dinv-sample"`
+	DinvCutTick    int    `json:"dinv-cut-tick"`
+	DinvInjectBugs bool   `json:"dinv-inject-bugs"`
+	DinvFaultSeed  int64  `json:"dinv-fault-seed"`
+	DinvFaults     bool   `json:"dinv-enable-faults"`
+	DinvBugLog     string `json:"dinv-bug-log"`
+	DinvPiggyback  bool   `json:"dinv-piggyback"`
+	DinvShiVizLog  string `json:"dinv-shiviz-log"`
+	DinvDtrace     string `json:"dinv-dtrace"`
+
 	printVersion bool
 
 	autoCompactionRetention int
@@ -219,6 +238,11 @@ func NewConfig() *config {
 		plog.Panicf("unexpected error setting up clusterStateFlag: %v", err)
 	}
 	fs.BoolVar(&cfg.StrictReconfigCheck, "strict-reconfig-check", false, "Reject reconfiguration requests that would cause quorum loss.")
+	fs.BoolVar(&cfg.PreVote, "pre-vote", false, "Enable the raft Pre-Vote algorithm to prevent disruption when a partitioned member rejoins the cluster.")
+	fs.BoolVar(&cfg.AsyncFsync, "async-fsync", false, "Sync the WAL in the background; followers acknowledge entries once they are synced.")
+	fs.BoolVar(&cfg.BatchProposals, "batch-proposals", false, "Coalesce small concurrent puts and deletes into one raft entry. Enable only once every member supports it.")
+	fs.BoolVar(&cfg.Witness, "witness", false, "Run as a witness member, which votes but keeps no application state and serves no client requests.")
+	fs.UintVar(&cfg.LeaseCheckpointSec, "lease-checkpoint-interval", 0, "Time (in seconds) between checkpoints of the remaining TTLs of leases. 0 means disable lease checkpointing.")
 
 	// proxy
 	fs.Var(cfg.proxy, "proxy", fmt.Sprintf("Valid values include %s", strings.Join(cfg.proxy.Values, ", ")))
@@ -253,6 +277,19 @@ func NewConfig() *config {
 	// unsafe
 	fs.BoolVar(&cfg.ForceNewCluster, "force-new-cluster", false, "Force to create a new one member cluster.")
 
+	// dinv
+	fs.StringVar(&cfg.DinvInvariants, "dinv-invariants", "", "Comma-separated list of dinv invariants to check.")
+	fs.BoolVar(&cfg.DinvLeaderOnly, "dinv-leader-only", false, "Check dinv invariants on the leader only.")
+	fs.IntVar(&cfg.DinvSample, "dinv-sample", 100, "Check dinv invariants on one in every N raft steps.")
+	fs.IntVar(&cfg.DinvCutTick, "dinv-cut-tick", 0, "Check dinv invariants over a consistent cut taken by the leader every N raft ticks instead. 0 disables the cuts.")
+	fs.BoolVar(&cfg.DinvInjectBugs, "dinv-inject-bugs", false, "Inject the bug paired with each dinv invariant.")
+	fs.Int64Var(&cfg.DinvFaultSeed, "dinv-fault-seed", 0, "Seed for the random choices of the raft fault points.")
+	fs.StringVar(&cfg.DinvBugLog, "dinv-bug-log", "", "Path to the file the injected and caught bugs are appended to as JSON lines.")
+	fs.StringVar(&cfg.DinvShiVizLog, "dinv-shiviz-log", "", "Path to the file the raft state transitions and messages are appended to with vector clocks in the ShiViz log format.")
+	fs.StringVar(&cfg.DinvDtrace, "dinv-dtrace", "", "Path to the Daikon data trace file the raft state is written to at the tracked program points.")
+	fs.BoolVar(&cfg.DinvPiggyback, "dinv-piggyback", true, "Piggyback dinv vector clocks on the messages exchanged with peers that enable it as well.")
+	fs.BoolVar(&cfg.DinvFaults, "dinv-enable-faults", false, "Enable the raft fault points via HTTP server. Address is at client URL + \"/debug/raftfaults\"")
+
 	// version
 	fs.BoolVar(&cfg.printVersion, "version", false, "Print the version and exit.")
 
@@ -439,6 +476,11 @@ func (cfg *config) validateConfig(isSet func(field string) bool) error {
 		return fmt.Errorf("--election-timeout[%vms] is too long, and should be set less than %vms", cfg.ElectionMs, maxElectionMs)
 	}
 
+	dc := cfg.dinvConfig()
+	if err := dc.Validate(); err != nil {
+		return fmt.Errorf("invalid dinv configuration: %v", err)
+	}
+
 	return nil
 }
 
@@ -456,3 +498,18 @@ func (cfg config) isReadonlyProxy() bool       { return cfg.proxy.String() == pr
 func (cfg config) shouldFallbackToProxy() bool { return cfg.fallback.String() == fallbackFlagProxy }
 
 func (cfg config) electionTicks() int { return int(cfg.ElectionMs / cfg.TickMs) }
+
+func (cfg config) dinvConfig() raft.DinvConfig {
+	var invariants []string
+	if cfg.DinvInvariants != "" {
+		invariants = strings.Split(cfg.DinvInvariants, ",")
+	}
+	return raft.DinvConfig{
+		Invariants: invariants,
+		LeaderOnly: cfg.DinvLeaderOnly,
+		Sample:     cfg.DinvSample,
+		CutTick:    cfg.DinvCutTick,
+		InjectBugs: cfg.DinvInjectBugs,
+		FaultSeed:  cfg.DinvFaultSeed,
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/coreos/etcd/raft"
 	"github.com/ghodss/yaml"
 )
 
@@ -571,3 +572,61 @@ func validateOtherFlags(t *testing.T, cfg *config) {
 		t.Errorf("forceNewCluster = %t, want %t", cfg.ForceNewCluster, wcfg.ForceNewCluster)
 	}
 }
+
+func TestConfigParsingDinvFlags(t *testing.T) {
+	args := []string{
+		"-dinv-invariants=strong-leadership,election-safety",
+		"-dinv-leader-only",
+		"-dinv-sample=10",
+		"-dinv-cut-tick=5",
+		"-dinv-fault-seed=7",
+		"-dinv-bug-log=bugs.jsonl",
+		"-dinv-piggyback=false",
+		"-dinv-shiviz-log=shiviz.log",
+		"-dinv-dtrace=raft.dtrace",
+	}
+
+	cfg := NewConfig()
+	if err := cfg.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	wcfg := raft.DinvConfig{
+		Invariants: []string{"strong-leadership", "election-safety"},
+		LeaderOnly: true,
+		Sample:     10,
+		CutTick:    5,
+		FaultSeed:  7,
+	}
+	if g := cfg.dinvConfig(); !reflect.DeepEqual(g, wcfg) {
+		t.Errorf("dinv config = %+v, want %+v", g, wcfg)
+	}
+	if cfg.DinvBugLog != "bugs.jsonl" {
+		t.Errorf("dinv bug log = %q, want %q", cfg.DinvBugLog, "bugs.jsonl")
+	}
+	if cfg.DinvPiggyback {
+		t.Errorf("dinv piggyback = %t, want false", cfg.DinvPiggyback)
+	}
+	if cfg.DinvShiVizLog != "shiviz.log" {
+		t.Errorf("dinv shiviz log = %q, want %q", cfg.DinvShiVizLog, "shiviz.log")
+	}
+	if cfg.DinvDtrace != "raft.dtrace" {
+		t.Errorf("dinv dtrace = %q, want %q", cfg.DinvDtrace, "raft.dtrace")
+	}
+}
+
+func TestConfigParsingInvalidDinvFlags(t *testing.T) {
+	tests := [][]string{
+		{"-dinv-invariants=unknown"},
+		{"-dinv-invariants=election-safety", "-dinv-inject-bugs"},
+		{"-dinv-inject-bugs"},
+		{"-dinv-invariants=log-matching", "-dinv-sample=-1"},
+		{"-dinv-invariants=log-matching", "-dinv-cut-tick=-1"},
+	}
+	for i, args := range tests {
+		cfg := NewConfig()
+		err := cfg.Parse(args)
+		if err == nil || !strings.Contains(err.Error(), "invalid dinv configuration") {
+			t.Errorf("#%d: err = %v, want invalid dinv configuration", i, err)
+		}
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
 	"github.com/coreos/etcd/proxy/httpproxy"
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/rafthttp"
 	"github.com/coreos/etcd/version"
 	"github.com/coreos/go-systemd/daemon"
@@ -360,7 +361,36 @@ func startEtcd(cfg *config) (<-chan struct{}, error) {
 		AutoCompactionRetention: cfg.autoCompactionRetention,
 		QuotaBackendBytes:       cfg.QuotaBackendBytes,
 		StrictReconfigCheck:     cfg.StrictReconfigCheck,
+		PreVote:                 cfg.PreVote,
+		AsyncFsync:              cfg.AsyncFsync,
+		BatchProposals:          cfg.BatchProposals,
+		Witness:                 cfg.Witness,
+		LeaseCheckpointInterval: time.Duration(cfg.LeaseCheckpointSec) * time.Second,
 		EnablePprof:             cfg.enablePprof,
+		Dinv:                    cfg.dinvConfig(),
+		EnableRaftFaults:        cfg.DinvFaults,
+		DinvPiggyback:           cfg.DinvPiggyback,
+	}
+	if cfg.DinvBugLog != "" {
+		var bl *raft.BugEventLog
+		if bl, err = raft.OpenBugEventLog(cfg.DinvBugLog); err != nil {
+			return nil, fmt.Errorf("cannot open dinv bug log: %v", err)
+		}
+		srvcfg.RaftBugLog = bl
+	}
+	if cfg.DinvShiVizLog != "" {
+		var sl *raft.ShiVizLog
+		if sl, err = raft.OpenShiVizLog(cfg.Name, cfg.DinvShiVizLog); err != nil {
+			return nil, fmt.Errorf("cannot open dinv shiviz log: %v", err)
+		}
+		srvcfg.RaftTracer = sl
+	}
+	if cfg.DinvDtrace != "" {
+		var dw *raft.DtraceWriter
+		if dw, err = raft.OpenDtraceWriter(cfg.DinvDtrace); err != nil {
+			return nil, fmt.Errorf("cannot open dinv dtrace: %v", err)
+		}
+		srvcfg.RaftStateTracker = dw
 	}
 	var s *etcdserver.EtcdServer
 	s, err = etcdserver.NewServer(srvcfg)
@@ -385,6 +415,14 @@ func startEtcd(cfg *config) (<-chan struct{}, error) {
 			plog.Fatal(servePeerHTTP(l, ph))
 		}(l)
 	}
+	if cfg.Witness {
+		// a witness has no application state to serve.
+		for _, sctx := range sctxs {
+			plog.Infof("witness does not serve client requests; stopping listening on %s", sctx.host)
+			sctx.l.Close()
+		}
+		sctxs = nil
+	}
 	// Start a client server goroutine for each listen address
 	for _, sctx := range sctxs {
 		go func(sctx *serveCtx) {
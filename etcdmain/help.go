@@ -82,6 +82,16 @@ clustering flags:
 		dns srv domain used to bootstrap the cluster.
 	--strict-reconfig-check
 		reject reconfiguration requests that would cause quorum loss.
+	--pre-vote
+		enable the raft Pre-Vote algorithm to prevent disruption when a partitioned member rejoins the cluster.
+	--async-fsync
+		sync the WAL in the background; followers acknowledge entries once they are synced.
+	--batch-proposals
+		coalesce small concurrent puts and deletes into one raft entry. Enable only once every member supports it.
+	--witness
+		run as a witness member, which votes but keeps no application state and serves no client requests.
+	--lease-checkpoint-interval '0'
+		time (in seconds) between checkpoints of the remaining TTLs of leases. 0 means disable lease checkpointing.
 	--auto-compaction-retention '0'
 		auto compaction retention in hour. 0 means disable auto compaction.
 
@@ -143,6 +153,32 @@ given by the consensus protocol.
 
 	--force-new-cluster 'false'
 		force to create a new one-member cluster.
+
+dinv flags:
+
+	--dinv-invariants ''
+		comma-separated list of invariants to check ('leader-agreement', 'strong-leadership', 'log-matching',
+		'election-safety', 'leader-completeness' or 'state-machine-safety').
+	--dinv-leader-only 'false'
+		check invariants on the leader only.
+	--dinv-sample 100
+		check invariants on one in every N raft steps.
+	--dinv-cut-tick 0
+		check invariants over a consistent cut taken by the leader every N raft ticks instead. 0 disables the cuts.
+	--dinv-inject-bugs 'false'
+		inject the bug paired with each invariant ('leader-agreement', 'strong-leadership' or 'log-matching').
+	--dinv-fault-seed 0
+		seed for the random choices of the raft fault points.
+	--dinv-bug-log ''
+		path to the file the injected and caught bugs are appended to as JSON lines.
+	--dinv-shiviz-log ''
+		path to the file the raft state transitions and messages are appended to with vector clocks in the ShiViz log format.
+	--dinv-dtrace ''
+		path to the Daikon data trace file the raft state is written to at the tracked program points.
+	--dinv-piggyback 'true'
+		piggyback vector clocks on the messages exchanged with peers that enable it as well.
+	--dinv-enable-faults 'false'
+		enable the raft fault points via HTTP server. Address is at client URL + "/debug/raftfaults"
 	
 profiling flags:
 	--enable-pprof 'false'
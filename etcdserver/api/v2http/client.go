@@ -127,6 +127,17 @@ func NewClientHandler(server *etcdserver.EtcdServer, timeout time.Duration) http
 		mux.Handle(pprofPrefix+"/block", pprof.Handler("block"))
 	}
 
+	rch := &raftCutHandler{cutter: server, timeout: timeout}
+	mux.Handle(raftCutPrefix, rch)
+
+	if server.IsRaftFaultsEnabled() {
+		plog.Infof("raft fault points are enabled under %s", raftFaultsPrefix)
+
+		rfh := &raftFaultsHandler{faults: server.RaftFaults()}
+		mux.Handle(raftFaultsPrefix, rfh)
+		mux.Handle(raftFaultsPrefix+"/", rfh)
+	}
+
 	api.RunCapabilityLoop(server)
 	return requestLogger(mux)
 }
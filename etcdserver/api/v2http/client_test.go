@@ -118,6 +118,16 @@ func (s *serverRecorder) UpdateMember(_ context.Context, m membership.Member) er
 	return nil
 }
 
+func (s *serverRecorder) PromoteMember(_ context.Context, id uint64) error {
+	s.actions = append(s.actions, action{name: "PromoteMember", params: []interface{}{id}})
+	return nil
+}
+
+func (s *serverRecorder) ReconfigureMembers(_ context.Context, add []membership.Member, remove []uint64) error {
+	s.actions = append(s.actions, action{name: "ReconfigureMembers", params: []interface{}{add, remove}})
+	return nil
+}
+
 func (s *serverRecorder) ClusterVersion() *semver.Version { return nil }
 
 type action struct {
@@ -153,7 +163,11 @@ func (rs *resServer) Process(_ context.Context, _ raftpb.Message) error
 func (rs *resServer) AddMember(_ context.Context, _ membership.Member) error    { return nil }
 func (rs *resServer) RemoveMember(_ context.Context, _ uint64) error            { return nil }
 func (rs *resServer) UpdateMember(_ context.Context, _ membership.Member) error { return nil }
-func (rs *resServer) ClusterVersion() *semver.Version                           { return nil }
+func (rs *resServer) PromoteMember(_ context.Context, _ uint64) error           { return nil }
+func (rs *resServer) ReconfigureMembers(_ context.Context, _ []membership.Member, _ []uint64) error {
+	return nil
+}
+func (rs *resServer) ClusterVersion() *semver.Version { return nil }
 
 func boolp(b bool) *bool { return &b }
 
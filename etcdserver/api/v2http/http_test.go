@@ -76,6 +76,12 @@ func (fs *errServer) RemoveMember(ctx context.Context, id uint64) error {
 func (fs *errServer) UpdateMember(ctx context.Context, m membership.Member) error {
 	return fs.err
 }
+func (fs *errServer) PromoteMember(ctx context.Context, id uint64) error {
+	return fs.err
+}
+func (fs *errServer) ReconfigureMembers(ctx context.Context, add []membership.Member, remove []uint64) error {
+	return fs.err
+}
 
 func (fs *errServer) ClusterVersion() *semver.Version { return nil }
 
@@ -17,39 +17,55 @@ package v2http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/api"
+	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/lease/leasehttp"
 	"github.com/coreos/etcd/rafthttp"
+	"golang.org/x/net/context"
 )
 
 const (
 	peerMembersPrefix = "/members"
-	leasesPrefix      = "/leases"
 )
 
+// memberPromoter promotes learners to voting members.
+type memberPromoter interface {
+	PromoteMember(ctx context.Context, id uint64) error
+}
+
 // NewPeerHandler generates an http.Handler to handle etcd peer requests.
 func NewPeerHandler(s *etcdserver.EtcdServer) http.Handler {
 	var lh http.Handler
 	if l := s.Lessor(); l != nil {
 		lh = leasehttp.NewHandler(l)
 	}
-	return newPeerHandler(s.Cluster(), s.RaftHandler(), lh)
+	return newPeerHandler(s.Cluster(), s, s.Cfg.ReqTimeout(), s.RaftHandler(), lh)
 }
 
-func newPeerHandler(cluster api.Cluster, raftHandler http.Handler, leaseHandler http.Handler) http.Handler {
+func newPeerHandler(cluster api.Cluster, promoter memberPromoter, timeout time.Duration, raftHandler http.Handler, leaseHandler http.Handler) http.Handler {
 	mh := &peerMembersHandler{
 		cluster: cluster,
 	}
+	mph := &peerMemberPromoteHandler{
+		cluster:  cluster,
+		promoter: promoter,
+		timeout:  timeout,
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", http.NotFound)
 	mux.Handle(rafthttp.RaftPrefix, raftHandler)
 	mux.Handle(rafthttp.RaftPrefix+"/", raftHandler)
 	mux.Handle(peerMembersPrefix, mh)
+	mux.Handle(etcdserver.PeerMemberPromotePrefix, mph)
 	if leaseHandler != nil {
-		mux.Handle(leasesPrefix, leaseHandler)
+		mux.Handle(leasehttp.LeasePrefix, leaseHandler)
+		mux.Handle(leasehttp.LeaseInternalPrefix, leaseHandler)
 	}
 	mux.HandleFunc(versionPath, versionHandler(cluster, serveVersion))
 	return mux
@@ -75,3 +91,42 @@ func (h *peerMembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		plog.Warningf("failed to encode members response (%v)", err)
 	}
 }
+
+// peerMemberPromoteHandler promotes a learner on behalf of a follower, which
+// cannot tell whether the learner caught up with the leader.
+type peerMemberPromoteHandler struct {
+	cluster  api.Cluster
+	promoter memberPromoter
+	timeout  time.Duration
+}
+
+func (h *peerMemberPromoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r.Method, "POST") {
+		return
+	}
+	w.Header().Set("X-Etcd-Cluster-ID", h.cluster.ID().String())
+
+	if !strings.HasPrefix(r.URL.Path, etcdserver.PeerMemberPromotePrefix) {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, etcdserver.PeerMemberPromotePrefix), 10, 64)
+	if err != nil {
+		http.Error(w, "bad member id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	switch err = h.promoter.PromoteMember(ctx, id); err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case membership.ErrIDNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case membership.ErrMemberNotLearner, etcdserver.ErrLearnerNotReady, etcdserver.ErrNotEnoughStartedMembers:
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	default:
+		plog.Warningf("failed to promote member %d (%v)", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
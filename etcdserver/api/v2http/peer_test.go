@@ -21,10 +21,13 @@ import (
 	"net/http/httptest"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/pkg/testutil"
 	"github.com/coreos/etcd/rafthttp"
+	"golang.org/x/net/context"
 )
 
 // TestNewPeerHandlerOnRaftPrefix tests that NewPeerHandler returns a handler that
@@ -33,7 +36,7 @@ func TestNewPeerHandlerOnRaftPrefix(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("test data"))
 	})
-	ph := newPeerHandler(&fakeCluster{}, h, nil)
+	ph := newPeerHandler(&fakeCluster{}, nil, time.Second, h, nil)
 	srv := httptest.NewServer(ph)
 	defer srv.Close()
 
@@ -132,3 +135,51 @@ func TestServeMembersGet(t *testing.T) {
 		}
 	}
 }
+
+type fakePromoter struct {
+	id  uint64
+	err error
+}
+
+func (p *fakePromoter) PromoteMember(ctx context.Context, id uint64) error {
+	p.id = id
+	return p.err
+}
+
+func TestServeMemberPromote(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		err    error
+
+		wcode int
+		wid   uint64
+	}{
+		{"POST", etcdserver.PeerMemberPromotePrefix + "1234", nil, http.StatusOK, 1234},
+		{"POST", etcdserver.PeerMemberPromotePrefix + "1234", membership.ErrIDNotFound, http.StatusNotFound, 1234},
+		{"POST", etcdserver.PeerMemberPromotePrefix + "1234", etcdserver.ErrLearnerNotReady, http.StatusPreconditionFailed, 1234},
+		{"POST", etcdserver.PeerMemberPromotePrefix + "1234", etcdserver.ErrTimeout, http.StatusInternalServerError, 1234},
+		{"POST", etcdserver.PeerMemberPromotePrefix + "bad", nil, http.StatusBadRequest, 0},
+		{"GET", etcdserver.PeerMemberPromotePrefix + "1234", nil, http.StatusMethodNotAllowed, 0},
+	}
+	for i, tt := range tests {
+		p := &fakePromoter{err: tt.err}
+		h := &peerMemberPromoteHandler{cluster: &fakeCluster{id: 1}, promoter: p, timeout: time.Second}
+		req, err := http.NewRequest(tt.method, testutil.MustNewURL(t, tt.path).String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		if rw.Code != tt.wcode {
+			t.Errorf("#%d: code = %d, want %d", i, rw.Code, tt.wcode)
+		}
+		if p.id != tt.wid {
+			t.Errorf("#%d: promoted id = %d, want %d", i, p.id, tt.wid)
+		}
+		if tt.err != nil && rw.Body.String() != tt.err.Error()+"\n" {
+			t.Errorf("#%d: body = %q, want %q", i, rw.Body.String(), tt.err.Error()+"\n")
+		}
+	}
+}
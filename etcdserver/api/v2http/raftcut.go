@@ -0,0 +1,100 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
+)
+
+const raftCutPrefix = "/debug/raftcut"
+
+// raftCutter takes consistent cuts of the raft state of the cluster.
+type raftCutter interface {
+	RaftCut(ctx context.Context) (*raft.Cut, error)
+}
+
+// raftCutNode is the JSON representation of the state of a member in a
+// raft cut.
+type raftCutNode struct {
+	ID        string         `json:"id"`
+	Term      uint64         `json:"term"`
+	Vote      string         `json:"vote"`
+	Leader    string         `json:"leader"`
+	State     string         `json:"state"`
+	Committed uint64         `json:"committed"`
+	Applied   uint64         `json:"applied"`
+	LastIndex uint64         `json:"lastIndex"`
+	LastTerm  uint64         `json:"lastTerm"`
+	Log       []raftpb.Entry `json:"log,omitempty"`
+	InFlight  []string       `json:"inFlight"`
+}
+
+// raftCutHandler takes a consistent cut of the raft state of the cluster on
+// GET of raftCutPrefix and returns the state of every member. The log of
+// every member is included if the log query parameter is true.
+type raftCutHandler struct {
+	cutter  raftCutter
+	timeout time.Duration
+}
+
+func (h *raftCutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r.Method, "GET") {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	c, err := h.cutter.RaftCut(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	withLog := r.URL.Query().Get("log") == "true"
+	nodes := make([]raftCutNode, len(c.Nodes))
+	for i, nc := range c.Nodes {
+		n := raftCutNode{
+			ID:        types.ID(nc.ID).String(),
+			Term:      nc.Term,
+			Vote:      types.ID(nc.Vote).String(),
+			Leader:    types.ID(nc.Lead).String(),
+			State:     nc.RaftState.String(),
+			Committed: nc.Committed,
+			Applied:   nc.Applied,
+			InFlight:  []string{},
+		}
+		if len(nc.Log) > 0 {
+			last := nc.Log[len(nc.Log)-1]
+			n.LastIndex, n.LastTerm = last.Index, last.Term
+		}
+		if withLog {
+			n.Log = nc.Log
+		}
+		for _, m := range nc.InFlight {
+			n.InFlight = append(n.InFlight, raft.DescribeMessage(m, nil))
+		}
+		nodes[i] = n
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nodes); err != nil {
+		plog.Warningf("failed to encode raft cut (%v)", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
+)
+
+type fakeRaftCutter struct {
+	cut *raft.Cut
+	err error
+}
+
+func (c *fakeRaftCutter) RaftCut(ctx context.Context) (*raft.Cut, error) { return c.cut, c.err }
+
+func TestServeRaftCut(t *testing.T) {
+	cut := &raft.Cut{ID: 1, Nodes: []raft.NodeCut{
+		{ID: 1, Term: 2, Vote: 1, Lead: 1, RaftState: raft.StateLeader, Committed: 3, Applied: 3,
+			Log: []raftpb.Entry{{Term: 1, Index: 1}, {Term: 2, Index: 3}}},
+		{ID: 2, Term: 2, Vote: 1, Lead: 1, RaftState: raft.StateFollower, Committed: 2, Applied: 2,
+			Log:      []raftpb.Entry{{Term: 1, Index: 1}},
+			InFlight: []raftpb.Message{{Type: raftpb.MsgApp, From: 1, To: 2, Term: 2, LogTerm: 1, Index: 1}}},
+	}}
+	tests := []struct {
+		method string
+		query  string
+		cutter *fakeRaftCutter
+
+		wcode int
+		wlog  bool
+	}{
+		{"GET", "", &fakeRaftCutter{cut: cut}, http.StatusOK, false},
+		{"GET", "?log=true", &fakeRaftCutter{cut: cut}, http.StatusOK, true},
+		{"GET", "", &fakeRaftCutter{err: errors.New("timeout")}, http.StatusServiceUnavailable, false},
+		{"PUT", "", &fakeRaftCutter{cut: cut}, http.StatusMethodNotAllowed, false},
+	}
+	for i, tt := range tests {
+		h := &raftCutHandler{cutter: tt.cutter, timeout: time.Second}
+		req, err := http.NewRequest(tt.method, "http://localhost"+raftCutPrefix+tt.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+		if rw.Code != tt.wcode {
+			t.Fatalf("#%d: code = %d, want %d", i, rw.Code, tt.wcode)
+		}
+		if rw.Code != http.StatusOK {
+			continue
+		}
+
+		var nodes []raftCutNode
+		if err := json.Unmarshal(rw.Body.Bytes(), &nodes); err != nil {
+			t.Fatalf("#%d: unexpected unmarshal error: %v", i, err)
+		}
+		if len(nodes) != 2 {
+			t.Fatalf("#%d: len(nodes) = %d, want 2", i, len(nodes))
+		}
+		if nodes[0].State != "StateLeader" || nodes[0].LastIndex != 3 || nodes[0].LastTerm != 2 {
+			t.Errorf("#%d: node = %+v, want leader with last entry 3 at term 2", i, nodes[0])
+		}
+		if g := len(nodes[1].InFlight); g != 1 {
+			t.Errorf("#%d: len(inFlight) = %d, want 1", i, g)
+		}
+		if g := len(nodes[0].Log) > 0; g != tt.wlog {
+			t.Errorf("#%d: log included = %v, want %v", i, g, tt.wlog)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/etcd/raft"
+)
+
+const raftFaultsPrefix = "/debug/raftfaults"
+
+// raftFault is the JSON representation of a raft fault point.
+type raftFault struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Spec        string `json:"spec,omitempty"`
+	Hits        int    `json:"hits"`
+	Fired       int    `json:"fired"`
+}
+
+// raftFaultsHandler lists the raft fault points on GET of raftFaultsPrefix,
+// enables the named fault point with the spec in the request body on PUT of
+// raftFaultsPrefix/<name>, and disables it on DELETE.
+type raftFaultsHandler struct {
+	faults *raft.FaultInjector
+}
+
+func (h *raftFaultsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, raftFaultsPrefix), "/")
+	if name == "" {
+		if !allowMethod(w, r.Method, "GET") {
+			return
+		}
+		var rfs []raftFault
+		for _, fs := range h.faults.Status() {
+			rf := raftFault{
+				Name:        fs.Name,
+				Description: fs.Description,
+				Enabled:     fs.Enabled,
+				Hits:        fs.Hits,
+				Fired:       fs.Fired,
+			}
+			if fs.Enabled {
+				rf.Spec = fs.Spec.String()
+			}
+			rfs = append(rfs, rf)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rfs); err != nil {
+			plog.Warningf("failed to encode raft faults (%v)", err)
+		}
+		return
+	}
+
+	if !allowMethod(w, r.Method, "PUT", "DELETE") {
+		return
+	}
+	var err error
+	switch r.Method {
+	case "PUT":
+		var b []byte
+		if b, err = ioutil.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var spec raft.FaultSpec
+		if spec, err = raft.ParseFaultSpec(string(b)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = h.faults.Enable(name, spec)
+	case "DELETE":
+		err = h.faults.Disable(name)
+	}
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case raft.ErrFaultNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
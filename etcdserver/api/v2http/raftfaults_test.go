@@ -0,0 +1,84 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/raft"
+)
+
+func TestServeRaftFaults(t *testing.T) {
+	h := &raftFaultsHandler{faults: raft.NewFaultInjector(1)}
+	tests := []struct {
+		method string
+		path   string
+		body   string
+
+		wcode    int
+		wenabled map[string]string
+	}{
+		{"GET", raftFaultsPrefix, "", http.StatusOK, map[string]string{}},
+		{"PUT", raftFaultsPrefix + "/" + raft.FaultFalseLeader, "role=leader,trigger=3", http.StatusNoContent,
+			map[string]string{raft.FaultFalseLeader: "role=leader,trigger=3"}},
+		{"PUT", raftFaultsPrefix + "/" + raft.FaultCorruptEntry, "role=follower,probability=0.5", http.StatusNoContent,
+			map[string]string{raft.FaultFalseLeader: "role=leader,trigger=3", raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"DELETE", raftFaultsPrefix + "/" + raft.FaultFalseLeader, "", http.StatusNoContent,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"PUT", raftFaultsPrefix + "/unknown", "trigger=1", http.StatusNotFound,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"DELETE", raftFaultsPrefix + "/unknown", "", http.StatusNotFound,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"PUT", raftFaultsPrefix + "/" + raft.FaultFalseLeader, "probability=2", http.StatusBadRequest,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"POST", raftFaultsPrefix + "/" + raft.FaultFalseLeader, "trigger=1", http.StatusMethodNotAllowed,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+		{"PUT", raftFaultsPrefix, "trigger=1", http.StatusMethodNotAllowed,
+			map[string]string{raft.FaultCorruptEntry: "role=follower,probability=0.5"}},
+	}
+	for i, tt := range tests {
+		req, err := http.NewRequest(tt.method, "http://example.com"+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+		if rw.Code != tt.wcode {
+			t.Errorf("#%d: code = %d, want %d (%s)", i, rw.Code, tt.wcode, rw.Body.String())
+		}
+
+		if req, err = http.NewRequest("GET", "http://example.com"+raftFaultsPrefix, nil); err != nil {
+			t.Fatal(err)
+		}
+		rw = httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+		var rfs []raftFault
+		if err := json.Unmarshal(rw.Body.Bytes(), &rfs); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if len(rfs) != 3 {
+			t.Errorf("#%d: len(faults) = %d, want 3", i, len(rfs))
+		}
+		for _, rf := range rfs {
+			if spec, ok := tt.wenabled[rf.Name]; rf.Enabled != ok || rf.Spec != spec {
+				t.Errorf("#%d: %s = %v %q, want %v %q", i, rf.Name, rf.Enabled, rf.Spec, ok, spec)
+			}
+		}
+	}
+}
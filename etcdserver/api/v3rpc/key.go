@@ -16,8 +16,6 @@
 package v3rpc
 
 import (
-	"sort"
-
 	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
@@ -174,60 +172,92 @@ func checkTxnRequest(r *pb.TxnRequest) error {
 
 // checkRequestDupKeys gives rpctypes.ErrGRPCDuplicateKey if the same key is modified twice
 func checkRequestDupKeys(reqs []*pb.RequestOp) error {
-	// check put overlap
-	keys := make(map[string]struct{})
-	for _, requ := range reqs {
-		tv, ok := requ.Request.(*pb.RequestOp_RequestPut)
-		if !ok {
-			continue
+	_, _, err := checkRequestKeys(reqs)
+	return err
+}
+
+// checkRequestKeys returns the keys put and the ranges deleted by reqs,
+// including those of the nested txns. Only one branch of a nested txn is
+// executed, so the operations of its success and failure branches never
+// overlap each other, but both may overlap the other operations of reqs.
+func checkRequestKeys(reqs []*pb.RequestOp) (map[string]struct{}, []*pb.DeleteRangeRequest, error) {
+	puts := make(map[string]struct{})
+	var dels []*pb.DeleteRangeRequest
+
+	// add merges the keys of an operation into puts and dels, checking for
+	// overlaps with the operations merged before; delete overlaps are permitted.
+	add := func(ps map[string]struct{}, ds []*pb.DeleteRangeRequest) error {
+		for k := range ps {
+			if _, ok := puts[k]; ok {
+				return rpctypes.ErrGRPCDuplicateKey
+			}
+			for _, d := range dels {
+				if deletesKey(d, k) {
+					return rpctypes.ErrGRPCDuplicateKey
+				}
+			}
 		}
-		preq := tv.RequestPut
-		if preq == nil {
-			continue
+		for _, d := range ds {
+			for k := range puts {
+				if deletesKey(d, k) {
+					return rpctypes.ErrGRPCDuplicateKey
+				}
+			}
 		}
-		if _, ok := keys[string(preq.Key)]; ok {
-			return rpctypes.ErrGRPCDuplicateKey
+		for k := range ps {
+			puts[k] = struct{}{}
 		}
-		keys[string(preq.Key)] = struct{}{}
-	}
-
-	// no need to check deletes if no puts; delete overlaps are permitted
-	if len(keys) == 0 {
+		dels = append(dels, ds...)
 		return nil
 	}
 
-	// sort keys for range checking
-	sortedKeys := []string{}
-	for k := range keys {
-		sortedKeys = append(sortedKeys, k)
-	}
-	sort.Strings(sortedKeys)
-
-	// check put overlap with deletes
 	for _, requ := range reqs {
-		tv, ok := requ.Request.(*pb.RequestOp_RequestDeleteRange)
-		if !ok {
-			continue
-		}
-		dreq := tv.RequestDeleteRange
-		if dreq == nil {
-			continue
-		}
-		if dreq.RangeEnd == nil {
-			if _, found := keys[string(dreq.Key)]; found {
-				return rpctypes.ErrGRPCDuplicateKey
+		var err error
+		switch tv := requ.Request.(type) {
+		case *pb.RequestOp_RequestPut:
+			if tv.RequestPut == nil {
+				continue
 			}
-		} else {
-			lo := sort.SearchStrings(sortedKeys, string(dreq.Key))
-			hi := sort.SearchStrings(sortedKeys, string(dreq.RangeEnd))
-			if lo != hi {
-				// element between lo and hi => overlap
-				return rpctypes.ErrGRPCDuplicateKey
+			err = add(map[string]struct{}{string(tv.RequestPut.Key): {}}, nil)
+		case *pb.RequestOp_RequestDeleteRange:
+			if tv.RequestDeleteRange == nil {
+				continue
+			}
+			err = add(nil, []*pb.DeleteRangeRequest{tv.RequestDeleteRange})
+		case *pb.RequestOp_RequestTxn:
+			if tv.RequestTxn == nil {
+				continue
+			}
+			sputs, sdels, serr := checkRequestKeys(tv.RequestTxn.Success)
+			if serr != nil {
+				return nil, nil, serr
+			}
+			fputs, fdels, ferr := checkRequestKeys(tv.RequestTxn.Failure)
+			if ferr != nil {
+				return nil, nil, ferr
+			}
+			for k := range fputs {
+				sputs[k] = struct{}{}
 			}
+			err = add(sputs, append(sdels, fdels...))
+		}
+		if err != nil {
+			return nil, nil, err
 		}
 	}
+	return puts, dels, nil
+}
 
-	return nil
+// deletesKey returns true if the delete request d deletes key.
+func deletesKey(d *pb.DeleteRangeRequest, key string) bool {
+	if d.RangeEnd == nil {
+		return string(d.Key) == key
+	}
+	if key < string(d.Key) {
+		return false
+	}
+	// range_end '\0' deletes all keys >= key
+	return (len(d.RangeEnd) == 1 && d.RangeEnd[0] == 0) || key < string(d.RangeEnd)
 }
 
 func checkRequestOp(u *pb.RequestOp) error {
@@ -245,6 +275,10 @@ func checkRequestOp(u *pb.RequestOp) error {
 		if uv.RequestDeleteRange != nil {
 			return checkDeleteRequest(uv.RequestDeleteRange)
 		}
+	case *pb.RequestOp_RequestTxn:
+		if uv.RequestTxn != nil {
+			return checkTxnRequest(uv.RequestTxn)
+		}
 	default:
 		// empty op
 		return nil
@@ -43,13 +43,11 @@ func NewClusterServer(s *etcdserver.EtcdServer) *ClusterServer {
 }
 
 func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest) (*pb.MemberAddResponse, error) {
-	urls, err := types.NewURLs(r.PeerURLs)
+	now := time.Now()
+	m, err := newMember(r, &now)
 	if err != nil {
-		return nil, rpctypes.ErrGRPCMemberBadURLs
+		return nil, err
 	}
-
-	now := time.Now()
-	m := membership.NewMember("", urls, "", &now)
 	err = cs.server.AddMember(ctx, *m)
 	switch {
 	case err == membership.ErrIDExists:
@@ -62,10 +60,27 @@ func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest)
 
 	return &pb.MemberAddResponse{
 		Header: cs.header(),
-		Member: &pb.Member{ID: uint64(m.ID), PeerURLs: m.PeerURLs},
+		Member: &pb.Member{ID: uint64(m.ID), PeerURLs: m.PeerURLs, IsLearner: m.IsLearner, IsWitness: m.IsWitness},
 	}, nil
 }
 
+// newMember creates the member requested by r.
+func newMember(r *pb.MemberAddRequest, now *time.Time) (*membership.Member, error) {
+	urls, err := types.NewURLs(r.PeerURLs)
+	if err != nil {
+		return nil, rpctypes.ErrGRPCMemberBadURLs
+	}
+	switch {
+	case r.IsLearner && r.IsWitness:
+		return nil, rpctypes.ErrGRPCLearnerWitness
+	case r.IsLearner:
+		return membership.NewMemberAsLearner("", urls, "", now), nil
+	case r.IsWitness:
+		return membership.NewMemberAsWitness("", urls, "", now), nil
+	}
+	return membership.NewMember("", urls, "", now), nil
+}
+
 func (cs *ClusterServer) MemberRemove(ctx context.Context, r *pb.MemberRemoveRequest) (*pb.MemberRemoveResponse, error) {
 	err := cs.server.RemoveMember(ctx, r.ID)
 	switch {
@@ -99,6 +114,63 @@ func (cs *ClusterServer) MemberUpdate(ctx context.Context, r *pb.MemberUpdateReq
 }
 
 func (cs *ClusterServer) MemberList(ctx context.Context, r *pb.MemberListRequest) (*pb.MemberListResponse, error) {
+	return &pb.MemberListResponse{Header: cs.header(), Members: cs.protoMembers()}, nil
+}
+
+func (cs *ClusterServer) MemberPromote(ctx context.Context, r *pb.MemberPromoteRequest) (*pb.MemberPromoteResponse, error) {
+	err := cs.server.PromoteMember(ctx, r.ID)
+	switch {
+	case err == membership.ErrIDRemoved:
+		fallthrough
+	case err == membership.ErrIDNotFound:
+		return nil, rpctypes.ErrGRPCMemberNotFound
+	case err == membership.ErrMemberNotLearner:
+		return nil, rpctypes.ErrGRPCMemberNotLearner
+	case err == etcdserver.ErrLearnerNotReady:
+		return nil, rpctypes.ErrGRPCLearnerNotReady
+	case err == etcdserver.ErrNotLeader:
+		return nil, rpctypes.ErrGRPCNotLeader
+	case err != nil:
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.MemberPromoteResponse{Header: cs.header(), Members: cs.protoMembers()}, nil
+}
+
+func (cs *ClusterServer) MemberReconfigure(ctx context.Context, r *pb.MemberReconfigureRequest) (*pb.MemberReconfigureResponse, error) {
+	now := time.Now()
+	add := make([]membership.Member, len(r.Add))
+	for i, ar := range r.Add {
+		m, err := newMember(ar, &now)
+		if err != nil {
+			return nil, err
+		}
+		add[i] = *m
+	}
+	err := cs.server.ReconfigureMembers(ctx, add, r.Remove)
+	switch {
+	case err == membership.ErrIDExists:
+		return nil, rpctypes.ErrGRPCMemberExist
+	case err == membership.ErrPeerURLexists:
+		return nil, rpctypes.ErrGRPCPeerURLExist
+	case err == membership.ErrIDRemoved:
+		fallthrough
+	case err == membership.ErrIDNotFound:
+		return nil, rpctypes.ErrGRPCMemberNotFound
+	case err == etcdserver.ErrJointConfChangeInProgress:
+		return nil, rpctypes.ErrGRPCJointConfChangeInProgress
+	case err != nil:
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	added := make([]*pb.Member, len(add))
+	for i, m := range add {
+		added[i] = &pb.Member{ID: uint64(m.ID), PeerURLs: m.PeerURLs, IsLearner: m.IsLearner, IsWitness: m.IsWitness}
+	}
+	return &pb.MemberReconfigureResponse{Header: cs.header(), Added: added, Members: cs.protoMembers()}, nil
+}
+
+func (cs *ClusterServer) protoMembers() []*pb.Member {
 	membs := cs.cluster.Members()
 
 	protoMembs := make([]*pb.Member, len(membs))
@@ -108,10 +180,11 @@ func (cs *ClusterServer) MemberList(ctx context.Context, r *pb.MemberListRequest
 			ID:         uint64(membs[i].ID),
 			PeerURLs:   membs[i].PeerURLs,
 			ClientURLs: membs[i].ClientURLs,
+			IsLearner:  membs[i].IsLearner,
+			IsWitness:  membs[i].IsWitness,
 		}
 	}
-
-	return &pb.MemberListResponse{Header: cs.header(), Members: protoMembs}, nil
+	return protoMembs
 }
 
 func (cs *ClusterServer) header() *pb.ResponseHeader {
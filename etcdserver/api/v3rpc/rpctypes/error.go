@@ -28,6 +28,8 @@ var (
 	ErrGRPCFutureRev    = grpc.Errorf(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace      = grpc.Errorf(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
 
+	ErrGRPCInvalidContinueToken = grpc.Errorf(codes.InvalidArgument, "etcdserver: invalid continue token")
+
 	ErrGRPCLeaseNotFound = grpc.Errorf(codes.NotFound, "etcdserver: requested lease not found")
 	ErrGRPCLeaseExist    = grpc.Errorf(codes.FailedPrecondition, "etcdserver: lease already exists")
 
@@ -36,7 +38,15 @@ var (
 	ErrGRPCMemberBadURLs  = grpc.Errorf(codes.InvalidArgument, "etcdserver: given member URLs are invalid")
 	ErrGRPCMemberNotFound = grpc.Errorf(codes.NotFound, "etcdserver: member not found")
 
+	ErrGRPCMemberNotLearner = grpc.Errorf(codes.FailedPrecondition, "etcdserver: can only promote a learner member")
+	ErrGRPCLearnerNotReady  = grpc.Errorf(codes.FailedPrecondition, "etcdserver: can only promote a learner member which is in sync with leader")
+	ErrGRPCLearnerWitness   = grpc.Errorf(codes.InvalidArgument, "etcdserver: a member cannot be both a learner and a witness")
+
+	ErrGRPCJointConfChangeInProgress = grpc.Errorf(codes.FailedPrecondition, "etcdserver: a joint configuration change is in progress")
+	ErrGRPCTransfereeNotVoter        = grpc.Errorf(codes.FailedPrecondition, "etcdserver: can only transfer leadership to a voting member")
+
 	ErrGRPCRequestTooLarge = grpc.Errorf(codes.InvalidArgument, "etcdserver: request is too large")
+	ErrGRPCTooManyRequests = grpc.Errorf(codes.ResourceExhausted, "etcdserver: too many requests")
 
 	ErrGRPCRootUserNotExist     = grpc.Errorf(codes.FailedPrecondition, "etcdserver: root user does not exist")
 	ErrGRPCRootRoleNotExist     = grpc.Errorf(codes.FailedPrecondition, "etcdserver: root user does not have root role")
@@ -51,8 +61,11 @@ var (
 	ErrGRPCAuthNotEnabled       = grpc.Errorf(codes.FailedPrecondition, "etcdserver: authentication is not enabled")
 
 	ErrGRPCNoLeader   = grpc.Errorf(codes.Unavailable, "etcdserver: no leader")
+	ErrGRPCNotLeader  = grpc.Errorf(codes.Unavailable, "etcdserver: not leader")
 	ErrGRPCNotCapable = grpc.Errorf(codes.Unavailable, "etcdserver: not capable")
 
+	ErrGRPCTimeoutLeaderTransfer = grpc.Errorf(codes.Unavailable, "etcdserver: request timed out, leader transfer took too long")
+
 	errStringToError = map[string]error{
 		grpc.ErrorDesc(ErrGRPCEmptyKey):     ErrGRPCEmptyKey,
 		grpc.ErrorDesc(ErrGRPCTooManyOps):   ErrGRPCTooManyOps,
@@ -61,6 +74,8 @@ var (
 		grpc.ErrorDesc(ErrGRPCFutureRev):    ErrGRPCFutureRev,
 		grpc.ErrorDesc(ErrGRPCNoSpace):      ErrGRPCNoSpace,
 
+		grpc.ErrorDesc(ErrGRPCInvalidContinueToken): ErrGRPCInvalidContinueToken,
+
 		grpc.ErrorDesc(ErrGRPCLeaseNotFound): ErrGRPCLeaseNotFound,
 		grpc.ErrorDesc(ErrGRPCLeaseExist):    ErrGRPCLeaseExist,
 
@@ -69,7 +84,15 @@ var (
 		grpc.ErrorDesc(ErrGRPCMemberBadURLs):  ErrGRPCMemberBadURLs,
 		grpc.ErrorDesc(ErrGRPCMemberNotFound): ErrGRPCMemberNotFound,
 
+		grpc.ErrorDesc(ErrGRPCMemberNotLearner): ErrGRPCMemberNotLearner,
+		grpc.ErrorDesc(ErrGRPCLearnerNotReady):  ErrGRPCLearnerNotReady,
+		grpc.ErrorDesc(ErrGRPCLearnerWitness):   ErrGRPCLearnerWitness,
+
+		grpc.ErrorDesc(ErrGRPCJointConfChangeInProgress): ErrGRPCJointConfChangeInProgress,
+		grpc.ErrorDesc(ErrGRPCTransfereeNotVoter):        ErrGRPCTransfereeNotVoter,
+
 		grpc.ErrorDesc(ErrGRPCRequestTooLarge): ErrGRPCRequestTooLarge,
+		grpc.ErrorDesc(ErrGRPCTooManyRequests): ErrGRPCTooManyRequests,
 
 		grpc.ErrorDesc(ErrGRPCRootUserNotExist):     ErrGRPCRootUserNotExist,
 		grpc.ErrorDesc(ErrGRPCRootRoleNotExist):     ErrGRPCRootRoleNotExist,
@@ -84,7 +107,10 @@ var (
 		grpc.ErrorDesc(ErrGRPCAuthNotEnabled):       ErrGRPCAuthNotEnabled,
 
 		grpc.ErrorDesc(ErrGRPCNoLeader):   ErrGRPCNoLeader,
+		grpc.ErrorDesc(ErrGRPCNotLeader):  ErrGRPCNotLeader,
 		grpc.ErrorDesc(ErrGRPCNotCapable): ErrGRPCNotCapable,
+
+		grpc.ErrorDesc(ErrGRPCTimeoutLeaderTransfer): ErrGRPCTimeoutLeaderTransfer,
 	}
 
 	// client-side error
@@ -95,6 +121,8 @@ var (
 	ErrFutureRev    = Error(ErrGRPCFutureRev)
 	ErrNoSpace      = Error(ErrGRPCNoSpace)
 
+	ErrInvalidContinueToken = Error(ErrGRPCInvalidContinueToken)
+
 	ErrLeaseNotFound = Error(ErrGRPCLeaseNotFound)
 	ErrLeaseExist    = Error(ErrGRPCLeaseExist)
 
@@ -103,7 +131,15 @@ var (
 	ErrMemberBadURLs  = Error(ErrGRPCMemberBadURLs)
 	ErrMemberNotFound = Error(ErrGRPCMemberNotFound)
 
+	ErrMemberNotLearner = Error(ErrGRPCMemberNotLearner)
+	ErrLearnerNotReady  = Error(ErrGRPCLearnerNotReady)
+	ErrLearnerWitness   = Error(ErrGRPCLearnerWitness)
+
+	ErrJointConfChangeInProgress = Error(ErrGRPCJointConfChangeInProgress)
+	ErrTransfereeNotVoter        = Error(ErrGRPCTransfereeNotVoter)
+
 	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
+	ErrTooManyRequests = Error(ErrGRPCTooManyRequests)
 
 	ErrRootUserNotExist     = Error(ErrGRPCRootUserNotExist)
 	ErrRootRoleNotExist     = Error(ErrGRPCRootRoleNotExist)
@@ -118,7 +154,10 @@ var (
 	ErrAuthNotEnabled       = Error(ErrGRPCAuthNotEnabled)
 
 	ErrNoLeader   = Error(ErrGRPCNoLeader)
+	ErrNotLeader  = Error(ErrGRPCNotLeader)
 	ErrNotCapable = Error(ErrGRPCNotCapable)
+
+	ErrTimeoutLeaderTransfer = Error(ErrGRPCTimeoutLeaderTransfer)
 )
 
 // EtcdError defines gRPC server errors.
@@ -18,6 +18,7 @@ import (
 	"github.com/coreos/etcd/auth"
 	"github.com/coreos/etcd/etcdserver"
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/mvcc"
 	"google.golang.org/grpc"
@@ -35,8 +36,22 @@ func togRPCError(err error) error {
 	// TODO: handle error from raft and timeout
 	case etcdserver.ErrRequestTooLarge:
 		return rpctypes.ErrGRPCRequestTooLarge
+	case etcdserver.ErrTooManyRequests:
+		return rpctypes.ErrGRPCTooManyRequests
+	case etcdserver.ErrInvalidContinueToken:
+		return rpctypes.ErrGRPCInvalidContinueToken
 	case etcdserver.ErrNoSpace:
 		return rpctypes.ErrGRPCNoSpace
+	case etcdserver.ErrNoLeader:
+		return rpctypes.ErrGRPCNoLeader
+	case etcdserver.ErrNotLeader:
+		return rpctypes.ErrGRPCNotLeader
+	case etcdserver.ErrTimeoutLeaderTransfer:
+		return rpctypes.ErrGRPCTimeoutLeaderTransfer
+	case etcdserver.ErrTransfereeNotVoter:
+		return rpctypes.ErrGRPCTransfereeNotVoter
+	case membership.ErrIDNotFound:
+		return rpctypes.ErrGRPCMemberNotFound
 
 	case auth.ErrRootUserNotExist:
 		return rpctypes.ErrGRPCRootUserNotExist
@@ -16,6 +16,7 @@ package etcdserver
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"sort"
 	"time"
@@ -50,6 +51,10 @@ type applyResult struct {
 // applierV3 is the interface for processing V3 raft messages
 type applierV3 interface {
 	Apply(r *pb.InternalRaftRequest) *applyResult
+	// Batch applies the put and delete range requests batched into one
+	// entry in a single txn, each at its own revision, and returns their
+	// results in order.
+	Batch(reqs []*pb.InternalRaftRequest) []*applyResult
 
 	Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse, error)
 	Range(txnID int64, r *pb.RangeRequest) (*pb.RangeResponse, error)
@@ -59,6 +64,7 @@ type applierV3 interface {
 
 	LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
 	LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
+	LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error)
 
 	Alarm(*pb.AlarmRequest) (*pb.AlarmResponse, error)
 
@@ -112,6 +118,8 @@ func (a *applierV3backend) Apply(r *pb.InternalRaftRequest) *applyResult {
 		ar.resp, ar.err = a.s.applyV3.LeaseGrant(r.LeaseGrant)
 	case r.LeaseRevoke != nil:
 		ar.resp, ar.err = a.s.applyV3.LeaseRevoke(r.LeaseRevoke)
+	case r.LeaseCheckpoint != nil:
+		ar.resp, ar.err = a.s.applyV3.LeaseCheckpoint(r.LeaseCheckpoint)
 	case r.Alarm != nil:
 		ar.resp, ar.err = a.s.applyV3.Alarm(r.Alarm)
 	case r.Authenticate != nil:
@@ -152,6 +160,56 @@ func (a *applierV3backend) Apply(r *pb.InternalRaftRequest) *applyResult {
 	return ar
 }
 
+func (a *applierV3backend) Batch(reqs []*pb.InternalRaftRequest) []*applyResult {
+	ars := make([]*applyResult, len(reqs))
+
+	// Readers either see all the requests of the batch or none of them.
+	txnID := a.s.KV().TxnBegin()
+	for i, r := range reqs {
+		ar := &applyResult{}
+		switch {
+		case r.Put != nil:
+			ar.resp, ar.err = a.Put(txnID, r.Put)
+		case r.DeleteRange != nil:
+			ar.resp, ar.err = a.DeleteRange(txnID, r.DeleteRange)
+		default:
+			plog.Panicf("unexpected request %v in batch", r)
+		}
+		ars[i] = ar
+		// the next request gets its own revision, just as if it had
+		// been proposed on its own.
+		if err := a.s.KV().TxnNextRev(txnID); err != nil {
+			plog.Panicf("unexpected error when moving txn %d to the next revision (%v)", txnID, err)
+		}
+	}
+	if err := a.s.KV().TxnEnd(txnID); err != nil {
+		plog.Panicf("unexpected error when closing txn %d (%v)", txnID, err)
+	}
+	return ars
+}
+
+// batchRest applies with app the requests of reqs which have no result in
+// ars yet, and fills their results in.
+func batchRest(app applierV3, reqs []*pb.InternalRaftRequest, ars []*applyResult) []*applyResult {
+	var (
+		rest []*pb.InternalRaftRequest
+		idxs []int
+	)
+	for i, r := range reqs {
+		if ars[i] == nil {
+			rest = append(rest, r)
+			idxs = append(idxs, i)
+		}
+	}
+	if len(rest) == 0 {
+		return ars
+	}
+	for i, ar := range app.Batch(rest) {
+		ars[idxs[i]] = ar
+	}
+	return ars
+}
+
 func (a *applierV3backend) Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse, error) {
 	resp := &pb.PutResponse{}
 	resp.Header = &pb.ResponseHeader{}
@@ -160,6 +218,15 @@ func (a *applierV3backend) Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse,
 		err error
 	)
 
+	// the lease is checked before the txn writes anything, since the store
+	// cannot attach a key to a missing lease.
+	leaseID := lease.LeaseID(p.Lease)
+	if leaseID != lease.NoLease {
+		if l := a.s.lessor.Lookup(leaseID); l == nil {
+			return nil, lease.ErrLeaseNotFound
+		}
+	}
+
 	var rr *mvcc.RangeResult
 	if p.PrevKv {
 		if txnID != noTxn {
@@ -176,17 +243,11 @@ func (a *applierV3backend) Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse,
 	}
 
 	if txnID != noTxn {
-		rev, err = a.s.KV().TxnPut(txnID, p.Key, p.Value, lease.LeaseID(p.Lease))
+		rev, err = a.s.KV().TxnPut(txnID, p.Key, p.Value, leaseID)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		leaseID := lease.LeaseID(p.Lease)
-		if leaseID != lease.NoLease {
-			if l := a.s.lessor.Lookup(leaseID); l == nil {
-				return nil, lease.ErrLeaseNotFound
-			}
-		}
 		rev = a.s.KV().Put(p.Key, p.Value, leaseID)
 	}
 	resp.Header.Revision = rev
@@ -257,6 +318,13 @@ func (a *applierV3backend) Range(txnID int64, r *pb.RangeRequest) (*pb.RangeResp
 		r.RangeEnd = []byte{}
 	}
 
+	key, rev := r.Key, r.Revision
+	if len(r.ContinueToken) != 0 {
+		if key, rev, err = decodeContinueToken(r); err != nil {
+			return nil, err
+		}
+	}
+
 	limit := r.Limit
 	if r.SortOrder != pb.RangeRequest_NONE {
 		// fetch everything; sort and truncate afterwards
@@ -268,18 +336,22 @@ func (a *applierV3backend) Range(txnID int64, r *pb.RangeRequest) (*pb.RangeResp
 	}
 
 	ro := mvcc.RangeOptions{
-		Limit: limit,
-		Rev:   r.Revision,
-		Count: r.CountOnly,
+		Limit:        limit,
+		Rev:          rev,
+		Count:        r.CountOnly,
+		MinModRev:    r.MinModRevision,
+		MaxModRev:    r.MaxModRevision,
+		MinCreateRev: r.MinCreateRevision,
+		MaxCreateRev: r.MaxCreateRevision,
 	}
 
 	if txnID != noTxn {
-		rr, err = a.s.KV().TxnRange(txnID, r.Key, r.RangeEnd, ro)
+		rr, err = a.s.KV().TxnRange(txnID, key, r.RangeEnd, ro)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		rr, err = a.s.KV().Range(r.Key, r.RangeEnd, ro)
+		rr, err = a.s.KV().Range(key, r.RangeEnd, ro)
 		if err != nil {
 			return nil, err
 		}
@@ -308,6 +380,15 @@ func (a *applierV3backend) Range(txnID int64, r *pb.RangeRequest) (*pb.RangeResp
 	}
 
 	if r.Limit > 0 && len(rr.KVs) > int(r.Limit) {
+		if r.SortOrder == pb.RangeRequest_NONE ||
+			(r.SortTarget == pb.RangeRequest_KEY && r.SortOrder == pb.RangeRequest_ASCEND) {
+			if rev <= 0 {
+				// the range was over the current revision.
+				rev = rr.Rev
+			}
+			// resume from the first key left out.
+			resp.ContinueToken = encodeContinueToken(rev, rr.KVs[r.Limit].Key)
+		}
 		rr.KVs = rr.KVs[:r.Limit]
 		resp.More = true
 	}
@@ -324,31 +405,20 @@ func (a *applierV3backend) Range(txnID int64, r *pb.RangeRequest) (*pb.RangeResp
 }
 
 func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
-	ok := true
-	for _, c := range rt.Compare {
-		if _, ok = a.applyCompare(c); !ok {
-			break
-		}
-	}
-
-	var reqs []*pb.RequestOp
-	if ok {
-		reqs = rt.Success
-	} else {
-		reqs = rt.Failure
-	}
+	txnPath := a.compareToPath(rt)
 
-	if err := a.checkRequestLeases(reqs); err != nil {
+	if _, err := checkRequests(rt, txnPath, a.checkRequestLease); err != nil {
 		return nil, err
 	}
-	if err := a.checkRequestRange(reqs); err != nil {
+	if _, err := checkRequests(rt, txnPath, a.checkRequestRange); err != nil {
 		return nil, err
 	}
 
 	revision := a.s.KV().Rev()
 
 	// When executing the operations of txn, we need to hold the txn lock.
-	// So the reader will not see any intermediate results.
+	// So the reader will not see any intermediate results. The operations
+	// of the nested txns are executed within the same txn.
 	txnID := a.s.KV().TxnBegin()
 	defer func() {
 		err := a.s.KV().TxnEnd(txnID)
@@ -357,32 +427,100 @@ func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 		}
 	}()
 
+	txnResp, _, changedKV := a.applyTxn(txnID, rt, txnPath)
+
+	if changedKV {
+		revision += 1
+	}
+	txnResp.Header.Revision = revision
+	return txnResp, nil
+}
+
+// compareToPath evaluates the compares of the txn and of the nested txns on
+// the branches it takes. The path holds, in depth-first order, whether the
+// compares of each txn executed succeeded.
+func (a *applierV3backend) compareToPath(rt *pb.TxnRequest) []bool {
+	txnPath := []bool{true}
+	for _, c := range rt.Compare {
+		if _, txnPath[0] = a.applyCompare(c); !txnPath[0] {
+			break
+		}
+	}
+	for _, requ := range txnBranch(rt, txnPath[0]) {
+		if tv, ok := requ.Request.(*pb.RequestOp_RequestTxn); ok && tv.RequestTxn != nil {
+			txnPath = append(txnPath, a.compareToPath(tv.RequestTxn)...)
+		}
+	}
+	return txnPath
+}
+
+// txnBranch returns the operations of the txn to execute.
+func txnBranch(rt *pb.TxnRequest, succeeded bool) []*pb.RequestOp {
+	if succeeded {
+		return rt.Success
+	}
+	return rt.Failure
+}
+
+// checkRequests checks the operations the txn and its nested txns execute
+// following txnPath with f. It returns the number of txns checked.
+func checkRequests(rt *pb.TxnRequest, txnPath []bool, f func(*pb.RequestOp) error) (int, error) {
+	txnCount := 0
+	for _, requ := range txnBranch(rt, txnPath[0]) {
+		if tv, ok := requ.Request.(*pb.RequestOp_RequestTxn); ok && tv.RequestTxn != nil {
+			txns, err := checkRequests(tv.RequestTxn, txnPath[1+txnCount:], f)
+			if err != nil {
+				return 0, err
+			}
+			txnCount += txns
+			continue
+		}
+		if err := f(requ); err != nil {
+			return 0, err
+		}
+	}
+	return txnCount + 1, nil
+}
+
+// applyTxn executes the operations the txn and its nested txns execute
+// following txnPath. It returns the response of the txn, the number of txns
+// executed and whether any of the operations may have changed the store.
+func (a *applierV3backend) applyTxn(txnID int64, rt *pb.TxnRequest, txnPath []bool) (*pb.TxnResponse, int, bool) {
+	reqs := txnBranch(rt, txnPath[0])
 	resps := make([]*pb.ResponseOp, len(reqs))
+	txnCount := 0
 	changedKV := false
 	for i := range reqs {
+		if tv, ok := reqs[i].Request.(*pb.RequestOp_RequestTxn); ok && tv.RequestTxn != nil {
+			resp, txns, changed := a.applyTxn(txnID, tv.RequestTxn, txnPath[1+txnCount:])
+			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: resp}}
+			txnCount += txns
+			changedKV = changedKV || changed
+			continue
+		}
 		if reqs[i].GetRequestRange() == nil {
 			changedKV = true
 		}
 		resps[i] = a.applyUnion(txnID, reqs[i])
 	}
 
-	if changedKV {
-		revision += 1
-	}
-
 	txnResp := &pb.TxnResponse{}
 	txnResp.Header = &pb.ResponseHeader{}
-	txnResp.Header.Revision = revision
 	txnResp.Responses = resps
-	txnResp.Succeeded = ok
-	return txnResp, nil
+	txnResp.Succeeded = txnPath[0]
+	return txnResp, txnCount + 1, changedKV
 }
 
 // applyCompare applies the compare request.
 // It returns the revision at which the comparison happens. If the comparison
-// succeeds, the it returns true. Otherwise it returns false.
+// succeeds, the it returns true. Otherwise it returns false. A comparison
+// with a range end succeeds if it succeeds for every key in the range.
 func (a *applierV3backend) applyCompare(c *pb.Compare) (int64, bool) {
-	rr, err := a.s.KV().Range(c.Key, nil, mvcc.RangeOptions{})
+	end := c.RangeEnd
+	if isGteRange(end) {
+		end = []byte{}
+	}
+	rr, err := a.s.KV().Range(c.Key, end, mvcc.RangeOptions{})
 	rev := rr.Rev
 
 	if err != nil {
@@ -391,11 +529,7 @@ func (a *applierV3backend) applyCompare(c *pb.Compare) (int64, bool) {
 		}
 		return rev, false
 	}
-	var ckv mvccpb.KeyValue
-	if len(rr.KVs) != 0 {
-		ckv = rr.KVs[0]
-	} else {
-		// Use the zero value of ckv normally. However...
+	if len(rr.KVs) == 0 {
 		if c.Target == pb.Compare_VALUE {
 			// Always fail if we're comparing a value on a key that doesn't exist.
 			// We can treat non-existence as the empty set explicitly, such that
@@ -403,8 +537,18 @@ func (a *applierV3backend) applyCompare(c *pb.Compare) (int64, bool) {
 			// that was written that way
 			return rev, false
 		}
+		// Use the zero value of the key-value otherwise.
+		return rev, compareKV(c, mvccpb.KeyValue{})
+	}
+	for _, kv := range rr.KVs {
+		if !compareKV(c, kv) {
+			return rev, false
+		}
 	}
+	return rev, true
+}
 
+func compareKV(c *pb.Compare, ckv mvccpb.KeyValue) bool {
 	// -1 is less, 0 is equal, 1 is greater
 	var result int
 	switch c.Target {
@@ -433,19 +577,13 @@ func (a *applierV3backend) applyCompare(c *pb.Compare) (int64, bool) {
 
 	switch c.Result {
 	case pb.Compare_EQUAL:
-		if result != 0 {
-			return rev, false
-		}
+		return result == 0
 	case pb.Compare_GREATER:
-		if result != 1 {
-			return rev, false
-		}
+		return result == 1
 	case pb.Compare_LESS:
-		if result != -1 {
-			return rev, false
-		}
+		return result == -1
 	}
-	return rev, true
+	return true
 }
 
 func (a *applierV3backend) applyUnion(txnID int64, union *pb.RequestOp) *pb.ResponseOp {
@@ -512,6 +650,17 @@ func (a *applierV3backend) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevo
 	return &pb.LeaseRevokeResponse{Header: &pb.ResponseHeader{Revision: a.s.KV().Rev()}}, err
 }
 
+func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error) {
+	for _, c := range lc.Checkpoints {
+		err := a.s.lessor.Checkpoint(lease.LeaseID(c.ID), c.Remaining_TTL)
+		// the lease may have been revoked since it was checkpointed.
+		if err != nil && err != lease.ErrLeaseNotFound {
+			return nil, err
+		}
+	}
+	return &pb.LeaseCheckpointResponse{Header: &pb.ResponseHeader{Revision: a.s.KV().Rev()}}, nil
+}
+
 func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
 	resp := &pb.AlarmResponse{}
 	oldCount := len(a.s.alarmStore.Get(ar.Alarm))
@@ -574,6 +723,16 @@ func (a *applierV3Capped) Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse, e
 	return nil, ErrNoSpace
 }
 
+func (a *applierV3Capped) Batch(reqs []*pb.InternalRaftRequest) []*applyResult {
+	ars := make([]*applyResult, len(reqs))
+	for i, r := range reqs {
+		if r.Put != nil {
+			ars[i] = &applyResult{err: ErrNoSpace}
+		}
+	}
+	return batchRest(a.applierV3, reqs, ars)
+}
+
 func (a *applierV3Capped) Txn(r *pb.TxnRequest) (*pb.TxnResponse, error) {
 	if a.q.Cost(r) > 0 {
 		return nil, ErrNoSpace
@@ -673,6 +832,20 @@ func (a *quotaApplierV3) Put(txnID int64, p *pb.PutRequest) (*pb.PutResponse, er
 	return resp, err
 }
 
+func (a *quotaApplierV3) Batch(reqs []*pb.InternalRaftRequest) []*applyResult {
+	oks := make([]bool, len(reqs))
+	for i, r := range reqs {
+		oks[i] = r.Put == nil || a.q.Available(r.Put)
+	}
+	ars := a.applierV3.Batch(reqs)
+	for i, ar := range ars {
+		if ar.err == nil && !oks[i] {
+			ar.err = ErrNoSpace
+		}
+	}
+	return ars
+}
+
 func (a *quotaApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 	ok := a.q.Available(rt)
 	resp, err := a.applierV3.Txn(rt)
@@ -730,40 +903,36 @@ func (s *kvSortByValue) Less(i, j int) bool {
 	return bytes.Compare(s.kvs[i].Value, s.kvs[j].Value) < 0
 }
 
-func (a *applierV3backend) checkRequestLeases(reqs []*pb.RequestOp) error {
-	for _, requ := range reqs {
-		tv, ok := requ.Request.(*pb.RequestOp_RequestPut)
-		if !ok {
-			continue
-		}
-		preq := tv.RequestPut
-		if preq == nil || lease.LeaseID(preq.Lease) == lease.NoLease {
-			continue
-		}
-		if l := a.s.lessor.Lookup(lease.LeaseID(preq.Lease)); l == nil {
-			return lease.ErrLeaseNotFound
-		}
+func (a *applierV3backend) checkRequestLease(requ *pb.RequestOp) error {
+	tv, ok := requ.Request.(*pb.RequestOp_RequestPut)
+	if !ok {
+		return nil
+	}
+	preq := tv.RequestPut
+	if preq == nil || lease.LeaseID(preq.Lease) == lease.NoLease {
+		return nil
+	}
+	if l := a.s.lessor.Lookup(lease.LeaseID(preq.Lease)); l == nil {
+		return lease.ErrLeaseNotFound
 	}
 	return nil
 }
 
-func (a *applierV3backend) checkRequestRange(reqs []*pb.RequestOp) error {
-	for _, requ := range reqs {
-		tv, ok := requ.Request.(*pb.RequestOp_RequestRange)
-		if !ok {
-			continue
-		}
-		greq := tv.RequestRange
-		if greq == nil || greq.Revision == 0 {
-			continue
-		}
+func (a *applierV3backend) checkRequestRange(requ *pb.RequestOp) error {
+	tv, ok := requ.Request.(*pb.RequestOp_RequestRange)
+	if !ok {
+		return nil
+	}
+	greq := tv.RequestRange
+	if greq == nil || greq.Revision == 0 {
+		return nil
+	}
 
-		if greq.Revision > a.s.KV().Rev() {
-			return mvcc.ErrFutureRev
-		}
-		if greq.Revision < a.s.KV().FirstRev() {
-			return mvcc.ErrCompacted
-		}
+	if greq.Revision > a.s.KV().Rev() {
+		return mvcc.ErrFutureRev
+	}
+	if greq.Revision < a.s.KV().FirstRev() {
+		return mvcc.ErrCompacted
 	}
 	return nil
 }
@@ -779,6 +948,42 @@ func compareInt64(a, b int64) int {
 	}
 }
 
+// continueTokenVersion versions the encoding of continue tokens, which are
+// the version, the revision of the range and the key to resume from.
+const continueTokenVersion = 1
+
+func encodeContinueToken(rev int64, key []byte) []byte {
+	tok := make([]byte, 9+len(key))
+	tok[0] = continueTokenVersion
+	binary.BigEndian.PutUint64(tok[1:], uint64(rev))
+	copy(tok[9:], key)
+	return tok
+}
+
+// decodeContinueToken returns the key and revision to resume the range
+// request from. The key must be within the range of the request, and the
+// revision must match the revision of the request if one is given.
+func decodeContinueToken(r *pb.RangeRequest) (key []byte, rev int64, err error) {
+	tok := r.ContinueToken
+	if len(tok) <= 9 || tok[0] != continueTokenVersion {
+		return nil, 0, ErrInvalidContinueToken
+	}
+	rev, key = int64(binary.BigEndian.Uint64(tok[1:9])), tok[9:]
+	switch {
+	case rev <= 0:
+		return nil, 0, ErrInvalidContinueToken
+	case r.Revision > 0 && r.Revision != rev:
+		return nil, 0, ErrInvalidContinueToken
+	case bytes.Compare(key, r.Key) < 0:
+		return nil, 0, ErrInvalidContinueToken
+	case r.RangeEnd == nil && !bytes.Equal(key, r.Key):
+		return nil, 0, ErrInvalidContinueToken
+	case len(r.RangeEnd) > 0 && bytes.Compare(key, r.RangeEnd) >= 0:
+		return nil, 0, ErrInvalidContinueToken
+	}
+	return key, rev, nil
+}
+
 // isGteRange determines if the range end is a >= range. This works around grpc
 // sending empty byte strings as nil; >= is encoded in the range end as '\0'.
 func isGteRange(rangeEnd []byte) bool {
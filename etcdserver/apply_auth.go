@@ -52,6 +52,42 @@ func (aa *authApplierV3) Apply(r *pb.InternalRaftRequest) *applyResult {
 	return ret
 }
 
+// Batch checks the permissions of the batched requests before they are
+// applied, since the permission checks cannot read the auth store once the
+// txn of the batch holds the backend.
+func (aa *authApplierV3) Batch(reqs []*pb.InternalRaftRequest) []*applyResult {
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+	ars := make([]*applyResult, len(reqs))
+	for i, r := range reqs {
+		aa.user = ""
+		if r.Header != nil {
+			aa.user = r.Header.Username
+		}
+		if !aa.checkBatchedPermission(r) {
+			ars[i] = &applyResult{err: auth.ErrPermissionDenied}
+		}
+	}
+	aa.user = ""
+	return batchRest(aa.applierV3, reqs, ars)
+}
+
+func (aa *authApplierV3) checkBatchedPermission(r *pb.InternalRaftRequest) bool {
+	switch {
+	case r.Put != nil:
+		if !aa.as.IsPutPermitted(aa.user, r.Put.Key) {
+			return false
+		}
+		return !r.Put.PrevKv || aa.as.IsRangePermitted(aa.user, r.Put.Key, nil)
+	case r.DeleteRange != nil:
+		if !aa.as.IsDeleteRangePermitted(aa.user, r.DeleteRange.Key, r.DeleteRange.RangeEnd) {
+			return false
+		}
+		return !r.DeleteRange.PrevKv || aa.as.IsRangePermitted(aa.user, r.DeleteRange.Key, r.DeleteRange.RangeEnd)
+	}
+	return true
+}
+
 func (aa *authApplierV3) Put(txnID int64, r *pb.PutRequest) (*pb.PutResponse, error) {
 	if !aa.as.IsPutPermitted(aa.user, r.Key) {
 		return nil, auth.ErrPermissionDenied
@@ -113,23 +149,33 @@ func (aa *authApplierV3) checkTxnReqsPermission(reqs []*pb.RequestOp) bool {
 			if !aa.as.IsDeleteRangePermitted(aa.user, tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd) {
 				return false
 			}
+
+		case *pb.RequestOp_RequestTxn:
+			if tv.RequestTxn == nil {
+				continue
+			}
+
+			if !aa.checkTxnPermission(tv.RequestTxn) {
+				return false
+			}
 		}
 	}
 
 	return true
 }
 
-func (aa *authApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+func (aa *authApplierV3) checkTxnPermission(rt *pb.TxnRequest) bool {
 	for _, c := range rt.Compare {
-		if !aa.as.IsRangePermitted(aa.user, c.Key, nil) {
-			return nil, auth.ErrPermissionDenied
+		if !aa.as.IsRangePermitted(aa.user, c.Key, c.RangeEnd) {
+			return false
 		}
 	}
 
-	if !aa.checkTxnReqsPermission(rt.Success) {
-		return nil, auth.ErrPermissionDenied
-	}
-	if !aa.checkTxnReqsPermission(rt.Failure) {
+	return aa.checkTxnReqsPermission(rt.Success) && aa.checkTxnReqsPermission(rt.Failure)
+}
+
+func (aa *authApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+	if !aa.checkTxnPermission(rt) {
 		return nil, auth.ErrPermissionDenied
 	}
 
@@ -0,0 +1,148 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"golang.org/x/net/context"
+)
+
+const (
+	// maxBatchRequests is the maximum number of requests batched into
+	// one raft entry.
+	maxBatchRequests = 128
+	// maxBatchBytes is the maximum size of the requests batched into one
+	// raft entry.
+	maxBatchBytes = 64 * 1024
+	// maxBatchedRequestBytes is the size above which a request is always
+	// proposed on its own.
+	maxBatchedRequestBytes = 4 * 1024
+)
+
+// proposal is a V3 request waiting to be proposed by the batcher.
+type proposal struct {
+	req  *pb.InternalRaftRequest
+	data []byte
+	// errc receives the error of proposing the entry carrying req.
+	errc chan error
+}
+
+// batcher coalesces the small V3 requests proposed concurrently into a single
+// raft entry. It does not wait to fill a batch: every entry carries the
+// requests that queued up while the previous one was being proposed, so a
+// lone request goes out right away and batches only grow under load.
+type batcher struct {
+	propc chan *proposal
+	// propose proposes the marshaled entry data to raft.
+	propose func(ctx context.Context, data []byte) error
+}
+
+func newBatcher(propose func(ctx context.Context, data []byte) error) *batcher {
+	return &batcher{
+		propc:   make(chan *proposal, maxBatchRequests),
+		propose: propose,
+	}
+}
+
+// batchable returns true if r can be batched with other requests. Only
+// small puts and deletes are batched; the other requests either open their
+// own mvcc txn or change state outside of the mvcc store.
+func batchable(r *pb.InternalRaftRequest, data []byte) bool {
+	return (r.Put != nil || r.DeleteRange != nil) && len(data) <= maxBatchedRequestBytes
+}
+
+// run proposes the queued requests until stopc is closed.
+func (b *batcher) run(stopc <-chan struct{}) {
+	var pending *proposal
+	for {
+		ps := make([]*proposal, 0, 1)
+		if pending != nil {
+			ps, pending = append(ps, pending), nil
+		} else {
+			select {
+			case p := <-b.propc:
+				ps = append(ps, p)
+			case <-stopc:
+				return
+			}
+		}
+
+		size := len(ps[0].data)
+	drain:
+		for len(ps) < maxBatchRequests {
+			select {
+			case p := <-b.propc:
+				if size+len(p.data) > maxBatchBytes {
+					pending = p
+					break drain
+				}
+				ps = append(ps, p)
+				size += len(p.data)
+			default:
+				break drain
+			}
+		}
+		b.proposeBatch(ps, stopc)
+	}
+}
+
+// proposeBatch proposes ps as a single entry and reports the outcome to
+// each of them.
+func (b *batcher) proposeBatch(ps []*proposal, stopc <-chan struct{}) {
+	data := ps[0].data
+	if len(ps) > 1 {
+		reqs := make([]*pb.InternalRaftRequest, len(ps))
+		for i, p := range ps {
+			reqs[i] = p.req
+		}
+		var err error
+		if data, err = (&pb.InternalRaftRequest{Batch: reqs}).Marshal(); err != nil {
+			plog.Panicf("marshal batch should never fail: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), maxV3RequestTimeout)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopc:
+			cancel()
+		}
+	}()
+	err := b.propose(ctx, data)
+	cancel()
+	for _, p := range ps {
+		p.errc <- err
+	}
+}
+
+// submit queues p to be proposed and waits for the outcome of the proposal.
+func (b *batcher) submit(ctx context.Context, p *proposal, stopc <-chan struct{}) error {
+	select {
+	case b.propc <- p:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stopc:
+		return ErrStopped
+	}
+	select {
+	case err := <-p.errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stopc:
+		return ErrStopped
+	}
+}
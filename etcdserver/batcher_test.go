@@ -0,0 +1,172 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"golang.org/x/net/context"
+)
+
+func TestBatchable(t *testing.T) {
+	tests := []struct {
+		r    pb.InternalRaftRequest
+		size int
+		w    bool
+	}{
+		{pb.InternalRaftRequest{Put: &pb.PutRequest{}}, 10, true},
+		{pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{}}, 10, true},
+		{pb.InternalRaftRequest{Put: &pb.PutRequest{}}, maxBatchedRequestBytes + 1, false},
+		{pb.InternalRaftRequest{Txn: &pb.TxnRequest{}}, 10, false},
+		{pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{}}, 10, false},
+		{pb.InternalRaftRequest{AuthEnable: &pb.AuthEnableRequest{}}, 10, false},
+	}
+	for i, tt := range tests {
+		if g := batchable(&tt.r, make([]byte, tt.size)); g != tt.w {
+			t.Errorf("#%d: batchable = %v, want %v", i, g, tt.w)
+		}
+	}
+}
+
+// TestBatcherCoalesce tests that the requests queued up while an entry is
+// being proposed are proposed together in the next entry.
+func TestBatcherCoalesce(t *testing.T) {
+	proposec := make(chan []byte)
+	releasec := make(chan struct{})
+	b := newBatcher(func(ctx context.Context, data []byte) error {
+		proposec <- data
+		<-releasec
+		return nil
+	})
+	stopc := make(chan struct{})
+	defer close(stopc)
+	go b.run(stopc)
+
+	reqs := make([]*pb.InternalRaftRequest, 4)
+	errc := make(chan error, len(reqs))
+	for i := range reqs {
+		reqs[i] = &pb.InternalRaftRequest{
+			Header: &pb.RequestHeader{ID: uint64(i + 1)},
+			Put:    &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")},
+		}
+	}
+	submit := func(r *pb.InternalRaftRequest) {
+		p := &proposal{req: r, data: pbutil.MustMarshal(r), errc: make(chan error, 1)}
+		errc <- b.submit(context.Background(), p, stopc)
+	}
+
+	// the first request is proposed alone.
+	go submit(reqs[0])
+	if data := <-proposec; !bytes.Equal(data, pbutil.MustMarshal(reqs[0])) {
+		t.Errorf("data = %v, want the first request", data)
+	}
+	// the others queue up behind it.
+	for _, r := range reqs[1:] {
+		go submit(r)
+	}
+	for len(b.propc) != len(reqs)-1 {
+		time.Sleep(time.Millisecond)
+	}
+	releasec <- struct{}{}
+
+	var batch pb.InternalRaftRequest
+	pbutil.MustUnmarshal(&batch, <-proposec)
+	releasec <- struct{}{}
+	if len(batch.Batch) != len(reqs)-1 {
+		t.Fatalf("len(batch) = %d, want %d", len(batch.Batch), len(reqs)-1)
+	}
+	// the queued requests may be batched in any order.
+	ids := make(map[uint64]bool)
+	for _, r := range batch.Batch {
+		ids[r.Header.ID] = true
+	}
+	wids := map[uint64]bool{2: true, 3: true, 4: true}
+	if !reflect.DeepEqual(ids, wids) {
+		t.Errorf("batched ids = %v, want %v", ids, wids)
+	}
+	for range reqs {
+		if err := <-errc; err != nil {
+			t.Errorf("err = %v, want nil", err)
+		}
+	}
+}
+
+// TestBatcherMaxBytes tests that a request which does not fit in the batch
+// is carried over to the next one.
+func TestBatcherMaxBytes(t *testing.T) {
+	var datas [][]byte
+	b := newBatcher(func(ctx context.Context, data []byte) error {
+		datas = append(datas, data)
+		return nil
+	})
+	n := maxBatchBytes/maxBatchedRequestBytes + 1
+	ps := make([]*proposal, n)
+	for i := range ps {
+		ps[i] = &proposal{
+			req:  &pb.InternalRaftRequest{Put: &pb.PutRequest{}},
+			data: make([]byte, maxBatchedRequestBytes),
+			errc: make(chan error, 1),
+		}
+		b.propc <- ps[i]
+	}
+	stopc := make(chan struct{})
+	go b.run(stopc)
+	for _, p := range ps {
+		<-p.errc
+	}
+	close(stopc)
+
+	if len(datas) != 2 {
+		t.Fatalf("len(proposals) = %d, want 2", len(datas))
+	}
+	var batch pb.InternalRaftRequest
+	pbutil.MustUnmarshal(&batch, datas[0])
+	if len(batch.Batch) != n-1 {
+		t.Errorf("len(batch) = %d, want %d", len(batch.Batch), n-1)
+	}
+	if len(datas[1]) != maxBatchedRequestBytes {
+		t.Errorf("len(data) = %d, want %d", len(datas[1]), maxBatchedRequestBytes)
+	}
+}
+
+func TestBatcherProposeError(t *testing.T) {
+	errProposal := errors.New("proposal error")
+	b := newBatcher(func(ctx context.Context, data []byte) error { return errProposal })
+	stopc := make(chan struct{})
+	defer close(stopc)
+	go b.run(stopc)
+
+	p := &proposal{req: &pb.InternalRaftRequest{Put: &pb.PutRequest{}}, errc: make(chan error, 1)}
+	if err := b.submit(context.Background(), p, stopc); err != errProposal {
+		t.Errorf("err = %v, want %v", err, errProposal)
+	}
+}
+
+func TestBatcherStopped(t *testing.T) {
+	b := newBatcher(func(ctx context.Context, data []byte) error { return nil })
+	stopc := make(chan struct{})
+	close(stopc)
+
+	p := &proposal{req: &pb.InternalRaftRequest{Put: &pb.PutRequest{}}, errc: make(chan error, 1)}
+	if err := b.submit(context.Background(), p, stopc); err != ErrStopped {
+		t.Errorf("err = %v, want %v", err, ErrStopped)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/coreos/etcd/etcdserver/membership"
@@ -257,3 +258,45 @@ func getVersion(m *membership.Member, rt http.RoundTripper) (*version.Versions,
 	}
 	return nil, err
 }
+
+// PeerMemberPromotePrefix is the path prefix of the peer endpoint that
+// promotes the learner whose decimal id follows it.
+const PeerMemberPromotePrefix = "/members/promote/"
+
+// promoteErrs are the errors of PromoteMember that promoteMemberHTTP
+// recovers from the response of the peer.
+var promoteErrs = []error{
+	membership.ErrIDNotFound,
+	membership.ErrMemberNotLearner,
+	ErrLearnerNotReady,
+	ErrNotEnoughStartedMembers,
+	ErrNotLeader,
+}
+
+// promoteMemberHTTP asks the member serving the peerURL url to promote the
+// learner with the given id.
+func promoteMemberHTTP(url string, id uint64, rt http.RoundTripper, timeout time.Duration) error {
+	cc := &http.Client{
+		Transport: rt,
+		Timeout:   timeout,
+	}
+	resp, err := cc.Post(url+PeerMemberPromotePrefix+fmt.Sprintf("%d", id), "", nil)
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	msg := strings.TrimSpace(string(b))
+	for _, perr := range promoteErrs {
+		if msg == perr.Error() {
+			return perr
+		}
+	}
+	return fmt.Errorf("failed to promote member %s through %s: %s", types.ID(id), url, msg)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/coreos/etcd/pkg/netutil"
 	"github.com/coreos/etcd/pkg/transport"
 	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
 )
 
 // ServerConfig holds the configuration of etcd as taken from the command line or discovery.
@@ -55,7 +57,55 @@ type ServerConfig struct {
 
 	StrictReconfigCheck bool
 
+	// PreVote enables the raft Pre-Vote algorithm.
+	PreVote bool
+
+	// AsyncFsync moves the WAL fsync of a follower off the raft routine.
+	// The messages of a follower are only sent once the writes they
+	// acknowledge are synced. The leader always syncs its writes in place.
+	AsyncFsync bool
+
+	// BatchProposals coalesces the small puts and delete ranges proposed
+	// concurrently into one raft entry. Members without batch support apply
+	// nothing for such entries, so it must only be enabled once every
+	// member of the cluster supports it.
+	BatchProposals bool
+
+	// Witness runs the member as a witness: it keeps the raft log without
+	// the data of the entries, and has no backend, lessor or mvcc store.
+	// The member must have been added to the cluster as a witness.
+	Witness bool
+
+	// LeaseCheckpointInterval is the interval at which the leader checkpoints
+	// the remaining TTLs of leases through raft. 0 disables checkpointing.
+	LeaseCheckpointInterval time.Duration
+
+	// EntryTypes are the application entry types replicated by the server,
+	// by entry type. Every member must register the same types.
+	EntryTypes map[raftpb.EntryType]EntryType
+
 	EnablePprof bool
+
+	// EnableRaftFaults serves the raft fault points over HTTP.
+	EnableRaftFaults bool
+
+	// Dinv configures the invariants checked by the local raft node.
+	Dinv raft.DinvConfig
+	// DinvPiggyback piggybacks dinv vector clocks on the messages exchanged
+	// with peers that enable it as well.
+	DinvPiggyback bool
+	// RaftFaults holds the fault points of the local raft node. If nil and
+	// EnableRaftFaults or Dinv.InjectBugs is set, an injector seeded with
+	// Dinv.FaultSeed is created. Otherwise no fault is injected.
+	RaftFaults *raft.FaultInjector
+	// RaftBugLog records the bugs injected and caught by the local raft node.
+	RaftBugLog raft.BugLogger
+	// RaftTracer records the state transitions of the local raft node and
+	// the messages it exchanges with peers with a vector clock.
+	RaftTracer raft.Tracer
+	// RaftStateTracker records the state of the local raft node at the
+	// program points tracked by dinv. If nil, the state is not tracked.
+	RaftStateTracker raft.StateTracker
 }
 
 // VerifyBootstrap sanity-checks the initial config for bootstrap case
@@ -64,6 +114,9 @@ func (c *ServerConfig) VerifyBootstrap() error {
 	if err := c.verifyLocalMember(true); err != nil {
 		return err
 	}
+	if c.Witness {
+		return fmt.Errorf("a witness cannot bootstrap a cluster; it must join an existing one")
+	}
 	if checkDuplicateURL(c.InitialPeerURLsMap) {
 		return fmt.Errorf("initial cluster %s has duplicate url", c.InitialPeerURLsMap)
 	}
@@ -168,6 +221,10 @@ func (c *ServerConfig) print(initial bool) {
 		}
 	}
 	plog.Infof("advertise client URLs = %s", c.ClientURLs)
+	if len(c.Dinv.Invariants) != 0 {
+		plog.Infof("dinv invariants = %s (sample 1/%d, leader only %v, cut tick %d, inject bugs %v, fault seed %d)",
+			strings.Join(c.Dinv.Invariants, ","), c.Dinv.Sample, c.Dinv.LeaderOnly, c.Dinv.CutTick, c.Dinv.InjectBugs, c.Dinv.FaultSeed)
+	}
 	if initial {
 		plog.Infof("initial advertise peer URLs = %s", c.PeerURLs)
 		plog.Infof("initial cluster = %s", c.InitialPeerURLsMap)
@@ -0,0 +1,83 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
+)
+
+// EntryType is an application entry type replicated by the server. It lets
+// an embedding application replicate its own commands, such as barriers or
+// timestamps, alongside the etcd requests.
+type EntryType struct {
+	// Name names the entry type in logs.
+	Name string
+	// Codec encodes and decodes the values carried by the entries.
+	Codec raft.EntryCodec
+	// Apply applies the value of each committed entry of the type at the
+	// given index. It is called by the apply routine, in log order with the
+	// other entries, so it must not block. Entries may be applied again
+	// after a restart.
+	Apply func(index uint64, v interface{})
+}
+
+func newEntryTypes(types map[raftpb.EntryType]EntryType) (*raft.EntryTypes, error) {
+	et := raft.NewEntryTypes()
+	for t, typ := range types {
+		if typ.Codec == nil || typ.Apply == nil {
+			return nil, fmt.Errorf("entry type %d (%s) needs both a codec and an apply function", t, typ.Name)
+		}
+		if err := et.Register(t, typ.Name, typ.Codec); err != nil {
+			return nil, fmt.Errorf("cannot register entry type %d (%s): %v", t, typ.Name, err)
+		}
+	}
+	return et, nil
+}
+
+// ProposeEntry proposes an entry of the application entry type t carrying v.
+// It returns once the entry is appended to the log of the leader; the Apply
+// function of t is called on every member once it is committed.
+func (s *EtcdServer) ProposeEntry(ctx context.Context, t raftpb.EntryType, v interface{}) error {
+	e, err := s.entryTypes.Encode(t, v)
+	if err != nil {
+		return err
+	}
+	if len(e.Data) > maxRequestBytes {
+		return ErrRequestTooLarge
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	start := time.Now()
+	if err = s.r.ProposeEntry(cctx, t, e.Data); err != nil {
+		proposalsFailed.Inc()
+		return s.parseProposeCtxErr(err, start)
+	}
+	return nil
+}
+
+// applyEntryApplication applies an entry of an application entry type.
+func (s *EtcdServer) applyEntryApplication(e *raftpb.Entry) {
+	v, err := s.entryTypes.Decode(*e)
+	if err != nil {
+		plog.Panicf("decode entry %d of type %d error: %v", e.Index, e.Type, err)
+	}
+	s.Cfg.EntryTypes[e.Type].Apply(e.Index, v)
+}
@@ -0,0 +1,112 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/pkg/testutil"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
+)
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(v interface{}) ([]byte, error)    { return []byte(v.(string)), nil }
+func (stringCodec) Decode(data []byte) (interface{}, error) { return string(data), nil }
+
+func TestNewEntryTypes(t *testing.T) {
+	apply := func(uint64, interface{}) {}
+	tests := []struct {
+		types map[raftpb.EntryType]EntryType
+		werr  bool
+	}{
+		{nil, false},
+		{map[raftpb.EntryType]EntryType{raft.FirstApplicationEntryType: {"s", stringCodec{}, apply}}, false},
+		// reserved by raft
+		{map[raftpb.EntryType]EntryType{raftpb.EntryNormal: {"s", stringCodec{}, apply}}, true},
+		// no apply function
+		{map[raftpb.EntryType]EntryType{raft.FirstApplicationEntryType: {"s", stringCodec{}, nil}}, true},
+		// no codec
+		{map[raftpb.EntryType]EntryType{raft.FirstApplicationEntryType: {"s", nil, apply}}, true},
+	}
+	for i, tt := range tests {
+		if _, err := newEntryTypes(tt.types); (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+	}
+}
+
+// TestProposeEntry tests that ProposeEntry proposes the encoded value with
+// the given entry type.
+func TestProposeEntry(t *testing.T) {
+	typ := raft.FirstApplicationEntryType
+	cfg := &ServerConfig{
+		TickMs:     1,
+		EntryTypes: map[raftpb.EntryType]EntryType{typ: {"s", stringCodec{}, func(uint64, interface{}) {}}},
+	}
+	et, err := newEntryTypes(cfg.EntryTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := newNodeRecorder()
+	srv := &EtcdServer{Cfg: cfg, r: raftNode{Node: n}, entryTypes: et}
+
+	if err = srv.ProposeEntry(context.TODO(), typ, "barrier"); err != nil {
+		t.Fatal(err)
+	}
+	if err = srv.ProposeEntry(context.TODO(), typ+1, "barrier"); err != raft.ErrUnknownEntryType {
+		t.Errorf("err = %v, want %v", err, raft.ErrUnknownEntryType)
+	}
+	wacts := []testutil.Action{{Name: "ProposeEntry", Params: []interface{}{typ, []byte("barrier")}}}
+	if acts := n.Action(); !reflect.DeepEqual(acts, wacts) {
+		t.Errorf("actions = %+v, want %+v", acts, wacts)
+	}
+}
+
+// TestApplyEntryApplication tests that the committed application entries are
+// decoded and applied in log order with the other entries.
+func TestApplyEntryApplication(t *testing.T) {
+	typ := raft.FirstApplicationEntryType
+	type applied struct {
+		index uint64
+		v     interface{}
+	}
+	var got []applied
+	cfg := &ServerConfig{
+		EntryTypes: map[raftpb.EntryType]EntryType{
+			typ: {"s", stringCodec{}, func(index uint64, v interface{}) { got = append(got, applied{index, v}) }},
+		},
+	}
+	et, err := newEntryTypes(cfg.EntryTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &EtcdServer{Cfg: cfg, r: raftNode{Node: newNodeNop()}, entryTypes: et}
+
+	ents := []raftpb.Entry{
+		{Index: 1, Term: 1, Type: typ, Data: []byte("a")},
+		{Index: 2, Term: 1, Type: typ, Data: []byte("b")},
+	}
+	if idx, _ := srv.apply(ents, &raftpb.ConfState{}); idx != 2 {
+		t.Errorf("applied index = %d, want 2", idx)
+	}
+	wgot := []applied{{1, "a"}, {2, "b"}}
+	if !reflect.DeepEqual(got, wgot) {
+		t.Errorf("applied = %+v, want %+v", got, wgot)
+	}
+}
@@ -26,11 +26,19 @@ var (
 	ErrTimeout                    = errors.New("etcdserver: request timed out")
 	ErrTimeoutDueToLeaderFail     = errors.New("etcdserver: request timed out, possibly due to previous leader failure")
 	ErrTimeoutDueToConnectionLost = errors.New("etcdserver: request timed out, possibly due to connection lost")
+	ErrTimeoutLeaderTransfer      = errors.New("etcdserver: request timed out, leader transfer took too long")
 	ErrNotEnoughStartedMembers    = errors.New("etcdserver: re-configuration failed due to not enough started members")
 	ErrNoLeader                   = errors.New("etcdserver: no leader")
+	ErrNotLeader                  = errors.New("etcdserver: not leader")
+	ErrLearnerNotReady            = errors.New("etcdserver: can only promote a learner member which is in sync with leader")
+	ErrJointConfChangeInProgress  = errors.New("etcdserver: a joint configuration change is in progress")
+	ErrTransfereeNotVoter         = errors.New("etcdserver: can only transfer leadership to a voting member")
+	ErrUnhealthy                  = errors.New("etcdserver: unhealthy cluster")
 	ErrRequestTooLarge            = errors.New("etcdserver: request is too large")
+	ErrTooManyRequests            = errors.New("etcdserver: too many requests")
 	ErrNoSpace                    = errors.New("etcdserver: no space")
 	ErrInvalidAuthToken           = errors.New("etcdserver: invalid auth token")
+	ErrInvalidContinueToken       = errors.New("etcdserver: invalid continue token")
 )
 
 type DiscoveryError struct {
@@ -44,6 +44,8 @@ type InternalRaftRequest struct {
 	LeaseGrant               *LeaseGrantRequest               `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant" json:"lease_grant,omitempty"`
 	LeaseRevoke              *LeaseRevokeRequest              `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke" json:"lease_revoke,omitempty"`
 	Alarm                    *AlarmRequest                    `protobuf:"bytes,10,opt,name=alarm" json:"alarm,omitempty"`
+	Batch                    []*InternalRaftRequest           `protobuf:"bytes,11,rep,name=batch" json:"batch,omitempty"`
+	LeaseCheckpoint          *LeaseCheckpointRequest          `protobuf:"bytes,12,opt,name=lease_checkpoint,json=leaseCheckpoint" json:"lease_checkpoint,omitempty"`
 	AuthEnable               *AuthEnableRequest               `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable" json:"auth_enable,omitempty"`
 	AuthDisable              *AuthDisableRequest              `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable" json:"auth_disable,omitempty"`
 	Authenticate             *InternalAuthenticateRequest     `protobuf:"bytes,1012,opt,name=authenticate" json:"authenticate,omitempty"`
@@ -92,11 +94,50 @@ func (*InternalAuthenticateRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptorRaftInternal, []int{3}
 }
 
+type LeaseCheckpoint struct {
+	// ID is the lease ID to checkpoint.
+	ID int64 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+	// remaining_TTL is the remaining time until expiry of the lease.
+	Remaining_TTL int64 `protobuf:"varint,2,opt,name=remaining_TTL,json=remainingTTL,proto3" json:"remaining_TTL,omitempty"`
+}
+
+func (m *LeaseCheckpoint) Reset()                    { *m = LeaseCheckpoint{} }
+func (m *LeaseCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*LeaseCheckpoint) ProtoMessage()               {}
+func (*LeaseCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptorRaftInternal, []int{4} }
+
+// LeaseCheckpointRequest records the remaining TTLs of leases so that
+// a newly elected leader does not extend them to their full TTLs.
+type LeaseCheckpointRequest struct {
+	Checkpoints []*LeaseCheckpoint `protobuf:"bytes,1,rep,name=checkpoints" json:"checkpoints,omitempty"`
+}
+
+func (m *LeaseCheckpointRequest) Reset()         { *m = LeaseCheckpointRequest{} }
+func (m *LeaseCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseCheckpointRequest) ProtoMessage()    {}
+func (*LeaseCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorRaftInternal, []int{5}
+}
+
+type LeaseCheckpointResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+}
+
+func (m *LeaseCheckpointResponse) Reset()         { *m = LeaseCheckpointResponse{} }
+func (m *LeaseCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseCheckpointResponse) ProtoMessage()    {}
+func (*LeaseCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorRaftInternal, []int{6}
+}
+
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "etcdserverpb.RequestHeader")
 	proto.RegisterType((*InternalRaftRequest)(nil), "etcdserverpb.InternalRaftRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "etcdserverpb.EmptyResponse")
 	proto.RegisterType((*InternalAuthenticateRequest)(nil), "etcdserverpb.InternalAuthenticateRequest")
+	proto.RegisterType((*LeaseCheckpoint)(nil), "etcdserverpb.LeaseCheckpoint")
+	proto.RegisterType((*LeaseCheckpointRequest)(nil), "etcdserverpb.LeaseCheckpointRequest")
+	proto.RegisterType((*LeaseCheckpointResponse)(nil), "etcdserverpb.LeaseCheckpointResponse")
 }
 func (m *RequestHeader) Marshal() (data []byte, err error) {
 	size := m.Size()
@@ -237,6 +278,28 @@ func (m *InternalRaftRequest) MarshalTo(data []byte) (int, error) {
 		}
 		i += n9
 	}
+	if len(m.Batch) > 0 {
+		for _, msg := range m.Batch {
+			data[i] = 0x5a
+			i++
+			i = encodeVarintRaftInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.LeaseCheckpoint != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintRaftInternal(data, i, uint64(m.LeaseCheckpoint.Size()))
+		n27, err := m.LeaseCheckpoint.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
 	if m.Header != nil {
 		data[i] = 0xa2
 		i++
@@ -498,6 +561,92 @@ func (m *InternalAuthenticateRequest) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LeaseCheckpoint) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseCheckpoint) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRaftInternal(data, i, uint64(m.ID))
+	}
+	if m.Remaining_TTL != 0 {
+		data[i] = 0x10
+		i++
+		i = encodeVarintRaftInternal(data, i, uint64(m.Remaining_TTL))
+	}
+	return i, nil
+}
+
+func (m *LeaseCheckpointRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseCheckpointRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Checkpoints) > 0 {
+		for _, msg := range m.Checkpoints {
+			data[i] = 0xa
+			i++
+			i = encodeVarintRaftInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *LeaseCheckpointResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseCheckpointResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintRaftInternal(data, i, uint64(m.Header.Size()))
+		n28, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	return i, nil
+}
+
 func encodeFixed64RaftInternal(data []byte, offset int, v uint64) int {
 	data[offset] = uint8(v)
 	data[offset+1] = uint8(v >> 8)
@@ -580,6 +729,16 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.Alarm.Size()
 		n += 1 + l + sovRaftInternal(uint64(l))
 	}
+	if len(m.Batch) > 0 {
+		for _, e := range m.Batch {
+			l = e.Size()
+			n += 1 + l + sovRaftInternal(uint64(l))
+		}
+	}
+	if m.LeaseCheckpoint != nil {
+		l = m.LeaseCheckpoint.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -675,6 +834,40 @@ func (m *InternalAuthenticateRequest) Size() (n int) {
 	return n
 }
 
+func (m *LeaseCheckpoint) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRaftInternal(uint64(m.ID))
+	}
+	if m.Remaining_TTL != 0 {
+		n += 1 + sovRaftInternal(uint64(m.Remaining_TTL))
+	}
+	return n
+}
+
+func (m *LeaseCheckpointRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Checkpoints) > 0 {
+		for _, e := range m.Checkpoints {
+			l = e.Size()
+			n += 1 + l + sovRaftInternal(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LeaseCheckpointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	return n
+}
+
 func sovRaftInternal(x uint64) (n int) {
 	for {
 		n++
@@ -1131,6 +1324,70 @@ func (m *InternalRaftRequest) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Batch = append(m.Batch, &InternalRaftRequest{})
+			if err := m.Batch[len(m.Batch)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseCheckpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseCheckpoint == nil {
+				m.LeaseCheckpoint = &LeaseCheckpointRequest{}
+			}
+			if err := m.LeaseCheckpoint.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
@@ -1900,6 +2157,258 @@ func (m *InternalAuthenticateRequest) Unmarshal(data []byte) error {
 	}
 	return nil
 }
+func (m *LeaseCheckpoint) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remaining_TTL", wireType)
+			}
+			m.Remaining_TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Remaining_TTL |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseCheckpointRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseCheckpointRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseCheckpointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checkpoints = append(m.Checkpoints, &LeaseCheckpoint{})
+			if err := m.Checkpoints[len(m.Checkpoints)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseCheckpointResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseCheckpointResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseCheckpointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipRaftInternal(data []byte) (n int, err error) {
 	l := len(data)
 	iNdEx := 0
@@ -2006,56 +2515,64 @@ var (
 )
 
 var fileDescriptorRaftInternal = []byte{
-	// 808 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x96, 0x4b, 0x6f, 0xd3, 0x4a,
-	0x14, 0xc7, 0x6f, 0xd2, 0x67, 0x26, 0x69, 0x6e, 0xef, 0xb4, 0xbd, 0x77, 0x6e, 0x2a, 0x95, 0x36,
-	0x88, 0x37, 0x14, 0xd4, 0x2e, 0x59, 0x40, 0x68, 0xaa, 0xb6, 0x52, 0x85, 0x2a, 0xab, 0x48, 0x48,
-	0x2c, 0xac, 0x69, 0x3c, 0xa4, 0x06, 0xbf, 0xb0, 0x27, 0xa5, 0x7c, 0x2b, 0x5e, 0x1f, 0xa2, 0x0b,
-	0x1e, 0x05, 0xbe, 0x00, 0xb0, 0x62, 0xc5, 0x06, 0x3e, 0x00, 0xf3, 0xf2, 0xd8, 0x4e, 0xc6, 0x5d,
-	0x44, 0xb2, 0xcf, 0xf9, 0x9f, 0xdf, 0x39, 0xd3, 0xf9, 0x8f, 0xa7, 0x60, 0x2e, 0xc6, 0x8f, 0xa9,
-	0xed, 0x06, 0x94, 0xc4, 0x01, 0xf6, 0x56, 0xa3, 0x38, 0xa4, 0x21, 0x6c, 0x10, 0xda, 0x73, 0x12,
-	0x12, 0x1f, 0x91, 0x38, 0x3a, 0x68, 0xcd, 0xf7, 0xc3, 0x7e, 0x28, 0x12, 0x37, 0xf9, 0x93, 0xd4,
-	0xb4, 0x66, 0x33, 0x8d, 0x8a, 0xd4, 0xe2, 0xa8, 0x27, 0x1f, 0xdb, 0xb7, 0xc1, 0x8c, 0x45, 0x9e,
-	0x0d, 0x48, 0x42, 0xb7, 0x09, 0x76, 0x48, 0x0c, 0x9b, 0xa0, 0xba, 0xd3, 0x45, 0x95, 0xe5, 0xca,
-	0xe5, 0x71, 0xab, 0xea, 0x76, 0x61, 0x0b, 0x4c, 0x0f, 0x12, 0xde, 0xd2, 0x27, 0xa8, 0xca, 0xa2,
-	0x35, 0x4b, 0xbf, 0xb7, 0x7f, 0x36, 0xc1, 0xdc, 0x8e, 0x1a, 0xc8, 0x62, 0xd3, 0x29, 0xd2, 0x08,
-	0xe3, 0x02, 0xa8, 0x1e, 0xad, 0x89, 0xea, 0xfa, 0xda, 0xc2, 0x6a, 0x7e, 0xe4, 0x55, 0x55, 0x62,
-	0x31, 0x01, 0xbc, 0x05, 0x26, 0x62, 0x1c, 0xf4, 0x09, 0x1a, 0x13, 0xca, 0xd6, 0x90, 0x92, 0xa7,
-	0x52, 0xb9, 0x14, 0xc2, 0xab, 0x60, 0x2c, 0x1a, 0x50, 0x34, 0x2e, 0xf4, 0xa8, 0xa8, 0xdf, 0x1b,
-	0xa4, 0xf3, 0x58, 0x5c, 0x04, 0x37, 0x40, 0xc3, 0x21, 0x1e, 0xa1, 0xc4, 0x96, 0x4d, 0x26, 0x44,
-	0xd1, 0x72, 0xb1, 0xa8, 0x2b, 0x14, 0x85, 0x56, 0x75, 0x27, 0x8b, 0xf1, 0x86, 0xf4, 0x38, 0x40,
-	0x93, 0xa6, 0x86, 0xfb, 0xc7, 0x81, 0x6e, 0xc8, 0x44, 0xf0, 0x0e, 0x00, 0xbd, 0xd0, 0x8f, 0x70,
-	0x8f, 0xba, 0x61, 0x80, 0xa6, 0x44, 0xc9, 0xb9, 0x62, 0xc9, 0x86, 0xce, 0xa7, 0x95, 0xb9, 0x12,
-	0x78, 0x17, 0xd4, 0x3d, 0x82, 0x13, 0x62, 0xf7, 0xd9, 0xc4, 0x14, 0x4d, 0x9b, 0x08, 0xbb, 0x5c,
-	0xb0, 0xc5, 0xf3, 0x9a, 0xe0, 0xe9, 0x10, 0x5f, 0xb3, 0x24, 0xc4, 0xe4, 0x28, 0x7c, 0x4a, 0x50,
-	0xcd, 0xb4, 0x66, 0x81, 0xb0, 0x84, 0x40, 0xaf, 0xd9, 0xcb, 0x62, 0x7c, 0x5b, 0xb0, 0x87, 0x63,
-	0x1f, 0x01, 0xd3, 0xb6, 0x74, 0x78, 0x4a, 0x6f, 0x8b, 0x10, 0xc2, 0x75, 0x30, 0x79, 0x28, 0xdc,
-	0x84, 0x1c, 0x51, 0xb2, 0x68, 0xdc, 0x73, 0x69, 0x38, 0x4b, 0x49, 0x61, 0x07, 0xd4, 0xf1, 0x80,
-	0x1e, 0xda, 0x24, 0xc0, 0x07, 0x1e, 0x41, 0x3f, 0x8c, 0x7f, 0xb0, 0x0e, 0x53, 0x6c, 0x0a, 0x81,
-	0x5e, 0x2e, 0xd6, 0x21, 0xd8, 0x05, 0x0d, 0x81, 0x70, 0xdc, 0x44, 0x30, 0x7e, 0x4d, 0x99, 0xd6,
-	0xcb, 0x19, 0x5d, 0xa9, 0xd0, 0xeb, 0xc5, 0x59, 0x0c, 0xde, 0x97, 0x14, 0x12, 0x50, 0xb7, 0x87,
-	0x29, 0x41, 0xbf, 0x25, 0xe5, 0x4a, 0x91, 0x92, 0xfa, 0xbe, 0x93, 0x93, 0xa6, 0xb8, 0x42, 0x3d,
-	0xdc, 0x04, 0x33, 0x62, 0x2a, 0x7e, 0x6c, 0x6c, 0xec, 0x38, 0xe8, 0xdd, 0x74, 0xd9, 0x58, 0x0f,
-	0xd8, 0x5b, 0xc7, 0x71, 0x0a, 0x63, 0xa9, 0x18, 0x1b, 0x6b, 0x36, 0xc3, 0x48, 0x4f, 0xa2, 0xf7,
-	0x92, 0x74, 0xde, 0x4c, 0x52, 0x66, 0x56, 0xb0, 0x26, 0x2e, 0x84, 0x8b, 0x63, 0xf5, 0x09, 0x45,
-	0x1f, 0xce, 0x1c, 0x6b, 0x8b, 0xd0, 0x91, 0xb1, 0x58, 0x0c, 0xf6, 0xc1, 0xff, 0x19, 0xa6, 0x77,
-	0xc8, 0x4f, 0x89, 0x1d, 0xe1, 0x24, 0x79, 0x1e, 0xc6, 0x0e, 0xfa, 0x28, 0x91, 0xd7, 0xcc, 0xc8,
-	0x0d, 0xa1, 0xde, 0x53, 0xe2, 0x94, 0xfe, 0x2f, 0x36, 0xa6, 0xe1, 0x43, 0x30, 0x9f, 0x9b, 0x97,
-	0xdb, 0xdb, 0x8e, 0x43, 0xb6, 0xc9, 0xa7, 0xb2, 0xc7, 0xc5, 0x92, 0xb1, 0xc5, 0xd1, 0x08, 0xb3,
-	0xad, 0xfe, 0x07, 0x0f, 0x67, 0xe0, 0x23, 0xb0, 0x90, 0x91, 0xe5, 0x49, 0x91, 0xe8, 0x4f, 0x12,
-	0x7d, 0xc9, 0x8c, 0x56, 0x47, 0x26, 0xc7, 0x86, 0x78, 0x24, 0x05, 0xb7, 0x41, 0x33, 0x83, 0x7b,
-	0x6e, 0x42, 0xd1, 0x67, 0x49, 0x5d, 0x31, 0x53, 0x77, 0x99, 0xa4, 0xe0, 0xa3, 0x34, 0xa8, 0x49,
-	0x7c, 0x34, 0x49, 0xfa, 0x52, 0x4a, 0xe2, 0xad, 0x47, 0x48, 0x69, 0x50, 0x6f, 0xbd, 0x20, 0x71,
-	0x47, 0xbe, 0xac, 0x95, 0x6d, 0x3d, 0xaf, 0x19, 0x76, 0xa4, 0x8a, 0x69, 0x47, 0x0a, 0x8c, 0x72,
-	0xe4, 0xab, 0x5a, 0x99, 0x23, 0x79, 0x95, 0xc1, 0x91, 0x59, 0xb8, 0x38, 0x16, 0x77, 0xe4, 0xeb,
-	0x33, 0xc7, 0x1a, 0x76, 0xa4, 0x8a, 0xc1, 0x27, 0xa0, 0x95, 0xc3, 0x08, 0xa3, 0x44, 0x24, 0xf6,
-	0xdd, 0x24, 0xe1, 0xdf, 0xe1, 0x37, 0x92, 0x79, 0xbd, 0x84, 0xc9, 0xe5, 0x7b, 0x5a, 0x9d, 0xf2,
-	0xff, 0xc3, 0xe6, 0x3c, 0xf4, 0xc1, 0x62, 0xd6, 0x4b, 0x59, 0x27, 0xd7, 0xec, 0xad, 0x6c, 0x76,
-	0xc3, 0xdc, 0x4c, 0xba, 0x64, 0xb4, 0x1b, 0xc2, 0x25, 0x82, 0xf6, 0xdf, 0x60, 0x66, 0xd3, 0x8f,
-	0xe8, 0x0b, 0x8b, 0x24, 0x51, 0x18, 0x24, 0xa4, 0x1d, 0x81, 0xc5, 0x33, 0x3e, 0x44, 0x10, 0x82,
-	0x71, 0x71, 0x71, 0x57, 0xc4, 0xc5, 0x2d, 0x9e, 0xf9, 0x85, 0xae, 0xcf, 0xa7, 0xba, 0xd0, 0xd3,
-	0x77, 0xb8, 0x02, 0x1a, 0x89, 0xeb, 0x47, 0x6c, 0x2d, 0x94, 0x35, 0x0e, 0xc4, 0x45, 0x5c, 0xb3,
-	0xea, 0x32, 0xb6, 0xcf, 0x43, 0xf7, 0x66, 0x4f, 0xbe, 0x2d, 0xfd, 0x75, 0xf2, 0x7d, 0xa9, 0x72,
-	0xca, 0x7e, 0x5f, 0xd9, 0xef, 0x60, 0x52, 0xfc, 0x27, 0xb1, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff,
-	0xdc, 0xb1, 0xbb, 0xb7, 0xa1, 0x08, 0x00, 0x00,
+	// 936 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x96, 0x5b, 0x73, 0xe3, 0x34,
+	0x14, 0xc7, 0x71, 0xd2, 0xed, 0x36, 0x27, 0xe9, 0x05, 0xed, 0x4d, 0xa4, 0x50, 0xba, 0x59, 0x2e,
+	0x0b, 0x2c, 0x85, 0xe9, 0x3e, 0xf2, 0x00, 0xd9, 0xa6, 0xd3, 0xdd, 0x99, 0xcc, 0xd2, 0xf1, 0x84,
+	0x19, 0x66, 0x78, 0xf0, 0xa8, 0xf6, 0xd9, 0xc4, 0xd4, 0xb1, 0x8d, 0xa5, 0x94, 0xf2, 0xad, 0xb8,
+	0x7d, 0x88, 0x7d, 0xe0, 0xb2, 0xc0, 0x17, 0x80, 0x3e, 0xf1, 0xca, 0xc0, 0x07, 0x60, 0x24, 0xd9,
+	0xb2, 0x9d, 0x28, 0x79, 0x4b, 0x8e, 0xfe, 0xe7, 0x77, 0x8e, 0xa4, 0xbf, 0x2c, 0xc1, 0x8d, 0x8c,
+	0x3d, 0x13, 0x5e, 0x18, 0x0b, 0xcc, 0x62, 0x16, 0x1d, 0xa4, 0x59, 0x22, 0x12, 0xd2, 0x41, 0xe1,
+	0x07, 0x1c, 0xb3, 0x0b, 0xcc, 0xd2, 0xb3, 0xee, 0xcd, 0x71, 0x32, 0x4e, 0xd4, 0xc0, 0x07, 0xf2,
+	0x97, 0xd6, 0x74, 0x77, 0x4a, 0x4d, 0x1e, 0x69, 0x65, 0xa9, 0xaf, 0x7f, 0xf6, 0x3e, 0x82, 0x4d,
+	0x17, 0xbf, 0x9a, 0x21, 0x17, 0x8f, 0x91, 0x05, 0x98, 0x91, 0x2d, 0x68, 0x3c, 0x19, 0x50, 0x67,
+	0xdf, 0xb9, 0xbf, 0xe6, 0x36, 0xc2, 0x01, 0xe9, 0xc2, 0xc6, 0x8c, 0xcb, 0x92, 0x53, 0xa4, 0x8d,
+	0x7d, 0xe7, 0x7e, 0xcb, 0x35, 0xff, 0x7b, 0xff, 0x6c, 0xc3, 0x8d, 0x27, 0x79, 0x43, 0x2e, 0x7b,
+	0x26, 0x72, 0xd2, 0x02, 0xe3, 0x4d, 0x68, 0x5c, 0x1c, 0xaa, 0xec, 0xf6, 0xe1, 0xad, 0x83, 0x6a,
+	0xcb, 0x07, 0x79, 0x8a, 0xdb, 0xb8, 0x38, 0x24, 0x1f, 0xc2, 0xb5, 0x8c, 0xc5, 0x63, 0xa4, 0x4d,
+	0xa5, 0xec, 0xce, 0x29, 0xe5, 0x50, 0x21, 0xd7, 0x42, 0xf2, 0x2e, 0x34, 0xd3, 0x99, 0xa0, 0x6b,
+	0x4a, 0x4f, 0xeb, 0xfa, 0xd3, 0x59, 0xd1, 0x8f, 0x2b, 0x45, 0xe4, 0x08, 0x3a, 0x01, 0x46, 0x28,
+	0xd0, 0xd3, 0x45, 0xae, 0xa9, 0xa4, 0xfd, 0x7a, 0xd2, 0x40, 0x29, 0x6a, 0xa5, 0xda, 0x41, 0x19,
+	0x93, 0x05, 0xc5, 0x65, 0x4c, 0xd7, 0x6d, 0x05, 0x47, 0x97, 0xb1, 0x29, 0x28, 0x2e, 0x63, 0xf2,
+	0x31, 0x80, 0x9f, 0x4c, 0x53, 0xe6, 0x8b, 0x30, 0x89, 0xe9, 0x75, 0x95, 0xf2, 0x7a, 0x3d, 0xe5,
+	0xc8, 0x8c, 0x17, 0x99, 0x95, 0x14, 0xf2, 0x09, 0xb4, 0x23, 0x64, 0x1c, 0xbd, 0x71, 0xc6, 0x62,
+	0x41, 0x37, 0x6c, 0x84, 0xa1, 0x14, 0x9c, 0xc8, 0x71, 0x43, 0x88, 0x4c, 0x48, 0xce, 0x59, 0x13,
+	0x32, 0xbc, 0x48, 0xce, 0x91, 0xb6, 0x6c, 0x73, 0x56, 0x08, 0x57, 0x09, 0xcc, 0x9c, 0xa3, 0x32,
+	0x26, 0xb7, 0x85, 0x45, 0x2c, 0x9b, 0x52, 0xb0, 0x6d, 0x4b, 0x5f, 0x0e, 0x99, 0x6d, 0x51, 0x42,
+	0xf2, 0x10, 0xd6, 0x27, 0xca, 0x4d, 0x34, 0x50, 0x29, 0xbb, 0xd6, 0x3d, 0xd7, 0x86, 0x73, 0x73,
+	0x29, 0xe9, 0x43, 0x9b, 0xcd, 0xc4, 0xc4, 0xc3, 0x98, 0x9d, 0x45, 0x48, 0xff, 0xb6, 0x2e, 0x58,
+	0x7f, 0x26, 0x26, 0xc7, 0x4a, 0x60, 0xa6, 0xcb, 0x4c, 0x88, 0x0c, 0xa0, 0xa3, 0x10, 0x41, 0xc8,
+	0x15, 0xe3, 0xdf, 0xeb, 0xb6, 0xf9, 0x4a, 0xc6, 0x20, 0xe4, 0x55, 0x48, 0x9b, 0x95, 0x31, 0xf2,
+	0x54, 0x53, 0x30, 0x16, 0xa1, 0xcf, 0x04, 0xd2, 0xff, 0x34, 0xe5, 0x9d, 0x3a, 0xa5, 0xf0, 0x7d,
+	0xbf, 0x22, 0x2d, 0x70, 0xb5, 0x7c, 0x72, 0x0c, 0x9b, 0xaa, 0x2b, 0x79, 0x6c, 0x3c, 0x16, 0x04,
+	0xf4, 0xa7, 0x8d, 0x65, 0x6d, 0x7d, 0xc6, 0x31, 0xeb, 0x07, 0x41, 0xad, 0xad, 0x3c, 0x46, 0x9e,
+	0xc2, 0x4e, 0x89, 0xd1, 0x9e, 0xa4, 0x3f, 0x6b, 0xd2, 0x3d, 0x3b, 0x29, 0x37, 0x73, 0x0e, 0xdb,
+	0x62, 0xb5, 0x70, 0xbd, 0xad, 0x31, 0x0a, 0xfa, 0xcb, 0xca, 0xb6, 0x4e, 0x50, 0x2c, 0xb4, 0x75,
+	0x82, 0x82, 0x8c, 0xe1, 0x95, 0x12, 0xe3, 0x4f, 0xe4, 0x29, 0xf1, 0x52, 0xc6, 0xf9, 0xd7, 0x49,
+	0x16, 0xd0, 0x5f, 0x35, 0xf2, 0x3d, 0x3b, 0xf2, 0x48, 0xa9, 0x4f, 0x73, 0x71, 0x41, 0xbf, 0xcd,
+	0xac, 0xc3, 0xe4, 0x73, 0xb8, 0x59, 0xe9, 0x57, 0xda, 0xdb, 0xcb, 0x92, 0x08, 0xe9, 0x0b, 0x5d,
+	0xe3, 0xad, 0x25, 0x6d, 0xab, 0xa3, 0x91, 0x94, 0x5b, 0xfd, 0x32, 0x9b, 0x1f, 0x21, 0x5f, 0xc0,
+	0xad, 0x92, 0xac, 0x4f, 0x8a, 0x46, 0xff, 0xa6, 0xd1, 0x6f, 0xdb, 0xd1, 0xf9, 0x91, 0xa9, 0xb0,
+	0x09, 0x5b, 0x18, 0x22, 0x8f, 0x61, 0xab, 0x84, 0x47, 0x21, 0x17, 0xf4, 0x77, 0x4d, 0xbd, 0x6b,
+	0xa7, 0x0e, 0x43, 0x2e, 0x6a, 0x3e, 0x2a, 0x82, 0x86, 0x24, 0x5b, 0xd3, 0xa4, 0x3f, 0x96, 0x92,
+	0x64, 0xe9, 0x05, 0x52, 0x11, 0x34, 0x5b, 0xaf, 0x48, 0xd2, 0x91, 0xdf, 0xb6, 0x96, 0x6d, 0xbd,
+	0xcc, 0x99, 0x77, 0x64, 0x1e, 0x33, 0x8e, 0x54, 0x98, 0xdc, 0x91, 0xdf, 0xb5, 0x96, 0x39, 0x52,
+	0x66, 0x59, 0x1c, 0x59, 0x86, 0xeb, 0x6d, 0x49, 0x47, 0x7e, 0xbf, 0xb2, 0xad, 0x79, 0x47, 0xe6,
+	0x31, 0xf2, 0x25, 0x74, 0x2b, 0x18, 0x65, 0x94, 0x14, 0xb3, 0x69, 0xc8, 0xb9, 0xfc, 0x0e, 0xff,
+	0xa0, 0x99, 0x0f, 0x96, 0x30, 0xa5, 0xfc, 0xd4, 0xa8, 0x0b, 0xfe, 0x1d, 0x66, 0x1f, 0x27, 0x53,
+	0xd8, 0x2d, 0x6b, 0xe5, 0xd6, 0xa9, 0x14, 0xfb, 0x51, 0x17, 0x7b, 0xdf, 0x5e, 0x4c, 0xbb, 0x64,
+	0xb1, 0x1a, 0x65, 0x4b, 0x04, 0xf2, 0x53, 0x7c, 0xc6, 0x84, 0x3f, 0xa1, 0xed, 0xfd, 0xe6, 0xe2,
+	0xc6, 0xdb, 0xae, 0xe2, 0x4f, 0x61, 0x47, 0xdf, 0x00, 0xfe, 0x04, 0xfd, 0xf3, 0x34, 0x09, 0x63,
+	0x41, 0x3b, 0xaa, 0xa9, 0x37, 0x2c, 0xb7, 0xc0, 0x91, 0x11, 0x15, 0xbd, 0x6c, 0x47, 0xf5, 0x78,
+	0x6f, 0x1b, 0x36, 0x8f, 0xa7, 0xa9, 0xf8, 0xc6, 0x45, 0x9e, 0x26, 0x31, 0xc7, 0x5e, 0x0a, 0xbb,
+	0x2b, 0xbe, 0x85, 0x84, 0xc0, 0x9a, 0x7a, 0x3b, 0x38, 0xea, 0xed, 0xa0, 0x7e, 0xcb, 0x37, 0x85,
+	0xf9, 0x44, 0xe4, 0x6f, 0x8a, 0xe2, 0x3f, 0xb9, 0x0b, 0x1d, 0x1e, 0x4e, 0xd3, 0x08, 0x3d, 0x91,
+	0x9c, 0x63, 0xac, 0xde, 0x02, 0x2d, 0xb7, 0xad, 0x63, 0x23, 0x19, 0xea, 0x3d, 0x82, 0xed, 0xb9,
+	0x6e, 0x09, 0x98, 0x17, 0x47, 0x93, 0xdc, 0x83, 0xcd, 0x0c, 0xa7, 0x2c, 0x8c, 0xc3, 0x78, 0xec,
+	0x8d, 0x46, 0x43, 0x55, 0xa2, 0xe9, 0x76, 0x4c, 0x70, 0x34, 0x1a, 0xf6, 0x86, 0x70, 0xdb, 0x3e,
+	0x63, 0x72, 0x08, 0xed, 0x72, 0xad, 0x38, 0x75, 0xd4, 0x4a, 0xbf, 0xb6, 0x72, 0xb1, 0x7a, 0x27,
+	0x70, 0x67, 0x81, 0xa6, 0x97, 0x87, 0x3c, 0x30, 0x77, 0xa1, 0xa3, 0x96, 0xfd, 0xd5, 0xf9, 0xbb,
+	0x50, 0xeb, 0xf4, 0x65, 0xf8, 0x68, 0xe7, 0xf9, 0x5f, 0x7b, 0x2f, 0x3d, 0xbf, 0xda, 0x73, 0x5e,
+	0x5c, 0xed, 0x39, 0x7f, 0x5e, 0xed, 0x39, 0x67, 0xeb, 0xea, 0x9d, 0xf6, 0xf0, 0xff, 0x01, 0x00,
+	0xf7, 0xb7, 0x9e, 0x15, 0xff, 0x09, 0x00, 0x00,
 }
@@ -202,7 +202,7 @@ func (x AlarmRequest_AlarmAction) String() string {
 	return proto.EnumName(AlarmRequest_AlarmAction_name, int32(x))
 }
 func (AlarmRequest_AlarmAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{39, 0}
+	return fileDescriptorRpc, []int{43, 0}
 }
 
 type ResponseHeader struct {
@@ -251,6 +251,21 @@ type RangeRequest struct {
 	KeysOnly bool `protobuf:"varint,8,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
 	// count_only when set returns only the count of the keys in the range.
 	CountOnly bool `protobuf:"varint,9,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	// min_mod_revision is the lower bound for returned key mod revisions; all keys with
+	// lesser mod revisions will be filtered away.
+	MinModRevision int64 `protobuf:"varint,10,opt,name=min_mod_revision,json=minModRevision,proto3" json:"min_mod_revision,omitempty"`
+	// max_mod_revision is the upper bound for returned key mod revisions; all keys with
+	// greater mod revisions will be filtered away.
+	MaxModRevision int64 `protobuf:"varint,11,opt,name=max_mod_revision,json=maxModRevision,proto3" json:"max_mod_revision,omitempty"`
+	// min_create_revision is the lower bound for returned key create revisions; all keys with
+	// lesser create revisions will be filtered away.
+	MinCreateRevision int64 `protobuf:"varint,12,opt,name=min_create_revision,json=minCreateRevision,proto3" json:"min_create_revision,omitempty"`
+	// max_create_revision is the upper bound for returned key create revisions; all keys with
+	// greater create revisions will be filtered away.
+	MaxCreateRevision int64 `protobuf:"varint,13,opt,name=max_create_revision,json=maxCreateRevision,proto3" json:"max_create_revision,omitempty"`
+	// continue_token resumes the range where the previous response that returned it left
+	// off, at the same revision. The other fields of the request must be left unchanged.
+	ContinueToken []byte `protobuf:"bytes,14,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
 }
 
 func (m *RangeRequest) Reset()                    { *m = RangeRequest{} }
@@ -267,6 +282,9 @@ type RangeResponse struct {
 	More bool `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
 	// count is set to the number of keys within the range when requested.
 	Count int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// continue_token is set when more is true and the range is ordered by key; passing
+	// it in the next request returns the following keys at the same revision.
+	ContinueToken []byte `protobuf:"bytes,5,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
 }
 
 func (m *RangeResponse) Reset()                    { *m = RangeResponse{} }
@@ -382,6 +400,7 @@ type RequestOp struct {
 	//	*RequestOp_RequestRange
 	//	*RequestOp_RequestPut
 	//	*RequestOp_RequestDeleteRange
+	//	*RequestOp_RequestTxn
 	Request isRequestOp_Request `protobuf_oneof:"request"`
 }
 
@@ -405,10 +424,14 @@ type RequestOp_RequestPut struct {
 type RequestOp_RequestDeleteRange struct {
 	RequestDeleteRange *DeleteRangeRequest `protobuf:"bytes,3,opt,name=request_delete_range,json=requestDeleteRange,oneof"`
 }
+type RequestOp_RequestTxn struct {
+	RequestTxn *TxnRequest `protobuf:"bytes,4,opt,name=request_txn,json=requestTxn,oneof"`
+}
 
 func (*RequestOp_RequestRange) isRequestOp_Request()       {}
 func (*RequestOp_RequestPut) isRequestOp_Request()         {}
 func (*RequestOp_RequestDeleteRange) isRequestOp_Request() {}
+func (*RequestOp_RequestTxn) isRequestOp_Request()         {}
 
 func (m *RequestOp) GetRequest() isRequestOp_Request {
 	if m != nil {
@@ -438,12 +461,20 @@ func (m *RequestOp) GetRequestDeleteRange() *DeleteRangeRequest {
 	return nil
 }
 
+func (m *RequestOp) GetRequestTxn() *TxnRequest {
+	if x, ok := m.GetRequest().(*RequestOp_RequestTxn); ok {
+		return x.RequestTxn
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*RequestOp) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _RequestOp_OneofMarshaler, _RequestOp_OneofUnmarshaler, _RequestOp_OneofSizer, []interface{}{
 		(*RequestOp_RequestRange)(nil),
 		(*RequestOp_RequestPut)(nil),
 		(*RequestOp_RequestDeleteRange)(nil),
+		(*RequestOp_RequestTxn)(nil),
 	}
 }
 
@@ -466,6 +497,11 @@ func _RequestOp_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.RequestDeleteRange); err != nil {
 			return err
 		}
+	case *RequestOp_RequestTxn:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RequestTxn); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("RequestOp.Request has unexpected type %T", x)
@@ -500,6 +536,14 @@ func _RequestOp_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buff
 		err := b.DecodeMessage(msg)
 		m.Request = &RequestOp_RequestDeleteRange{msg}
 		return true, err
+	case 4: // request.request_txn
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TxnRequest)
+		err := b.DecodeMessage(msg)
+		m.Request = &RequestOp_RequestTxn{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -524,6 +568,11 @@ func _RequestOp_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *RequestOp_RequestTxn:
+		s := proto.Size(x.RequestTxn)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -538,6 +587,7 @@ type ResponseOp struct {
 	//	*ResponseOp_ResponseRange
 	//	*ResponseOp_ResponsePut
 	//	*ResponseOp_ResponseDeleteRange
+	//	*ResponseOp_ResponseTxn
 	Response isResponseOp_Response `protobuf_oneof:"response"`
 }
 
@@ -561,10 +611,14 @@ type ResponseOp_ResponsePut struct {
 type ResponseOp_ResponseDeleteRange struct {
 	ResponseDeleteRange *DeleteRangeResponse `protobuf:"bytes,3,opt,name=response_delete_range,json=responseDeleteRange,oneof"`
 }
+type ResponseOp_ResponseTxn struct {
+	ResponseTxn *TxnResponse `protobuf:"bytes,4,opt,name=response_txn,json=responseTxn,oneof"`
+}
 
 func (*ResponseOp_ResponseRange) isResponseOp_Response()       {}
 func (*ResponseOp_ResponsePut) isResponseOp_Response()         {}
 func (*ResponseOp_ResponseDeleteRange) isResponseOp_Response() {}
+func (*ResponseOp_ResponseTxn) isResponseOp_Response()         {}
 
 func (m *ResponseOp) GetResponse() isResponseOp_Response {
 	if m != nil {
@@ -594,12 +648,20 @@ func (m *ResponseOp) GetResponseDeleteRange() *DeleteRangeResponse {
 	return nil
 }
 
+func (m *ResponseOp) GetResponseTxn() *TxnResponse {
+	if x, ok := m.GetResponse().(*ResponseOp_ResponseTxn); ok {
+		return x.ResponseTxn
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ResponseOp) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ResponseOp_OneofMarshaler, _ResponseOp_OneofUnmarshaler, _ResponseOp_OneofSizer, []interface{}{
 		(*ResponseOp_ResponseRange)(nil),
 		(*ResponseOp_ResponsePut)(nil),
 		(*ResponseOp_ResponseDeleteRange)(nil),
+		(*ResponseOp_ResponseTxn)(nil),
 	}
 }
 
@@ -622,6 +684,11 @@ func _ResponseOp_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ResponseDeleteRange); err != nil {
 			return err
 		}
+	case *ResponseOp_ResponseTxn:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ResponseTxn); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ResponseOp.Response has unexpected type %T", x)
@@ -656,6 +723,14 @@ func _ResponseOp_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buf
 		err := b.DecodeMessage(msg)
 		m.Response = &ResponseOp_ResponseDeleteRange{msg}
 		return true, err
+	case 4: // response.response_txn
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TxnResponse)
+		err := b.DecodeMessage(msg)
+		m.Response = &ResponseOp_ResponseTxn{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -680,6 +755,11 @@ func _ResponseOp_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ResponseOp_ResponseTxn:
+		s := proto.Size(x.ResponseTxn)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -700,6 +780,9 @@ type Compare struct {
 	//	*Compare_ModRevision
 	//	*Compare_Value
 	TargetUnion isCompare_TargetUnion `protobuf_oneof:"target_union"`
+	// range_end compares the given target to all keys in the range [key, range_end).
+	// See RangeRequest for more details on key ranges.
+	RangeEnd []byte `protobuf:"bytes,64,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
 }
 
 func (m *Compare) Reset()                    { *m = Compare{} }
@@ -1322,6 +1405,83 @@ func (m *LeaseKeepAliveResponse) GetHeader() *ResponseHeader {
 	return nil
 }
 
+type LeaseTimeToLiveRequest struct {
+	// ID is the lease ID for the lease.
+	ID int64 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+	// keys is true to query all the keys attached to this lease.
+	Keys bool `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (m *LeaseTimeToLiveRequest) Reset()                    { *m = LeaseTimeToLiveRequest{} }
+func (m *LeaseTimeToLiveRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseTimeToLiveRequest) ProtoMessage()               {}
+func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{28} }
+
+type LeaseTimeToLiveResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// ID is the lease ID from the keep alive request.
+	ID int64 `protobuf:"varint,2,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+	// TTL is the remaining TTL in seconds for the lease; the lease will expire in under TTL+1 seconds.
+	TTL int64 `protobuf:"varint,3,opt,name=TTL,json=tTL,proto3" json:"TTL,omitempty"`
+	// grantedTTL is the initial granted time in seconds upon lease creation/renewal.
+	GrantedTTL int64 `protobuf:"varint,4,opt,name=grantedTTL,proto3" json:"grantedTTL,omitempty"`
+	// keys is the list of keys attached to this lease.
+	Keys [][]byte `protobuf:"bytes,5,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (m *LeaseTimeToLiveResponse) Reset()                    { *m = LeaseTimeToLiveResponse{} }
+func (m *LeaseTimeToLiveResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseTimeToLiveResponse) ProtoMessage()               {}
+func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{29} }
+
+func (m *LeaseTimeToLiveResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type LeaseLeasesRequest struct {
+}
+
+func (m *LeaseLeasesRequest) Reset()                    { *m = LeaseLeasesRequest{} }
+func (m *LeaseLeasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseLeasesRequest) ProtoMessage()               {}
+func (*LeaseLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{30} }
+
+type LeaseStatus struct {
+	ID int64 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+}
+
+func (m *LeaseStatus) Reset()                    { *m = LeaseStatus{} }
+func (m *LeaseStatus) String() string            { return proto.CompactTextString(m) }
+func (*LeaseStatus) ProtoMessage()               {}
+func (*LeaseStatus) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{31} }
+
+type LeaseLeasesResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Leases []*LeaseStatus  `protobuf:"bytes,2,rep,name=leases" json:"leases,omitempty"`
+}
+
+func (m *LeaseLeasesResponse) Reset()                    { *m = LeaseLeasesResponse{} }
+func (m *LeaseLeasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseLeasesResponse) ProtoMessage()               {}
+func (*LeaseLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{32} }
+
+func (m *LeaseLeasesResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *LeaseLeasesResponse) GetLeases() []*LeaseStatus {
+	if m != nil {
+		return m.Leases
+	}
+	return nil
+}
+
 type Member struct {
 	// ID is the member ID for this member.
 	ID uint64 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
@@ -1331,22 +1491,30 @@ type Member struct {
 	PeerURLs []string `protobuf:"bytes,3,rep,name=peerURLs" json:"peerURLs,omitempty"`
 	// clientURLs is the list of URLs the member exposes to clients for communication. If the member is not started, clientURLs will be empty.
 	ClientURLs []string `protobuf:"bytes,4,rep,name=clientURLs" json:"clientURLs,omitempty"`
+	// isLearner indicates if the member is a non-voting learner.
+	IsLearner bool `protobuf:"varint,5,opt,name=isLearner,proto3" json:"isLearner,omitempty"`
+	// isWitness indicates if the member is a witness, which votes but keeps no application state.
+	IsWitness bool `protobuf:"varint,6,opt,name=isWitness,proto3" json:"isWitness,omitempty"`
 }
 
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{28} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{33} }
 
 type MemberAddRequest struct {
 	// peerURLs is the list of URLs the added member will use to communicate with the cluster.
 	PeerURLs []string `protobuf:"bytes,1,rep,name=peerURLs" json:"peerURLs,omitempty"`
+	// isLearner indicates if the added member is a non-voting learner.
+	IsLearner bool `protobuf:"varint,2,opt,name=isLearner,proto3" json:"isLearner,omitempty"`
+	// isWitness indicates if the added member is a witness, which votes but keeps no application state.
+	IsWitness bool `protobuf:"varint,3,opt,name=isWitness,proto3" json:"isWitness,omitempty"`
 }
 
 func (m *MemberAddRequest) Reset()                    { *m = MemberAddRequest{} }
 func (m *MemberAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberAddRequest) ProtoMessage()               {}
-func (*MemberAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{29} }
+func (*MemberAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{34} }
 
 type MemberAddResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1357,7 +1525,7 @@ type MemberAddResponse struct {
 func (m *MemberAddResponse) Reset()                    { *m = MemberAddResponse{} }
 func (m *MemberAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberAddResponse) ProtoMessage()               {}
-func (*MemberAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{30} }
+func (*MemberAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{35} }
 
 func (m *MemberAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1381,7 +1549,7 @@ type MemberRemoveRequest struct {
 func (m *MemberRemoveRequest) Reset()                    { *m = MemberRemoveRequest{} }
 func (m *MemberRemoveRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberRemoveRequest) ProtoMessage()               {}
-func (*MemberRemoveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{31} }
+func (*MemberRemoveRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{36} }
 
 type MemberRemoveResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1390,7 +1558,7 @@ type MemberRemoveResponse struct {
 func (m *MemberRemoveResponse) Reset()                    { *m = MemberRemoveResponse{} }
 func (m *MemberRemoveResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberRemoveResponse) ProtoMessage()               {}
-func (*MemberRemoveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{32} }
+func (*MemberRemoveResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{37} }
 
 func (m *MemberRemoveResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1409,7 +1577,7 @@ type MemberUpdateRequest struct {
 func (m *MemberUpdateRequest) Reset()                    { *m = MemberUpdateRequest{} }
 func (m *MemberUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberUpdateRequest) ProtoMessage()               {}
-func (*MemberUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{33} }
+func (*MemberUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{38} }
 
 type MemberUpdateResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1418,7 +1586,7 @@ type MemberUpdateResponse struct {
 func (m *MemberUpdateResponse) Reset()                    { *m = MemberUpdateResponse{} }
 func (m *MemberUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberUpdateResponse) ProtoMessage()               {}
-func (*MemberUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{34} }
+func (*MemberUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{39} }
 
 func (m *MemberUpdateResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1433,7 +1601,7 @@ type MemberListRequest struct {
 func (m *MemberListRequest) Reset()                    { *m = MemberListRequest{} }
 func (m *MemberListRequest) String() string            { return proto.CompactTextString(m) }
 func (*MemberListRequest) ProtoMessage()               {}
-func (*MemberListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{35} }
+func (*MemberListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
 
 type MemberListResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1444,7 +1612,7 @@ type MemberListResponse struct {
 func (m *MemberListResponse) Reset()                    { *m = MemberListResponse{} }
 func (m *MemberListResponse) String() string            { return proto.CompactTextString(m) }
 func (*MemberListResponse) ProtoMessage()               {}
-func (*MemberListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{36} }
+func (*MemberListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
 
 func (m *MemberListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1460,13 +1628,101 @@ func (m *MemberListResponse) GetMembers() []*Member {
 	return nil
 }
 
+type MemberPromoteRequest struct {
+	// ID is the member ID of the learner to promote.
+	ID uint64 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+}
+
+func (m *MemberPromoteRequest) Reset()                    { *m = MemberPromoteRequest{} }
+func (m *MemberPromoteRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemberPromoteRequest) ProtoMessage()               {}
+func (*MemberPromoteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
+
+type MemberPromoteResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// members is a list of all members after the promotion.
+	Members []*Member `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+}
+
+func (m *MemberPromoteResponse) Reset()                    { *m = MemberPromoteResponse{} }
+func (m *MemberPromoteResponse) String() string            { return proto.CompactTextString(m) }
+func (*MemberPromoteResponse) ProtoMessage()               {}
+func (*MemberPromoteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{43} }
+
+func (m *MemberPromoteResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MemberPromoteResponse) GetMembers() []*Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type MemberReconfigureRequest struct {
+	// add is the list of members to add.
+	Add []*MemberAddRequest `protobuf:"bytes,1,rep,name=add" json:"add,omitempty"`
+	// remove is the list of the member IDs of the members to remove.
+	Remove []uint64 `protobuf:"varint,2,rep,packed,name=remove" json:"remove,omitempty"`
+}
+
+func (m *MemberReconfigureRequest) Reset()                    { *m = MemberReconfigureRequest{} }
+func (m *MemberReconfigureRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemberReconfigureRequest) ProtoMessage()               {}
+func (*MemberReconfigureRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{44} }
+
+func (m *MemberReconfigureRequest) GetAdd() []*MemberAddRequest {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+type MemberReconfigureResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// added is the list of the added members, in the order of the request.
+	Added []*Member `protobuf:"bytes,2,rep,name=added" json:"added,omitempty"`
+	// members is a list of all members after the reconfiguration.
+	Members []*Member `protobuf:"bytes,3,rep,name=members" json:"members,omitempty"`
+}
+
+func (m *MemberReconfigureResponse) Reset()                    { *m = MemberReconfigureResponse{} }
+func (m *MemberReconfigureResponse) String() string            { return proto.CompactTextString(m) }
+func (*MemberReconfigureResponse) ProtoMessage()               {}
+func (*MemberReconfigureResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{45} }
+
+func (m *MemberReconfigureResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *MemberReconfigureResponse) GetAdded() []*Member {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *MemberReconfigureResponse) GetMembers() []*Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
 type DefragmentRequest struct {
 }
 
 func (m *DefragmentRequest) Reset()                    { *m = DefragmentRequest{} }
 func (m *DefragmentRequest) String() string            { return proto.CompactTextString(m) }
 func (*DefragmentRequest) ProtoMessage()               {}
-func (*DefragmentRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{37} }
+func (*DefragmentRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{46} }
 
 type DefragmentResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1475,7 +1731,7 @@ type DefragmentResponse struct {
 func (m *DefragmentResponse) Reset()                    { *m = DefragmentResponse{} }
 func (m *DefragmentResponse) String() string            { return proto.CompactTextString(m) }
 func (*DefragmentResponse) ProtoMessage()               {}
-func (*DefragmentResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{38} }
+func (*DefragmentResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{47} }
 
 func (m *DefragmentResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1499,7 +1755,7 @@ type AlarmRequest struct {
 func (m *AlarmRequest) Reset()                    { *m = AlarmRequest{} }
 func (m *AlarmRequest) String() string            { return proto.CompactTextString(m) }
 func (*AlarmRequest) ProtoMessage()               {}
-func (*AlarmRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{39} }
+func (*AlarmRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{48} }
 
 type AlarmMember struct {
 	// memberID is the ID of the member associated with the raised alarm.
@@ -1511,7 +1767,7 @@ type AlarmMember struct {
 func (m *AlarmMember) Reset()                    { *m = AlarmMember{} }
 func (m *AlarmMember) String() string            { return proto.CompactTextString(m) }
 func (*AlarmMember) ProtoMessage()               {}
-func (*AlarmMember) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
+func (*AlarmMember) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{49} }
 
 type AlarmResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1522,7 +1778,7 @@ type AlarmResponse struct {
 func (m *AlarmResponse) Reset()                    { *m = AlarmResponse{} }
 func (m *AlarmResponse) String() string            { return proto.CompactTextString(m) }
 func (*AlarmResponse) ProtoMessage()               {}
-func (*AlarmResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
+func (*AlarmResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{50} }
 
 func (m *AlarmResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1544,7 +1800,7 @@ type StatusRequest struct {
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{51} }
 
 type StatusResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
@@ -1563,7 +1819,7 @@ type StatusResponse struct {
 func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
 func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatusResponse) ProtoMessage()               {}
-func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{43} }
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{52} }
 
 func (m *StatusResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1572,13 +1828,39 @@ func (m *StatusResponse) GetHeader() *ResponseHeader {
 	return nil
 }
 
+type MoveLeaderRequest struct {
+	// targetID is the member ID of the member to transfer the leadership to.
+	TargetID uint64 `protobuf:"varint,1,opt,name=targetID,proto3" json:"targetID,omitempty"`
+}
+
+func (m *MoveLeaderRequest) Reset()                    { *m = MoveLeaderRequest{} }
+func (m *MoveLeaderRequest) String() string            { return proto.CompactTextString(m) }
+func (*MoveLeaderRequest) ProtoMessage()               {}
+func (*MoveLeaderRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{53} }
+
+type MoveLeaderResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+}
+
+func (m *MoveLeaderResponse) Reset()                    { *m = MoveLeaderResponse{} }
+func (m *MoveLeaderResponse) String() string            { return proto.CompactTextString(m) }
+func (*MoveLeaderResponse) ProtoMessage()               {}
+func (*MoveLeaderResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{54} }
+
+func (m *MoveLeaderResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
 type AuthEnableRequest struct {
 }
 
 func (m *AuthEnableRequest) Reset()                    { *m = AuthEnableRequest{} }
 func (m *AuthEnableRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthEnableRequest) ProtoMessage()               {}
-func (*AuthEnableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{44} }
+func (*AuthEnableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{55} }
 
 type AuthDisableRequest struct {
 }
@@ -1586,7 +1868,7 @@ type AuthDisableRequest struct {
 func (m *AuthDisableRequest) Reset()                    { *m = AuthDisableRequest{} }
 func (m *AuthDisableRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthDisableRequest) ProtoMessage()               {}
-func (*AuthDisableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{45} }
+func (*AuthDisableRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{56} }
 
 type AuthenticateRequest struct {
 	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1596,7 +1878,7 @@ type AuthenticateRequest struct {
 func (m *AuthenticateRequest) Reset()                    { *m = AuthenticateRequest{} }
 func (m *AuthenticateRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthenticateRequest) ProtoMessage()               {}
-func (*AuthenticateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{46} }
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{57} }
 
 type AuthUserAddRequest struct {
 	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1606,7 +1888,7 @@ type AuthUserAddRequest struct {
 func (m *AuthUserAddRequest) Reset()                    { *m = AuthUserAddRequest{} }
 func (m *AuthUserAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserAddRequest) ProtoMessage()               {}
-func (*AuthUserAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{47} }
+func (*AuthUserAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{58} }
 
 type AuthUserGetRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1615,7 +1897,7 @@ type AuthUserGetRequest struct {
 func (m *AuthUserGetRequest) Reset()                    { *m = AuthUserGetRequest{} }
 func (m *AuthUserGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGetRequest) ProtoMessage()               {}
-func (*AuthUserGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{48} }
+func (*AuthUserGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{59} }
 
 type AuthUserDeleteRequest struct {
 	// name is the name of the user to delete.
@@ -1625,7 +1907,7 @@ type AuthUserDeleteRequest struct {
 func (m *AuthUserDeleteRequest) Reset()                    { *m = AuthUserDeleteRequest{} }
 func (m *AuthUserDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserDeleteRequest) ProtoMessage()               {}
-func (*AuthUserDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{49} }
+func (*AuthUserDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{60} }
 
 type AuthUserChangePasswordRequest struct {
 	// name is the name of the user whose password is being changed.
@@ -1638,7 +1920,7 @@ func (m *AuthUserChangePasswordRequest) Reset()         { *m = AuthUserChangePas
 func (m *AuthUserChangePasswordRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthUserChangePasswordRequest) ProtoMessage()    {}
 func (*AuthUserChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{50}
+	return fileDescriptorRpc, []int{61}
 }
 
 type AuthUserGrantRoleRequest struct {
@@ -1651,7 +1933,7 @@ type AuthUserGrantRoleRequest struct {
 func (m *AuthUserGrantRoleRequest) Reset()                    { *m = AuthUserGrantRoleRequest{} }
 func (m *AuthUserGrantRoleRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGrantRoleRequest) ProtoMessage()               {}
-func (*AuthUserGrantRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{51} }
+func (*AuthUserGrantRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{62} }
 
 type AuthUserRevokeRoleRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1661,7 +1943,7 @@ type AuthUserRevokeRoleRequest struct {
 func (m *AuthUserRevokeRoleRequest) Reset()                    { *m = AuthUserRevokeRoleRequest{} }
 func (m *AuthUserRevokeRoleRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserRevokeRoleRequest) ProtoMessage()               {}
-func (*AuthUserRevokeRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{52} }
+func (*AuthUserRevokeRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{63} }
 
 type AuthRoleAddRequest struct {
 	// name is the name of the role to add to the authentication system.
@@ -1671,7 +1953,7 @@ type AuthRoleAddRequest struct {
 func (m *AuthRoleAddRequest) Reset()                    { *m = AuthRoleAddRequest{} }
 func (m *AuthRoleAddRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleAddRequest) ProtoMessage()               {}
-func (*AuthRoleAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{53} }
+func (*AuthRoleAddRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{64} }
 
 type AuthRoleGetRequest struct {
 	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -1680,7 +1962,7 @@ type AuthRoleGetRequest struct {
 func (m *AuthRoleGetRequest) Reset()                    { *m = AuthRoleGetRequest{} }
 func (m *AuthRoleGetRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleGetRequest) ProtoMessage()               {}
-func (*AuthRoleGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{54} }
+func (*AuthRoleGetRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{65} }
 
 type AuthUserListRequest struct {
 }
@@ -1688,7 +1970,7 @@ type AuthUserListRequest struct {
 func (m *AuthUserListRequest) Reset()                    { *m = AuthUserListRequest{} }
 func (m *AuthUserListRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserListRequest) ProtoMessage()               {}
-func (*AuthUserListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{55} }
+func (*AuthUserListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{66} }
 
 type AuthRoleListRequest struct {
 }
@@ -1696,7 +1978,7 @@ type AuthRoleListRequest struct {
 func (m *AuthRoleListRequest) Reset()                    { *m = AuthRoleListRequest{} }
 func (m *AuthRoleListRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleListRequest) ProtoMessage()               {}
-func (*AuthRoleListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{56} }
+func (*AuthRoleListRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{67} }
 
 type AuthRoleDeleteRequest struct {
 	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -1705,7 +1987,7 @@ type AuthRoleDeleteRequest struct {
 func (m *AuthRoleDeleteRequest) Reset()                    { *m = AuthRoleDeleteRequest{} }
 func (m *AuthRoleDeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleDeleteRequest) ProtoMessage()               {}
-func (*AuthRoleDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{57} }
+func (*AuthRoleDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{68} }
 
 type AuthRoleGrantPermissionRequest struct {
 	// name is the name of the role which will be granted the permission.
@@ -1718,7 +2000,7 @@ func (m *AuthRoleGrantPermissionRequest) Reset()         { *m = AuthRoleGrantPer
 func (m *AuthRoleGrantPermissionRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleGrantPermissionRequest) ProtoMessage()    {}
 func (*AuthRoleGrantPermissionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{58}
+	return fileDescriptorRpc, []int{69}
 }
 
 func (m *AuthRoleGrantPermissionRequest) GetPerm() *authpb.Permission {
@@ -1738,7 +2020,7 @@ func (m *AuthRoleRevokePermissionRequest) Reset()         { *m = AuthRoleRevokeP
 func (m *AuthRoleRevokePermissionRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleRevokePermissionRequest) ProtoMessage()    {}
 func (*AuthRoleRevokePermissionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{59}
+	return fileDescriptorRpc, []int{70}
 }
 
 type AuthEnableResponse struct {
@@ -1748,7 +2030,7 @@ type AuthEnableResponse struct {
 func (m *AuthEnableResponse) Reset()                    { *m = AuthEnableResponse{} }
 func (m *AuthEnableResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthEnableResponse) ProtoMessage()               {}
-func (*AuthEnableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{60} }
+func (*AuthEnableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{71} }
 
 func (m *AuthEnableResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1764,7 +2046,7 @@ type AuthDisableResponse struct {
 func (m *AuthDisableResponse) Reset()                    { *m = AuthDisableResponse{} }
 func (m *AuthDisableResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthDisableResponse) ProtoMessage()               {}
-func (*AuthDisableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{61} }
+func (*AuthDisableResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{72} }
 
 func (m *AuthDisableResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1782,7 +2064,7 @@ type AuthenticateResponse struct {
 func (m *AuthenticateResponse) Reset()                    { *m = AuthenticateResponse{} }
 func (m *AuthenticateResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthenticateResponse) ProtoMessage()               {}
-func (*AuthenticateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{62} }
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{73} }
 
 func (m *AuthenticateResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1798,7 +2080,7 @@ type AuthUserAddResponse struct {
 func (m *AuthUserAddResponse) Reset()                    { *m = AuthUserAddResponse{} }
 func (m *AuthUserAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserAddResponse) ProtoMessage()               {}
-func (*AuthUserAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{63} }
+func (*AuthUserAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{74} }
 
 func (m *AuthUserAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1815,7 +2097,7 @@ type AuthUserGetResponse struct {
 func (m *AuthUserGetResponse) Reset()                    { *m = AuthUserGetResponse{} }
 func (m *AuthUserGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGetResponse) ProtoMessage()               {}
-func (*AuthUserGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{64} }
+func (*AuthUserGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{75} }
 
 func (m *AuthUserGetResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1831,7 +2113,7 @@ type AuthUserDeleteResponse struct {
 func (m *AuthUserDeleteResponse) Reset()                    { *m = AuthUserDeleteResponse{} }
 func (m *AuthUserDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserDeleteResponse) ProtoMessage()               {}
-func (*AuthUserDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{65} }
+func (*AuthUserDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{76} }
 
 func (m *AuthUserDeleteResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1848,7 +2130,7 @@ func (m *AuthUserChangePasswordResponse) Reset()         { *m = AuthUserChangePa
 func (m *AuthUserChangePasswordResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthUserChangePasswordResponse) ProtoMessage()    {}
 func (*AuthUserChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{66}
+	return fileDescriptorRpc, []int{77}
 }
 
 func (m *AuthUserChangePasswordResponse) GetHeader() *ResponseHeader {
@@ -1865,7 +2147,7 @@ type AuthUserGrantRoleResponse struct {
 func (m *AuthUserGrantRoleResponse) Reset()                    { *m = AuthUserGrantRoleResponse{} }
 func (m *AuthUserGrantRoleResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserGrantRoleResponse) ProtoMessage()               {}
-func (*AuthUserGrantRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{67} }
+func (*AuthUserGrantRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{78} }
 
 func (m *AuthUserGrantRoleResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1881,7 +2163,7 @@ type AuthUserRevokeRoleResponse struct {
 func (m *AuthUserRevokeRoleResponse) Reset()                    { *m = AuthUserRevokeRoleResponse{} }
 func (m *AuthUserRevokeRoleResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserRevokeRoleResponse) ProtoMessage()               {}
-func (*AuthUserRevokeRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{68} }
+func (*AuthUserRevokeRoleResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{79} }
 
 func (m *AuthUserRevokeRoleResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1897,7 +2179,7 @@ type AuthRoleAddResponse struct {
 func (m *AuthRoleAddResponse) Reset()                    { *m = AuthRoleAddResponse{} }
 func (m *AuthRoleAddResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleAddResponse) ProtoMessage()               {}
-func (*AuthRoleAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{69} }
+func (*AuthRoleAddResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{80} }
 
 func (m *AuthRoleAddResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1914,7 +2196,7 @@ type AuthRoleGetResponse struct {
 func (m *AuthRoleGetResponse) Reset()                    { *m = AuthRoleGetResponse{} }
 func (m *AuthRoleGetResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleGetResponse) ProtoMessage()               {}
-func (*AuthRoleGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{70} }
+func (*AuthRoleGetResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{81} }
 
 func (m *AuthRoleGetResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1938,7 +2220,7 @@ type AuthRoleListResponse struct {
 func (m *AuthRoleListResponse) Reset()                    { *m = AuthRoleListResponse{} }
 func (m *AuthRoleListResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleListResponse) ProtoMessage()               {}
-func (*AuthRoleListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{71} }
+func (*AuthRoleListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{82} }
 
 func (m *AuthRoleListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1955,7 +2237,7 @@ type AuthUserListResponse struct {
 func (m *AuthUserListResponse) Reset()                    { *m = AuthUserListResponse{} }
 func (m *AuthUserListResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthUserListResponse) ProtoMessage()               {}
-func (*AuthUserListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{72} }
+func (*AuthUserListResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{83} }
 
 func (m *AuthUserListResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1971,7 +2253,7 @@ type AuthRoleDeleteResponse struct {
 func (m *AuthRoleDeleteResponse) Reset()                    { *m = AuthRoleDeleteResponse{} }
 func (m *AuthRoleDeleteResponse) String() string            { return proto.CompactTextString(m) }
 func (*AuthRoleDeleteResponse) ProtoMessage()               {}
-func (*AuthRoleDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{73} }
+func (*AuthRoleDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{84} }
 
 func (m *AuthRoleDeleteResponse) GetHeader() *ResponseHeader {
 	if m != nil {
@@ -1988,7 +2270,7 @@ func (m *AuthRoleGrantPermissionResponse) Reset()         { *m = AuthRoleGrantPe
 func (m *AuthRoleGrantPermissionResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleGrantPermissionResponse) ProtoMessage()    {}
 func (*AuthRoleGrantPermissionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{74}
+	return fileDescriptorRpc, []int{85}
 }
 
 func (m *AuthRoleGrantPermissionResponse) GetHeader() *ResponseHeader {
@@ -2006,7 +2288,7 @@ func (m *AuthRoleRevokePermissionResponse) Reset()         { *m = AuthRoleRevoke
 func (m *AuthRoleRevokePermissionResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthRoleRevokePermissionResponse) ProtoMessage()    {}
 func (*AuthRoleRevokePermissionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorRpc, []int{75}
+	return fileDescriptorRpc, []int{86}
 }
 
 func (m *AuthRoleRevokePermissionResponse) GetHeader() *ResponseHeader {
@@ -2045,6 +2327,11 @@ func init() {
 	proto.RegisterType((*LeaseRevokeResponse)(nil), "etcdserverpb.LeaseRevokeResponse")
 	proto.RegisterType((*LeaseKeepAliveRequest)(nil), "etcdserverpb.LeaseKeepAliveRequest")
 	proto.RegisterType((*LeaseKeepAliveResponse)(nil), "etcdserverpb.LeaseKeepAliveResponse")
+	proto.RegisterType((*LeaseTimeToLiveRequest)(nil), "etcdserverpb.LeaseTimeToLiveRequest")
+	proto.RegisterType((*LeaseTimeToLiveResponse)(nil), "etcdserverpb.LeaseTimeToLiveResponse")
+	proto.RegisterType((*LeaseLeasesRequest)(nil), "etcdserverpb.LeaseLeasesRequest")
+	proto.RegisterType((*LeaseStatus)(nil), "etcdserverpb.LeaseStatus")
+	proto.RegisterType((*LeaseLeasesResponse)(nil), "etcdserverpb.LeaseLeasesResponse")
 	proto.RegisterType((*Member)(nil), "etcdserverpb.Member")
 	proto.RegisterType((*MemberAddRequest)(nil), "etcdserverpb.MemberAddRequest")
 	proto.RegisterType((*MemberAddResponse)(nil), "etcdserverpb.MemberAddResponse")
@@ -2054,6 +2341,10 @@ func init() {
 	proto.RegisterType((*MemberUpdateResponse)(nil), "etcdserverpb.MemberUpdateResponse")
 	proto.RegisterType((*MemberListRequest)(nil), "etcdserverpb.MemberListRequest")
 	proto.RegisterType((*MemberListResponse)(nil), "etcdserverpb.MemberListResponse")
+	proto.RegisterType((*MemberPromoteRequest)(nil), "etcdserverpb.MemberPromoteRequest")
+	proto.RegisterType((*MemberPromoteResponse)(nil), "etcdserverpb.MemberPromoteResponse")
+	proto.RegisterType((*MemberReconfigureRequest)(nil), "etcdserverpb.MemberReconfigureRequest")
+	proto.RegisterType((*MemberReconfigureResponse)(nil), "etcdserverpb.MemberReconfigureResponse")
 	proto.RegisterType((*DefragmentRequest)(nil), "etcdserverpb.DefragmentRequest")
 	proto.RegisterType((*DefragmentResponse)(nil), "etcdserverpb.DefragmentResponse")
 	proto.RegisterType((*AlarmRequest)(nil), "etcdserverpb.AlarmRequest")
@@ -2061,6 +2352,8 @@ func init() {
 	proto.RegisterType((*AlarmResponse)(nil), "etcdserverpb.AlarmResponse")
 	proto.RegisterType((*StatusRequest)(nil), "etcdserverpb.StatusRequest")
 	proto.RegisterType((*StatusResponse)(nil), "etcdserverpb.StatusResponse")
+	proto.RegisterType((*MoveLeaderRequest)(nil), "etcdserverpb.MoveLeaderRequest")
+	proto.RegisterType((*MoveLeaderResponse)(nil), "etcdserverpb.MoveLeaderResponse")
 	proto.RegisterType((*AuthEnableRequest)(nil), "etcdserverpb.AuthEnableRequest")
 	proto.RegisterType((*AuthDisableRequest)(nil), "etcdserverpb.AuthDisableRequest")
 	proto.RegisterType((*AuthenticateRequest)(nil), "etcdserverpb.AuthenticateRequest")
@@ -2452,6 +2745,10 @@ type LeaseClient interface {
 	// LeaseKeepAlive keeps the lease alive by streaming keep alive requests from the client
 	// to the server and streaming keep alive responses from the server to the client.
 	LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (Lease_LeaseKeepAliveClient, error)
+	// LeaseTimeToLive retrieves lease information.
+	LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error)
+	// LeaseLeases lists all existing leases.
+	LeaseLeases(ctx context.Context, in *LeaseLeasesRequest, opts ...grpc.CallOption) (*LeaseLeasesResponse, error)
 }
 
 type leaseClient struct {
@@ -2511,6 +2808,24 @@ func (x *leaseLeaseKeepAliveClient) Recv() (*LeaseKeepAliveResponse, error) {
 	return m, nil
 }
 
+func (c *leaseClient) LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error) {
+	out := new(LeaseTimeToLiveResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Lease/LeaseTimeToLive", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaseClient) LeaseLeases(ctx context.Context, in *LeaseLeasesRequest, opts ...grpc.CallOption) (*LeaseLeasesResponse, error) {
+	out := new(LeaseLeasesResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Lease/LeaseLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Lease service
 
 type LeaseServer interface {
@@ -2523,6 +2838,10 @@ type LeaseServer interface {
 	// LeaseKeepAlive keeps the lease alive by streaming keep alive requests from the client
 	// to the server and streaming keep alive responses from the server to the client.
 	LeaseKeepAlive(Lease_LeaseKeepAliveServer) error
+	// LeaseTimeToLive retrieves lease information.
+	LeaseTimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error)
+	// LeaseLeases lists all existing leases.
+	LeaseLeases(context.Context, *LeaseLeasesRequest) (*LeaseLeasesResponse, error)
 }
 
 func RegisterLeaseServer(s *grpc.Server, srv LeaseServer) {
@@ -2591,6 +2910,42 @@ func (x *leaseLeaseKeepAliveServer) Recv() (*LeaseKeepAliveRequest, error) {
 	return m, nil
 }
 
+func _Lease_LeaseTimeToLive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseTimeToLiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).LeaseTimeToLive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Lease/LeaseTimeToLive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).LeaseTimeToLive(ctx, req.(*LeaseTimeToLiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lease_LeaseLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseLeasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).LeaseLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Lease/LeaseLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).LeaseLeases(ctx, req.(*LeaseLeasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Lease_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "etcdserverpb.Lease",
 	HandlerType: (*LeaseServer)(nil),
@@ -2603,6 +2958,14 @@ var _Lease_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LeaseRevoke",
 			Handler:    _Lease_LeaseRevoke_Handler,
 		},
+		{
+			MethodName: "LeaseTimeToLive",
+			Handler:    _Lease_LeaseTimeToLive_Handler,
+		},
+		{
+			MethodName: "LeaseLeases",
+			Handler:    _Lease_LeaseLeases_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2626,6 +2989,10 @@ type ClusterClient interface {
 	MemberUpdate(ctx context.Context, in *MemberUpdateRequest, opts ...grpc.CallOption) (*MemberUpdateResponse, error)
 	// MemberList lists all the members in the cluster.
 	MemberList(ctx context.Context, in *MemberListRequest, opts ...grpc.CallOption) (*MemberListResponse, error)
+	// MemberPromote promotes a learner member to a voting member.
+	MemberPromote(ctx context.Context, in *MemberPromoteRequest, opts ...grpc.CallOption) (*MemberPromoteResponse, error)
+	// MemberReconfigure adds and removes several members at once.
+	MemberReconfigure(ctx context.Context, in *MemberReconfigureRequest, opts ...grpc.CallOption) (*MemberReconfigureResponse, error)
 }
 
 type clusterClient struct {
@@ -2672,6 +3039,24 @@ func (c *clusterClient) MemberList(ctx context.Context, in *MemberListRequest, o
 	return out, nil
 }
 
+func (c *clusterClient) MemberPromote(ctx context.Context, in *MemberPromoteRequest, opts ...grpc.CallOption) (*MemberPromoteResponse, error) {
+	out := new(MemberPromoteResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Cluster/MemberPromote", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) MemberReconfigure(ctx context.Context, in *MemberReconfigureRequest, opts ...grpc.CallOption) (*MemberReconfigureResponse, error) {
+	out := new(MemberReconfigureResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Cluster/MemberReconfigure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cluster service
 
 type ClusterServer interface {
@@ -2683,6 +3068,10 @@ type ClusterServer interface {
 	MemberUpdate(context.Context, *MemberUpdateRequest) (*MemberUpdateResponse, error)
 	// MemberList lists all the members in the cluster.
 	MemberList(context.Context, *MemberListRequest) (*MemberListResponse, error)
+	// MemberPromote promotes a learner member to a voting member.
+	MemberPromote(context.Context, *MemberPromoteRequest) (*MemberPromoteResponse, error)
+	// MemberReconfigure adds and removes several members at once.
+	MemberReconfigure(context.Context, *MemberReconfigureRequest) (*MemberReconfigureResponse, error)
 }
 
 func RegisterClusterServer(s *grpc.Server, srv ClusterServer) {
@@ -2761,6 +3150,42 @@ func _Cluster_MemberList_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cluster_MemberPromote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberPromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).MemberPromote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Cluster/MemberPromote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).MemberPromote(ctx, req.(*MemberPromoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_MemberReconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberReconfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).MemberReconfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Cluster/MemberReconfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).MemberReconfigure(ctx, req.(*MemberReconfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cluster_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "etcdserverpb.Cluster",
 	HandlerType: (*ClusterServer)(nil),
@@ -2781,6 +3206,14 @@ var _Cluster_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MemberList",
 			Handler:    _Cluster_MemberList_Handler,
 		},
+		{
+			MethodName: "MemberPromote",
+			Handler:    _Cluster_MemberPromote_Handler,
+		},
+		{
+			MethodName: "MemberReconfigure",
+			Handler:    _Cluster_MemberReconfigure_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: fileDescriptorRpc,
@@ -2801,6 +3234,8 @@ type MaintenanceClient interface {
 	Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error)
 	// Snapshot sends a snapshot of the entire backend from a member over a stream to a client.
 	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Maintenance_SnapshotClient, error)
+	// MoveLeader requests the leader to transfer its leadership to another member.
+	MoveLeader(ctx context.Context, in *MoveLeaderRequest, opts ...grpc.CallOption) (*MoveLeaderResponse, error)
 }
 
 type maintenanceClient struct {
@@ -2871,6 +3306,15 @@ type maintenanceSnapshotClient struct {
 	grpc.ClientStream
 }
 
+func (c *maintenanceClient) MoveLeader(ctx context.Context, in *MoveLeaderRequest, opts ...grpc.CallOption) (*MoveLeaderResponse, error) {
+	out := new(MoveLeaderResponse)
+	err := grpc.Invoke(ctx, "/etcdserverpb.Maintenance/MoveLeader", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (x *maintenanceSnapshotClient) Recv() (*SnapshotResponse, error) {
 	m := new(SnapshotResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
@@ -2894,6 +3338,8 @@ type MaintenanceServer interface {
 	Hash(context.Context, *HashRequest) (*HashResponse, error)
 	// Snapshot sends a snapshot of the entire backend from a member over a stream to a client.
 	Snapshot(*SnapshotRequest, Maintenance_SnapshotServer) error
+	// MoveLeader requests the leader to transfer its leadership to another member.
+	MoveLeader(context.Context, *MoveLeaderRequest) (*MoveLeaderResponse, error)
 }
 
 func RegisterMaintenanceServer(s *grpc.Server, srv MaintenanceServer) {
@@ -2993,6 +3439,24 @@ func (x *maintenanceSnapshotServer) Send(m *SnapshotResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Maintenance_MoveLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveLeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenanceServer).MoveLeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/etcdserverpb.Maintenance/MoveLeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenanceServer).MoveLeader(ctx, req.(*MoveLeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Maintenance_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "etcdserverpb.Maintenance",
 	HandlerType: (*MaintenanceServer)(nil),
@@ -3013,6 +3477,10 @@ var _Maintenance_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Hash",
 			Handler:    _Maintenance_Hash_Handler,
 		},
+		{
+			MethodName: "MoveLeader",
+			Handler:    _Maintenance_MoveLeader_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3730,6 +4198,32 @@ func (m *RangeRequest) MarshalTo(data []byte) (int, error) {
 		}
 		i++
 	}
+	if m.MinModRevision != 0 {
+		data[i] = 0x50
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MinModRevision))
+	}
+	if m.MaxModRevision != 0 {
+		data[i] = 0x58
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MaxModRevision))
+	}
+	if m.MinCreateRevision != 0 {
+		data[i] = 0x60
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MinCreateRevision))
+	}
+	if m.MaxCreateRevision != 0 {
+		data[i] = 0x68
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MaxCreateRevision))
+	}
+	if len(m.ContinueToken) > 0 {
+		data[i] = 0x72
+		i++
+		i = encodeVarintRpc(data, i, uint64(len(m.ContinueToken)))
+		i += copy(data[i:], m.ContinueToken)
+	}
 	return i, nil
 }
 
@@ -3785,6 +4279,12 @@ func (m *RangeResponse) MarshalTo(data []byte) (int, error) {
 		i++
 		i = encodeVarintRpc(data, i, uint64(m.Count))
 	}
+	if len(m.ContinueToken) > 0 {
+		data[i] = 0x2a
+		i++
+		i = encodeVarintRpc(data, i, uint64(len(m.ContinueToken)))
+		i += copy(data[i:], m.ContinueToken)
+	}
 	return i, nil
 }
 
@@ -4023,6 +4523,20 @@ func (m *RequestOp_RequestDeleteRange) MarshalTo(data []byte) (int, error) {
 	}
 	return i, nil
 }
+func (m *RequestOp_RequestTxn) MarshalTo(data []byte) (int, error) {
+	i := 0
+	if m.RequestTxn != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.RequestTxn.Size()))
+		n57, err := m.RequestTxn.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	return i, nil
+}
 func (m *ResponseOp) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -4090,6 +4604,20 @@ func (m *ResponseOp_ResponseDeleteRange) MarshalTo(data []byte) (int, error) {
 	}
 	return i, nil
 }
+func (m *ResponseOp_ResponseTxn) MarshalTo(data []byte) (int, error) {
+	i := 0
+	if m.ResponseTxn != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.ResponseTxn.Size()))
+		n58, err := m.ResponseTxn.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	return i, nil
+}
 func (m *Compare) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -4128,6 +4656,14 @@ func (m *Compare) MarshalTo(data []byte) (int, error) {
 		}
 		i += nn13
 	}
+	if len(m.RangeEnd) > 0 {
+		data[i] = 0x82
+		i++
+		data[i] = 0x4
+		i++
+		i = encodeVarintRpc(data, i, uint64(len(m.RangeEnd)))
+		i += copy(data[i:], m.RangeEnd)
+	}
 	return i, nil
 }
 
@@ -4827,6 +5363,171 @@ func (m *LeaseKeepAliveResponse) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LeaseTimeToLiveRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseTimeToLiveRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.ID))
+	}
+	if m.Keys {
+		data[i] = 0x10
+		i++
+		if m.Keys {
+			data[i] = 1
+		} else {
+			data[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *LeaseTimeToLiveResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseTimeToLiveResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
+		n55, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if m.ID != 0 {
+		data[i] = 0x10
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.ID))
+	}
+	if m.TTL != 0 {
+		data[i] = 0x18
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.TTL))
+	}
+	if m.GrantedTTL != 0 {
+		data[i] = 0x20
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.GrantedTTL))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			data[i] = 0x2a
+			i++
+			i = encodeVarintRpc(data, i, uint64(len(b)))
+			i += copy(data[i:], b)
+		}
+	}
+	return i, nil
+}
+
+func (m *LeaseLeasesRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseLeasesRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *LeaseStatus) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseStatus) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.ID))
+	}
+	return i, nil
+}
+
+func (m *LeaseLeasesResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *LeaseLeasesResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
+		n56, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	if len(m.Leases) > 0 {
+		for _, msg := range m.Leases {
+			data[i] = 0x12
+			i++
+			i = encodeVarintRpc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func (m *Member) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -4883,6 +5584,26 @@ func (m *Member) MarshalTo(data []byte) (int, error) {
 			i += copy(data[i:], s)
 		}
 	}
+	if m.IsLearner {
+		data[i] = 0x28
+		i++
+		if m.IsLearner {
+			data[i] = 1
+		} else {
+			data[i] = 0
+		}
+		i++
+	}
+	if m.IsWitness {
+		data[i] = 0x30
+		i++
+		if m.IsWitness {
+			data[i] = 1
+		} else {
+			data[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -4916,6 +5637,26 @@ func (m *MemberAddRequest) MarshalTo(data []byte) (int, error) {
 			i += copy(data[i:], s)
 		}
 	}
+	if m.IsLearner {
+		data[i] = 0x10
+		i++
+		if m.IsLearner {
+			data[i] = 1
+		} else {
+			data[i] = 0
+		}
+		i++
+	}
+	if m.IsWitness {
+		data[i] = 0x18
+		i++
+		if m.IsWitness {
+			data[i] = 1
+		} else {
+			data[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -5132,7 +5873,7 @@ func (m *MemberListResponse) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DefragmentRequest) Marshal() (data []byte, err error) {
+func (m *MemberPromoteRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -5142,15 +5883,20 @@ func (m *DefragmentRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DefragmentRequest) MarshalTo(data []byte) (int, error) {
+func (m *MemberPromoteRequest) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.ID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.ID))
+	}
 	return i, nil
 }
 
-func (m *DefragmentResponse) Marshal() (data []byte, err error) {
+func (m *MemberPromoteResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -5160,7 +5906,7 @@ func (m *DefragmentResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DefragmentResponse) MarshalTo(data []byte) (int, error) {
+func (m *MemberPromoteResponse) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5169,16 +5915,28 @@ func (m *DefragmentResponse) MarshalTo(data []byte) (int, error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
-		n30, err := m.Header.MarshalTo(data[i:])
+		n50, err := m.Header.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n50
+	}
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			data[i] = 0x12
+			i++
+			i = encodeVarintRpc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	return i, nil
 }
 
-func (m *AlarmRequest) Marshal() (data []byte, err error) {
+func (m *MemberReconfigureRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -5188,30 +5946,44 @@ func (m *AlarmRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *AlarmRequest) MarshalTo(data []byte) (int, error) {
+func (m *MemberReconfigureRequest) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Action != 0 {
-		data[i] = 0x8
-		i++
-		i = encodeVarintRpc(data, i, uint64(m.Action))
-	}
-	if m.MemberID != 0 {
-		data[i] = 0x10
-		i++
-		i = encodeVarintRpc(data, i, uint64(m.MemberID))
+	if len(m.Add) > 0 {
+		for _, msg := range m.Add {
+			data[i] = 0xa
+			i++
+			i = encodeVarintRpc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.Alarm != 0 {
-		data[i] = 0x18
+	if len(m.Remove) > 0 {
+		data52 := make([]byte, len(m.Remove)*10)
+		var j51 int
+		for _, num := range m.Remove {
+			for num >= 1<<7 {
+				data52[j51] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j51++
+			}
+			data52[j51] = uint8(num)
+			j51++
+		}
+		data[i] = 0x12
 		i++
-		i = encodeVarintRpc(data, i, uint64(m.Alarm))
+		i = encodeVarintRpc(data, i, uint64(j51))
+		i += copy(data[i:], data52[:j51])
 	}
 	return i, nil
 }
 
-func (m *AlarmMember) Marshal() (data []byte, err error) {
+func (m *MemberReconfigureResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -5221,25 +5993,156 @@ func (m *AlarmMember) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *AlarmMember) MarshalTo(data []byte) (int, error) {
+func (m *MemberReconfigureResponse) MarshalTo(data []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.MemberID != 0 {
-		data[i] = 0x8
-		i++
-		i = encodeVarintRpc(data, i, uint64(m.MemberID))
-	}
-	if m.Alarm != 0 {
-		data[i] = 0x10
+	if m.Header != nil {
+		data[i] = 0xa
 		i++
-		i = encodeVarintRpc(data, i, uint64(m.Alarm))
+		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
+		n53, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
 	}
-	return i, nil
-}
-
-func (m *AlarmResponse) Marshal() (data []byte, err error) {
+	if len(m.Added) > 0 {
+		for _, msg := range m.Added {
+			data[i] = 0x12
+			i++
+			i = encodeVarintRpc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintRpc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *DefragmentRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DefragmentRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *DefragmentResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DefragmentResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
+		n30, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	return i, nil
+}
+
+func (m *AlarmRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *AlarmRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Action != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Action))
+	}
+	if m.MemberID != 0 {
+		data[i] = 0x10
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MemberID))
+	}
+	if m.Alarm != 0 {
+		data[i] = 0x18
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Alarm))
+	}
+	return i, nil
+}
+
+func (m *AlarmMember) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *AlarmMember) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.MemberID))
+	}
+	if m.Alarm != 0 {
+		data[i] = 0x10
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Alarm))
+	}
+	return i, nil
+}
+
+func (m *AlarmResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -5351,6 +6254,57 @@ func (m *StatusResponse) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
+func (m *MoveLeaderRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *MoveLeaderRequest) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TargetID != 0 {
+		data[i] = 0x8
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.TargetID))
+	}
+	return i, nil
+}
+
+func (m *MoveLeaderResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *MoveLeaderResponse) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintRpc(data, i, uint64(m.Header.Size()))
+		n54, err := m.Header.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	return i, nil
+}
+
 func (m *AuthEnableRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -6351,6 +7305,22 @@ func (m *RangeRequest) Size() (n int) {
 	if m.CountOnly {
 		n += 2
 	}
+	if m.MinModRevision != 0 {
+		n += 1 + sovRpc(uint64(m.MinModRevision))
+	}
+	if m.MaxModRevision != 0 {
+		n += 1 + sovRpc(uint64(m.MaxModRevision))
+	}
+	if m.MinCreateRevision != 0 {
+		n += 1 + sovRpc(uint64(m.MinCreateRevision))
+	}
+	if m.MaxCreateRevision != 0 {
+		n += 1 + sovRpc(uint64(m.MaxCreateRevision))
+	}
+	l = len(m.ContinueToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -6373,6 +7343,10 @@ func (m *RangeResponse) Size() (n int) {
 	if m.Count != 0 {
 		n += 1 + sovRpc(uint64(m.Count))
 	}
+	l = len(m.ContinueToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -6482,6 +7456,15 @@ func (m *RequestOp_RequestDeleteRange) Size() (n int) {
 	}
 	return n
 }
+func (m *RequestOp_RequestTxn) Size() (n int) {
+	var l int
+	_ = l
+	if m.RequestTxn != nil {
+		l = m.RequestTxn.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
 func (m *ResponseOp) Size() (n int) {
 	var l int
 	_ = l
@@ -6518,6 +7501,15 @@ func (m *ResponseOp_ResponseDeleteRange) Size() (n int) {
 	}
 	return n
 }
+func (m *ResponseOp_ResponseTxn) Size() (n int) {
+	var l int
+	_ = l
+	if m.ResponseTxn != nil {
+		l = m.ResponseTxn.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
 func (m *Compare) Size() (n int) {
 	var l int
 	_ = l
@@ -6534,6 +7526,10 @@ func (m *Compare) Size() (n int) {
 	if m.TargetUnion != nil {
 		n += m.TargetUnion.Size()
 	}
+	l = len(m.RangeEnd)
+	if l > 0 {
+		n += 2 + l + sovRpc(uint64(l))
+	}
 	return n
 }
 
@@ -6839,58 +7835,50 @@ func (m *LeaseKeepAliveResponse) Size() (n int) {
 	return n
 }
 
-func (m *Member) Size() (n int) {
+func (m *LeaseTimeToLiveRequest) Size() (n int) {
 	var l int
 	_ = l
 	if m.ID != 0 {
 		n += 1 + sovRpc(uint64(m.ID))
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	if len(m.PeerURLs) > 0 {
-		for _, s := range m.PeerURLs {
-			l = len(s)
-			n += 1 + l + sovRpc(uint64(l))
-		}
-	}
-	if len(m.ClientURLs) > 0 {
-		for _, s := range m.ClientURLs {
-			l = len(s)
-			n += 1 + l + sovRpc(uint64(l))
-		}
+	if m.Keys {
+		n += 2
 	}
 	return n
 }
 
-func (m *MemberAddRequest) Size() (n int) {
+func (m *LeaseTimeToLiveResponse) Size() (n int) {
 	var l int
 	_ = l
-	if len(m.PeerURLs) > 0 {
-		for _, s := range m.PeerURLs {
-			l = len(s)
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.ID != 0 {
+		n += 1 + sovRpc(uint64(m.ID))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovRpc(uint64(m.TTL))
+	}
+	if m.GrantedTTL != 0 {
+		n += 1 + sovRpc(uint64(m.GrantedTTL))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
 			n += 1 + l + sovRpc(uint64(l))
 		}
 	}
 	return n
 }
 
-func (m *MemberAddResponse) Size() (n int) {
+func (m *LeaseLeasesRequest) Size() (n int) {
 	var l int
 	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	if m.Member != nil {
-		l = m.Member.Size()
-		n += 1 + l + sovRpc(uint64(l))
-	}
 	return n
 }
 
-func (m *MemberRemoveRequest) Size() (n int) {
+func (m *LeaseStatus) Size() (n int) {
 	var l int
 	_ = l
 	if m.ID != 0 {
@@ -6899,30 +7887,118 @@ func (m *MemberRemoveRequest) Size() (n int) {
 	return n
 }
 
-func (m *MemberRemoveResponse) Size() (n int) {
+func (m *LeaseLeasesResponse) Size() (n int) {
 	var l int
 	_ = l
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 1 + l + sovRpc(uint64(l))
 	}
+	if len(m.Leases) > 0 {
+		for _, e := range m.Leases {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *MemberUpdateRequest) Size() (n int) {
+func (m *Member) Size() (n int) {
 	var l int
 	_ = l
 	if m.ID != 0 {
 		n += 1 + sovRpc(uint64(m.ID))
 	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
 	if len(m.PeerURLs) > 0 {
 		for _, s := range m.PeerURLs {
 			l = len(s)
 			n += 1 + l + sovRpc(uint64(l))
 		}
 	}
-	return n
-}
+	if len(m.ClientURLs) > 0 {
+		for _, s := range m.ClientURLs {
+			l = len(s)
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	if m.IsLearner {
+		n += 2
+	}
+	if m.IsWitness {
+		n += 2
+	}
+	return n
+}
+
+func (m *MemberAddRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.PeerURLs) > 0 {
+		for _, s := range m.PeerURLs {
+			l = len(s)
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	if m.IsLearner {
+		n += 2
+	}
+	if m.IsWitness {
+		n += 2
+	}
+	return n
+}
+
+func (m *MemberAddResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *MemberRemoveRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpc(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *MemberRemoveResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *MemberUpdateRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpc(uint64(m.ID))
+	}
+	if len(m.PeerURLs) > 0 {
+		for _, s := range m.PeerURLs {
+			l = len(s)
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	return n
+}
 
 func (m *MemberUpdateResponse) Size() (n int) {
 	var l int
@@ -6956,6 +8032,72 @@ func (m *MemberListResponse) Size() (n int) {
 	return n
 }
 
+func (m *MemberPromoteRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpc(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *MemberPromoteResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MemberReconfigureRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Add) > 0 {
+		for _, e := range m.Add {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	if len(m.Remove) > 0 {
+		l = 0
+		for _, e := range m.Remove {
+			l += sovRpc(uint64(e))
+		}
+		n += 1 + sovRpc(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *MemberReconfigureResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if len(m.Added) > 0 {
+		for _, e := range m.Added {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *DefragmentRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -7047,6 +8189,25 @@ func (m *StatusResponse) Size() (n int) {
 	return n
 }
 
+func (m *MoveLeaderRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.TargetID != 0 {
+		n += 1 + sovRpc(uint64(m.TargetID))
+	}
+	return n
+}
+
+func (m *MoveLeaderResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
 func (m *AuthEnableRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -7777,6 +8938,113 @@ func (m *RangeRequest) Unmarshal(data []byte) error {
 				}
 			}
 			m.CountOnly = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinModRevision", wireType)
+			}
+			m.MinModRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinModRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxModRevision", wireType)
+			}
+			m.MaxModRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxModRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinCreateRevision", wireType)
+			}
+			m.MinCreateRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinCreateRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCreateRevision", wireType)
+			}
+			m.MaxCreateRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxCreateRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinueToken", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinueToken = append(m.ContinueToken[:0], data[iNdEx:postIndex]...)
+			if m.ContinueToken == nil {
+				m.ContinueToken = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -7930,16 +9198,47 @@ func (m *RangeResponse) Unmarshal(data []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpc(data[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinueToken", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinueToken = append(m.ContinueToken[:0], data[iNdEx:postIndex]...)
+			if m.ContinueToken == nil {
+				m.ContinueToken = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -8608,6 +9907,38 @@ func (m *RequestOp) Unmarshal(data []byte) error {
 			}
 			m.Request = &RequestOp_RequestDeleteRange{v}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestTxn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TxnRequest{}
+			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Request = &RequestOp_RequestTxn{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -8754,6 +10085,38 @@ func (m *ResponseOp) Unmarshal(data []byte) error {
 			}
 			m.Response = &ResponseOp_ResponseDeleteRange{v}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseTxn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TxnResponse{}
+			if err := v.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Response = &ResponseOp_ResponseTxn{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -8963,6 +10326,37 @@ func (m *Compare) Unmarshal(data []byte) error {
 			copy(v, data[iNdEx:postIndex])
 			m.TargetUnion = &Compare_Value{v}
 			iNdEx = postIndex
+		case 64:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeEnd", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RangeEnd = append(m.RangeEnd[:0], data[iNdEx:postIndex]...)
+			if m.RangeEnd == nil {
+				m.RangeEnd = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -10914,7 +12308,7 @@ func (m *LeaseKeepAliveResponse) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *Member) Unmarshal(data []byte) error {
+func (m *LeaseTimeToLiveRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10937,10 +12331,10 @@ func (m *Member) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+			return fmt.Errorf("proto: LeaseTimeToLiveRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LeaseTimeToLiveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10957,16 +12351,16 @@ func (m *Member) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.ID |= (uint64(b) & 0x7F) << shift
+				m.ID |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -10976,26 +12370,67 @@ func (m *Member) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			m.Keys = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(data[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseTimeToLiveResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseTimeToLiveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseTimeToLiveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11005,26 +12440,87 @@ func (m *Member) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.TTL |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GrantedTTL", wireType)
+			}
+			m.GrantedTTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.GrantedTTL |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientURLs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11034,20 +12530,20 @@ func (m *Member) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientURLs = append(m.ClientURLs, string(data[iNdEx:postIndex]))
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], data[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11070,7 +12566,7 @@ func (m *Member) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberAddRequest) Unmarshal(data []byte) error {
+func (m *LeaseLeasesRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11093,17 +12589,750 @@ func (m *MemberAddRequest) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberAddRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LeaseLeasesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LeaseLeasesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseStatus) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseLeasesResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseLeasesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseLeasesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leases = append(m.Leases, &LeaseStatus{})
+			if err := m.Leases[len(m.Leases)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientURLs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientURLs = append(m.ClientURLs, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsLearner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsLearner = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsWitness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsWitness = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberAddRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberAddRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsLearner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsLearner = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsWitness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsWitness = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberAddResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberAddResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberAddResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Member == nil {
+				m.Member = &Member{}
+			}
+			if err := m.Member.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberRemoveRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberRemoveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberRemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberRemoveResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberRemoveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberRemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11113,20 +13342,24 @@ func (m *MemberAddRequest) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11149,7 +13382,7 @@ func (m *MemberAddRequest) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberAddResponse) Unmarshal(data []byte) error {
+func (m *MemberUpdateRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11172,17 +13405,17 @@ func (m *MemberAddResponse) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberAddResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberUpdateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberAddResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberUpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var msglen int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11192,30 +13425,16 @@ func (m *MemberAddResponse) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ID |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpc
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
-			}
-			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11225,24 +13444,20 @@ func (m *MemberAddResponse) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Member == nil {
-				m.Member = &Member{}
-			}
-			if err := m.Member.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11265,7 +13480,7 @@ func (m *MemberAddResponse) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberRemoveRequest) Unmarshal(data []byte) error {
+func (m *MemberUpdateResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11288,17 +13503,17 @@ func (m *MemberRemoveRequest) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberRemoveRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberUpdateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberRemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberUpdateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.ID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -11308,11 +13523,25 @@ func (m *MemberRemoveRequest) Unmarshal(data []byte) error {
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.ID |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -11334,7 +13563,7 @@ func (m *MemberRemoveRequest) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberRemoveResponse) Unmarshal(data []byte) error {
+func (m *MemberListRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11357,10 +13586,60 @@ func (m *MemberRemoveResponse) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberRemoveResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberListRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberRemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberListResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11396,6 +13675,37 @@ func (m *MemberRemoveResponse) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -11417,7 +13727,8 @@ func (m *MemberRemoveResponse) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberUpdateRequest) Unmarshal(data []byte) error {
+
+func (m *MemberPromoteRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11440,10 +13751,10 @@ func (m *MemberUpdateRequest) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberUpdateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberPromoteRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberUpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberPromoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11465,35 +13776,6 @@ func (m *MemberUpdateRequest) Unmarshal(data []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerURLs", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpc
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PeerURLs = append(m.PeerURLs, string(data[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -11515,7 +13797,8 @@ func (m *MemberUpdateRequest) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberUpdateResponse) Unmarshal(data []byte) error {
+
+func (m *MemberPromoteResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11534,19 +13817,52 @@ func (m *MemberUpdateResponse) Unmarshal(data []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MemberUpdateResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberUpdateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberPromoteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberPromoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11570,10 +13886,8 @@ func (m *MemberUpdateResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
-			}
-			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11598,7 +13912,7 @@ func (m *MemberUpdateResponse) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberListRequest) Unmarshal(data []byte) error {
+func (m *MemberReconfigureRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11621,12 +13935,105 @@ func (m *MemberListRequest) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberListRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberReconfigureRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberReconfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Add", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Add = append(m.Add, &MemberAddRequest{})
+			if err := m.Add[len(m.Add)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := data[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Remove = append(m.Remove, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := data[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpc
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := data[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Remove = append(m.Remove, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remove", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(data[iNdEx:])
@@ -11648,7 +14055,7 @@ func (m *MemberListRequest) Unmarshal(data []byte) error {
 	}
 	return nil
 }
-func (m *MemberListResponse) Unmarshal(data []byte) error {
+func (m *MemberReconfigureResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11671,10 +14078,10 @@ func (m *MemberListResponse) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberListResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberReconfigureResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberReconfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11711,6 +14118,37 @@ func (m *MemberListResponse) Unmarshal(data []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Added", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Added = append(m.Added, &Member{})
+			if err := m.Added[len(m.Added)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
@@ -12442,6 +14880,158 @@ func (m *StatusResponse) Unmarshal(data []byte) error {
 	}
 	return nil
 }
+func (m *MoveLeaderRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MoveLeaderRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MoveLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetID", wireType)
+			}
+			m.TargetID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.TargetID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MoveLeaderResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MoveLeaderResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MoveLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *AuthEnableRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -15407,207 +17997,238 @@ var (
 )
 
 var fileDescriptorRpc = []byte{
-	// 3218 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x3a, 0xcd, 0x6f, 0x1b, 0xd7,
-	0xf1, 0x5e, 0x92, 0x22, 0xc5, 0x21, 0x45, 0xcb, 0x4f, 0xb2, 0x43, 0xad, 0x65, 0x59, 0x7e, 0xfe,
-	0x4c, 0x9c, 0x88, 0xbf, 0x28, 0xf9, 0xf5, 0xd0, 0x16, 0x01, 0x28, 0x91, 0x71, 0x54, 0x29, 0x92,
-	0xb3, 0xa2, 0x95, 0x14, 0x08, 0x2a, 0xac, 0xc8, 0xb5, 0x44, 0x88, 0x5f, 0xd9, 0x5d, 0xca, 0x56,
-	0xda, 0x02, 0x45, 0xd0, 0x1c, 0xda, 0x63, 0x73, 0x28, 0x9a, 0x1e, 0xfb, 0x37, 0xf4, 0xd6, 0x3f,
-	0xa0, 0xe8, 0xa5, 0x01, 0x7a, 0xec, 0xa5, 0x28, 0x7a, 0xe8, 0xa1, 0xf7, 0xa2, 0xa7, 0xf6, 0x7d,
-	0xee, 0xbe, 0x5d, 0xbe, 0xa5, 0x94, 0x6e, 0x73, 0xb0, 0xb5, 0x6f, 0xde, 0xbc, 0x99, 0x79, 0xf3,
-	0x66, 0xe6, 0xcd, 0xcc, 0x23, 0x14, 0xdd, 0x51, 0x7b, 0x6d, 0xe4, 0x0e, 0xfd, 0x21, 0x2a, 0x3b,
-	0x7e, 0xbb, 0xe3, 0x39, 0xee, 0x99, 0xe3, 0x8e, 0x8e, 0xcc, 0xc5, 0xe3, 0xe1, 0xf1, 0x90, 0x4d,
-	0xd4, 0xe8, 0x17, 0xc7, 0x31, 0x97, 0x28, 0x4e, 0xad, 0x7f, 0xd6, 0x6e, 0xb3, 0xff, 0x46, 0x47,
-	0xb5, 0xd3, 0x33, 0x31, 0x75, 0x93, 0x4d, 0xd9, 0x63, 0xff, 0x84, 0xfd, 0x47, 0xa6, 0xe8, 0x1f,
-	0x31, 0xb9, 0x7c, 0x3c, 0x1c, 0x1e, 0xf7, 0x9c, 0x9a, 0x3d, 0xea, 0xd6, 0xec, 0xc1, 0x60, 0xe8,
-	0xdb, 0x7e, 0x77, 0x38, 0xf0, 0xf8, 0x2c, 0xfe, 0xdc, 0x80, 0x8a, 0xe5, 0x78, 0x23, 0x02, 0x71,
-	0xde, 0x73, 0xec, 0x8e, 0xe3, 0xa2, 0x5b, 0x00, 0xed, 0xde, 0xd8, 0xf3, 0x1d, 0xf7, 0xb0, 0xdb,
-	0xa9, 0x1a, 0xab, 0xc6, 0xa3, 0x9c, 0x55, 0x14, 0x90, 0xad, 0x0e, 0xba, 0x09, 0xc5, 0xbe, 0xd3,
-	0x3f, 0xe2, 0xb3, 0x19, 0x36, 0x3b, 0xcb, 0x01, 0x64, 0xd2, 0x84, 0x59, 0xd7, 0x39, 0xeb, 0x7a,
-	0x84, 0x43, 0x35, 0x4b, 0xe6, 0xb2, 0x56, 0x30, 0xa6, 0x0b, 0x5d, 0xfb, 0xb9, 0x7f, 0x48, 0xc8,
-	0xf4, 0xab, 0x39, 0xbe, 0x90, 0x02, 0x5a, 0x64, 0x8c, 0xbf, 0xca, 0x42, 0xd9, 0xb2, 0x07, 0xc7,
-	0x8e, 0xe5, 0x7c, 0x32, 0x76, 0x3c, 0x1f, 0xcd, 0x43, 0xf6, 0xd4, 0x39, 0x67, 0xec, 0xcb, 0x16,
-	0xfd, 0xe4, 0xeb, 0x09, 0xc6, 0xa1, 0x33, 0xe0, 0x8c, 0xcb, 0x74, 0x3d, 0x01, 0x34, 0x07, 0x1d,
-	0xb4, 0x08, 0x33, 0xbd, 0x6e, 0xbf, 0xeb, 0x0b, 0xae, 0x7c, 0x10, 0x11, 0x27, 0x17, 0x13, 0x67,
-	0x13, 0xc0, 0x1b, 0xba, 0xfe, 0xe1, 0xd0, 0x25, 0x9b, 0xae, 0xce, 0x90, 0xd9, 0xca, 0xfa, 0xbd,
-	0x35, 0xf5, 0x20, 0xd6, 0x54, 0x81, 0xd6, 0xf6, 0x09, 0xf2, 0x1e, 0xc5, 0xb5, 0x8a, 0x9e, 0xfc,
-	0x44, 0xef, 0x42, 0x89, 0x11, 0xf1, 0x6d, 0xf7, 0xd8, 0xf1, 0xab, 0x79, 0x46, 0xe5, 0xfe, 0x05,
-	0x54, 0x5a, 0x0c, 0xd9, 0x62, 0xec, 0xf9, 0x37, 0xc2, 0x50, 0x26, 0xf8, 0x5d, 0xbb, 0xd7, 0xfd,
-	0xd4, 0x3e, 0xea, 0x39, 0xd5, 0x02, 0x21, 0x34, 0x6b, 0x45, 0x60, 0x74, 0xff, 0x44, 0x0d, 0xde,
-	0xe1, 0x70, 0xd0, 0x3b, 0xaf, 0xce, 0x32, 0x84, 0x59, 0x0a, 0xd8, 0x23, 0x63, 0x76, 0x68, 0xc3,
-	0xf1, 0xc0, 0xe7, 0xb3, 0x45, 0x36, 0x5b, 0x64, 0x10, 0x3a, 0x8d, 0xd7, 0xa0, 0x18, 0xc8, 0x8f,
-	0x66, 0x21, 0xb7, 0xbb, 0xb7, 0xdb, 0x9c, 0xbf, 0x82, 0x00, 0xf2, 0xf5, 0xfd, 0xcd, 0xe6, 0x6e,
-	0x63, 0xde, 0x40, 0x25, 0x28, 0x34, 0x9a, 0x7c, 0x90, 0xc1, 0x1b, 0x00, 0xa1, 0xa4, 0xa8, 0x00,
-	0xd9, 0xed, 0xe6, 0xf7, 0x09, 0x3e, 0xc1, 0x39, 0x68, 0x5a, 0xfb, 0x5b, 0x7b, 0xbb, 0x64, 0x01,
-	0x59, 0xbc, 0x69, 0x35, 0xeb, 0xad, 0xe6, 0x7c, 0x86, 0x62, 0xbc, 0xbf, 0xd7, 0x98, 0xcf, 0xa2,
-	0x22, 0xcc, 0x1c, 0xd4, 0x77, 0x9e, 0x35, 0xe7, 0x73, 0xf8, 0x0b, 0x03, 0xe6, 0xc4, 0xde, 0xb9,
-	0x7d, 0xa1, 0xb7, 0x21, 0x7f, 0xc2, 0x6c, 0x8c, 0x1d, 0x6b, 0x69, 0x7d, 0x39, 0xa6, 0xa8, 0x88,
-	0x1d, 0x5a, 0x02, 0x97, 0xe8, 0x26, 0x7b, 0x7a, 0xe6, 0x91, 0x13, 0xcf, 0x92, 0x25, 0xf3, 0x6b,
-	0xdc, 0xf8, 0xd7, 0xb6, 0x9d, 0xf3, 0x03, 0xbb, 0x37, 0x76, 0x2c, 0x3a, 0x89, 0x10, 0xe4, 0xfa,
-	0x43, 0xd7, 0x61, 0xa7, 0x3f, 0x6b, 0xb1, 0x6f, 0x6a, 0x12, 0x4c, 0x01, 0xe2, 0xe4, 0xf9, 0x00,
-	0xb7, 0x01, 0x9e, 0x8e, 0xfd, 0x64, 0x2b, 0x23, 0xab, 0xce, 0x28, 0x5d, 0x61, 0x61, 0x7c, 0xc0,
-	0xcc, 0xcb, 0xb1, 0x3d, 0x27, 0x30, 0x2f, 0x3a, 0x40, 0xaf, 0x40, 0x61, 0x44, 0xec, 0xe9, 0xf0,
-	0xf4, 0x8c, 0xf1, 0x98, 0xb5, 0xf2, 0x74, 0xb8, 0x7d, 0x86, 0x07, 0x50, 0x62, 0x4c, 0x52, 0xed,
-	0xfb, 0xd5, 0x90, 0x7a, 0x86, 0x2d, 0x9b, 0xdc, 0xbb, 0xe4, 0xf7, 0x31, 0xa0, 0x86, 0xd3, 0x73,
-	0x7c, 0x27, 0x8d, 0x0b, 0x29, 0xbb, 0xc9, 0x46, 0x76, 0xf3, 0x0b, 0x03, 0x16, 0x22, 0xe4, 0x53,
-	0x6d, 0xab, 0x0a, 0x85, 0x0e, 0x23, 0xc6, 0x25, 0xc8, 0x5a, 0x72, 0x88, 0x1e, 0xc3, 0xac, 0x10,
-	0xc0, 0x23, 0x12, 0xe8, 0x4f, 0xbb, 0xc0, 0x65, 0xf2, 0xf0, 0x3f, 0x0c, 0x28, 0x8a, 0x8d, 0xee,
-	0x8d, 0x50, 0x1d, 0xe6, 0x5c, 0x3e, 0x38, 0x64, 0xfb, 0x11, 0x12, 0x99, 0xc9, 0x9e, 0xf8, 0xde,
-	0x15, 0xab, 0x2c, 0x96, 0x30, 0x30, 0xfa, 0x0e, 0x94, 0x24, 0x89, 0xd1, 0xd8, 0x17, 0x2a, 0xaf,
-	0x46, 0x09, 0x84, 0x96, 0x43, 0x96, 0x83, 0x40, 0x27, 0x40, 0xd4, 0x82, 0x45, 0xb9, 0x98, 0xef,
-	0x46, 0x88, 0x91, 0x65, 0x54, 0x56, 0xa3, 0x54, 0x26, 0x8f, 0x8a, 0x50, 0x43, 0x62, 0xbd, 0x32,
-	0xb9, 0x51, 0x84, 0x82, 0x80, 0xe2, 0x7f, 0x1a, 0x00, 0x52, 0xa1, 0x64, 0xbf, 0x0d, 0xa8, 0xb8,
-	0x62, 0x14, 0xd9, 0xf0, 0x4d, 0xed, 0x86, 0xc5, 0x39, 0x5c, 0xb1, 0xe6, 0xe4, 0x22, 0xbe, 0xe5,
-	0x77, 0xa0, 0x1c, 0x50, 0x09, 0xf7, 0xbc, 0xa4, 0xd9, 0x73, 0x40, 0xa1, 0x24, 0x17, 0xd0, 0x5d,
-	0x7f, 0x08, 0xd7, 0x83, 0xf5, 0x9a, 0x6d, 0xdf, 0x99, 0xb2, 0xed, 0x80, 0xe0, 0x82, 0xa4, 0xa0,
-	0x6e, 0x1c, 0x68, 0xdc, 0xe6, 0x60, 0xfc, 0x65, 0x16, 0x0a, 0x9b, 0xc3, 0xfe, 0xc8, 0x76, 0xe9,
-	0x19, 0xe5, 0x09, 0x7c, 0xdc, 0xf3, 0xd9, 0x76, 0x2b, 0xeb, 0x77, 0xa3, 0x1c, 0x04, 0x9a, 0xfc,
-	0x6b, 0x31, 0x54, 0x4b, 0x2c, 0xa1, 0x8b, 0x45, 0x98, 0xce, 0x5c, 0x62, 0xb1, 0x08, 0xd2, 0x62,
-	0x89, 0xf4, 0xa5, 0x6c, 0xe8, 0x4b, 0x26, 0x14, 0xc8, 0xc2, 0xf0, 0x6a, 0x21, 0x7b, 0x91, 0x00,
-	0xe2, 0xba, 0x57, 0xdb, 0xae, 0x63, 0x53, 0x7d, 0xc8, 0xeb, 0x67, 0x46, 0xe0, 0x54, 0xf8, 0x84,
-	0x25, 0xaf, 0xa1, 0xbb, 0x50, 0xee, 0x0f, 0x3b, 0x21, 0x5e, 0x5e, 0xe0, 0x95, 0x08, 0x34, 0x40,
-	0xba, 0x21, 0x83, 0x12, 0xbd, 0x17, 0xca, 0x64, 0x96, 0x0f, 0xf1, 0x9b, 0x30, 0x17, 0xd9, 0x2b,
-	0x0d, 0xbf, 0xcd, 0x0f, 0x9e, 0xd5, 0x77, 0x78, 0xac, 0x7e, 0xc2, 0xc2, 0xb3, 0x45, 0x62, 0x35,
-	0x09, 0xf9, 0x3b, 0xcd, 0xfd, 0x7d, 0x12, 0xd9, 0xbf, 0x1b, 0x2c, 0x11, 0xc1, 0x5d, 0x89, 0xe9,
-	0x57, 0x94, 0x98, 0x6e, 0xc8, 0x98, 0x9e, 0x09, 0x63, 0x7a, 0x76, 0xa3, 0x02, 0x65, 0xae, 0x90,
-	0xc3, 0xf1, 0x80, 0x08, 0x86, 0x7f, 0x43, 0xcc, 0xb2, 0xf5, 0x72, 0x20, 0x23, 0x4e, 0x0d, 0x0a,
-	0x6d, 0x4e, 0x9c, 0x1c, 0x10, 0x75, 0xe0, 0xeb, 0x5a, 0x1d, 0x5b, 0x12, 0x0b, 0xbd, 0x09, 0x05,
-	0x6f, 0xdc, 0x6e, 0x3b, 0x9e, 0x8c, 0xef, 0xaf, 0xc4, 0x63, 0x88, 0xf0, 0x70, 0x4b, 0xe2, 0xd1,
-	0x25, 0xcf, 0xed, 0x6e, 0x6f, 0xcc, 0xa2, 0xfd, 0xf4, 0x25, 0x02, 0x0f, 0xff, 0xca, 0x80, 0x12,
-	0x93, 0x32, 0x55, 0xe0, 0x5a, 0x86, 0x22, 0x93, 0xc1, 0xe9, 0x88, 0xd0, 0x45, 0x6e, 0xd8, 0x00,
-	0x80, 0xbe, 0x45, 0x42, 0xab, 0x58, 0x27, 0xa3, 0x57, 0x55, 0x4f, 0x96, 0x48, 0x16, 0xa2, 0xe2,
-	0x6d, 0xb8, 0xc6, 0xb4, 0xd2, 0xa6, 0x59, 0x99, 0xd4, 0xa3, 0x9a, 0xb7, 0x18, 0xb1, 0xbc, 0x85,
-	0xcc, 0x8d, 0x4e, 0xce, 0xbd, 0x6e, 0xdb, 0xee, 0x09, 0x29, 0x82, 0x31, 0xfe, 0x1e, 0x20, 0x95,
-	0x58, 0x9a, 0xed, 0xe2, 0x39, 0x28, 0xbd, 0x67, 0x7b, 0x27, 0x42, 0x24, 0xfc, 0x11, 0x94, 0xf9,
-	0x30, 0x95, 0x0e, 0xc9, 0x3d, 0x7d, 0x42, 0xa8, 0x30, 0xc1, 0xe7, 0x2c, 0xf6, 0x8d, 0xaf, 0xc1,
-	0xd5, 0xfd, 0x81, 0x3d, 0xf2, 0x4e, 0x86, 0x32, 0xb8, 0xd2, 0xac, 0x74, 0x3e, 0x84, 0xa5, 0xe2,
-	0xf8, 0x10, 0xae, 0xba, 0x4e, 0xdf, 0xee, 0x0e, 0xba, 0x83, 0xe3, 0xc3, 0xa3, 0x73, 0xdf, 0xf1,
-	0x44, 0xd2, 0x5a, 0x09, 0xc0, 0x1b, 0x14, 0x4a, 0x45, 0x3b, 0xea, 0x0d, 0x8f, 0x84, 0x8b, 0xb3,
-	0x6f, 0xfc, 0x5b, 0x03, 0xca, 0x1f, 0xda, 0x7e, 0x5b, 0x6a, 0x01, 0x6d, 0x41, 0x25, 0x70, 0x6c,
-	0x06, 0x11, 0xb2, 0xc4, 0x22, 0x3c, 0x5b, 0xb3, 0x29, 0x1c, 0x5d, 0x46, 0xf8, 0xb9, 0xb6, 0x0a,
-	0x60, 0xa4, 0xec, 0x41, 0xdb, 0xe9, 0x05, 0xa4, 0x32, 0xc9, 0xa4, 0x18, 0xa2, 0x4a, 0x4a, 0x05,
-	0x6c, 0x5c, 0x0d, 0x6f, 0x3f, 0xee, 0x96, 0x5f, 0x66, 0x00, 0x4d, 0xca, 0xf0, 0x75, 0x13, 0x82,
-	0xfb, 0x50, 0xf1, 0x88, 0xb7, 0xfb, 0x87, 0xb1, 0x94, 0x7e, 0x8e, 0x41, 0x83, 0xe0, 0x44, 0x34,
-	0x4c, 0x6a, 0x89, 0x63, 0x62, 0xd2, 0xde, 0x21, 0x29, 0x2f, 0xba, 0xcf, 0xcf, 0x45, 0x36, 0x54,
-	0x91, 0xe0, 0x5d, 0x06, 0x45, 0x4d, 0xe2, 0xb9, 0xdd, 0x1e, 0x49, 0xff, 0x3d, 0x12, 0x0d, 0xb3,
-	0x24, 0x02, 0x3f, 0xbe, 0x48, 0x6b, 0x6b, 0xef, 0x32, 0xfc, 0xd6, 0xf9, 0x88, 0xc4, 0x0c, 0xb1,
-	0x56, 0xcd, 0x53, 0xf2, 0x91, 0x3c, 0xe5, 0x3e, 0x40, 0x88, 0x4f, 0xa3, 0xd6, 0xee, 0xde, 0xd3,
-	0x67, 0x2d, 0x12, 0xd5, 0xca, 0x30, 0xbb, 0xbb, 0xd7, 0x68, 0xee, 0x34, 0x69, 0x5c, 0xc3, 0x35,
-	0xa9, 0x1b, 0x55, 0x87, 0x68, 0x09, 0x66, 0x5f, 0x50, 0xa8, 0xac, 0x79, 0x48, 0x5e, 0xc2, 0xc6,
-	0x5b, 0x1d, 0xfc, 0x77, 0x92, 0xc8, 0x0a, 0x2b, 0x48, 0x65, 0x8a, 0x2a, 0x8b, 0x4c, 0x84, 0x05,
-	0x4d, 0x8a, 0xb8, 0x75, 0x74, 0x44, 0xee, 0x25, 0x87, 0xd4, 0xdd, 0xf9, 0x61, 0x93, 0x29, 0xae,
-	0xd6, 0x60, 0x4c, 0xae, 0x99, 0xf9, 0x36, 0x77, 0xf7, 0xd8, 0x3d, 0x63, 0x5d, 0x15, 0xf0, 0xe0,
-	0x90, 0xee, 0x43, 0xde, 0x39, 0x73, 0x06, 0xbe, 0x57, 0x2d, 0xb1, 0xd8, 0x34, 0x27, 0x33, 0xab,
-	0x26, 0x85, 0x5a, 0x62, 0x12, 0xff, 0x3f, 0x5c, 0xdb, 0xa1, 0xa9, 0xed, 0x13, 0x62, 0x04, 0x6a,
-	0x92, 0xdc, 0x6a, 0xed, 0x08, 0xad, 0x64, 0xfd, 0xd6, 0x0e, 0xaa, 0x40, 0x66, 0xab, 0x21, 0xf6,
-	0x90, 0xe9, 0x36, 0xf0, 0x67, 0x06, 0x20, 0x75, 0x5d, 0x2a, 0x35, 0xc5, 0x88, 0x4b, 0xf6, 0xd9,
-	0x90, 0x3d, 0xc9, 0xc6, 0x1d, 0xd7, 0x1d, 0xba, 0x4c, 0x21, 0x45, 0x8b, 0x0f, 0xf0, 0x3d, 0x21,
-	0x03, 0xd9, 0xf3, 0xf0, 0x34, 0xb0, 0x79, 0x4e, 0xcd, 0x08, 0x44, 0xdd, 0x86, 0x85, 0x08, 0x56,
-	0xaa, 0x18, 0xf9, 0x10, 0xae, 0x33, 0x62, 0xdb, 0x8e, 0x33, 0xaa, 0xf7, 0xba, 0x67, 0x89, 0x5c,
-	0x47, 0x70, 0x23, 0x8e, 0xf8, 0xcd, 0xea, 0x08, 0x9f, 0x40, 0xfe, 0x7d, 0x56, 0x95, 0x2b, 0xb2,
-	0xe4, 0x18, 0x2e, 0x09, 0x74, 0x03, 0xbb, 0xcf, 0x0b, 0x9c, 0xa2, 0xc5, 0xbe, 0xd9, 0xa5, 0xe2,
-	0x38, 0xee, 0x33, 0x6b, 0x87, 0x5f, 0x5e, 0x45, 0x2b, 0x18, 0xa3, 0x15, 0xda, 0x0f, 0xe8, 0x12,
-	0xf3, 0x60, 0xb3, 0x39, 0x36, 0xab, 0x40, 0x48, 0x6d, 0x39, 0xcf, 0x39, 0xd5, 0x3b, 0x1d, 0xe5,
-	0x02, 0x0b, 0xe8, 0x19, 0x51, 0x7a, 0xf8, 0x05, 0x5c, 0x53, 0xf0, 0x53, 0xa9, 0xe1, 0x75, 0xc8,
-	0xf3, 0xd6, 0x83, 0x88, 0x9d, 0x8b, 0xd1, 0x55, 0x9c, 0x8d, 0x25, 0x70, 0x48, 0x7c, 0x58, 0x10,
-	0x10, 0xa7, 0x3f, 0xd4, 0x9d, 0x15, 0xd3, 0x0f, 0xde, 0x81, 0xc5, 0x28, 0x5a, 0x2a, 0x13, 0xa9,
-	0x4b, 0xa6, 0xcf, 0x46, 0x1d, 0x25, 0x14, 0xc7, 0x0f, 0x45, 0x55, 0x58, 0x26, 0xa6, 0xb0, 0x40,
-	0x20, 0x49, 0x22, 0x95, 0x40, 0x0b, 0x52, 0xfd, 0x3b, 0x5d, 0x2f, 0xb8, 0x70, 0x3f, 0x05, 0xa4,
-	0x02, 0x53, 0x1d, 0xca, 0x1a, 0x14, 0xb8, 0xc2, 0x65, 0x4e, 0xa7, 0x3f, 0x15, 0x89, 0x44, 0x05,
-	0x6a, 0x38, 0xcf, 0x5d, 0xfb, 0xb8, 0xef, 0x04, 0x31, 0x87, 0x66, 0x32, 0x2a, 0x30, 0xd5, 0x8e,
-	0xff, 0x48, 0x6e, 0xf1, 0x7a, 0xcf, 0x76, 0xfb, 0x52, 0xf9, 0xef, 0x40, 0x9e, 0xa7, 0x48, 0xa2,
-	0x8c, 0x78, 0x10, 0x25, 0xa3, 0xe2, 0xf2, 0x41, 0x9d, 0x27, 0x54, 0x62, 0x15, 0x3d, 0x2c, 0xd1,
-	0xf1, 0x6a, 0xc4, 0x3a, 0x60, 0x0d, 0xf4, 0x06, 0xcc, 0xd8, 0x74, 0x09, 0xf3, 0xc5, 0x4a, 0x3c,
-	0x39, 0x65, 0xd4, 0xd8, 0x75, 0xc6, 0xb1, 0xf0, 0xdb, 0x50, 0x52, 0x38, 0xd0, 0x9c, 0xfb, 0x49,
-	0x53, 0x5c, 0x59, 0xf5, 0xcd, 0xd6, 0xd6, 0x01, 0x4f, 0xc5, 0x2b, 0x00, 0x8d, 0x66, 0x30, 0xce,
-	0x90, 0x64, 0x8c, 0xaf, 0x12, 0x1e, 0xae, 0xca, 0x63, 0x24, 0xc9, 0x93, 0xb9, 0x94, 0x3c, 0x2f,
-	0x61, 0x4e, 0x6c, 0x3f, 0x95, 0x0d, 0xbc, 0x49, 0x34, 0x4c, 0xc9, 0x48, 0x13, 0x58, 0xd2, 0xb0,
-	0x95, 0xde, 0xc9, 0x11, 0x31, 0x49, 0x62, 0xf6, 0x7d, 0xdb, 0x1f, 0x7b, 0xd2, 0x04, 0xfe, 0x60,
-	0x40, 0x45, 0x42, 0xd2, 0x76, 0x1c, 0x64, 0xa5, 0xc6, 0x63, 0x5e, 0x50, 0xa7, 0xdd, 0x80, 0x7c,
-	0xe7, 0x68, 0xbf, 0xfb, 0xa9, 0xec, 0xeb, 0x88, 0x11, 0x85, 0xf7, 0x38, 0x1f, 0xde, 0xa7, 0x14,
-	0x23, 0x5a, 0x02, 0xd0, 0x8e, 0xe5, 0xd6, 0xa0, 0xe3, 0xbc, 0x64, 0x37, 0x6d, 0xce, 0x0a, 0x01,
-	0x2c, 0x6b, 0x17, 0xfd, 0x4c, 0x96, 0x99, 0xa8, 0xfd, 0x4d, 0x62, 0xe4, 0xf5, 0xb1, 0x7f, 0xd2,
-	0x1c, 0xd0, 0x56, 0x9e, 0xdc, 0xe1, 0x22, 0x20, 0x0a, 0x6c, 0x74, 0x3d, 0x15, 0xda, 0x84, 0x05,
-	0x0a, 0x25, 0x76, 0x4f, 0x72, 0xfa, 0x30, 0x62, 0xc8, 0xb0, 0x6d, 0xc4, 0xc2, 0xb6, 0xed, 0x79,
-	0x2f, 0x86, 0x6e, 0x47, 0x6c, 0x2d, 0x18, 0xe3, 0x06, 0x27, 0xfe, 0xcc, 0x8b, 0x04, 0xe6, 0xaf,
-	0x4b, 0xe5, 0x51, 0x48, 0xe5, 0x89, 0xe3, 0x4f, 0xa1, 0x82, 0x1f, 0xc3, 0x75, 0x89, 0x29, 0x4a,
-	0xf9, 0x29, 0xc8, 0x7b, 0x70, 0x4b, 0x22, 0x6f, 0x9e, 0xd0, 0x7c, 0xf3, 0xa9, 0x60, 0xf8, 0xdf,
-	0xca, 0xb9, 0x01, 0xd5, 0x40, 0x4e, 0x96, 0x83, 0x0c, 0x7b, 0xaa, 0x00, 0x63, 0x4f, 0xd8, 0x0c,
-	0xa1, 0x45, 0xbf, 0x29, 0xcc, 0x25, 0x28, 0xf2, 0x12, 0xa4, 0xdf, 0x78, 0x13, 0x96, 0x24, 0x0d,
-	0x91, 0x1d, 0x44, 0x89, 0x4c, 0x08, 0xa4, 0x23, 0x22, 0x14, 0x46, 0x97, 0x4e, 0x57, 0xbb, 0x8a,
-	0x19, 0x55, 0x2d, 0xa3, 0x69, 0x28, 0x34, 0xaf, 0x73, 0x8b, 0xa0, 0x82, 0xa9, 0x41, 0x5b, 0x80,
-	0x29, 0x01, 0x15, 0x2c, 0x0e, 0x82, 0x82, 0x27, 0x0e, 0x62, 0x82, 0xf4, 0xc7, 0xb0, 0x12, 0x08,
-	0x41, 0xf5, 0xf6, 0x94, 0x18, 0x6b, 0xd7, 0xf3, 0x94, 0x5a, 0x54, 0xb7, 0xf1, 0x07, 0x90, 0x1b,
-	0x39, 0x22, 0xa6, 0x94, 0xd6, 0xd1, 0x1a, 0x7f, 0x75, 0x58, 0x53, 0x16, 0xb3, 0x79, 0xdc, 0x81,
-	0xdb, 0x92, 0x3a, 0xd7, 0xa8, 0x96, 0x7c, 0x5c, 0x28, 0x59, 0xa7, 0x70, 0xb5, 0x4e, 0xd6, 0x29,
-	0x59, 0x7e, 0xf6, 0xb2, 0x4e, 0xa1, 0x77, 0x85, 0xea, 0x5b, 0xa9, 0xee, 0x8a, 0x6d, 0xae, 0xd3,
-	0xc0, 0x25, 0x53, 0x11, 0x3b, 0x82, 0xc5, 0xa8, 0x27, 0xa7, 0x0a, 0x63, 0x24, 0xeb, 0xf5, 0x89,
-	0x0a, 0x65, 0x10, 0xe3, 0x03, 0x29, 0x70, 0xe0, 0xe6, 0xa9, 0x04, 0xb6, 0x43, 0x62, 0xcc, 0x24,
-	0xd3, 0xca, 0x4b, 0x4f, 0x53, 0xe6, 0x33, 0x7c, 0x80, 0x77, 0xe1, 0x46, 0x3c, 0x4c, 0xa4, 0x12,
-	0xf9, 0x80, 0x1b, 0xb0, 0x2e, 0x92, 0xa4, 0xa2, 0xfb, 0x41, 0x18, 0x0c, 0x94, 0x80, 0x92, 0x8a,
-	0xa4, 0x05, 0xa6, 0x2e, 0xbe, 0xfc, 0x2f, 0xec, 0x35, 0x08, 0x37, 0xa9, 0x88, 0x79, 0x21, 0xb1,
-	0xf4, 0xc7, 0x1f, 0xc6, 0x88, 0xec, 0xd4, 0x18, 0x21, 0x9c, 0x24, 0x8c, 0x62, 0xdf, 0x80, 0xd1,
-	0x09, 0x1e, 0x61, 0x00, 0x4d, 0xcb, 0x83, 0xde, 0x21, 0x01, 0x0f, 0x36, 0x90, 0x86, 0xad, 0x86,
-	0xdd, 0x54, 0x87, 0xf1, 0x61, 0x18, 0x3b, 0x27, 0x22, 0x73, 0x2a, 0xc2, 0x1f, 0xc1, 0x6a, 0x72,
-	0x50, 0x4e, 0x43, 0xf9, 0x35, 0x0c, 0xc5, 0x20, 0xa1, 0x54, 0x5e, 0x19, 0x4b, 0x50, 0xd8, 0xdd,
-	0xdb, 0x7f, 0x5a, 0xdf, 0x24, 0xa9, 0xec, 0xfa, 0x9f, 0xb3, 0x90, 0xd9, 0x3e, 0x40, 0x3f, 0x80,
-	0x19, 0xfe, 0x06, 0x31, 0xe5, 0x89, 0xc6, 0x9c, 0xf6, 0x9a, 0x81, 0x97, 0x3f, 0xfb, 0xd3, 0xdf,
-	0xbe, 0xc8, 0xdc, 0xc0, 0xd7, 0x6a, 0x67, 0x6f, 0xd9, 0xbd, 0xd1, 0x89, 0x5d, 0x3b, 0x3d, 0xab,
-	0xb1, 0x3b, 0xe1, 0xdb, 0xc6, 0x6b, 0xe8, 0x00, 0xb2, 0xf4, 0x85, 0x22, 0xf1, 0xfd, 0xc6, 0x4c,
-	0x7e, 0xe5, 0xc0, 0x26, 0xa3, 0xbc, 0x88, 0xaf, 0xaa, 0x94, 0x47, 0x63, 0x9f, 0xd2, 0x6d, 0x41,
-	0x49, 0x79, 0xa8, 0x40, 0x17, 0xbe, 0xec, 0x98, 0x17, 0x3f, 0x82, 0xe0, 0x2b, 0x54, 0xda, 0xd6,
-	0xcb, 0x41, 0x5c, 0xda, 0xb0, 0xb1, 0x1e, 0x97, 0x56, 0x69, 0x66, 0xeb, 0xa5, 0xf5, 0x5f, 0x0e,
-	0xa8, 0xb4, 0x43, 0xf1, 0x74, 0xd2, 0xf6, 0xd1, 0x6d, 0x4d, 0x27, 0x5e, 0xed, 0x39, 0x9b, 0xab,
-	0xc9, 0x08, 0x82, 0xd3, 0x1d, 0xc6, 0xe9, 0x26, 0xbe, 0xa1, 0x72, 0x6a, 0x07, 0x78, 0x84, 0xe1,
-	0xfa, 0x09, 0xcc, 0xb0, 0x4e, 0x19, 0x3a, 0x94, 0x1f, 0xa6, 0xa6, 0xc7, 0x97, 0x70, 0xbe, 0x91,
-	0x1e, 0x1b, 0x5e, 0x62, 0xdc, 0x16, 0x70, 0x25, 0xe0, 0xc6, 0x9a, 0x65, 0x84, 0xcb, 0x23, 0xe3,
-	0xff, 0x8c, 0xf5, 0x7f, 0x65, 0x60, 0x86, 0xb5, 0x54, 0xd0, 0x08, 0x20, 0xec, 0x3d, 0xc5, 0xf7,
-	0x39, 0xd1, 0xcd, 0x8a, 0xef, 0x73, 0xb2, 0x6d, 0x85, 0x6f, 0x33, 0xce, 0x4b, 0x78, 0x31, 0xe0,
-	0xcc, 0x9e, 0x7b, 0x6b, 0xc7, 0x14, 0x8b, 0xaa, 0xf5, 0x05, 0x94, 0x94, 0x1e, 0x12, 0xd2, 0x51,
-	0x8c, 0x34, 0xa1, 0xe2, 0x46, 0xa0, 0x69, 0x40, 0xe1, 0xbb, 0x8c, 0xe9, 0x2d, 0x5c, 0x55, 0x95,
-	0xcb, 0xf9, 0xba, 0x0c, 0x93, 0x32, 0xfe, 0x29, 0x29, 0x89, 0xa2, 0x7d, 0x24, 0x74, 0x57, 0x43,
-	0x3a, 0xde, 0x8e, 0x32, 0xef, 0x4d, 0x47, 0x4a, 0x14, 0x81, 0xf3, 0x3f, 0x25, 0x98, 0x36, 0xc5,
-	0x94, 0xba, 0xff, 0x37, 0x7d, 0x92, 0xe3, 0x3f, 0x08, 0x41, 0x3e, 0x14, 0x83, 0x6e, 0x0e, 0x5a,
-	0xd1, 0x55, 0xfa, 0x61, 0x1a, 0x6c, 0xde, 0x4e, 0x9c, 0x17, 0x22, 0x3c, 0x60, 0x22, 0xac, 0xe2,
-	0x9b, 0x81, 0x08, 0xe2, 0x87, 0x27, 0x35, 0x5e, 0xd0, 0xd6, 0xec, 0x4e, 0x87, 0x2a, 0xe2, 0x27,
-	0xa4, 0xa4, 0x57, 0x9b, 0x34, 0xe8, 0x8e, 0xb6, 0xc7, 0xa0, 0xf6, 0x79, 0x4c, 0x3c, 0x0d, 0x45,
-	0xf0, 0x7f, 0x95, 0xf1, 0xbf, 0x8b, 0x57, 0x92, 0xf8, 0xbb, 0x0c, 0x3f, 0x2a, 0x02, 0x6f, 0xcb,
-	0xe8, 0x45, 0x88, 0x74, 0x7d, 0xf4, 0x22, 0x44, 0xbb, 0x3a, 0x17, 0x8b, 0x30, 0x66, 0xf8, 0x54,
-	0x84, 0x97, 0x00, 0x61, 0xd7, 0x06, 0x69, 0x95, 0xab, 0x14, 0x06, 0x71, 0xcb, 0x9f, 0x6c, 0xf8,
-	0xe0, 0x87, 0x8c, 0xf7, 0x1d, 0xbc, 0x9c, 0xc4, 0xbb, 0x47, 0xb0, 0xa9, 0x9f, 0xff, 0x2e, 0x07,
-	0xa5, 0xf7, 0xed, 0xee, 0xc0, 0x77, 0x06, 0xb4, 0x19, 0x8d, 0x8e, 0x61, 0x86, 0x45, 0xfe, 0xb8,
-	0xbb, 0xab, 0xad, 0x94, 0xb8, 0xbb, 0x47, 0xfa, 0x0c, 0xf8, 0x3e, 0x63, 0x7d, 0x1b, 0x9b, 0x01,
-	0xeb, 0x7e, 0x48, 0xbf, 0xc6, 0x7a, 0x04, 0x74, 0xcb, 0xa7, 0x90, 0xe7, 0x3d, 0x01, 0x14, 0xa3,
-	0x16, 0xe9, 0x1d, 0x98, 0xcb, 0xfa, 0xc9, 0x44, 0x2b, 0x53, 0x79, 0x79, 0x0c, 0x99, 0x32, 0xfb,
-	0x21, 0x40, 0xd8, 0x84, 0x8a, 0xeb, 0x77, 0xa2, 0x67, 0x65, 0xae, 0x26, 0x23, 0x08, 0xc6, 0xaf,
-	0x31, 0xc6, 0xf7, 0xf0, 0x6d, 0x2d, 0xe3, 0x4e, 0xb0, 0x80, 0x32, 0x6f, 0x43, 0x8e, 0x3e, 0xb8,
-	0xa1, 0x58, 0xe8, 0x57, 0xde, 0xe4, 0x4c, 0x53, 0x37, 0x25, 0x58, 0xdd, 0x63, 0xac, 0x56, 0xf0,
-	0x92, 0x96, 0x15, 0x7d, 0x78, 0xa3, 0x4c, 0xc6, 0x30, 0x2b, 0xdf, 0xd9, 0xd0, 0xad, 0x98, 0xce,
-	0xa2, 0x6f, 0x72, 0xe6, 0x4a, 0xd2, 0xb4, 0x60, 0xf8, 0x88, 0x31, 0xc4, 0xf8, 0x96, 0x5e, 0xa9,
-	0x02, 0x9d, 0x30, 0x25, 0x01, 0xe4, 0xe7, 0xf3, 0x90, 0xa3, 0x39, 0x08, 0x8d, 0xdd, 0x61, 0xe9,
-	0x16, 0xd7, 0xf0, 0x44, 0xc3, 0x24, 0xae, 0xe1, 0xc9, 0xaa, 0x4f, 0x13, 0xbb, 0xd9, 0xcf, 0xe2,
-	0x1c, 0x86, 0x45, 0x77, 0xec, 0x43, 0x49, 0x29, 0xf0, 0x90, 0x86, 0x62, 0xb4, 0x1d, 0x13, 0x8f,
-	0xdd, 0x9a, 0xea, 0x10, 0xaf, 0x32, 0xa6, 0x26, 0xbe, 0x1e, 0x65, 0xda, 0xe1, 0x68, 0x94, 0xeb,
-	0x8f, 0xa0, 0xac, 0x56, 0x82, 0x48, 0x43, 0x34, 0xd6, 0xef, 0x89, 0xc7, 0x0a, 0x5d, 0x21, 0xa9,
-	0x71, 0x9a, 0xe0, 0x47, 0x80, 0x12, 0x97, 0x72, 0xff, 0x04, 0x0a, 0xa2, 0x3e, 0xd4, 0xed, 0x37,
-	0xda, 0x21, 0xd2, 0xed, 0x37, 0x56, 0x5c, 0x6a, 0x12, 0x01, 0xc6, 0x96, 0xe6, 0xc1, 0x32, 0x40,
-	0x0b, 0x96, 0xa4, 0x8c, 0x48, 0x62, 0x19, 0xf6, 0x3c, 0x92, 0x58, 0x2a, 0x35, 0xc8, 0x54, 0x96,
-	0xc7, 0x8e, 0x2f, 0x6c, 0x59, 0x26, 0xf8, 0x28, 0x81, 0xa2, 0x1a, 0x0d, 0xf1, 0x34, 0x14, 0xc1,
-	0x15, 0x33, 0xae, 0xcb, 0xf8, 0x15, 0x0d, 0x57, 0x11, 0x0a, 0xd1, 0x8f, 0x01, 0xc2, 0x62, 0x36,
-	0x7e, 0x1d, 0x6b, 0x3b, 0x62, 0xf1, 0xeb, 0x58, 0x5f, 0x0f, 0x6b, 0x3c, 0x38, 0x64, 0xce, 0x7f,
-	0x6d, 0x43, 0xd9, 0xff, 0xd2, 0x00, 0x34, 0x59, 0xfc, 0xa2, 0xc7, 0x7a, 0x16, 0xda, 0x66, 0x9b,
-	0xf9, 0xfa, 0xe5, 0x90, 0x13, 0xa3, 0x67, 0x28, 0x57, 0x9b, 0x2d, 0x19, 0xbd, 0xa0, 0x92, 0x7d,
-	0x6e, 0xc0, 0x5c, 0xa4, 0x7c, 0x46, 0x0f, 0x12, 0xce, 0x39, 0xd6, 0xb0, 0x33, 0x1f, 0x5e, 0x88,
-	0x97, 0x98, 0xb1, 0x28, 0x56, 0x21, 0xb3, 0xb5, 0x9f, 0x91, 0xa4, 0x29, 0x5a, 0x73, 0xa3, 0x04,
-	0x06, 0x13, 0x5d, 0x3f, 0xf3, 0xd1, 0xc5, 0x88, 0x97, 0x38, 0xad, 0x30, 0x81, 0x23, 0x6e, 0x21,
-	0x4a, 0x75, 0x9d, 0x5b, 0x44, 0x9b, 0x86, 0x3a, 0xb7, 0x88, 0xd5, 0xf9, 0x49, 0x6e, 0x41, 0xab,
-	0x5e, 0xc5, 0x13, 0x45, 0x41, 0x9f, 0xc4, 0x72, 0xba, 0x27, 0xc6, 0xba, 0x01, 0x53, 0x59, 0x86,
-	0x9e, 0x28, 0xcb, 0x79, 0x94, 0x40, 0xf1, 0x02, 0x4f, 0x8c, 0x77, 0x03, 0x92, 0x3c, 0x91, 0x71,
-	0x55, 0x3c, 0x31, 0xac, 0xbe, 0x75, 0x9e, 0x38, 0xd1, 0x12, 0xd5, 0x79, 0xe2, 0x64, 0x01, 0x9f,
-	0x74, 0xb6, 0x8c, 0x79, 0xc4, 0x13, 0x17, 0x34, 0xd5, 0x3a, 0x7a, 0x3d, 0x41, 0xa7, 0xda, 0x76,
-	0xab, 0xf9, 0xc6, 0x25, 0xb1, 0xa7, 0x7b, 0x00, 0x3f, 0x0d, 0xe9, 0x01, 0xbf, 0x36, 0x60, 0x51,
-	0x57, 0xee, 0xa3, 0x04, 0x66, 0x09, 0xbd, 0x5a, 0x73, 0xed, 0xb2, 0xe8, 0x97, 0xd0, 0x5b, 0xe0,
-	0x13, 0x1b, 0xe5, 0xdf, 0xff, 0x75, 0xc5, 0xf8, 0x8a, 0xfc, 0xfb, 0x0b, 0xf9, 0x77, 0x94, 0x67,
-	0xbf, 0x4b, 0x7f, 0xeb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x6f, 0xc2, 0x22, 0x9e, 0x1e, 0x2f,
-	0x00, 0x00,
+	// 3715 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x5b, 0xcd, 0x6f, 0x1b, 0x49,
+	0x76, 0x57, 0xf3, 0x9b, 0x8f, 0x1f, 0xa2, 0x4a, 0xb2, 0x87, 0x6a, 0xdb, 0x32, 0x55, 0xb2, 0x6c,
+	0xd9, 0x9e, 0x91, 0x76, 0x34, 0x9b, 0x1c, 0x26, 0xc1, 0x22, 0xb2, 0xc4, 0xb5, 0xbd, 0x92, 0x25,
+	0x6f, 0x8b, 0xb6, 0x27, 0xc0, 0x22, 0x42, 0x8b, 0x2c, 0x4b, 0x0d, 0x91, 0xdd, 0x9c, 0xee, 0x26,
+	0x2d, 0x4d, 0x12, 0x20, 0x58, 0x64, 0x11, 0x24, 0x87, 0x1c, 0xf2, 0x81, 0x20, 0x09, 0x72, 0xca,
+	0x29, 0xc8, 0x39, 0x58, 0x20, 0xd7, 0x5c, 0x82, 0x5c, 0x12, 0x20, 0xc7, 0x5c, 0x82, 0x41, 0x0e,
+	0xf9, 0x23, 0x72, 0x08, 0xea, 0xab, 0xbb, 0xba, 0xd9, 0x4d, 0x69, 0xcc, 0x9d, 0x8b, 0xcc, 0x7a,
+	0xf5, 0xea, 0xfd, 0x5e, 0xbd, 0xaa, 0xf7, 0x5e, 0xd5, 0xab, 0x36, 0x94, 0xdd, 0x61, 0x77, 0x73,
+	0xe8, 0x3a, 0xbe, 0x83, 0xaa, 0xc4, 0xef, 0xf6, 0x3c, 0xe2, 0x8e, 0x89, 0x3b, 0x3c, 0xd5, 0x97,
+	0xce, 0x9c, 0x33, 0x87, 0x75, 0x6c, 0xd1, 0x5f, 0x9c, 0x47, 0x5f, 0xa6, 0x3c, 0x5b, 0x83, 0x71,
+	0xb7, 0xcb, 0xfe, 0x0c, 0x4f, 0xb7, 0x2e, 0xc6, 0xa2, 0xeb, 0x0e, 0xeb, 0x32, 0x47, 0xfe, 0x39,
+	0xfb, 0x33, 0x3c, 0x65, 0xff, 0x88, 0xce, 0xbb, 0x67, 0x8e, 0x73, 0xd6, 0x27, 0x5b, 0xe6, 0xd0,
+	0xda, 0x32, 0x6d, 0xdb, 0xf1, 0x4d, 0xdf, 0x72, 0x6c, 0x8f, 0xf7, 0xe2, 0x5f, 0x68, 0x50, 0x37,
+	0x88, 0x37, 0x74, 0x6c, 0x8f, 0xbc, 0x20, 0x66, 0x8f, 0xb8, 0xe8, 0x1e, 0x40, 0xb7, 0x3f, 0xf2,
+	0x7c, 0xe2, 0x9e, 0x58, 0xbd, 0xa6, 0xd6, 0xd2, 0x36, 0x72, 0x46, 0x59, 0x50, 0x5e, 0xf6, 0xd0,
+	0x1d, 0x28, 0x0f, 0xc8, 0xe0, 0x94, 0xf7, 0x66, 0x58, 0x6f, 0x89, 0x13, 0x5e, 0xf6, 0x90, 0x0e,
+	0x25, 0x97, 0x8c, 0x2d, 0xcf, 0x72, 0xec, 0x66, 0xb6, 0xa5, 0x6d, 0x64, 0x8d, 0xa0, 0x4d, 0x07,
+	0xba, 0xe6, 0x7b, 0xff, 0xc4, 0x27, 0xee, 0xa0, 0x99, 0xe3, 0x03, 0x29, 0xa1, 0x43, 0xdc, 0x01,
+	0xfe, 0xc7, 0x3c, 0x54, 0x0d, 0xd3, 0x3e, 0x23, 0x06, 0xf9, 0x7a, 0x44, 0x3c, 0x1f, 0x35, 0x20,
+	0x7b, 0x41, 0xae, 0x18, 0x7c, 0xd5, 0xa0, 0x3f, 0xf9, 0x78, 0xfb, 0x8c, 0x9c, 0x10, 0x9b, 0x03,
+	0x57, 0xe9, 0x78, 0xfb, 0x8c, 0xb4, 0xed, 0x1e, 0x5a, 0x82, 0x7c, 0xdf, 0x1a, 0x58, 0xbe, 0x40,
+	0xe5, 0x8d, 0x88, 0x3a, 0xb9, 0x98, 0x3a, 0xbb, 0x00, 0x9e, 0xe3, 0xfa, 0x27, 0x8e, 0xdb, 0x23,
+	0x6e, 0x33, 0xdf, 0xd2, 0x36, 0xea, 0xdb, 0x0f, 0x36, 0xd5, 0x85, 0xd8, 0x54, 0x15, 0xda, 0x3c,
+	0x76, 0x5c, 0xff, 0x88, 0xf2, 0x1a, 0x65, 0x4f, 0xfe, 0x44, 0x3f, 0x86, 0x0a, 0x13, 0xe2, 0x9b,
+	0xee, 0x19, 0xf1, 0x9b, 0x05, 0x26, 0x65, 0xfd, 0x1a, 0x29, 0x1d, 0xc6, 0x6c, 0x80, 0x17, 0xfc,
+	0x46, 0x18, 0xaa, 0x1e, 0x71, 0x2d, 0xb3, 0x6f, 0x7d, 0x63, 0x9e, 0xf6, 0x49, 0xb3, 0xd8, 0xd2,
+	0x36, 0x4a, 0x46, 0x84, 0x46, 0xe7, 0x7f, 0x41, 0xae, 0xbc, 0x13, 0xc7, 0xee, 0x5f, 0x35, 0x4b,
+	0x8c, 0xa1, 0x44, 0x09, 0x47, 0x76, 0xff, 0x8a, 0x2d, 0x9a, 0x33, 0xb2, 0x7d, 0xde, 0x5b, 0x66,
+	0xbd, 0x65, 0x46, 0x61, 0xdd, 0x1b, 0xd0, 0x18, 0x58, 0xf6, 0xc9, 0xc0, 0xe9, 0x9d, 0x04, 0x06,
+	0x01, 0x66, 0x90, 0xfa, 0xc0, 0xb2, 0x5f, 0x39, 0x3d, 0x43, 0x9a, 0x85, 0x72, 0x9a, 0x97, 0x51,
+	0xce, 0x8a, 0xe0, 0x34, 0x2f, 0x55, 0xce, 0x4d, 0x58, 0xa4, 0x32, 0xbb, 0x2e, 0x31, 0x7d, 0x12,
+	0x32, 0x57, 0x19, 0xf3, 0xc2, 0xc0, 0xb2, 0x77, 0x59, 0x4f, 0x84, 0xdf, 0xbc, 0x9c, 0xe0, 0xaf,
+	0x09, 0x7e, 0xf3, 0x32, 0xc6, 0xbf, 0x0e, 0xf5, 0xae, 0x63, 0xfb, 0x96, 0x3d, 0x22, 0x27, 0xbe,
+	0x73, 0x41, 0xec, 0x66, 0x9d, 0x2d, 0x7a, 0x4d, 0x52, 0x3b, 0x94, 0x88, 0x37, 0xa1, 0x1c, 0x2c,
+	0x0d, 0x2a, 0x41, 0xee, 0xf0, 0xe8, 0xb0, 0xdd, 0x98, 0x43, 0x00, 0x85, 0x9d, 0xe3, 0xdd, 0xf6,
+	0xe1, 0x5e, 0x43, 0x43, 0x15, 0x28, 0xee, 0xb5, 0x79, 0x23, 0x83, 0x9f, 0x01, 0x84, 0x8b, 0x80,
+	0x8a, 0x90, 0xdd, 0x6f, 0xff, 0x76, 0x63, 0x8e, 0xf2, 0xbc, 0x6d, 0x1b, 0xc7, 0x2f, 0x8f, 0x0e,
+	0x1b, 0x1a, 0x1d, 0xbc, 0x6b, 0xb4, 0x77, 0x3a, 0xed, 0x46, 0x86, 0x72, 0xbc, 0x3a, 0xda, 0x6b,
+	0x64, 0x51, 0x19, 0xf2, 0x6f, 0x77, 0x0e, 0xde, 0xb4, 0x1b, 0x39, 0xfc, 0xcf, 0x1a, 0xd4, 0xc4,
+	0xb2, 0x72, 0xd7, 0x41, 0x3f, 0x84, 0xc2, 0x39, 0x73, 0x1f, 0xb6, 0x63, 0x2b, 0xdb, 0x77, 0x63,
+	0x7b, 0x20, 0xe2, 0x62, 0x86, 0xe0, 0x45, 0x18, 0xb2, 0x17, 0x63, 0xaf, 0x99, 0x69, 0x65, 0x37,
+	0x2a, 0xdb, 0x8d, 0x4d, 0xee, 0xd7, 0x9b, 0xfb, 0xe4, 0xea, 0xad, 0xd9, 0x1f, 0x11, 0x83, 0x76,
+	0x22, 0x04, 0xb9, 0x81, 0xe3, 0x12, 0xb6, 0xb1, 0x4b, 0x06, 0xfb, 0x4d, 0x77, 0x3b, 0x5b, 0x5b,
+	0xb1, 0xa9, 0x79, 0x23, 0xc1, 0x60, 0xf9, 0x24, 0x83, 0x75, 0x01, 0x5e, 0x8f, 0xfc, 0x74, 0x3f,
+	0x5b, 0x82, 0xfc, 0x98, 0xc2, 0x0b, 0x1f, 0xe3, 0x0d, 0xe6, 0x60, 0xc4, 0xf4, 0x48, 0xe0, 0x60,
+	0xb4, 0x81, 0x3e, 0x81, 0xe2, 0xd0, 0x25, 0xe3, 0x93, 0x8b, 0x31, 0x53, 0xa5, 0x64, 0x14, 0x68,
+	0x73, 0x7f, 0x8c, 0x6d, 0xa8, 0x30, 0x90, 0x99, 0xcc, 0xf3, 0x38, 0x94, 0x9e, 0x69, 0x69, 0x89,
+	0x26, 0x92, 0x78, 0x3f, 0x03, 0xb4, 0x47, 0xfa, 0xc4, 0x27, 0xb3, 0x04, 0x11, 0x65, 0x36, 0xd9,
+	0xc8, 0x6c, 0xfe, 0x4c, 0x83, 0xc5, 0x88, 0xf8, 0x99, 0xa6, 0xd5, 0x84, 0x62, 0x8f, 0x09, 0xe3,
+	0x1a, 0x64, 0x0d, 0xd9, 0x44, 0x4f, 0xa1, 0x24, 0x14, 0xf0, 0x9a, 0xd9, 0x94, 0x4d, 0x51, 0xe4,
+	0x3a, 0x79, 0xf8, 0x1f, 0x32, 0x50, 0x16, 0x13, 0x3d, 0x1a, 0xa2, 0x1d, 0xa8, 0xb9, 0xbc, 0x71,
+	0xc2, 0xe6, 0x23, 0x34, 0xd2, 0xd3, 0x63, 0xd1, 0x8b, 0x39, 0xa3, 0x2a, 0x86, 0x30, 0x32, 0xfa,
+	0x0d, 0xa8, 0x48, 0x11, 0xc3, 0x91, 0x2f, 0x4c, 0xde, 0x8c, 0x0a, 0x08, 0x77, 0xce, 0x8b, 0x39,
+	0x03, 0x04, 0xfb, 0xeb, 0x91, 0x8f, 0x3a, 0xb0, 0x24, 0x07, 0xf3, 0xd9, 0x08, 0x35, 0xb2, 0x4c,
+	0x4a, 0x2b, 0x2a, 0x65, 0x72, 0xa9, 0x5e, 0xcc, 0x19, 0x48, 0x8c, 0x57, 0x3a, 0x55, 0x95, 0xfc,
+	0x4b, 0x1e, 0xc3, 0x27, 0x54, 0xea, 0x5c, 0xda, 0x93, 0x2a, 0x75, 0x2e, 0xed, 0x67, 0x65, 0x28,
+	0x8a, 0x16, 0xfe, 0x65, 0x06, 0x40, 0xae, 0xc6, 0xd1, 0x10, 0xed, 0x41, 0xdd, 0x15, 0xad, 0x88,
+	0xb5, 0xee, 0x24, 0x5a, 0x4b, 0x2c, 0xe2, 0x9c, 0x51, 0x93, 0x83, 0xb8, 0x72, 0x3f, 0x82, 0x6a,
+	0x20, 0x25, 0x34, 0xd8, 0x72, 0x82, 0xc1, 0x02, 0x09, 0x15, 0x39, 0x80, 0x9a, 0xec, 0x1d, 0xdc,
+	0x0a, 0xc6, 0x27, 0xd8, 0x6c, 0x75, 0x8a, 0xcd, 0x02, 0x81, 0x8b, 0x52, 0x82, 0x6a, 0x35, 0x55,
+	0xb1, 0xd0, 0x6c, 0xcb, 0x09, 0x66, 0x9b, 0x54, 0x8c, 0x1a, 0x0e, 0xa0, 0x24, 0x9b, 0xf8, 0x97,
+	0x59, 0x28, 0xee, 0x3a, 0x83, 0xa1, 0xe9, 0xd2, 0xd5, 0x28, 0xb8, 0xc4, 0x1b, 0xf5, 0x7d, 0x66,
+	0xae, 0xfa, 0xf6, 0x5a, 0x54, 0xa2, 0x60, 0x93, 0xff, 0x1a, 0x8c, 0xd5, 0x10, 0x43, 0xe8, 0x60,
+	0x91, 0x25, 0x33, 0x37, 0x18, 0x2c, 0x72, 0xa4, 0x18, 0x22, 0x1d, 0x39, 0x1b, 0x3a, 0xb2, 0x0e,
+	0xc5, 0x31, 0x71, 0xc3, 0xcc, 0xfe, 0x62, 0xce, 0x90, 0x04, 0xf4, 0x18, 0xe6, 0xe3, 0x59, 0x26,
+	0x2f, 0x78, 0xea, 0xdd, 0x68, 0x92, 0x59, 0x83, 0x6a, 0x24, 0xd5, 0x15, 0x04, 0x5f, 0x65, 0xa0,
+	0x64, 0xba, 0xdb, 0x32, 0x22, 0xd2, 0xb4, 0x5c, 0x7d, 0x31, 0x27, 0x63, 0x62, 0x24, 0x98, 0xfc,
+	0x56, 0x34, 0x98, 0xe0, 0xcf, 0xa1, 0x16, 0x31, 0x04, 0xcd, 0x1f, 0xed, 0x9f, 0xbe, 0xd9, 0x39,
+	0xe0, 0xc9, 0xe6, 0x39, 0xcb, 0x2f, 0x46, 0x43, 0xa3, 0x39, 0xeb, 0xa0, 0x7d, 0x7c, 0xdc, 0xc8,
+	0xe0, 0xdf, 0x84, 0x5a, 0x64, 0xfa, 0x6a, 0x52, 0x9a, 0x53, 0x92, 0x92, 0x26, 0x93, 0x52, 0x26,
+	0x4c, 0x4a, 0xd9, 0x67, 0x75, 0xa8, 0x72, 0x6b, 0x9d, 0x8c, 0x6c, 0xcb, 0xb1, 0xf1, 0xdf, 0x6b,
+	0x00, 0xa1, 0x6f, 0xa0, 0x2d, 0x28, 0x76, 0xb9, 0xf0, 0xa6, 0xc6, 0x42, 0xcb, 0xad, 0xc4, 0x05,
+	0x30, 0x24, 0x17, 0xfa, 0x1c, 0x8a, 0xde, 0xa8, 0xdb, 0x25, 0x9e, 0x4c, 0x50, 0x9f, 0xc4, 0xa3,
+	0x9b, 0x88, 0x3d, 0x86, 0xe4, 0xa3, 0x43, 0xde, 0x9b, 0x56, 0x7f, 0xc4, 0xd2, 0xd5, 0xf4, 0x21,
+	0x82, 0x0f, 0xff, 0xb5, 0x06, 0x15, 0x65, 0x2b, 0x7e, 0x64, 0x48, 0xbd, 0x0b, 0x65, 0xa6, 0x03,
+	0xe9, 0x89, 0xa0, 0x5a, 0x32, 0x42, 0x02, 0xfa, 0x75, 0x28, 0xcb, 0xfd, 0x2c, 0xe3, 0x6a, 0x33,
+	0x59, 0xec, 0xd1, 0xd0, 0x08, 0x59, 0xf1, 0x3e, 0x2c, 0x30, 0xab, 0x74, 0xe9, 0x89, 0x59, 0xda,
+	0x51, 0x3d, 0x53, 0x6a, 0xb1, 0x33, 0xa5, 0x0e, 0xa5, 0xe1, 0xf9, 0x95, 0x67, 0x75, 0xcd, 0xbe,
+	0xd0, 0x22, 0x68, 0xe3, 0x9f, 0x00, 0x52, 0x85, 0xcd, 0x32, 0x5d, 0x5c, 0x83, 0xca, 0x0b, 0xd3,
+	0x3b, 0x17, 0x2a, 0xe1, 0xaf, 0xa0, 0xca, 0x9b, 0x33, 0xd9, 0x10, 0x41, 0xee, 0xdc, 0xf4, 0xce,
+	0x99, 0xe2, 0x35, 0x83, 0xfd, 0xc6, 0x0b, 0x30, 0x7f, 0x6c, 0x9b, 0x43, 0xef, 0xdc, 0x91, 0x61,
+	0x9f, 0xde, 0x18, 0x1a, 0x21, 0x6d, 0x26, 0xc4, 0x47, 0x30, 0xef, 0x92, 0x81, 0x69, 0xd9, 0x96,
+	0x7d, 0x76, 0x72, 0x7a, 0xe5, 0x13, 0x4f, 0x5c, 0x28, 0xea, 0x01, 0xf9, 0x19, 0xa5, 0x52, 0xd5,
+	0x4e, 0xfb, 0xce, 0xa9, 0xf0, 0x7f, 0xf6, 0x1b, 0xff, 0x93, 0x06, 0xd5, 0x77, 0xa6, 0xdf, 0x95,
+	0x56, 0x40, 0x2f, 0xa1, 0x1e, 0x78, 0x3d, 0xa3, 0x34, 0xb5, 0xa4, 0xdc, 0xc3, 0xc6, 0xc8, 0xa3,
+	0xa6, 0x4c, 0x1b, 0xb5, 0xae, 0x4a, 0x60, 0xa2, 0x4c, 0xbb, 0x4b, 0xfa, 0x81, 0xa8, 0x4c, 0xba,
+	0x28, 0xc6, 0xa8, 0x8a, 0x52, 0x09, 0xcf, 0xe6, 0xc3, 0xbc, 0xcc, 0xdd, 0xf2, 0x6f, 0x32, 0x80,
+	0x26, 0x75, 0xf8, 0xae, 0x47, 0x95, 0x75, 0xa8, 0x7b, 0xbe, 0xe9, 0xfa, 0x27, 0xb1, 0xeb, 0x56,
+	0x8d, 0x51, 0x83, 0xc8, 0xf5, 0x08, 0xe6, 0x87, 0xae, 0x73, 0xe6, 0x12, 0xcf, 0x3b, 0xb1, 0x1d,
+	0xdf, 0x7a, 0x7f, 0x25, 0xce, 0x69, 0x75, 0x49, 0x3e, 0x64, 0x54, 0xd4, 0x86, 0xe2, 0x7b, 0xab,
+	0xef, 0x13, 0xd7, 0x6b, 0xe6, 0x5b, 0xd9, 0x8d, 0xfa, 0xf6, 0xd3, 0xeb, 0xac, 0xb6, 0xf9, 0x63,
+	0xc6, 0xdf, 0xb9, 0x1a, 0x12, 0x43, 0x8e, 0x55, 0x4f, 0x50, 0x85, 0xc8, 0x09, 0x6a, 0x1d, 0x20,
+	0xe4, 0xa7, 0x51, 0xeb, 0xf0, 0xe8, 0xf5, 0x9b, 0x4e, 0x63, 0x0e, 0x55, 0xa1, 0x74, 0x78, 0xb4,
+	0xd7, 0x3e, 0x68, 0xd3, 0xb8, 0x86, 0xb7, 0xa4, 0x6d, 0x54, 0x1b, 0xa2, 0x65, 0x28, 0x7d, 0xa0,
+	0x54, 0x79, 0x1f, 0xcd, 0x1a, 0x45, 0xd6, 0x7e, 0xd9, 0xc3, 0xff, 0xab, 0x41, 0x4d, 0xec, 0x82,
+	0x99, 0xb6, 0xa2, 0x0a, 0x91, 0x89, 0x40, 0xd0, 0xe3, 0x1a, 0xdf, 0x1d, 0x3d, 0x71, 0x2a, 0x94,
+	0x4d, 0xea, 0xee, 0x7c, 0xb1, 0x49, 0x4f, 0x98, 0x35, 0x68, 0xa3, 0xc7, 0xd0, 0xe8, 0x72, 0x77,
+	0x8f, 0x25, 0x21, 0x63, 0x5e, 0xd0, 0x95, 0x8b, 0x4e, 0x81, 0x8c, 0x89, 0xed, 0x7b, 0xcd, 0x0a,
+	0x8b, 0x4d, 0x35, 0x79, 0xe6, 0x6b, 0x53, 0xaa, 0x21, 0x3a, 0xf1, 0xaf, 0xc1, 0xc2, 0x01, 0x31,
+	0x3d, 0xf2, 0xdc, 0x35, 0x6d, 0xf5, 0xf8, 0xde, 0xe9, 0x1c, 0x08, 0xab, 0x64, 0xfd, 0xce, 0x01,
+	0xaa, 0x43, 0xe6, 0xe5, 0x9e, 0x98, 0x43, 0xc6, 0xda, 0xc3, 0x3f, 0xd7, 0x00, 0xa9, 0xe3, 0x66,
+	0x32, 0x53, 0x4c, 0xb8, 0x84, 0xcf, 0x86, 0xf0, 0x4b, 0x90, 0x27, 0xae, 0xeb, 0xb8, 0xcc, 0x20,
+	0x65, 0x83, 0x37, 0xf0, 0x03, 0xa1, 0x83, 0x41, 0xc6, 0xce, 0x45, 0xb0, 0xe7, 0xb9, 0x34, 0x2d,
+	0x50, 0x75, 0x1f, 0x16, 0x23, 0x5c, 0x33, 0xc5, 0xc8, 0x47, 0x70, 0x8b, 0x09, 0xdb, 0x27, 0x64,
+	0xb8, 0xd3, 0xb7, 0xc6, 0xa9, 0xa8, 0x43, 0xb8, 0x1d, 0x67, 0xfc, 0x7e, 0x6d, 0x84, 0xb7, 0x05,
+	0x62, 0xc7, 0x1a, 0x90, 0x8e, 0x73, 0xa0, 0xe8, 0x06, 0xa1, 0x6e, 0xa8, 0x0a, 0x39, 0x7a, 0xbd,
+	0xe7, 0x89, 0x84, 0x2e, 0xe3, 0x27, 0x13, 0x83, 0x84, 0x9e, 0x9f, 0x7e, 0x17, 0x3d, 0x05, 0x06,
+	0xd3, 0x0f, 0x55, 0x14, 0xdd, 0x10, 0x02, 0x38, 0xa3, 0x7b, 0x84, 0xf4, 0x28, 0x2d, 0x17, 0x51,
+	0x82, 0x06, 0x85, 0x2a, 0x5e, 0x12, 0xcb, 0xc8, 0xfe, 0x78, 0x32, 0x23, 0x2c, 0x43, 0x85, 0x11,
+	0x8e, 0x7d, 0xd3, 0x1f, 0x79, 0xea, 0x1c, 0xb0, 0x2d, 0x56, 0x54, 0x0e, 0xf8, 0x28, 0x85, 0x1f,
+	0x43, 0x81, 0xdd, 0x36, 0xe5, 0x39, 0x24, 0x76, 0x90, 0x55, 0xb0, 0xf1, 0x9f, 0x6a, 0x50, 0x78,
+	0xc5, 0x8a, 0x51, 0xca, 0x32, 0xe7, 0xd8, 0x32, 0x20, 0xc8, 0xd9, 0xe6, 0x80, 0xdf, 0x6a, 0xcb,
+	0x06, 0xfb, 0xcd, 0xf2, 0x35, 0x21, 0xee, 0x1b, 0xe3, 0x80, 0x9f, 0x0b, 0xca, 0x46, 0xd0, 0x46,
+	0x2b, 0xb4, 0x0c, 0x66, 0x11, 0xdb, 0x67, 0xbd, 0x39, 0xd6, 0xab, 0x50, 0xd0, 0x02, 0x94, 0x2d,
+	0xef, 0x80, 0x98, 0xae, 0x2d, 0xca, 0x47, 0x25, 0x4e, 0x7a, 0x67, 0xf9, 0x36, 0xf1, 0x3c, 0x1e,
+	0xff, 0x70, 0x07, 0x1a, 0x5c, 0x9f, 0x9d, 0x5e, 0x4f, 0x39, 0x41, 0x04, 0xa8, 0x5a, 0x0c, 0x35,
+	0x22, 0x35, 0x33, 0x29, 0x95, 0x45, 0x20, 0xfc, 0x01, 0x16, 0x14, 0xa9, 0x33, 0xed, 0xd6, 0x4f,
+	0xa1, 0xc0, 0xab, 0x77, 0x22, 0xc5, 0x2d, 0x45, 0x47, 0x71, 0x18, 0x43, 0xf0, 0xe0, 0x75, 0x58,
+	0x14, 0x14, 0x32, 0x70, 0x92, 0x5c, 0x8a, 0xd9, 0x1a, 0x1f, 0xc0, 0x52, 0x94, 0x6d, 0x26, 0x4f,
+	0xde, 0x91, 0xa0, 0x6f, 0x86, 0x3d, 0xd3, 0x4f, 0x03, 0x8d, 0x98, 0x35, 0x13, 0x35, 0x6b, 0xa8,
+	0x90, 0x14, 0x31, 0x93, 0x42, 0x8b, 0xd2, 0xfc, 0x07, 0x96, 0x17, 0x9c, 0x8b, 0xbe, 0x01, 0xa4,
+	0x12, 0x67, 0x5a, 0x94, 0x4d, 0x28, 0x72, 0x83, 0xcb, 0x2d, 0x9f, 0xbc, 0x2a, 0x92, 0x09, 0x63,
+	0x39, 0xbd, 0xd7, 0xae, 0x33, 0x70, 0xfc, 0x84, 0x70, 0x92, 0xc3, 0x7d, 0xb8, 0x15, 0xe3, 0xf9,
+	0x28, 0x67, 0x5c, 0xbf, 0x91, 0x6a, 0xf8, 0x1d, 0x34, 0xe5, 0x0e, 0xe8, 0x3a, 0xf6, 0x7b, 0xeb,
+	0x6c, 0xe4, 0x06, 0x5a, 0x3d, 0x85, 0xac, 0xd9, 0xeb, 0x89, 0x5b, 0xc8, 0x4a, 0xd2, 0x70, 0xc5,
+	0x59, 0xea, 0xf4, 0xce, 0x49, 0x37, 0x11, 0x83, 0xcb, 0xe1, 0xbf, 0xd4, 0x60, 0x39, 0x41, 0xf2,
+	0x47, 0xcd, 0x65, 0x0d, 0xf2, 0x66, 0x8f, 0xdf, 0x18, 0x52, 0x67, 0xa2, 0x4e, 0x38, 0x3b, 0x65,
+	0xc2, 0x8b, 0xb0, 0xb0, 0x47, 0xde, 0xbb, 0xe6, 0xd9, 0x80, 0x04, 0xd9, 0x99, 0x9e, 0xf9, 0x55,
+	0xe2, 0x4c, 0x9b, 0xee, 0xdf, 0x35, 0xa8, 0xee, 0xf4, 0x4d, 0x77, 0x20, 0x2d, 0xf3, 0x23, 0x28,
+	0xf0, 0xcb, 0x84, 0xb8, 0x8d, 0x3f, 0x8c, 0x8a, 0x51, 0x79, 0x79, 0x63, 0x87, 0x71, 0x1b, 0x62,
+	0x14, 0xf5, 0x17, 0x51, 0xb7, 0xdf, 0x8b, 0xd5, 0xf1, 0xf7, 0xd0, 0x67, 0x90, 0x37, 0xe9, 0x10,
+	0x16, 0x6f, 0xea, 0xf1, 0x6b, 0x1c, 0x93, 0xc6, 0x0e, 0x7e, 0x9c, 0x0b, 0xff, 0x10, 0x2a, 0x0a,
+	0x02, 0xbd, 0x9d, 0x3e, 0x6f, 0x8b, 0xc3, 0xdd, 0xce, 0x6e, 0xe7, 0xe5, 0x5b, 0x7e, 0x69, 0xad,
+	0x03, 0xec, 0xb5, 0x83, 0x76, 0x06, 0x7f, 0x25, 0x46, 0x09, 0x43, 0xab, 0xfa, 0x68, 0x69, 0xfa,
+	0x64, 0x6e, 0xa4, 0xcf, 0x25, 0xd4, 0xc4, 0xf4, 0x67, 0x72, 0xc3, 0xcf, 0xa1, 0xc0, 0xe4, 0xa5,
+	0x24, 0x1e, 0x45, 0x79, 0x43, 0x30, 0xe2, 0x79, 0xa8, 0xf1, 0x54, 0x24, 0xb7, 0xc0, 0xbf, 0x69,
+	0x50, 0x97, 0x94, 0x59, 0xab, 0x86, 0xb2, 0xe0, 0xc1, 0x53, 0x98, 0x6c, 0xa2, 0xdb, 0x50, 0xe8,
+	0x9d, 0x1e, 0x5b, 0xdf, 0xc8, 0xda, 0xac, 0x68, 0x51, 0x7a, 0x9f, 0xe3, 0xf0, 0xd7, 0x96, 0x42,
+	0x3f, 0xb8, 0x2c, 0xd3, 0x77, 0x97, 0x97, 0x76, 0x8f, 0x5c, 0xb2, 0xcc, 0x95, 0x33, 0x42, 0x02,
+	0xbb, 0xdf, 0x8a, 0x57, 0x99, 0x66, 0x21, 0xf6, 0x4a, 0xb3, 0x0e, 0x0b, 0xaf, 0x9c, 0x31, 0x39,
+	0xe0, 0x9a, 0x05, 0x47, 0xd0, 0x12, 0xaf, 0x3b, 0x04, 0xa1, 0xe6, 0x19, 0x20, 0x95, 0xed, 0x63,
+	0x7c, 0x93, 0xfa, 0xd3, 0xce, 0xc8, 0x3f, 0x6f, 0xdb, 0xf4, 0xed, 0x43, 0x1a, 0x73, 0x09, 0x10,
+	0x25, 0xee, 0x59, 0x9e, 0x4a, 0x6d, 0xc3, 0x22, 0xa5, 0x12, 0xdb, 0xb7, 0xba, 0x4a, 0x7e, 0x90,
+	0x09, 0x5f, 0x8b, 0x25, 0x7c, 0xd3, 0xf3, 0x3e, 0x38, 0x6e, 0x4f, 0x58, 0x31, 0x68, 0xe3, 0x3d,
+	0x2e, 0xfc, 0x8d, 0x17, 0x89, 0x3f, 0xdf, 0x55, 0xca, 0x46, 0x28, 0xe5, 0x39, 0xf1, 0xa7, 0x48,
+	0xc1, 0x4f, 0xe1, 0x96, 0xe4, 0x14, 0xc5, 0xbb, 0x29, 0xcc, 0x47, 0x70, 0x4f, 0x32, 0xef, 0x9e,
+	0xd3, 0x4b, 0xe0, 0x6b, 0x01, 0xf8, 0xb1, 0x7a, 0x3e, 0x83, 0x66, 0xa0, 0x27, 0xbb, 0x18, 0x38,
+	0x7d, 0x55, 0x81, 0x91, 0x27, 0xd6, 0xa9, 0x6c, 0xb0, 0xdf, 0x94, 0xe6, 0x3a, 0xfd, 0xe0, 0xf8,
+	0x44, 0x7f, 0xe3, 0x5d, 0x58, 0x96, 0x32, 0xc4, 0x91, 0x3d, 0x2a, 0x64, 0x42, 0xa1, 0x24, 0x21,
+	0xc2, 0x60, 0x74, 0xe8, 0x74, 0xb3, 0xab, 0x9c, 0x51, 0xd3, 0x32, 0x99, 0x9a, 0x22, 0xf3, 0x16,
+	0x2c, 0x4a, 0xc5, 0xd4, 0x14, 0x2d, 0xc8, 0x54, 0x80, 0x4a, 0x16, 0x0b, 0x41, 0xc9, 0x13, 0x0b,
+	0x31, 0x21, 0xfa, 0x67, 0xb0, 0x12, 0x28, 0x41, 0xed, 0xf6, 0x9a, 0xb8, 0x03, 0xcb, 0xf3, 0x94,
+	0x02, 0x51, 0xd2, 0xc4, 0x1f, 0x42, 0x6e, 0x48, 0x44, 0xf8, 0xaa, 0x6c, 0xa3, 0x4d, 0xfe, 0x4c,
+	0xbb, 0xa9, 0x0c, 0x66, 0xfd, 0xb8, 0x07, 0xf7, 0xa5, 0x74, 0x6e, 0xd1, 0x44, 0xf1, 0x71, 0xa5,
+	0x64, 0xf1, 0x80, 0x9b, 0x75, 0xb2, 0x78, 0x90, 0xe5, 0x6b, 0x1f, 0x94, 0x26, 0x7f, 0x02, 0x48,
+	0xf5, 0xad, 0x99, 0xd2, 0xd2, 0x3e, 0x2c, 0x46, 0x5c, 0x72, 0x26, 0x61, 0xa7, 0xb0, 0x14, 0xf5,
+	0xe4, 0x99, 0x22, 0xe6, 0x12, 0xe4, 0xf9, 0x33, 0x18, 0xb7, 0x0b, 0x6f, 0xe0, 0xfd, 0x70, 0x6f,
+	0xcc, 0x7c, 0x7a, 0xc6, 0x66, 0x28, 0x8c, 0x6d, 0xc9, 0x59, 0xf5, 0xa5, 0xab, 0x29, 0x4f, 0xaf,
+	0xbc, 0x81, 0x0f, 0xe1, 0x76, 0x3c, 0x4c, 0xcc, 0xa4, 0xf2, 0x5b, 0x58, 0x91, 0xf2, 0xe2, 0x91,
+	0x64, 0x26, 0xb9, 0x3f, 0x0d, 0x83, 0x81, 0x12, 0x50, 0x66, 0x12, 0x69, 0x80, 0x9e, 0x14, 0x5f,
+	0x7e, 0x15, 0xfb, 0x35, 0x08, 0x37, 0x33, 0x09, 0xf3, 0x42, 0x61, 0xb3, 0x2f, 0x7f, 0x18, 0x23,
+	0xb2, 0x53, 0x63, 0x84, 0x70, 0x92, 0x30, 0x8a, 0x7d, 0x0f, 0x9b, 0x4e, 0x60, 0x84, 0x01, 0x74,
+	0x56, 0x0c, 0x9a, 0x43, 0x02, 0x0c, 0xd6, 0x90, 0x1b, 0x5b, 0x0d, 0xbb, 0x33, 0x2d, 0xc6, 0xbb,
+	0x30, 0x76, 0x4e, 0x44, 0xe6, 0x99, 0x04, 0x7f, 0x05, 0xad, 0xf4, 0xa0, 0x3c, 0x8b, 0xe4, 0x27,
+	0x18, 0xca, 0xc1, 0xd9, 0x55, 0xf9, 0x76, 0xa1, 0x02, 0xc5, 0xc3, 0xa3, 0xe3, 0xd7, 0x3b, 0xbb,
+	0xed, 0x86, 0xb6, 0xfd, 0x5f, 0x59, 0xc8, 0xec, 0xbf, 0x45, 0xbf, 0x03, 0x79, 0xfe, 0xb8, 0x37,
+	0xe5, 0x45, 0x57, 0x9f, 0xf6, 0x7e, 0x89, 0xef, 0xfe, 0xfc, 0x3f, 0xff, 0xe7, 0xcf, 0x33, 0xb7,
+	0xf1, 0xc2, 0xd6, 0xf8, 0x0b, 0xb3, 0x3f, 0x3c, 0x37, 0xb7, 0x2e, 0xc6, 0x5b, 0x2c, 0x27, 0x7c,
+	0xa9, 0x3d, 0x41, 0x6f, 0x21, 0x4b, 0xdf, 0x24, 0x53, 0x9f, 0x7b, 0xf5, 0xf4, 0x77, 0x4d, 0xac,
+	0x33, 0xc9, 0x4b, 0x78, 0x5e, 0x95, 0x3c, 0x1c, 0xf9, 0x54, 0x6e, 0x07, 0x2a, 0xea, 0xd3, 0xe4,
+	0xb5, 0x0f, 0xc1, 0xfa, 0xf5, 0xcf, 0x9e, 0x78, 0x8e, 0x6a, 0xdb, 0xb9, 0xb4, 0x51, 0xea, 0x4b,
+	0xb0, 0x9e, 0xfe, 0xd8, 0x99, 0xac, 0xad, 0x7f, 0x69, 0x53, 0x6d, 0x1d, 0xf1, 0xd8, 0xd9, 0xf5,
+	0xd1, 0xfd, 0x84, 0xe7, 0x31, 0xf5, 0x21, 0x48, 0x6f, 0xa5, 0x33, 0x08, 0xa4, 0x55, 0x86, 0x74,
+	0x07, 0xdf, 0x56, 0x91, 0xba, 0x01, 0xdf, 0x97, 0xda, 0x93, 0xed, 0x73, 0xc8, 0xb3, 0xf2, 0x35,
+	0x3a, 0x91, 0x3f, 0xf4, 0x84, 0xc2, 0x7b, 0xca, 0xfa, 0x46, 0x0a, 0xdf, 0x78, 0x99, 0xa1, 0x2d,
+	0xe2, 0x7a, 0x80, 0xc6, 0x2a, 0xd8, 0x5f, 0x6a, 0x4f, 0x36, 0xb4, 0x1f, 0x68, 0xdb, 0xff, 0x97,
+	0x83, 0x3c, 0x2b, 0x95, 0xa1, 0x21, 0x40, 0x58, 0x10, 0x8e, 0xcf, 0x73, 0xa2, 0xc4, 0xac, 0xb7,
+	0xd2, 0x19, 0x04, 0xf2, 0x7d, 0x86, 0xbc, 0x8c, 0x97, 0x02, 0x64, 0x56, 0xaf, 0xdb, 0x62, 0xd5,
+	0x44, 0x6a, 0xd6, 0x0f, 0xa2, 0x42, 0xc8, 0xdd, 0x07, 0x25, 0x49, 0x8c, 0x54, 0x86, 0xf5, 0xd5,
+	0x29, 0x1c, 0x02, 0x74, 0x8d, 0x81, 0xde, 0xc3, 0x4d, 0xd5, 0xb8, 0x1c, 0xd7, 0x65, 0x9c, 0x14,
+	0xf8, 0x0f, 0x35, 0xa8, 0x47, 0x8b, 0xbb, 0x68, 0x2d, 0x41, 0x74, 0xbc, 0x46, 0xac, 0x3f, 0x98,
+	0xce, 0x94, 0xaa, 0x02, 0xc7, 0xbf, 0x20, 0x64, 0x68, 0x52, 0x4e, 0x61, 0x7b, 0xf4, 0x47, 0x1a,
+	0xcc, 0xc7, 0x8a, 0xb7, 0x28, 0x09, 0x62, 0xa2, 0x20, 0xac, 0xaf, 0x5f, 0xc3, 0x25, 0x34, 0x79,
+	0xc4, 0x34, 0x59, 0xc5, 0x77, 0x27, 0x8d, 0xe1, 0x5b, 0x03, 0xe2, 0x3b, 0x42, 0x9b, 0x60, 0x25,
+	0xd8, 0x1f, 0x2f, 0x71, 0x25, 0x22, 0xc5, 0x5d, 0x7d, 0x75, 0x0a, 0xc7, 0xf5, 0x2b, 0xc1, 0xfe,
+	0x7a, 0x74, 0xa3, 0xff, 0x5d, 0x01, 0x8a, 0xbb, 0xfc, 0x23, 0x42, 0xe4, 0x43, 0x39, 0xa8, 0xf3,
+	0xa0, 0x6b, 0x0a, 0x40, 0xfa, 0xfd, 0xd4, 0x7e, 0x01, 0xff, 0x90, 0xc1, 0xb7, 0xf0, 0x9d, 0x00,
+	0x5e, 0x7c, 0xac, 0xb8, 0xc5, 0xcb, 0x07, 0x5b, 0x66, 0xaf, 0x47, 0xa7, 0xfe, 0x07, 0x1a, 0x54,
+	0xd5, 0xaa, 0x24, 0x5a, 0x4d, 0x92, 0x1c, 0x29, 0x6c, 0xea, 0x78, 0x1a, 0x8b, 0xc0, 0x7f, 0xcc,
+	0xf0, 0xd7, 0xf0, 0x4a, 0x1a, 0x3e, 0xaf, 0x5f, 0x45, 0x55, 0xe0, 0x75, 0xc8, 0x64, 0x15, 0x22,
+	0x65, 0x4e, 0x1d, 0x4f, 0x63, 0xb9, 0xa9, 0x0a, 0x23, 0xc6, 0x4f, 0x55, 0xb8, 0x04, 0x08, 0xcb,
+	0x94, 0x28, 0xd1, 0xb8, 0xca, 0xdd, 0x48, 0x6f, 0xa5, 0x33, 0xa4, 0x6e, 0xbd, 0x18, 0x76, 0xdf,
+	0xf2, 0x7c, 0xe1, 0x8b, 0xb5, 0x48, 0x05, 0x12, 0x25, 0x4e, 0x2d, 0x5a, 0xc2, 0xd4, 0xd7, 0xa6,
+	0xf2, 0x08, 0x1d, 0x9e, 0x30, 0x1d, 0x1e, 0xe0, 0xfb, 0x69, 0x3a, 0x0c, 0xf9, 0x00, 0xaa, 0xc6,
+	0x5f, 0x68, 0xb0, 0x30, 0x51, 0x40, 0x44, 0x0f, 0x93, 0x17, 0x3a, 0x5e, 0xbb, 0xd4, 0x1f, 0x5d,
+	0xcb, 0x27, 0x54, 0xda, 0x64, 0x2a, 0x6d, 0xe0, 0xb5, 0xf4, 0x5d, 0x11, 0x0c, 0xa2, 0xfe, 0xf1,
+	0x2f, 0x79, 0xa8, 0xbc, 0x32, 0x2d, 0xdb, 0x27, 0x36, 0x7d, 0x42, 0x44, 0x67, 0x90, 0x67, 0x47,
+	0x83, 0x78, 0x3e, 0x50, 0xcb, 0x7a, 0xfa, 0x9d, 0xc4, 0x3e, 0xa1, 0xc1, 0x3a, 0xd3, 0xe0, 0x3e,
+	0xd6, 0x03, 0x0d, 0x06, 0xa1, 0xfc, 0x2d, 0x56, 0xaf, 0xa2, 0xf6, 0xb8, 0x80, 0x82, 0x78, 0xb8,
+	0x89, 0x49, 0x8b, 0xd4, 0xb1, 0xf4, 0xbb, 0xc9, 0x9d, 0xa9, 0x3e, 0xa8, 0x62, 0x79, 0x8c, 0x99,
+	0x82, 0xfd, 0x2e, 0x40, 0x58, 0x10, 0x8d, 0xef, 0xbe, 0x89, 0xfa, 0xa9, 0xde, 0x4a, 0x67, 0x48,
+	0x5d, 0x79, 0x15, 0xb8, 0x17, 0x0c, 0xa0, 0xe0, 0x5d, 0xc8, 0xd1, 0xcf, 0x24, 0x50, 0xec, 0x6c,
+	0xa0, 0x7c, 0x49, 0xa1, 0xeb, 0x49, 0x5d, 0x02, 0xea, 0x01, 0x83, 0x5a, 0xc1, 0xcb, 0x89, 0x50,
+	0xf4, 0x73, 0x09, 0x0a, 0x32, 0x82, 0x92, 0xfc, 0x3a, 0x02, 0xdd, 0x8b, 0xd9, 0x2c, 0xfa, 0x25,
+	0x85, 0xbe, 0x92, 0xd6, 0x2d, 0x00, 0x37, 0x18, 0x20, 0xc6, 0xf7, 0x92, 0x8d, 0x2a, 0xd8, 0xbf,
+	0xd4, 0x9e, 0xfc, 0x40, 0xa3, 0xce, 0x05, 0x61, 0xcd, 0x6d, 0xc2, 0xaf, 0xe3, 0x45, 0x3b, 0xbd,
+	0x95, 0xce, 0x20, 0xd0, 0xbf, 0x60, 0xe8, 0x9f, 0xe1, 0x8d, 0x44, 0x74, 0xdf, 0x35, 0x6d, 0xef,
+	0x3d, 0x71, 0x3f, 0xe3, 0x35, 0x45, 0xef, 0xdc, 0x1a, 0xd2, 0x5d, 0xfc, 0x27, 0x0d, 0xc8, 0xd1,
+	0xb3, 0x32, 0x3d, 0x63, 0x84, 0x25, 0x86, 0xb8, 0x3a, 0x13, 0x85, 0x3d, 0xbd, 0x95, 0xce, 0x90,
+	0x7a, 0xc6, 0x60, 0xdf, 0xbb, 0x13, 0xc6, 0x45, 0x0d, 0xef, 0x43, 0x45, 0x29, 0x44, 0xa0, 0x04,
+	0x89, 0xd1, 0xb2, 0xa1, 0xbe, 0x3a, 0x85, 0x43, 0x80, 0xb6, 0x18, 0xa8, 0x8e, 0x6f, 0x45, 0x41,
+	0x7b, 0x96, 0x27, 0x51, 0x7f, 0x0f, 0xaa, 0x6a, 0xc5, 0x02, 0x25, 0x08, 0x8d, 0xd5, 0x25, 0x75,
+	0x3c, 0x8d, 0x25, 0xd5, 0x77, 0x83, 0xaf, 0xfb, 0x25, 0x2f, 0x45, 0xff, 0x1a, 0x8a, 0xa2, 0x8e,
+	0x91, 0x34, 0xdf, 0x68, 0x25, 0x53, 0x5f, 0x9d, 0xc2, 0x91, 0x7a, 0x60, 0x65, 0xb0, 0x23, 0x2f,
+	0xcc, 0xa2, 0x02, 0xf2, 0x39, 0xf1, 0xd3, 0x20, 0xc3, 0xda, 0x9c, 0xbe, 0x3a, 0x85, 0xe3, 0x06,
+	0x90, 0x67, 0xc4, 0x17, 0x2e, 0x25, 0x2f, 0xa2, 0x28, 0x45, 0xa2, 0x9a, 0xb2, 0xf0, 0x34, 0x16,
+	0x81, 0x8a, 0x19, 0xea, 0x5d, 0xfc, 0x49, 0x02, 0xaa, 0xcc, 0x57, 0xbf, 0x0f, 0x10, 0x16, 0x5d,
+	0xd0, 0x5a, 0xb2, 0xd4, 0x48, 0xc1, 0x50, 0x7f, 0x30, 0x9d, 0x29, 0x35, 0x90, 0x84, 0xe0, 0xfc,
+	0x3b, 0x50, 0x0a, 0xff, 0x57, 0x1a, 0xa0, 0xc9, 0x22, 0x0d, 0x7a, 0x9a, 0x0c, 0x91, 0x58, 0x14,
+	0xd6, 0x3f, 0xbd, 0x19, 0x73, 0x6a, 0x10, 0x0f, 0xf5, 0xea, 0xb2, 0x21, 0xc3, 0x0f, 0x54, 0xb3,
+	0x5f, 0x68, 0x50, 0x8b, 0x94, 0x79, 0xd0, 0xc3, 0x64, 0x9c, 0x78, 0x61, 0x59, 0x7f, 0x74, 0x2d,
+	0x5f, 0xea, 0x91, 0x52, 0xd9, 0x15, 0xf2, 0x56, 0xf1, 0xc7, 0x1a, 0xd4, 0xa3, 0xb5, 0x21, 0x94,
+	0x02, 0x30, 0x51, 0x9d, 0xd6, 0x37, 0xae, 0x67, 0xbc, 0xc1, 0x6a, 0x85, 0x17, 0x8d, 0xaf, 0xa1,
+	0x28, 0x4a, 0x4a, 0x49, 0x6e, 0x11, 0x2d, 0x6e, 0xeb, 0xab, 0x53, 0x38, 0xa6, 0xbb, 0x85, 0xeb,
+	0xf4, 0x89, 0xe2, 0x89, 0xa2, 0xf0, 0x94, 0x06, 0x39, 0xdd, 0x13, 0x63, 0x55, 0xab, 0xa9, 0x90,
+	0xa1, 0x27, 0xca, 0xb2, 0x13, 0x4a, 0x91, 0x78, 0x8d, 0x27, 0xc6, 0xab, 0x56, 0x69, 0x9e, 0xc8,
+	0x50, 0x15, 0x4f, 0x0c, 0xab, 0x44, 0x49, 0x9e, 0x38, 0x51, 0xba, 0xd7, 0x1f, 0x4c, 0x67, 0x9a,
+	0xbe, 0xb6, 0x0c, 0x3c, 0xe2, 0x89, 0x8b, 0x09, 0x55, 0x25, 0xf4, 0x69, 0x8a, 0x4d, 0x13, 0x9f,
+	0x05, 0xf4, 0xcf, 0x6e, 0xc8, 0x3d, 0xdd, 0x03, 0xf8, 0x6a, 0x48, 0x0f, 0xf8, 0x5b, 0x0d, 0x96,
+	0x92, 0xca, 0x52, 0x28, 0x05, 0x2c, 0xe5, 0x4d, 0x41, 0xdf, 0xbc, 0x29, 0xfb, 0x0d, 0xec, 0x16,
+	0xf8, 0xc4, 0xb3, 0xea, 0xbf, 0x7e, 0xbb, 0xa2, 0xfd, 0xc7, 0xb7, 0x2b, 0xda, 0x7f, 0x7f, 0xbb,
+	0xa2, 0x9d, 0x16, 0xd8, 0x7f, 0x38, 0xfb, 0xe2, 0xff, 0x07, 0x00, 0x53, 0x46, 0x4d, 0xcb, 0xf7,
+	0x36, 0x00, 0x00,
 }
@@ -131,6 +131,14 @@ func (c *RaftCluster) MemberIDs() []types.ID {
 	return ids
 }
 
+// IsWitness returns true if the member with the given id is a witness.
+func (c *RaftCluster) IsWitness(id types.ID) bool {
+	c.Lock()
+	defer c.Unlock()
+	m, ok := c.members[id]
+	return ok && m.IsWitness
+}
+
 func (c *RaftCluster) IsIDRemoved(id types.ID) bool {
 	c.Lock()
 	defer c.Unlock()
@@ -225,8 +233,12 @@ func (c *RaftCluster) ValidateConfigurationChange(cc raftpb.ConfChange) error {
 		return ErrIDRemoved
 	}
 	switch cc.Type {
-	case raftpb.ConfChangeAddNode:
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode, raftpb.ConfChangeAddWitnessNode:
 		if members[id] != nil {
+			// adding an existing learner as a voting member promotes it.
+			if cc.Type == raftpb.ConfChangeAddNode && members[id].IsLearner {
+				return nil
+			}
 			return ErrIDExists
 		}
 		urls := make(map[string]bool)
@@ -271,7 +283,40 @@ func (c *RaftCluster) ValidateConfigurationChange(cc raftpb.ConfChange) error {
 			}
 		}
 	default:
-		plog.Panicf("ConfChange type should be either AddNode, RemoveNode or UpdateNode")
+		plog.Panicf("ConfChange type should be either AddNode, AddLearnerNode, AddWitnessNode, RemoveNode or UpdateNode")
+	}
+	return nil
+}
+
+// ValidateConfigurationChanges takes the changes of a joint configuration
+// change and verifies that each of them can be applied, that no member is
+// changed twice and that the added members do not share peer URLs.
+func (c *RaftCluster) ValidateConfigurationChanges(ccs []raftpb.ConfChange) error {
+	changed := make(map[uint64]bool)
+	urls := make(map[string]bool)
+	for _, cc := range ccs {
+		if changed[cc.NodeID] {
+			return ErrIDExists
+		}
+		changed[cc.NodeID] = true
+		if err := c.ValidateConfigurationChange(cc); err != nil {
+			return err
+		}
+		switch cc.Type {
+		case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode, raftpb.ConfChangeAddWitnessNode:
+		default:
+			continue
+		}
+		m := new(Member)
+		if err := json.Unmarshal(cc.Context, m); err != nil {
+			plog.Panicf("unmarshal member should never fail: %v", err)
+		}
+		for _, u := range m.PeerURLs {
+			if urls[u] {
+				return ErrPeerURLexists
+			}
+			urls[u] = true
+		}
 	}
 	return nil
 }
@@ -347,6 +392,22 @@ func (c *RaftCluster) UpdateRaftAttributes(id types.ID, raftAttr RaftAttributes)
 	plog.Noticef("updated member %s %v in cluster %s", id, raftAttr.PeerURLs, c.id)
 }
 
+// PromoteMember marks the learner with the given id as a voting member.
+func (c *RaftCluster) PromoteMember(id types.ID) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.members[id].RaftAttributes.IsLearner = false
+	if c.store != nil {
+		mustUpdateMemberInStore(c.store, c.members[id])
+	}
+	if c.be != nil {
+		mustSaveMemberToBackend(c.be, c.members[id])
+	}
+
+	plog.Noticef("promoted member %s in cluster %s", id, c.id)
+}
+
 func (c *RaftCluster) Version() *semver.Version {
 	c.Lock()
 	defer c.Unlock()
@@ -379,6 +440,9 @@ func (c *RaftCluster) IsReadyToAddNewMember() bool {
 	nstarted := 0
 
 	for _, member := range c.members {
+		if member.IsLearner {
+			continue
+		}
 		if member.IsStarted() {
 			nstarted++
 		}
@@ -403,11 +467,16 @@ func (c *RaftCluster) IsReadyToAddNewMember() bool {
 }
 
 func (c *RaftCluster) IsReadyToRemoveMember(id uint64) bool {
+	if m := c.members[types.ID(id)]; m != nil && m.IsLearner {
+		// learners do not count toward the quorum.
+		return true
+	}
+
 	nmembers := 0
 	nstarted := 0
 
 	for _, member := range c.members {
-		if uint64(member.ID) == id {
+		if uint64(member.ID) == id || member.IsLearner {
 			continue
 		}
 
@@ -426,6 +495,67 @@ func (c *RaftCluster) IsReadyToRemoveMember(id uint64) bool {
 	return true
 }
 
+// IsReadyToReconfigure returns true if a quorum of the voting members
+// would still be started after adding the given members and removing the
+// members with the given ids at once.
+func (c *RaftCluster) IsReadyToReconfigure(add []Member, remove []uint64) bool {
+	removed := make(map[types.ID]bool)
+	for _, id := range remove {
+		removed[types.ID(id)] = true
+	}
+	nmembers := 0
+	nstarted := 0
+
+	for _, member := range c.members {
+		if member.IsLearner || removed[member.ID] {
+			continue
+		}
+		if member.IsStarted() {
+			nstarted++
+		}
+		nmembers++
+	}
+	for _, m := range add {
+		if !m.IsLearner {
+			nmembers++
+		}
+	}
+
+	nquorum := nmembers/2 + 1
+	if nstarted < nquorum {
+		plog.Warningf("Reject reconfigure request: the number of started member (%d) will be less than the quorum number of the cluster (%d)", nstarted, nquorum)
+		return false
+	}
+
+	return true
+}
+
+func (c *RaftCluster) IsReadyToPromoteMember(id uint64) bool {
+	nmembers := 1
+	nstarted := 0
+
+	for _, member := range c.members {
+		if member.IsLearner {
+			if uint64(member.ID) == id && member.IsStarted() {
+				nstarted++
+			}
+			continue
+		}
+		if member.IsStarted() {
+			nstarted++
+		}
+		nmembers++
+	}
+
+	nquorum := nmembers/2 + 1
+	if nstarted < nquorum {
+		plog.Warningf("Reject promote member request: the number of started member (%d) will be less than the quorum number of the cluster (%d)", nstarted, nquorum)
+		return false
+	}
+
+	return true
+}
+
 func membersFromStore(st store.Store) (map[types.ID]*Member, map[types.ID]bool) {
 	members := make(map[types.ID]*Member)
 	removed := make(map[types.ID]bool)
@@ -281,8 +281,10 @@ func TestClusterValidateConfigurationChange(t *testing.T) {
 		cl.AddMember(&Member{ID: types.ID(i), RaftAttributes: attr})
 	}
 	cl.RemoveMember(4)
+	attr := RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 6)}, IsLearner: true}
+	cl.AddMember(&Member{ID: types.ID(6), RaftAttributes: attr})
 
-	attr := RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 1)}}
+	attr = RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 1)}}
 	ctx, err := json.Marshal(&Member{ID: types.ID(5), RaftAttributes: attr})
 	if err != nil {
 		t.Fatal(err)
@@ -294,6 +296,18 @@ func TestClusterValidateConfigurationChange(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	attr = RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 5)}, IsLearner: true}
+	ctx5learner, err := json.Marshal(&Member{ID: types.ID(5), RaftAttributes: attr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attr = RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 6)}}
+	ctx6, err := json.Marshal(&Member{ID: types.ID(6), RaftAttributes: attr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	attr = RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", 3)}}
 	ctx2to3, err := json.Marshal(&Member{ID: types.ID(2), RaftAttributes: attr})
 	if err != nil {
@@ -369,6 +383,61 @@ func TestClusterValidateConfigurationChange(t *testing.T) {
 			},
 			ErrIDNotFound,
 		},
+		{
+			raftpb.ConfChange{
+				Type:    raftpb.ConfChangeAddLearnerNode,
+				NodeID:  5,
+				Context: ctx5learner,
+			},
+			nil,
+		},
+		{
+			raftpb.ConfChange{
+				Type:    raftpb.ConfChangeAddLearnerNode,
+				NodeID:  5,
+				Context: ctx,
+			},
+			ErrPeerURLexists,
+		},
+		{
+			raftpb.ConfChange{
+				Type:    raftpb.ConfChangeAddWitnessNode,
+				NodeID:  5,
+				Context: ctx5,
+			},
+			nil,
+		},
+		{
+			raftpb.ConfChange{
+				Type:   raftpb.ConfChangeAddWitnessNode,
+				NodeID: 1,
+			},
+			ErrIDExists,
+		},
+		// promote learner 6
+		{
+			raftpb.ConfChange{
+				Type:    raftpb.ConfChangeAddNode,
+				NodeID:  6,
+				Context: ctx6,
+			},
+			nil,
+		},
+		{
+			raftpb.ConfChange{
+				Type:    raftpb.ConfChangeAddLearnerNode,
+				NodeID:  6,
+				Context: ctx6,
+			},
+			ErrIDExists,
+		},
+		{
+			raftpb.ConfChange{
+				Type:   raftpb.ConfChangeAddLearnerNode,
+				NodeID: 1,
+			},
+			ErrIDExists,
+		},
 		// try to change the peer url of 2 to the peer url of 3
 		{
 			raftpb.ConfChange{
@@ -395,6 +464,46 @@ func TestClusterValidateConfigurationChange(t *testing.T) {
 	}
 }
 
+func TestClusterValidateConfigurationChanges(t *testing.T) {
+	cl := NewCluster("")
+	cl.SetStore(store.New())
+	for i := 1; i <= 3; i++ {
+		attr := RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", i)}}
+		cl.AddMember(&Member{ID: types.ID(i), RaftAttributes: attr})
+	}
+	ctx := func(id, port int) []byte {
+		attr := RaftAttributes{PeerURLs: []string{fmt.Sprintf("http://127.0.0.1:%d", port)}}
+		b, err := json.Marshal(&Member{ID: types.ID(id), RaftAttributes: attr})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	add := func(id, port int) raftpb.ConfChange {
+		return raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: uint64(id), Context: ctx(id, port)}
+	}
+	remove := func(id int) raftpb.ConfChange {
+		return raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: uint64(id)}
+	}
+
+	tests := []struct {
+		ccs  []raftpb.ConfChange
+		werr error
+	}{
+		{[]raftpb.ConfChange{add(4, 4), add(5, 5), remove(1), remove(2)}, nil},
+		{[]raftpb.ConfChange{add(4, 4), remove(4)}, ErrIDExists},
+		{[]raftpb.ConfChange{add(4, 4), add(5, 4)}, ErrPeerURLexists},
+		{[]raftpb.ConfChange{add(4, 4), remove(5)}, ErrIDNotFound},
+		{[]raftpb.ConfChange{add(4, 1), remove(2)}, ErrPeerURLexists},
+	}
+	for i, tt := range tests {
+		err := cl.ValidateConfigurationChanges(tt.ccs)
+		if err != tt.werr {
+			t.Errorf("#%d: validateConfigurationChanges error = %v, want %v", i, err, tt.werr)
+		}
+	}
+}
+
 func TestClusterGenID(t *testing.T) {
 	cs := newTestCluster([]*Member{
 		newTestMember(1, nil, "", nil),
@@ -507,6 +616,32 @@ func TestClusterRemoveMember(t *testing.T) {
 	}
 }
 
+func TestClusterPromoteMember(t *testing.T) {
+	st := mockstore.NewRecorder()
+	m := newTestMember(1, nil, "node1", nil)
+	m.IsLearner = true
+	c := newTestCluster([]*Member{m})
+	c.SetStore(st)
+	c.PromoteMember(1)
+
+	if c.Member(1).IsLearner {
+		t.Errorf("isLearner = true, want false")
+	}
+	wactions := []testutil.Action{
+		{
+			Name: "Update",
+			Params: []interface{}{
+				path.Join(StoreMembersPrefix, "1", "raftAttributes"),
+				`{"peerURLs":null}`,
+				store.TTLOptionSet{ExpireTime: store.Permanent},
+			},
+		},
+	}
+	if g := st.Action(); !reflect.DeepEqual(g, wactions) {
+		t.Errorf("actions = %v, want %v", g, wactions)
+	}
+}
+
 func TestClusterUpdateAttributes(t *testing.T) {
 	name := "etcd"
 	clientURLs := []string{"http://127.0.0.1:4001"}
@@ -639,6 +774,28 @@ func TestIsReadyToAddNewMember(t *testing.T) {
 			[]*Member{},
 			false,
 		},
+		{
+			// 2/3 voting members ready, unstarted learners do not count
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "3", nil),
+				newTestLearner(4, nil, "", nil),
+				newTestLearner(5, nil, "", nil),
+			},
+			true,
+		},
+		{
+			// 1/3 voting members ready, started learners do not count
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "", nil),
+				newTestMember(3, nil, "", nil),
+				newTestLearner(4, nil, "4", nil),
+				newTestLearner(5, nil, "5", nil),
+			},
+			false,
+		},
 	}
 	for i, tt := range tests {
 		c := newTestCluster(tt.members)
@@ -724,6 +881,27 @@ func TestIsReadyToRemoveMember(t *testing.T) {
 			4,
 			true,
 		},
+		{
+			// 1/2 voting members ready, should be fine to remove a learner
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "", nil),
+				newTestLearner(3, nil, "3", nil),
+			},
+			3,
+			true,
+		},
+		{
+			// 1/2 voting members ready, started learners do not count
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "", nil),
+				newTestLearner(4, nil, "4", nil),
+			},
+			2,
+			false,
+		},
 	}
 	for i, tt := range tests {
 		c := newTestCluster(tt.members)
@@ -732,3 +910,129 @@ func TestIsReadyToRemoveMember(t *testing.T) {
 		}
 	}
 }
+
+func TestIsReadyToPromoteMember(t *testing.T) {
+	tests := []struct {
+		members   []*Member
+		promoteID uint64
+		want      bool
+	}{
+		{
+			// 1/1 members ready, should be fine to promote a started learner
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestLearner(2, nil, "2", nil),
+			},
+			2,
+			true,
+		},
+		{
+			// 1/1 members ready, should fail to promote an unstarted learner
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestLearner(2, nil, "", nil),
+			},
+			2,
+			false,
+		},
+		{
+			// 2/3 members ready, should fail to promote an unstarted learner
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "", nil),
+				newTestLearner(4, nil, "", nil),
+			},
+			4,
+			false,
+		},
+		{
+			// 2/3 members ready, should be fine to promote a started learner
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "", nil),
+				newTestLearner(4, nil, "4", nil),
+				newTestLearner(5, nil, "5", nil),
+			},
+			4,
+			true,
+		},
+	}
+	for i, tt := range tests {
+		c := newTestCluster(tt.members)
+		if got := c.IsReadyToPromoteMember(tt.promoteID); got != tt.want {
+			t.Errorf("%d: isReadyToPromoteMember returned %t, want %t", i, got, tt.want)
+		}
+	}
+}
+
+func TestIsReadyToReconfigure(t *testing.T) {
+	tests := []struct {
+		members []*Member
+		add     []Member
+		remove  []uint64
+		want    bool
+	}{
+		{
+			// 3/3 members ready, should fail to replace two members at once
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "3", nil),
+			},
+			[]Member{*newTestMember(4, nil, "", nil), *newTestMember(5, nil, "", nil)},
+			[]uint64{2, 3},
+			false,
+		},
+		{
+			// 3/3 members ready, should be fine to replace a member
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "3", nil),
+			},
+			[]Member{*newTestMember(4, nil, "", nil)},
+			[]uint64{3},
+			true,
+		},
+		{
+			// 2/3 members ready, should fail to replace a started member
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "", nil),
+			},
+			[]Member{*newTestMember(4, nil, "", nil)},
+			[]uint64{2},
+			false,
+		},
+		{
+			// 2/3 members ready, should be fine to replace the unstarted member
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestMember(2, nil, "2", nil),
+				newTestMember(3, nil, "", nil),
+			},
+			[]Member{*newTestMember(4, nil, "", nil)},
+			[]uint64{3},
+			true,
+		},
+		{
+			// learners do not count toward the quorum
+			[]*Member{
+				newTestMember(1, nil, "1", nil),
+				newTestLearner(2, nil, "", nil),
+			},
+			[]Member{*newTestLearner(3, nil, "", nil)},
+			[]uint64{2},
+			true,
+		},
+	}
+	for i, tt := range tests {
+		c := newTestCluster(tt.members)
+		if got := c.IsReadyToReconfigure(tt.add, tt.remove); got != tt.want {
+			t.Errorf("%d: isReadyToReconfigure returned %t, want %t", i, got, tt.want)
+		}
+	}
+}
@@ -25,6 +25,8 @@ var (
 	ErrIDExists      = errors.New("membership: ID exists")
 	ErrIDNotFound    = errors.New("membership: ID not found")
 	ErrPeerURLexists = errors.New("membership: peerURL exists")
+
+	ErrMemberNotLearner = errors.New("membership: can only promote a learner member")
 )
 
 func isKeyNotFound(err error) bool {
@@ -35,6 +35,12 @@ type RaftAttributes struct {
 	// PeerURLs is the list of peers in the raft cluster.
 	// TODO(philips): ensure these are URLs
 	PeerURLs []string `json:"peerURLs"`
+	// IsLearner indicates if the member is a raft learner, which receives
+	// the log but does not vote or count toward the quorum.
+	IsLearner bool `json:"isLearner,omitempty"`
+	// IsWitness indicates if the member is a witness, which votes and counts
+	// toward the quorum but keeps no application state and never leads.
+	IsWitness bool `json:"isWitness,omitempty"`
 }
 
 // Attributes represents all the non-raft related attributes of an etcd member.
@@ -73,6 +79,24 @@ func NewMember(name string, peerURLs types.URLs, clusterName string, now *time.T
 	return m
 }
 
+// NewMemberAsLearner creates a learner Member without an ID and generates
+// one the same way as NewMember. This is used for adding a new member that
+// does not vote until it is promoted.
+func NewMemberAsLearner(name string, peerURLs types.URLs, clusterName string, now *time.Time) *Member {
+	m := NewMember(name, peerURLs, clusterName, now)
+	m.IsLearner = true
+	return m
+}
+
+// NewMemberAsWitness creates a witness Member without an ID and generates
+// one the same way as NewMember. This is used for adding a new member that
+// only takes part in elections and commits.
+func NewMemberAsWitness(name string, peerURLs types.URLs, clusterName string, now *time.Time) *Member {
+	m := NewMember(name, peerURLs, clusterName, now)
+	m.IsWitness = true
+	return m
+}
+
 // PickPeerURL chooses a random address from a given Member's PeerURLs.
 // It will panic if there is no PeerURLs available in Member.
 func (m *Member) PickPeerURL() string {
@@ -88,6 +112,10 @@ func (m *Member) Clone() *Member {
 	}
 	mm := &Member{
 		ID: m.ID,
+		RaftAttributes: RaftAttributes{
+			IsLearner: m.IsLearner,
+			IsWitness: m.IsWitness,
+		},
 		Attributes: Attributes{
 			Name: m.Name,
 		},
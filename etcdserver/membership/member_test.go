@@ -94,6 +94,8 @@ func TestMemberClone(t *testing.T) {
 		newTestMember(1, []string{"http://a"}, "abc", nil),
 		newTestMember(1, nil, "abc", []string{"http://b"}),
 		newTestMember(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		newTestLearner(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		newTestWitness(1, []string{"http://a"}, "abc", []string{"http://b"}),
 	}
 	for i, tt := range tests {
 		nm := tt.Clone()
@@ -113,3 +115,15 @@ func newTestMember(id uint64, peerURLs []string, name string, clientURLs []strin
 		Attributes:     Attributes{Name: name, ClientURLs: clientURLs},
 	}
 }
+
+func newTestLearner(id uint64, peerURLs []string, name string, clientURLs []string) *Member {
+	m := newTestMember(id, peerURLs, name, clientURLs)
+	m.IsLearner = true
+	return m
+}
+
+func newTestWitness(id uint64, peerURLs []string, name string, clientURLs []string) *Member {
+	m := newTestMember(id, peerURLs, name, clientURLs)
+	m.IsWitness = true
+	return m
+}
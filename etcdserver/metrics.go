@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/pkg/runtime"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -58,6 +60,23 @@ var (
 		Name:      "proposals_failed_total",
 		Help:      "The total number of failed proposals seen.",
 	})
+	followerInflightBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "follower_inflight_bytes",
+		Help:      "The bytes of log entries sent by the leader to a follower and not acknowledged yet.",
+	},
+		[]string{"To"},
+	)
+	readyPersistDurations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "raft_ready_persist_duration_seconds",
+		Help:      "The latency distributions of persisting a raft Ready until its messages are sent, by fsync mode.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	},
+		[]string{"Mode"},
+	)
 )
 
 func init() {
@@ -67,6 +86,8 @@ func init() {
 	prometheus.MustRegister(proposalsApplied)
 	prometheus.MustRegister(proposalsPending)
 	prometheus.MustRegister(proposalsFailed)
+	prometheus.MustRegister(followerInflightBytes)
+	prometheus.MustRegister(readyPersistDurations)
 }
 
 func monitorFileDescriptor(done <-chan struct{}) {
@@ -93,3 +114,26 @@ func monitorFileDescriptor(done <-chan struct{}) {
 		}
 	}
 }
+
+// monitorInflightBytes exports the bytes in flight to each follower while the
+// member is the leader.
+func monitorInflightBytes(n raft.Node, done <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+		st := n.Status()
+		// only the leader tracks the followers.
+		followerInflightBytes.Reset()
+		for id, pr := range st.Progress {
+			if id == st.ID {
+				continue
+			}
+			followerInflightBytes.WithLabelValues(types.ID(id).String()).Set(float64(pr.InflightBytes()))
+		}
+	}
+}
@@ -87,6 +87,9 @@ func (b *backendQuota) Cost(v interface{}) int {
 func costPut(r *pb.PutRequest) int { return kvOverhead + len(r.Key) + len(r.Value) }
 
 func costTxnReq(u *pb.RequestOp) int {
+	if t := u.GetRequestTxn(); t != nil {
+		return costTxn(t)
+	}
 	r := u.GetRequestPut()
 	if r == nil {
 		return 0
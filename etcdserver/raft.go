@@ -49,6 +49,16 @@ const (
 	// Never overflow the rafthttp buffer, which is 4096.
 	// TODO: a better const?
 	maxInflightMsgs = 4096 / 8
+	// Large entries fill the inflight messages with far more data than
+	// small ones, so the bytes in flight to a follower are limited as well.
+	maxInflightBytes = 32 * maxSizePerMsg
+	// The leader rejects proposals once its uncommitted entries reach this
+	// size, instead of buffering them for a slow quorum until it runs out
+	// of memory.
+	maxUncommittedEntriesSize = 1024 * 1024 * 1024
+	// The number of written Readys that may wait for an fsync before the
+	// raft routine blocks.
+	maxUnsyncedReadys = 1024
 )
 
 var (
@@ -86,6 +96,18 @@ type apply struct {
 	raftDone <-chan struct{} // rx {} after raft has persisted messages
 }
 
+// unsynced holds the messages of a Ready whose writes to the WAL are not on
+// stable storage yet. The messages are sent and raftDone is signaled once
+// they are.
+type unsynced struct {
+	msgs     []raftpb.Message
+	raftDone chan<- struct{}
+	// written is false if the Ready wrote nothing.
+	written bool
+	// start is when the Ready was received.
+	start time.Time
+}
+
 type raftNode struct {
 	// Cache of the latest raft index and raft term the server has seen.
 	// These three unit64 fields must be the first elements to keep 64-bit
@@ -103,6 +125,9 @@ type raftNode struct {
 	// a chan to send out apply
 	applyc chan apply
 
+	// a chan to send out readState
+	readStateC chan raft.ReadState
+
 	// TODO: remove the etcdserver related logic from raftNode
 	// TODO: add a state machine interface to apply the commit entries
 	// and do snapshot/recover
@@ -120,6 +145,12 @@ type raftNode struct {
 
 	td *contention.TimeoutDetector
 
+	// syncc queues the Readys for the sync routine when fsync is
+	// asynchronous; it is nil otherwise.
+	syncc     chan unsynced
+	syncStopc chan struct{}
+	syncDone  chan struct{}
+
 	stopped chan struct{}
 	done    chan struct{}
 }
@@ -130,6 +161,7 @@ type raftNode struct {
 func (r *raftNode) start(s *EtcdServer) {
 	r.s = s
 	r.applyc = make(chan apply)
+	r.readStateC = make(chan raft.ReadState, 1)
 	r.stopped = make(chan struct{})
 	r.done = make(chan struct{})
 
@@ -141,6 +173,13 @@ func (r *raftNode) start(s *EtcdServer) {
 	// expect to send a heartbeat within 2 heartbeat intervals.
 	r.td = contention.NewTimeoutDetector(2 * heartbeat)
 
+	if s.Cfg != nil && s.Cfg.AsyncFsync {
+		r.syncc = make(chan unsynced, maxUnsyncedReadys)
+		r.syncStopc = make(chan struct{})
+		r.syncDone = make(chan struct{})
+		go r.syncLoop()
+	}
+
 	go func() {
 		var syncC <-chan time.Time
 
@@ -152,6 +191,7 @@ func (r *raftNode) start(s *EtcdServer) {
 			case <-r.ticker:
 				r.Tick()
 			case rd := <-r.Ready():
+				start := time.Now()
 				if rd.SoftState != nil {
 					if lead := atomic.LoadUint64(&r.lead); rd.SoftState.Lead != raft.None && lead != rd.SoftState.Lead {
 						r.mu.Lock()
@@ -196,6 +236,27 @@ func (r *raftNode) start(s *EtcdServer) {
 					}
 				}
 
+				for i := range rd.Cuts {
+					r.s.w.Trigger(rd.Cuts[i].ID, &rd.Cuts[i])
+				}
+
+				// only the latest read state matters; the read routine has
+				// at most one read index request outstanding. A read state
+				// nobody took is out of date, so replace it rather than
+				// blocking raft.
+				if len(rd.ReadStates) != 0 {
+					rs := rd.ReadStates[len(rd.ReadStates)-1]
+					select {
+					case r.readStateC <- rs:
+					default:
+						select {
+						case <-r.readStateC:
+						default:
+						}
+						r.readStateC <- rs
+					}
+				}
+
 				raftDone := make(chan struct{}, 1)
 				ap := apply{
 					entries:  rd.CommittedEntries,
@@ -217,8 +278,19 @@ func (r *raftNode) start(s *EtcdServer) {
 					r.s.send(rd.Messages)
 				}
 
+				// only a follower defers its fsync. raft counts the entries of the
+				// leader as matched by the leader as soon as they are appended,
+				// so the leader must not move on to the next Ready before its
+				// entries are durable, or it could commit them ahead of its own
+				// disk.
+				async := r.syncc != nil && !islead
+
 				// gofail: var raftBeforeSave struct{}
-				if err := r.storage.Save(rd.HardState, rd.Entries); err != nil {
+				if async {
+					if err := r.storage.SaveNoSync(rd.HardState, rd.Entries); err != nil {
+						plog.Fatalf("raft write state and entries error: %v", err)
+					}
+				} else if err := r.storage.Save(rd.HardState, rd.Entries); err != nil {
 					plog.Fatalf("raft save state and entries error: %v", err)
 				}
 				if !raft.IsEmptyHardState(rd.HardState) {
@@ -239,11 +311,31 @@ func (r *raftNode) start(s *EtcdServer) {
 
 				r.raftStorage.Append(rd.Entries)
 
-				if !islead {
-					// gofail: var raftBeforeFollowerSend struct{}
-					r.s.send(rd.Messages)
+				if async {
+					// the sync routine sends the messages of the follower and
+					// signals raftDone once the writes they acknowledge are
+					// durable, so raft moves on to the next Ready without
+					// waiting for fsync. Readys that wrote nothing are queued
+					// too, so that raftDone is signaled in order.
+					u := unsynced{
+						msgs:     rd.Messages,
+						raftDone: raftDone,
+						written:  !raft.IsEmptyHardState(rd.HardState) || len(rd.Entries) != 0,
+						start:    start,
+					}
+					select {
+					case r.syncc <- u:
+					case <-r.stopped:
+						return
+					}
+				} else {
+					if !islead {
+						// gofail: var raftBeforeFollowerSend struct{}
+						r.s.send(rd.Messages)
+					}
+					readyPersistDurations.WithLabelValues("sync").Observe(time.Since(start).Seconds())
+					raftDone <- struct{}{}
 				}
-				raftDone <- struct{}{}
 				r.Advance()
 			case <-syncC:
 				r.s.sync(r.s.Cfg.ReqTimeout())
@@ -254,6 +346,46 @@ func (r *raftNode) start(s *EtcdServer) {
 	}()
 }
 
+// syncLoop makes the Readys written by the raft routine of a follower
+// durable and then sends their messages. A single fsync covers all the Readys queued up
+// while the previous one was running.
+func (r *raftNode) syncLoop() {
+	defer close(r.syncDone)
+	for {
+		var us []unsynced
+		select {
+		case u := <-r.syncc:
+			us = append(us, u)
+		case <-r.syncStopc:
+			return
+		}
+	drain:
+		for {
+			select {
+			case u := <-r.syncc:
+				us = append(us, u)
+			default:
+				break drain
+			}
+		}
+
+		for _, u := range us {
+			if u.written {
+				if err := r.storage.Sync(); err != nil {
+					plog.Fatalf("raft sync state and entries error: %v", err)
+				}
+				break
+			}
+		}
+		for _, u := range us {
+			// gofail: var raftBeforeFollowerSyncedSend struct{}
+			r.s.send(u.msgs)
+			readyPersistDurations.WithLabelValues("async").Observe(time.Since(u.start).Seconds())
+			u.raftDone <- struct{}{}
+		}
+	}
+}
+
 func (r *raftNode) apply() chan apply {
 	return r.applyc
 }
@@ -271,6 +403,10 @@ func (r *raftNode) stop() {
 
 func (r *raftNode) onStop() {
 	r.Stop()
+	if r.syncc != nil {
+		close(r.syncStopc)
+		<-r.syncDone
+	}
 	r.transport.Stop()
 	if err := r.storage.Close(); err != nil {
 		plog.Panicf("raft close storage error: %v", err)
@@ -323,13 +459,22 @@ func startNode(cfg *ServerConfig, cl *membership.RaftCluster, ids []types.ID) (i
 	plog.Infof("starting member %s in cluster %s", id, cl.ID())
 	s = raft.NewMemoryStorage()
 	c := &raft.Config{
-		ID:              uint64(id),
-		ElectionTick:    cfg.ElectionTicks,
-		HeartbeatTick:   1,
-		Storage:         s,
-		MaxSizePerMsg:   maxSizePerMsg,
-		MaxInflightMsgs: maxInflightMsgs,
-		CheckQuorum:     true,
+		ID:                        uint64(id),
+		ElectionTick:              cfg.ElectionTicks,
+		HeartbeatTick:             1,
+		Storage:                   s,
+		MaxSizePerMsg:             maxSizePerMsg,
+		MaxInflightMsgs:           maxInflightMsgs,
+		MaxInflightBytes:          maxInflightBytes,
+		MaxUncommittedEntriesSize: maxUncommittedEntriesSize,
+		CheckQuorum:               true,
+		PreVote:                   cfg.PreVote,
+		Witness:                   cfg.Witness,
+		Dinv:                      cfg.Dinv,
+		Faults:                    cfg.RaftFaults,
+		BugLog:                    cfg.RaftBugLog,
+		Tracer:                    cfg.RaftTracer,
+		StateTracker:              cfg.RaftStateTracker,
 	}
 
 	n = raft.StartNode(c, peers)
@@ -357,13 +502,22 @@ func restartNode(cfg *ServerConfig, snapshot *raftpb.Snapshot) (types.ID, *membe
 	s.SetHardState(st)
 	s.Append(ents)
 	c := &raft.Config{
-		ID:              uint64(id),
-		ElectionTick:    cfg.ElectionTicks,
-		HeartbeatTick:   1,
-		Storage:         s,
-		MaxSizePerMsg:   maxSizePerMsg,
-		MaxInflightMsgs: maxInflightMsgs,
-		CheckQuorum:     true,
+		ID:                        uint64(id),
+		ElectionTick:              cfg.ElectionTicks,
+		HeartbeatTick:             1,
+		Storage:                   s,
+		MaxSizePerMsg:             maxSizePerMsg,
+		MaxInflightMsgs:           maxInflightMsgs,
+		MaxInflightBytes:          maxInflightBytes,
+		MaxUncommittedEntriesSize: maxUncommittedEntriesSize,
+		CheckQuorum:               true,
+		PreVote:                   cfg.PreVote,
+		Witness:                   cfg.Witness,
+		Dinv:                      cfg.Dinv,
+		Faults:                    cfg.RaftFaults,
+		BugLog:                    cfg.RaftBugLog,
+		Tracer:                    cfg.RaftTracer,
+		StateTracker:              cfg.RaftStateTracker,
 	}
 
 	n := raft.RestartNode(c)
@@ -413,12 +567,20 @@ func restartAsStandaloneNode(cfg *ServerConfig, snapshot *raftpb.Snapshot) (type
 	s.SetHardState(st)
 	s.Append(ents)
 	c := &raft.Config{
-		ID:              uint64(id),
-		ElectionTick:    cfg.ElectionTicks,
-		HeartbeatTick:   1,
-		Storage:         s,
-		MaxSizePerMsg:   maxSizePerMsg,
-		MaxInflightMsgs: maxInflightMsgs,
+		ID:                        uint64(id),
+		ElectionTick:              cfg.ElectionTicks,
+		HeartbeatTick:             1,
+		Storage:                   s,
+		MaxSizePerMsg:             maxSizePerMsg,
+		MaxInflightMsgs:           maxInflightMsgs,
+		MaxInflightBytes:          maxInflightBytes,
+		MaxUncommittedEntriesSize: maxUncommittedEntriesSize,
+		CheckQuorum:               true,
+		Dinv:                      cfg.Dinv,
+		Faults:                    cfg.RaftFaults,
+		BugLog:                    cfg.RaftBugLog,
+		Tracer:                    cfg.RaftTracer,
+		StateTracker:              cfg.RaftStateTracker,
 	}
 	n := raft.RestartNode(c)
 	raftStatus = n.Status
@@ -428,23 +590,43 @@ func restartAsStandaloneNode(cfg *ServerConfig, snapshot *raftpb.Snapshot) (type
 // getIDs returns an ordered set of IDs included in the given snapshot and
 // the entries. The given snapshot/entries can contain two kinds of
 // ID-related entry:
-// - ConfChangeAddNode, in which case the contained ID will be added into the set.
+// - ConfChangeAddNode, ConfChangeAddLearnerNode or ConfChangeAddWitnessNode, in which case the contained ID will be added into the set.
 // - ConfChangeRemoveNode, in which case the contained ID will be removed from the set.
+// The IDs added by a ConfChangeV2 are added into the set too. The IDs it
+// removes are kept, as they are only removed from the cluster when the joint
+// configuration is left. The outgoing voters of a joint configuration in the
+// snapshot are kept for the same reason.
 func getIDs(snap *raftpb.Snapshot, ents []raftpb.Entry) []uint64 {
 	ids := make(map[uint64]bool)
 	if snap != nil {
 		for _, id := range snap.Metadata.ConfState.Nodes {
 			ids[id] = true
 		}
+		for _, id := range snap.Metadata.ConfState.Learners {
+			ids[id] = true
+		}
+		for _, id := range snap.Metadata.ConfState.NodesJoint {
+			ids[id] = true
+		}
 	}
 	for _, e := range ents {
+		if e.Type == raftpb.EntryConfChangeV2 {
+			var cc raftpb.ConfChangeV2
+			pbutil.MustUnmarshal(&cc, e.Data)
+			for _, c := range cc.Changes {
+				if c.Type != raftpb.ConfChangeRemoveNode && c.Type != raftpb.ConfChangeUpdateNode {
+					ids[c.NodeID] = true
+				}
+			}
+			continue
+		}
 		if e.Type != raftpb.EntryConfChange {
 			continue
 		}
 		var cc raftpb.ConfChange
 		pbutil.MustUnmarshal(&cc, e.Data)
 		switch cc.Type {
-		case raftpb.ConfChangeAddNode:
+		case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode, raftpb.ConfChangeAddWitnessNode:
 			ids[cc.NodeID] = true
 		case raftpb.ConfChangeRemoveNode:
 			delete(ids, cc.NodeID)
@@ -27,6 +27,8 @@ import (
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/rafthttp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func TestGetIDs(t *testing.T) {
@@ -37,6 +39,15 @@ func TestGetIDs(t *testing.T) {
 	normalEntry := raftpb.Entry{Type: raftpb.EntryNormal}
 	updatecc := &raftpb.ConfChange{Type: raftpb.ConfChangeUpdateNode, NodeID: 2}
 	updateEntry := raftpb.Entry{Type: raftpb.EntryConfChange, Data: pbutil.MustMarshal(updatecc)}
+	addLearnercc := &raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 3}
+	addLearnerEntry := raftpb.Entry{Type: raftpb.EntryConfChange, Data: pbutil.MustMarshal(addLearnercc)}
+	addWitnesscc := &raftpb.ConfChange{Type: raftpb.ConfChangeAddWitnessNode, NodeID: 5}
+	addWitnessEntry := raftpb.Entry{Type: raftpb.EntryConfChange, Data: pbutil.MustMarshal(addWitnesscc)}
+	jointcc := &raftpb.ConfChangeV2{Changes: []raftpb.ConfChangeSingle{
+		{Type: raftpb.ConfChangeAddNode, NodeID: 4},
+		{Type: raftpb.ConfChangeRemoveNode, NodeID: 1},
+	}}
+	jointEntry := raftpb.Entry{Type: raftpb.EntryConfChangeV2, Data: pbutil.MustMarshal(jointcc)}
 
 	tests := []struct {
 		confState *raftpb.ConfState
@@ -57,6 +68,16 @@ func TestGetIDs(t *testing.T) {
 			[]raftpb.Entry{addEntry, normalEntry, updateEntry}, []uint64{1, 2}},
 		{&raftpb.ConfState{Nodes: []uint64{1}},
 			[]raftpb.Entry{addEntry, removeEntry, normalEntry}, []uint64{1}},
+		{&raftpb.ConfState{Nodes: []uint64{1}, Learners: []uint64{2}},
+			[]raftpb.Entry{}, []uint64{1, 2}},
+		{&raftpb.ConfState{Nodes: []uint64{1}},
+			[]raftpb.Entry{addEntry, addLearnerEntry}, []uint64{1, 2, 3}},
+		{&raftpb.ConfState{Nodes: []uint64{1}},
+			[]raftpb.Entry{addWitnessEntry}, []uint64{1, 5}},
+		{&raftpb.ConfState{Nodes: []uint64{1}},
+			[]raftpb.Entry{jointEntry}, []uint64{1, 4}},
+		{&raftpb.ConfState{Nodes: []uint64{2}, NodesJoint: []uint64{1}},
+			[]raftpb.Entry{}, []uint64{1, 2}},
 	}
 
 	for i, tt := range tests {
@@ -174,3 +195,199 @@ func TestStopRaftWhenWaitingForApplyDone(t *testing.T) {
 		t.Fatalf("failed to stop raft loop")
 	}
 }
+
+// TestAsyncFsyncSendAfterSync tests that with AsyncFsync a follower sends the
+// messages of a Ready only once its writes are synced.
+func TestAsyncFsyncSendAfterSync(t *testing.T) {
+	n := newNopReadyNode()
+	st := mockstorage.NewStorageRecorderStream("")
+	tr := newSendTransporter()
+	srv := &EtcdServer{
+		Cfg:     &ServerConfig{AsyncFsync: true},
+		cluster: membership.NewCluster("abc"),
+		r: raftNode{
+			Node:        n,
+			storage:     st,
+			raftStorage: raft.NewMemoryStorage(),
+			transport:   tr,
+		},
+	}
+	srv.r.start(srv)
+	n.readyc <- raft.Ready{
+		HardState: raftpb.HardState{Term: 1, Commit: 1},
+		Entries:   []raftpb.Entry{{Index: 1, Term: 1}},
+		Messages:  []raftpb.Message{{To: 2, Type: raftpb.MsgAppResp, Index: 1}},
+	}
+	ap := <-srv.r.applyc
+
+	for _, w := range []string{"SaveNoSync", "Sync"} {
+		select {
+		case <-tr.sendc:
+			t.Fatalf("unexpected send before the sync")
+		case a := <-st.Chan():
+			if a.Name != w {
+				t.Fatalf("action = %s, want %s", a.Name, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failed to wait for %s", w)
+		}
+	}
+	// the sync routine sends the messages once the sync returns.
+	select {
+	case ms := <-tr.sendc:
+		if len(ms) != 1 || ms[0].Type != raftpb.MsgAppResp {
+			t.Errorf("sent = %+v, want the MsgAppResp", ms)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("failed to send the messages")
+	}
+	select {
+	case <-ap.raftDone:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to signal raftDone after the sync")
+	}
+
+	srv.r.stopped <- struct{}{}
+	select {
+	case <-srv.r.done:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to stop raft loop")
+	}
+}
+
+// TestAsyncFsyncLeaderSave tests that with AsyncFsync the leader still syncs
+// its writes before raft moves on to the next Ready.
+func TestAsyncFsyncLeaderSave(t *testing.T) {
+	n := newNopReadyNode()
+	st := mockstorage.NewStorageRecorderStream("")
+	tr := newSendTransporter()
+	srv := &EtcdServer{
+		Cfg:     &ServerConfig{AsyncFsync: true},
+		cluster: membership.NewCluster("abc"),
+		r: raftNode{
+			Node:        n,
+			storage:     st,
+			raftStorage: raft.NewMemoryStorage(),
+			transport:   tr,
+		},
+	}
+	srv.r.start(srv)
+	n.readyc <- raft.Ready{
+		SoftState: &raft.SoftState{Lead: 1, RaftState: raft.StateLeader},
+		HardState: raftpb.HardState{Term: 1, Commit: 1},
+		Entries:   []raftpb.Entry{{Index: 1, Term: 1}},
+		Messages:  []raftpb.Message{{To: 2, Type: raftpb.MsgApp, Index: 1}},
+	}
+	ap := <-srv.r.applyc
+
+	// the leader sends in parallel with its write.
+	select {
+	case <-tr.sendc:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to send the messages")
+	}
+	select {
+	case a := <-st.Chan():
+		if a.Name != "Save" {
+			t.Fatalf("action = %s, want Save", a.Name)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("failed to wait for Save")
+	}
+	select {
+	case <-ap.raftDone:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to signal raftDone")
+	}
+
+	srv.r.stopped <- struct{}{}
+	select {
+	case <-srv.r.done:
+	case <-time.After(time.Second):
+		t.Fatalf("failed to stop raft loop")
+	}
+}
+
+// TestReadyPersistDurations tests that the leader observes the time it takes
+// to persist a Ready under the sync mode and a follower with AsyncFsync under
+// the async mode.
+func TestReadyPersistDurations(t *testing.T) {
+	tests := []struct {
+		state raft.StateType
+		wmode string
+	}{
+		{raft.StateLeader, "sync"},
+		{raft.StateFollower, "async"},
+	}
+	for i, tt := range tests {
+		n := newNopReadyNode()
+		srv := &EtcdServer{
+			Cfg:     &ServerConfig{AsyncFsync: true},
+			cluster: membership.NewCluster("abc"),
+			r: raftNode{
+				Node:        n,
+				storage:     mockstorage.NewStorageRecorder(""),
+				raftStorage: raft.NewMemoryStorage(),
+				transport:   newSendTransporter(),
+			},
+		}
+		counts := make(map[string]uint64)
+		for _, m := range []string{"sync", "async"} {
+			counts[m] = readyPersistCount(t, m)
+		}
+		srv.r.start(srv)
+		n.readyc <- raft.Ready{
+			SoftState: &raft.SoftState{Lead: 1, RaftState: tt.state},
+			HardState: raftpb.HardState{Term: 1, Commit: 1},
+			Entries:   []raftpb.Entry{{Index: 1, Term: 1}},
+			Messages:  []raftpb.Message{{To: 2, Type: raftpb.MsgApp, Index: 1}},
+		}
+		ap := <-srv.r.applyc
+		select {
+		case <-ap.raftDone:
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: failed to signal raftDone", i)
+		}
+
+		for _, m := range []string{"sync", "async"} {
+			w := counts[m]
+			if m == tt.wmode {
+				w++
+			}
+			if c := readyPersistCount(t, m); c != w {
+				t.Errorf("#%d: %s observations = %d, want %d", i, m, c, w)
+			}
+		}
+
+		srv.r.stopped <- struct{}{}
+		select {
+		case <-srv.r.done:
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: failed to stop raft loop", i)
+		}
+	}
+}
+
+// readyPersistCount returns the number of persist durations observed under
+// the given fsync mode.
+func readyPersistCount(t *testing.T, mode string) uint64 {
+	m := &dto.Metric{}
+	if err := readyPersistDurations.WithLabelValues(mode).(prometheus.Histogram).Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+type sendTransporter struct {
+	rafthttp.Transporter
+	sendc chan []raftpb.Message
+}
+
+func newSendTransporter() *sendTransporter {
+	return &sendTransporter{
+		Transporter: rafthttp.NewNopTransporter(),
+		sendc:       make(chan []raftpb.Message, 1),
+	}
+}
+
+func (s *sendTransporter) Send(ms []raftpb.Message) { s.sendc <- ms }
@@ -75,6 +75,14 @@ const (
 	maxInFlightMsgSnap = 16
 
 	releaseDelayAfterSnapshot = 30 * time.Second
+
+	// a learner can be promoted once its log has at least this fraction of
+	// the entries of the log of the leader.
+	learnerReadyPercent = 0.9
+
+	// witnessMembersRetryInterval is the time a witness waits before it
+	// fetches the members added in a snapshot from its peers again.
+	witnessMembersRetryInterval = time.Second
 )
 
 var (
@@ -134,6 +142,17 @@ type Server interface {
 	// return ErrIDNotFound if the member ID does not exist.
 	UpdateMember(ctx context.Context, updateMemb membership.Member) error
 
+	// PromoteMember attempts to promote a learner to a voting member. It will
+	// return ErrIDNotFound if the member ID does not exist, or return
+	// ErrMemberNotLearner if the member is not a learner. A follower forwards
+	// the request to the leader.
+	PromoteMember(ctx context.Context, id uint64) error
+
+	// ReconfigureMembers attempts to add and remove the given members at
+	// once, through a joint configuration. It returns the same errors as
+	// AddMember and RemoveMember for each of the changes.
+	ReconfigureMembers(ctx context.Context, add []membership.Member, remove []uint64) error
+
 	// ClusterVersion is the cluster-wide minimum major.minor version.
 	// Cluster version is set to the min version that an etcd member is
 	// compatible with when first bootstrap.
@@ -168,6 +187,7 @@ type EtcdServer struct {
 
 	w          wait.Wait
 	stop       chan struct{}
+	stopping   chan struct{}
 	done       chan struct{}
 	errorc     chan error
 	id         types.ID
@@ -179,6 +199,18 @@ type EtcdServer struct {
 
 	applyV2 ApplierV2
 
+	readMu sync.RWMutex
+	// read routine notifies etcd server that it waits for reading by sending an empty struct to
+	// readwaitc
+	readwaitc chan struct{}
+	// readNotifier is used to notify the read routine that it can process the request
+	// when there is no error
+	readNotifier *notifier
+	// applyWait waits for the applied index to reach a given index.
+	applyWait wait.WaitIndex
+	// batcher coalesces the small V3 requests proposed concurrently.
+	batcher *batcher
+
 	applyV3    applierV3
 	kv         mvcc.ConsistentWatchableKV
 	lessor     lease.Lessor
@@ -208,6 +240,9 @@ type EtcdServer struct {
 
 	msgSnapC chan raftpb.Message
 
+	// entryTypes holds the codecs of the application entry types.
+	entryTypes *raft.EntryTypes
+
 	// wg is used to wait for the go routines that depends on the server state
 	// to exit when stopping the server.
 	wg sync.WaitGroup
@@ -231,6 +266,20 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 	if terr := fileutil.TouchDirAll(cfg.DataDir); terr != nil {
 		return nil, fmt.Errorf("cannot access data directory: %v", terr)
 	}
+	if cfg.Witness && cfg.ForceNewCluster {
+		return nil, fmt.Errorf("a witness cannot force a new cluster")
+	}
+
+	entryTypes, err := newEntryTypes(cfg.EntryTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an injector raft skips the fault points altogether, so one is
+	// only created when faults can be injected.
+	if cfg.RaftFaults == nil && (cfg.EnableRaftFaults || cfg.Dinv.InjectBugs) {
+		cfg.RaftFaults = raft.NewFaultInjector(cfg.Dinv.FaultSeed)
+	}
 
 	// Run the migrations.
 	dataVer, err := version.DetectDataDir(cfg.DataDir)
@@ -248,14 +297,18 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 	}
 	ss := snap.New(cfg.SnapDir())
 
+	// a witness keeps no application state, so it has no backend.
+	var be backend.Backend
 	bepath := path.Join(cfg.SnapDir(), databaseFilename)
 	beExist := fileutil.Exist(bepath)
-	be := backend.NewDefaultBackend(bepath)
-	defer func() {
-		if err != nil {
-			be.Close()
-		}
-	}()
+	if !cfg.Witness {
+		be = backend.NewDefaultBackend(bepath)
+		defer func() {
+			if err != nil {
+				be.Close()
+			}
+		}()
+	}
 
 	prt, err := rafthttp.NewRoundTripper(cfg.PeerTLSInfo, cfg.peerDialTimeout())
 	if err != nil {
@@ -289,7 +342,9 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		remotes = existingCluster.Members()
 		cl.SetID(existingCluster.ID())
 		cl.SetStore(st)
-		cl.SetBackend(be)
+		if be != nil {
+			cl.SetBackend(be)
+		}
 		cfg.Print()
 		id, n, s, w = startNode(cfg, cl, nil)
 	case !haveWAL && cfg.NewCluster:
@@ -343,7 +398,12 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 			return nil, err
 		}
 		if snapshot != nil {
-			if err = st.Recovery(snapshot.Data); err != nil {
+			if cfg.Witness && len(snapshot.Data) == 0 {
+				// the witness stopped before it recorded the membership in
+				// the snapshot it received; it is fetched again from the
+				// peers with the next snapshot.
+				plog.Warningf("witness snapshot at index %d has no membership", snapshot.Metadata.Index)
+			} else if err = st.Recovery(snapshot.Data); err != nil {
 				plog.Panicf("recovered store from snapshot error: %v", err)
 			}
 			plog.Infof("recovered store from snapshot at index %d", snapshot.Metadata.Index)
@@ -355,9 +415,11 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 			id, cl, n, s, w = restartAsStandaloneNode(cfg, snapshot)
 		}
 		cl.SetStore(st)
-		cl.SetBackend(be)
+		if be != nil {
+			cl.SetBackend(be)
+		}
 		cl.Recover()
-		if cl.Version() != nil && !cl.Version().LessThan(semver.Version{Major: 3}) && !beExist {
+		if be != nil && cl.Version() != nil && !cl.Version().LessThan(semver.Version{Major: 3}) && !beExist {
 			os.RemoveAll(bepath)
 			return nil, fmt.Errorf("database file (%v) of the backend is missing", bepath)
 		}
@@ -398,28 +460,38 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		reqIDGen:      idutil.NewGenerator(uint16(id), time.Now()),
 		forceVersionC: make(chan struct{}),
 		msgSnapC:      make(chan raftpb.Message, maxInFlightMsgSnap),
+		entryTypes:    entryTypes,
 	}
 
 	srv.applyV2 = &applierV2store{store: srv.store, cluster: srv.cluster}
 
-	srv.be = be
-	srv.lessor = lease.NewLessor(srv.be)
-	srv.kv = mvcc.New(srv.be, srv.lessor, &srv.consistIndex)
-	if beExist {
-		kvindex := srv.kv.ConsistentIndex()
-		if snapshot != nil && kvindex < snapshot.Metadata.Index {
-			return nil, fmt.Errorf("database file (%v index %d) does not match with snapshot (index %d).", bepath, kvindex, snapshot.Metadata.Index)
+	if be != nil {
+		srv.be = be
+		srv.lessor = lease.NewLessor(srv.be)
+		if d := cfg.LeaseCheckpointInterval; d > 0 {
+			srv.lessor.SetCheckpointer(d, func(ctx context.Context, cp *pb.LeaseCheckpointRequest) {
+				if _, err := srv.processInternalRaftRequest(ctx, pb.InternalRaftRequest{LeaseCheckpoint: cp}); err != nil {
+					plog.Warningf("failed to checkpoint %d leases (%v)", len(cp.Checkpoints), err)
+				}
+			})
+		}
+		srv.kv = mvcc.New(srv.be, srv.lessor, &srv.consistIndex)
+		if beExist {
+			kvindex := srv.kv.ConsistentIndex()
+			if snapshot != nil && kvindex < snapshot.Metadata.Index {
+				return nil, fmt.Errorf("database file (%v index %d) does not match with snapshot (index %d).", bepath, kvindex, snapshot.Metadata.Index)
+			}
+		}
+		srv.consistIndex.setConsistentIndex(srv.kv.ConsistentIndex())
+		srv.authStore = auth.NewAuthStore(srv.be)
+		if h := cfg.AutoCompactionRetention; h != 0 {
+			srv.compactor = compactor.NewPeriodic(h, srv.kv, srv)
+			srv.compactor.Run()
 		}
-	}
-	srv.consistIndex.setConsistentIndex(srv.kv.ConsistentIndex())
-	srv.authStore = auth.NewAuthStore(srv.be)
-	if h := cfg.AutoCompactionRetention; h != 0 {
-		srv.compactor = compactor.NewPeriodic(h, srv.kv, srv)
-		srv.compactor.Run()
-	}
 
-	if err = srv.restoreAlarms(); err != nil {
-		return nil, err
+		if err = srv.restoreAlarms(); err != nil {
+			return nil, err
+		}
 	}
 
 	// TODO: move transport initialization near the definition of remote
@@ -434,6 +506,8 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		ServerStats: sstats,
 		LeaderStats: lstats,
 		ErrorC:      srv.errorc,
+		Dinv:        cfg.DinvPiggyback,
+		Tracer:      cfg.RaftTracer,
 	}
 	if err = tr.Start(); err != nil {
 		return nil, err
@@ -462,6 +536,7 @@ func (s *EtcdServer) Start() {
 	go s.publish(s.Cfg.ReqTimeout())
 	go s.purgeFile()
 	go monitorFileDescriptor(s.done)
+	go monitorInflightBytes(s.r.Node, s.done)
 	go s.monitorVersions()
 }
 
@@ -474,8 +549,13 @@ func (s *EtcdServer) start() {
 		s.snapCount = DefaultSnapCount
 	}
 	s.w = wait.New()
+	s.applyWait = wait.NewIndexList()
 	s.done = make(chan struct{})
 	s.stop = make(chan struct{})
+	s.stopping = make(chan struct{})
+	s.readwaitc = make(chan struct{}, 1)
+	s.readNotifier = newNotifier()
+	s.batcher = newBatcher(s.r.Propose)
 	if s.ClusterVersion() != nil {
 		plog.Infof("starting server... [version: %v, cluster version: %v]", version.Version, version.Cluster(s.ClusterVersion().String()))
 	} else {
@@ -553,8 +633,23 @@ func (s *EtcdServer) run() {
 		snapi:     snap.Metadata.Index,
 		appliedi:  snap.Metadata.Index,
 	}
+	s.applyWait.Trigger(ep.appliedi)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.linearizableReadLoop()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.batcher.run(s.stopping)
+	}()
 
 	defer func() {
+		// stopping tells the routines in s.wg to exit.
+		close(s.stopping)
 		sched.Stop()
 
 		// wait for snapshots before closing raft so wal stays open
@@ -611,6 +706,7 @@ func (s *EtcdServer) applyAll(ep *etcdProgress, apply *apply) {
 	s.applySnapshot(ep, apply)
 	st := time.Now()
 	s.applyEntries(ep, apply)
+	s.applyWait.Trigger(ep.appliedi)
 	d := time.Since(st)
 	entriesNum := len(apply.entries)
 	if entriesNum != 0 && d > time.Duration(entriesNum)*warnApplyDuration {
@@ -645,6 +741,14 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 			apply.snapshot.Metadata.Index, ep.appliedi)
 	}
 
+	if s.Cfg.Witness {
+		s.applyWitnessSnapshot(apply.snapshot)
+		ep.appliedi = apply.snapshot.Metadata.Index
+		ep.snapi = ep.appliedi
+		ep.confState = apply.snapshot.Metadata.ConfState
+		return
+	}
+
 	snapfn, err := s.r.storage.DBFilePath(apply.snapshot.Metadata.Index)
 	if err != nil {
 		plog.Panicf("get database snapshot file path error: %v", err)
@@ -731,6 +835,91 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 	ep.confState = apply.snapshot.Metadata.ConfState
 }
 
+// applyWitnessSnapshot applies a snapshot received by a witness. Raft drops
+// the data of such snapshots, so the witness recovers the members added in
+// the snapshot from its peers, and saves the snapshot again with its store
+// so the membership survives a restart. It retries until it has the peer
+// URLs of every member of the snapshot, so an incomplete membership is never
+// saved; if the server stops first, the snapshot is left without membership.
+func (s *EtcdServer) applyWitnessSnapshot(snapshot raftpb.Snapshot) {
+	ids := make(map[types.ID]bool)
+	cs := snapshot.Metadata.ConfState
+	for _, ns := range [][]uint64{cs.Nodes, cs.Learners, cs.NodesJoint} {
+		for _, id := range ns {
+			ids[types.ID(id)] = true
+		}
+	}
+
+	var urls []string
+	for _, m := range s.cluster.Members() {
+		if m.ID == s.id {
+			continue
+		}
+		if !ids[m.ID] {
+			s.cluster.RemoveMember(m.ID)
+			s.r.transport.RemovePeer(m.ID)
+			continue
+		}
+		urls = append(urls, m.PeerURLs...)
+	}
+	var missing []types.ID
+	for id := range ids {
+		if s.cluster.Member(id) == nil {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) != 0 {
+		ms, ok := s.fetchWitnessMembers(snapshot.Metadata.Index, urls, missing)
+		if !ok {
+			plog.Warningf("stopped before recovering the members of snapshot at index %d", snapshot.Metadata.Index)
+			return
+		}
+		for _, m := range ms {
+			s.cluster.AddMember(m)
+			s.r.transport.AddPeer(m.ID, m.PeerURLs)
+		}
+	}
+
+	d, err := s.store.Clone().SaveNoCopy()
+	if err != nil {
+		plog.Panicf("store save should never fail: %v", err)
+	}
+	snapshot.Data = d
+	if err = s.r.storage.SaveSnap(snapshot); err != nil {
+		plog.Fatalf("save snapshot error: %v", err)
+	}
+	plog.Infof("applied witness snapshot at index %d", snapshot.Metadata.Index)
+}
+
+// fetchWitnessMembers fetches the members with the given ids from the peers
+// at urls. It retries until it finds all of them, and returns false if the
+// server stops first.
+func (s *EtcdServer) fetchWitnessMembers(index uint64, urls []string, ids []types.ID) ([]*membership.Member, bool) {
+	for {
+		cl, err := GetClusterFromRemotePeers(urls, s.peerRt)
+		if err == nil {
+			ms := make([]*membership.Member, 0, len(ids))
+			for _, id := range ids {
+				m := cl.Member(id)
+				if m == nil {
+					err = fmt.Errorf("cannot find the peer URLs of member %s", id)
+					break
+				}
+				ms = append(ms, m)
+			}
+			if err == nil {
+				return ms, true
+			}
+		}
+		plog.Warningf("cannot fetch the members added in snapshot at index %d: %v; retrying in %v", index, err, witnessMembersRetryInterval)
+		select {
+		case <-time.After(witnessMembersRetryInterval):
+		case <-s.stopping:
+			return nil, false
+		}
+	}
+}
+
 func (s *EtcdServer) applyEntries(ep *etcdProgress, apply *apply) {
 	if len(apply.entries) == 0 {
 		return
@@ -764,7 +953,18 @@ func (s *EtcdServer) triggerSnapshot(ep *etcdProgress) {
 
 // Stop stops the server gracefully, and shuts down the running goroutine.
 // Stop should be called after a Start(s), otherwise it will block forever.
+// When stopping the leader, Stop transfers its leadership to one of its
+// peers before stopping the server.
 func (s *EtcdServer) Stop() {
+	if err := s.transferLeadership(); err != nil {
+		plog.Warningf("%s failed to transfer leadership (%v)", s.ID(), err)
+	}
+	s.HardStop()
+}
+
+// HardStop stops the server without coordination with other members in the
+// cluster.
+func (s *EtcdServer) HardStop() {
 	select {
 	case s.stop <- struct{}{}:
 	case <-s.done:
@@ -805,7 +1005,8 @@ func (s *EtcdServer) LeaderStats() []byte {
 func (s *EtcdServer) StoreStats() []byte { return s.store.JsonStats() }
 
 func (s *EtcdServer) AddMember(ctx context.Context, memb membership.Member) error {
-	if s.Cfg.StrictReconfigCheck && !s.cluster.IsReadyToAddNewMember() {
+	// a learner does not count toward the quorum, so adding one is always safe.
+	if s.Cfg.StrictReconfigCheck && !memb.IsLearner && !s.cluster.IsReadyToAddNewMember() {
 		// If s.cfg.StrictReconfigCheck is false, it means the option --strict-reconfig-check isn't passed to etcd.
 		// In such a case adding a new member is allowed unconditionally
 		return ErrNotEnoughStartedMembers
@@ -821,9 +1022,88 @@ func (s *EtcdServer) AddMember(ctx context.Context, memb membership.Member) erro
 		NodeID:  uint64(memb.ID),
 		Context: b,
 	}
+	switch {
+	case memb.IsLearner:
+		cc.Type = raftpb.ConfChangeAddLearnerNode
+	case memb.IsWitness:
+		cc.Type = raftpb.ConfChangeAddWitnessNode
+	}
 	return s.configure(ctx, cc)
 }
 
+func (s *EtcdServer) PromoteMember(ctx context.Context, id uint64) error {
+	err := s.promoteMember(ctx, id)
+	if err != ErrNotLeader {
+		return err
+	}
+
+	// only the leader knows whether the learner caught up; forward to the
+	// leader manually, like lease renewals.
+	leader, err := s.waitLeader()
+	if err != nil {
+		return err
+	}
+	for _, url := range leader.PeerURLs {
+		err = promoteMemberHTTP(url, id, s.peerRt, s.Cfg.ReqTimeout())
+		if err == nil {
+			return nil
+		}
+		for _, perr := range promoteErrs {
+			if err == perr {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) error {
+	memb := s.cluster.Member(types.ID(id))
+	if memb == nil {
+		return membership.ErrIDNotFound
+	}
+	if !memb.IsLearner {
+		return membership.ErrMemberNotLearner
+	}
+	if err := s.isLearnerReady(id); err != nil {
+		return err
+	}
+	if s.Cfg.StrictReconfigCheck && !s.cluster.IsReadyToPromoteMember(id) {
+		return ErrNotEnoughStartedMembers
+	}
+
+	memb.IsLearner = false
+	b, err := json.Marshal(memb)
+	if err != nil {
+		return err
+	}
+	// adding a learner as a voting member promotes it.
+	cc := raftpb.ConfChange{
+		Type:    raftpb.ConfChangeAddNode,
+		NodeID:  id,
+		Context: b,
+	}
+	return s.configure(ctx, cc)
+}
+
+// isLearnerReady returns nil if the learner with the given id has caught up
+// with the log of the leader closely enough to vote. Only the leader tracks
+// the progress of the learner.
+func (s *EtcdServer) isLearnerReady(id uint64) error {
+	rs := s.r.Status()
+	if rs.RaftState != raft.StateLeader {
+		return ErrNotLeader
+	}
+	pr, ok := rs.Progress[id]
+	if !ok {
+		return membership.ErrIDNotFound
+	}
+	if float64(pr.Match) < float64(rs.Progress[uint64(s.id)].Match)*learnerReadyPercent {
+		return ErrLearnerNotReady
+	}
+	return nil
+}
+
 func (s *EtcdServer) RemoveMember(ctx context.Context, id uint64) error {
 	if s.Cfg.StrictReconfigCheck && !s.cluster.IsReadyToRemoveMember(id) {
 		// If s.cfg.StrictReconfigCheck is false, it means the option --strict-reconfig-check isn't passed to etcd.
@@ -851,6 +1131,96 @@ func (s *EtcdServer) UpdateMember(ctx context.Context, memb membership.Member) e
 	return s.configure(ctx, cc)
 }
 
+func (s *EtcdServer) ReconfigureMembers(ctx context.Context, add []membership.Member, remove []uint64) error {
+	if s.Cfg.StrictReconfigCheck && !s.cluster.IsReadyToReconfigure(add, remove) {
+		return ErrNotEnoughStartedMembers
+	}
+
+	b, err := json.Marshal(add)
+	if err != nil {
+		return err
+	}
+	cc := raftpb.ConfChangeV2{Context: b}
+	for _, m := range add {
+		typ := raftpb.ConfChangeAddNode
+		switch {
+		case m.IsLearner:
+			typ = raftpb.ConfChangeAddLearnerNode
+		case m.IsWitness:
+			typ = raftpb.ConfChangeAddWitnessNode
+		}
+		cc.Changes = append(cc.Changes, raftpb.ConfChangeSingle{Type: typ, NodeID: uint64(m.ID)})
+	}
+	for _, id := range remove {
+		cc.Changes = append(cc.Changes, raftpb.ConfChangeSingle{Type: raftpb.ConfChangeRemoveNode, NodeID: id})
+	}
+	cc.ID = s.reqIDGen.Next()
+	return s.proposeConfChange(ctx, cc.ID, cc)
+}
+
+// MoveLeader transfers the leadership of the local member to transferee, and
+// waits until transferee is elected or ctx is done.
+func (s *EtcdServer) MoveLeader(ctx context.Context, transferee uint64) error {
+	if !s.isLeader() {
+		return ErrNotLeader
+	}
+	memb := s.cluster.Member(types.ID(transferee))
+	if memb == nil {
+		return membership.ErrIDNotFound
+	}
+	if memb.IsLearner || memb.IsWitness {
+		return ErrTransfereeNotVoter
+	}
+
+	now := time.Now()
+	interval := time.Duration(s.Cfg.TickMs) * time.Millisecond
+
+	plog.Infof("%s starts leadership transfer to %s", s.ID(), types.ID(transferee))
+	s.r.TransferLeadership(ctx, uint64(s.ID()), transferee)
+	for s.Lead() != transferee {
+		select {
+		case <-ctx.Done():
+			return ErrTimeoutLeaderTransfer
+		case <-s.done:
+			return ErrStopped
+		case <-time.After(interval):
+		}
+	}
+	plog.Infof("%s finished leadership transfer to %s (took %v)", s.ID(), types.ID(transferee), time.Since(now))
+	return nil
+}
+
+// transferLeadership transfers the leadership of the local member, if it is
+// the leader, to the voting member it has been connected to for the longest.
+func (s *EtcdServer) transferLeadership() error {
+	if !s.isLeader() {
+		return nil
+	}
+	var voters []*membership.Member
+	for _, m := range s.cluster.Members() {
+		if m.ID != s.ID() && !m.IsLearner && !m.IsWitness {
+			voters = append(voters, m)
+		}
+	}
+	if len(voters) == 0 {
+		plog.Infof("skipped leadership transfer for single voting member cluster")
+		return nil
+	}
+
+	transferee, ok := longestConnected(s.r.transport, voters)
+	if !ok {
+		return ErrUnhealthy
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.Cfg.ReqTimeout())
+	err := s.MoveLeader(ctx, uint64(transferee))
+	cancel()
+	return err
+}
+
+func (s *EtcdServer) isLeader() bool {
+	return s.id != types.ID(raft.None) && uint64(s.id) == s.Lead()
+}
+
 // Implement the RaftTimer interface
 
 func (s *EtcdServer) Index() uint64 { return atomic.LoadUint64(&s.r.index) }
@@ -866,16 +1236,47 @@ func (s *EtcdServer) Leader() types.ID { return types.ID(s.Lead()) }
 
 func (s *EtcdServer) IsPprofEnabled() bool { return s.Cfg.EnablePprof }
 
+func (s *EtcdServer) IsRaftFaultsEnabled() bool { return s.Cfg.EnableRaftFaults }
+
+// RaftFaults returns the fault points of the local raft node.
+func (s *EtcdServer) RaftFaults() *raft.FaultInjector { return s.Cfg.RaftFaults }
+
+// RaftCut takes a consistent cut of the raft state of the cluster. It
+// will block until every member recorded its state or there is an error.
+func (s *EtcdServer) RaftCut(ctx context.Context) (*raft.Cut, error) {
+	id := s.reqIDGen.Next()
+	ch := s.w.Register(id)
+	if err := s.r.RequestCut(ctx, id); err != nil {
+		s.w.Trigger(id, nil)
+		return nil, err
+	}
+	select {
+	case x := <-ch:
+		return x.(*raft.Cut), nil
+	case <-ctx.Done():
+		s.w.Trigger(id, nil) // GC wait
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, ErrStopped
+	}
+}
+
 // configure sends a configuration change through consensus and
 // then waits for it to be applied to the server. It
 // will block until the change is performed or there is an error.
 func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) error {
 	cc.ID = s.reqIDGen.Next()
-	ch := s.w.Register(cc.ID)
+	return s.proposeConfChange(ctx, cc.ID, cc)
+}
+
+// proposeConfChange proposes cc, whose ID is id, and waits for it to be
+// applied like configure.
+func (s *EtcdServer) proposeConfChange(ctx context.Context, id uint64, cc raftpb.ConfChangeI) error {
+	ch := s.w.Register(id)
 	start := time.Now()
 	if err := s.r.ProposeConfChange(ctx, cc); err != nil {
-		s.w.Trigger(cc.ID, nil)
-		return err
+		s.w.Trigger(id, nil)
+		return s.parseProposeCtxErr(err, start)
 	}
 	select {
 	case x := <-ch:
@@ -887,7 +1288,7 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) error
 		}
 		return nil
 	case <-ctx.Done():
-		s.w.Trigger(cc.ID, nil) // GC wait
+		s.w.Trigger(id, nil) // GC wait
 		return s.parseProposeCtxErr(ctx.Err(), start)
 	case <-s.done:
 		return ErrStopped
@@ -932,7 +1333,12 @@ func (s *EtcdServer) publish(timeout time.Duration) {
 
 	for {
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		_, err := s.Do(ctx, req)
+		var err error
+		if s.Cfg.Witness {
+			err = s.proposeWitnessAttributes(ctx, req)
+		} else {
+			_, err = s.Do(ctx, req)
+		}
 		cancel()
 		switch err {
 		case nil:
@@ -948,6 +1354,27 @@ func (s *EtcdServer) publish(timeout time.Duration) {
 	}
 }
 
+// proposeWitnessAttributes proposes the attributes request of a witness. A
+// witness drops the data of the entries it applies, so it cannot wait for
+// the request to be applied; it is done once raft forwards the proposal to
+// the leader.
+func (s *EtcdServer) proposeWitnessAttributes(ctx context.Context, req pb.Request) error {
+	req.ID = s.reqIDGen.Next()
+	data, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+	if err = s.r.Propose(ctx, data); err != nil {
+		// retry after the timeout, like a request that is not applied.
+		select {
+		case <-ctx.Done():
+		case <-s.done:
+			return ErrStopped
+		}
+	}
+	return err
+}
+
 // TODO: move this function into raft.go
 func (s *EtcdServer) send(ms []raftpb.Message) {
 	sentAppResp := false
@@ -964,7 +1391,10 @@ func (s *EtcdServer) send(ms []raftpb.Message) {
 			}
 		}
 
-		if ms[i].Type == raftpb.MsgSnap {
+		if ms[i].Type == raftpb.MsgSnap && s.cluster.IsWitness(types.ID(ms[i].To)) {
+			// raft strips the data of the snapshots sent to a witness, so
+			// there is nothing to merge.
+		} else if ms[i].Type == raftpb.MsgSnap {
 			// There are two separate data store: the store for v2, and the KV for v3.
 			// The msgSnap only contains the most recent snapshot of store without KV.
 			// So we need to redirect the msgSnap to etcd server main loop for merging in the
@@ -1030,8 +1460,20 @@ func (s *EtcdServer) apply(es []raftpb.Entry, confState *raftpb.ConfState) (uint
 			removedSelf, err := s.applyConfChange(cc, confState)
 			shouldstop = shouldstop || removedSelf
 			s.w.Trigger(cc.ID, err)
+		case raftpb.EntryConfChangeV2:
+			var cc raftpb.ConfChangeV2
+			pbutil.MustUnmarshal(&cc, e.Data)
+			removedSelf, err := s.applyConfChangeV2(cc, confState)
+			shouldstop = shouldstop || removedSelf
+			s.w.Trigger(cc.ID, err)
 		default:
-			plog.Panicf("entry type should be either EntryNormal or EntryConfChange")
+			if !raft.IsApplicationEntryType(e.Type) {
+				plog.Panicf("entry type should be either EntryNormal, EntryConfChange, EntryConfChangeV2 or an application entry type")
+			}
+			// a witness does not keep the data of application entries.
+			if !s.Cfg.Witness {
+				s.applyEntryApplication(&e)
+			}
 		}
 		atomic.StoreUint64(&s.r.index, e.Index)
 		atomic.StoreUint64(&s.r.term, e.Term)
@@ -1077,13 +1519,34 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 		return
 	}
 
-	id := raftReq.ID
-	if id == 0 {
-		id = raftReq.Header.ID
+	if len(raftReq.Batch) != 0 {
+		s.applyBatch(e, raftReq.Batch)
+		return
 	}
 
 	ar := s.applyV3.Apply(&raftReq)
 	s.setAppliedIndex(e.Index)
+	s.triggerV3(&raftReq, ar)
+}
+
+// applyBatch applies the requests batched into the entry e in a single mvcc
+// txn, so they cost one backend transaction and readers never see only part
+// of the batch, and notifies each request of its own result and revision.
+func (s *EtcdServer) applyBatch(e *raftpb.Entry, reqs []*pb.InternalRaftRequest) {
+	ars := s.applyV3.Batch(reqs)
+	s.setAppliedIndex(e.Index)
+	for i, r := range reqs {
+		s.triggerV3(r, ars[i])
+	}
+}
+
+// triggerV3 notifies the V3 request r of its result ar. A request that ran
+// out of space first raises the NOSPACE alarm.
+func (s *EtcdServer) triggerV3(r *pb.InternalRaftRequest, ar *applyResult) {
+	id := r.ID
+	if id == 0 {
+		id = r.Header.ID
+	}
 	if ar.err != ErrNoSpace || len(s.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.w.Trigger(id, ar)
 		return
@@ -1096,8 +1559,8 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 			Action:   pb.AlarmRequest_ACTIVATE,
 			Alarm:    pb.AlarmType_NOSPACE,
 		}
-		r := pb.InternalRaftRequest{Alarm: a}
-		s.processInternalRaftRequest(context.TODO(), r)
+		req := pb.InternalRaftRequest{Alarm: a}
+		s.processInternalRaftRequest(context.TODO(), req)
 		s.w.Trigger(id, ar)
 	}()
 }
@@ -1111,8 +1574,89 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		return false, err
 	}
 	*confState = *s.r.ApplyConfChange(cc)
+	return s.applyMemberChange(cc), nil
+}
+
+// applyConfChangeV2 applies a ConfChangeV2 to the server. The members it
+// removes from the raft configuration are only removed from the cluster
+// when the joint configuration is left.
+func (s *EtcdServer) applyConfChangeV2(cc raftpb.ConfChangeV2, confState *raftpb.ConfState) (bool, error) {
+	if cc.LeaveJoint() {
+		outgoing := confState.NodesJoint
+		*confState = *s.r.ApplyConfChange(cc)
+		staying := make(map[uint64]bool)
+		for _, id := range append(confState.Nodes, confState.Learners...) {
+			staying[id] = true
+		}
+		removedSelf := false
+		for _, id := range outgoing {
+			if staying[id] || s.cluster.Member(types.ID(id)) == nil {
+				continue
+			}
+			rs := s.applyMemberChange(raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: id})
+			removedSelf = removedSelf || rs
+		}
+		return removedSelf, nil
+	}
+
+	ccs := confChangesFromV2(cc)
+	var err error
+	if len(confState.NodesJoint) > 0 {
+		err = ErrJointConfChangeInProgress
+	} else {
+		err = s.cluster.ValidateConfigurationChanges(ccs)
+	}
+	if err != nil {
+		s.r.ApplyConfChange(raftpb.ConfChange{NodeID: raft.None})
+		return false, err
+	}
+	*confState = *s.r.ApplyConfChange(cc)
+	outgoing := make(map[uint64]bool)
+	for _, id := range confState.NodesJoint {
+		outgoing[id] = true
+	}
+	removedSelf := false
+	for _, c := range ccs {
+		if c.Type == raftpb.ConfChangeRemoveNode && outgoing[c.NodeID] {
+			// the voter is removed when the joint configuration is left.
+			continue
+		}
+		rs := s.applyMemberChange(c)
+		removedSelf = removedSelf || rs
+	}
+	return removedSelf, nil
+}
+
+// confChangesFromV2 returns the ConfChange of every change of cc. The
+// context of cc holds the added members; each added member is moved to the
+// context of the change adding it.
+func confChangesFromV2(cc raftpb.ConfChangeV2) []raftpb.ConfChange {
+	var membs []membership.Member
+	if len(cc.Context) > 0 {
+		if err := json.Unmarshal(cc.Context, &membs); err != nil {
+			plog.Panicf("unmarshal members should never fail: %v", err)
+		}
+	}
+	ctxs := make(map[uint64][]byte)
+	for _, m := range membs {
+		b, err := json.Marshal(m)
+		if err != nil {
+			plog.Panicf("marshal member should never fail: %v", err)
+		}
+		ctxs[uint64(m.ID)] = b
+	}
+	ccs := make([]raftpb.ConfChange, len(cc.Changes))
+	for i, c := range cc.Changes {
+		ccs[i] = raftpb.ConfChange{ID: cc.ID, Type: c.Type, NodeID: c.NodeID, Context: ctxs[c.NodeID]}
+	}
+	return ccs
+}
+
+// applyMemberChange applies cc, which raft has already applied, to the
+// cluster and the transport. It returns true if it removes the local member.
+func (s *EtcdServer) applyMemberChange(cc raftpb.ConfChange) bool {
 	switch cc.Type {
-	case raftpb.ConfChangeAddNode:
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode, raftpb.ConfChangeAddWitnessNode:
 		m := new(membership.Member)
 		if err := json.Unmarshal(cc.Context, m); err != nil {
 			plog.Panicf("unmarshal member should never fail: %v", err)
@@ -1120,6 +1664,11 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		if cc.NodeID != uint64(m.ID) {
 			plog.Panicf("nodeID should always be equal to member ID")
 		}
+		if cc.Type == raftpb.ConfChangeAddNode && s.cluster.Member(m.ID) != nil {
+			// the learner is already a peer of this member.
+			s.cluster.PromoteMember(m.ID)
+			break
+		}
 		s.cluster.AddMember(m)
 		if m.ID != s.id {
 			s.r.transport.AddPeer(m.ID, m.PeerURLs)
@@ -1128,7 +1677,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		id := types.ID(cc.NodeID)
 		s.cluster.RemoveMember(id)
 		if id == s.id {
-			return true, nil
+			return true
 		}
 		s.r.transport.RemovePeer(id)
 	case raftpb.ConfChangeUpdateNode:
@@ -1144,7 +1693,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 			s.r.transport.UpdatePeer(m.ID, m.PeerURLs)
 		}
 	}
-	return false, nil
+	return false
 }
 
 // TODO: non-blocking snapshot
@@ -1171,7 +1720,9 @@ func (s *EtcdServer) snapshot(snapi uint64, confState raftpb.ConfState) {
 			plog.Panicf("unexpected create snapshot error %v", err)
 		}
 		// commit kv to write metadata (for example: consistent index) to disk.
-		s.KV().Commit()
+		if s.kv != nil {
+			s.KV().Commit()
+		}
 		// SaveSnap saves the snapshot and releases the locked wal files
 		// to the snapshot index.
 		if err = s.r.storage.SaveSnap(snap); err != nil {
@@ -1291,6 +1842,17 @@ func (s *EtcdServer) updateClusterVersion(ver string) {
 
 func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 	switch err {
+	case raft.ErrUncommittedSizeExceeded:
+		// too much of the leader's log is uncommitted; the client should
+		// back off and retry.
+		return ErrTooManyRequests
+	case raft.ErrProposalDropped:
+		// there is no leader, or the leader is transferring its leadership
+		// or was removed from the cluster.
+		if types.ID(atomic.LoadUint64(&s.r.lead)) == types.ID(raft.None) {
+			return ErrNoLeader
+		}
+		return ErrTimeout
 	case context.Canceled:
 		return ErrCanceled
 	case context.DeadlineExceeded:
@@ -15,15 +15,20 @@
 package etcdserver
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/coreos/etcd/auth"
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/lease"
@@ -714,6 +719,83 @@ func TestDoProposalStopped(t *testing.T) {
 	}
 }
 
+// TestLinearizableReadNotify tests that linearizable reads waiting together
+// are batched into one read index request, and are notified only once the
+// applied index reaches the read index.
+func TestLinearizableReadNotify(t *testing.T) {
+	n := newNodeRecorder()
+	srv := &EtcdServer{
+		Cfg:          &ServerConfig{TickMs: 1},
+		r:            raftNode{Node: n, readStateC: make(chan raft.ReadState, 1)},
+		reqIDGen:     idutil.NewGenerator(0, time.Time{}),
+		applyWait:    wait.NewIndexList(),
+		readwaitc:    make(chan struct{}, 1),
+		readNotifier: newNotifier(),
+		stopping:     make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	defer close(srv.stopping)
+	nc := srv.readNotifier
+
+	errc := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { errc <- srv.linearizableReadNotify(context.Background()) }()
+	}
+	// both reads wait on the same notifier before the loop starts.
+	for len(srv.readwaitc) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	go srv.linearizableReadLoop()
+
+	action, _ := n.Wait(1)
+	if len(action) != 1 || action[0].Name != "ReadIndex" {
+		t.Fatalf("action = %v, want [ReadIndex]", action)
+	}
+	rctx := action[0].Params[0].([]byte)
+	// an out-of-date read state is ignored.
+	srv.r.readStateC <- raft.ReadState{Index: 1, RequestCtx: []byte("stale")}
+	srv.r.readStateC <- raft.ReadState{Index: 5, RequestCtx: rctx}
+
+	srv.applyWait.Trigger(4)
+	select {
+	case err := <-errc:
+		t.Fatalf("read notified with err %v before the read index is applied", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	srv.applyWait.Trigger(5)
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Errorf("#%d: err = %v, want nil", i, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: read is not notified", i)
+		}
+	}
+	select {
+	case <-nc.c:
+	default:
+		t.Errorf("notifier is not notified")
+	}
+}
+
+// TestLinearizableReadNotifyStopped tests that a linearizable read returns
+// ErrStopped once the server is stopped.
+func TestLinearizableReadNotifyStopped(t *testing.T) {
+	srv := &EtcdServer{
+		readwaitc:    make(chan struct{}, 1),
+		readNotifier: newNotifier(),
+		done:         make(chan struct{}),
+	}
+	close(srv.done)
+	if err := srv.linearizableReadNotify(context.Background()); err != ErrStopped {
+		t.Errorf("err = %v, want %v", err, ErrStopped)
+	}
+}
+
 // TestSync tests sync 1. is nonblocking 2. proposes SYNC request.
 func TestSync(t *testing.T) {
 	n := newNodeRecorder()
@@ -1029,6 +1111,337 @@ func TestConcurrentApplyAndSnapshotV3(t *testing.T) {
 	}
 }
 
+// TestApplyBatch tests that the requests batched into one entry are applied
+// in order, each at its own revision, and are each notified of their own
+// result.
+func TestApplyBatch(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	srv := &EtcdServer{
+		Cfg: &ServerConfig{QuotaBackendBytes: -1},
+		w:   wait.New(),
+		be:  be,
+	}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	defer srv.kv.Close()
+	srv.authStore = auth.NewAuthStore(be)
+	srv.applyV3 = srv.newApplierV3()
+
+	reqs := []*pb.InternalRaftRequest{
+		{Header: &pb.RequestHeader{ID: 1}, Put: &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}},
+		{Header: &pb.RequestHeader{ID: 2}, Put: &pb.PutRequest{Key: []byte("baz"), Value: []byte("qux")}},
+		{Header: &pb.RequestHeader{ID: 3}, Put: &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar2")}},
+		{Header: &pb.RequestHeader{ID: 4}, DeleteRange: &pb.DeleteRangeRequest{Key: []byte("foo")}},
+	}
+	chs := make([]<-chan interface{}, len(reqs))
+	for i, r := range reqs {
+		chs[i] = srv.w.Register(r.Header.ID)
+	}
+	e := raftpb.Entry{Index: 1, Data: pbutil.MustMarshal(&pb.InternalRaftRequest{Batch: reqs})}
+	srv.applyEntryNormal(&e)
+
+	if g := srv.getAppliedIndex(); g != 1 {
+		t.Errorf("appliedIndex = %d, want 1", g)
+	}
+	if g := srv.KV().Rev(); g != int64(1+len(reqs)) {
+		t.Errorf("rev = %d, want %d", g, 1+len(reqs))
+	}
+	for i, ch := range chs {
+		ar := (<-ch).(*applyResult)
+		if ar.err != nil {
+			t.Fatalf("#%d: err = %v, want nil", i, ar.err)
+		}
+		wrev := int64(2 + i)
+		if i < 3 {
+			presp, ok := ar.resp.(*pb.PutResponse)
+			if !ok {
+				t.Fatalf("#%d: resp = %T, want *pb.PutResponse", i, ar.resp)
+			}
+			if presp.Header.Revision != wrev {
+				t.Errorf("#%d: rev = %d, want %d", i, presp.Header.Revision, wrev)
+			}
+			continue
+		}
+		dresp, ok := ar.resp.(*pb.DeleteRangeResponse)
+		if !ok {
+			t.Fatalf("#%d: resp = %T, want *pb.DeleteRangeResponse", i, ar.resp)
+		}
+		if dresp.Deleted != 1 {
+			t.Errorf("#%d: deleted = %d, want 1", i, dresp.Deleted)
+		}
+		if dresp.Header.Revision != wrev {
+			t.Errorf("#%d: rev = %d, want %d", i, dresp.Header.Revision, wrev)
+		}
+	}
+	rr, err := srv.KV().Range([]byte("a"), []byte("z"), mvcc.RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rr.KVs) != 1 || string(rr.KVs[0].Key) != "baz" {
+		t.Errorf("kvs = %+v, want only baz", rr.KVs)
+	}
+}
+
+// TestProposeBatchProposals tests that small puts only go through the
+// batcher with BatchProposals, and otherwise fall back to their own entries
+// so members without batch support can apply them.
+func TestProposeBatchProposals(t *testing.T) {
+	for i, batch := range []bool{false, true} {
+		n := newNodeRecorder()
+		srv := &EtcdServer{
+			Cfg:      &ServerConfig{BatchProposals: batch},
+			r:        raftNode{Node: n},
+			w:        wait.New(),
+			reqIDGen: idutil.NewGenerator(0, time.Time{}),
+		}
+		// the batcher does not run, so batched requests stay queued.
+		srv.batcher = newBatcher(srv.r.Propose)
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		srv.processInternalRaftRequest(ctx, pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("foo")}})
+		cancel()
+
+		if batch {
+			if len(srv.batcher.propc) != 1 {
+				t.Errorf("#%d: len(propc) = %d, want 1", i, len(srv.batcher.propc))
+			}
+			if action := n.Action(); len(action) != 0 {
+				t.Errorf("#%d: action = %+v, want none", i, action)
+			}
+			continue
+		}
+		if len(srv.batcher.propc) != 0 {
+			t.Errorf("#%d: len(propc) = %d, want 0", i, len(srv.batcher.propc))
+		}
+		action := n.Action()
+		if len(action) != 1 || action[0].Name != "Propose" {
+			t.Fatalf("#%d: action = %+v, want one Propose", i, action)
+		}
+		var r pb.InternalRaftRequest
+		pbutil.MustUnmarshal(&r, action[0].Params[0].([]byte))
+		if r.Put == nil || len(r.Batch) != 0 {
+			t.Errorf("#%d: proposed %+v, want the put on its own", i, r)
+		}
+	}
+}
+
+// TestApplyBatchAtomic tests that a reader never sees only part of a batch.
+func TestApplyBatchAtomic(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	srv := &EtcdServer{
+		Cfg: &ServerConfig{QuotaBackendBytes: -1},
+		w:   wait.New(),
+		be:  be,
+	}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	defer srv.kv.Close()
+	srv.authStore = auth.NewAuthStore(be)
+	srv.applyV3 = srv.newApplierV3()
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		for {
+			select {
+			case <-stopc:
+				return
+			default:
+			}
+			rr, err := srv.KV().Range([]byte("a"), []byte("z"), mvcc.RangeOptions{})
+			if err != nil {
+				t.Errorf("range error (%v)", err)
+				return
+			}
+			if len(rr.KVs) != 0 && len(rr.KVs) != len(keys) {
+				t.Errorf("rev %d: len(kvs) = %d, want 0 or %d", rr.Rev, len(rr.KVs), len(keys))
+				return
+			}
+			for _, kv := range rr.KVs {
+				if !bytes.Equal(kv.Value, rr.KVs[0].Value) {
+					t.Errorf("rev %d: kvs = %+v, want the values of one batch", rr.Rev, rr.KVs)
+					return
+				}
+			}
+		}
+	}()
+
+	for i := 1; i <= 10000; i++ {
+		reqs := make([]*pb.InternalRaftRequest, len(keys))
+		for j, k := range keys {
+			reqs[j] = &pb.InternalRaftRequest{
+				Header: &pb.RequestHeader{ID: uint64(i*len(keys) + j)},
+				Put:    &pb.PutRequest{Key: []byte(k), Value: []byte(fmt.Sprint(i))},
+			}
+		}
+		e := raftpb.Entry{Index: uint64(i), Data: pbutil.MustMarshal(&pb.InternalRaftRequest{Batch: reqs})}
+		srv.applyEntryNormal(&e)
+	}
+	close(stopc)
+	<-donec
+
+	if g, w := srv.KV().Rev(), int64(1+10000*len(keys)); g != w {
+		t.Errorf("rev = %d, want %d", g, w)
+	}
+}
+
+// TestApplyRangeContinue tests that a range request is paged through with
+// continue tokens at the revision of its first page, and that tokens
+// outside the requested range are rejected.
+func TestApplyRangeContinue(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	srv := &EtcdServer{
+		Cfg: &ServerConfig{QuotaBackendBytes: -1},
+		be:  be,
+	}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	defer srv.kv.Close()
+	srv.authStore = auth.NewAuthStore(be)
+	srv.applyV3 = srv.newApplierV3()
+
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		srv.kv.Put([]byte(k), []byte("v"), lease.NoLease)
+	}
+
+	r := &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("z"), Limit: 2}
+	var keys []string
+	for i := 0; ; i++ {
+		resp, err := srv.applyV3.Range(noTxn, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		if resp.More != (len(resp.ContinueToken) != 0) {
+			t.Fatalf("#%d: more = %v, continue token = %q", i, resp.More, resp.ContinueToken)
+		}
+		if !resp.More {
+			break
+		}
+		if i == 0 {
+			// the following pages are at the revision of the first one.
+			srv.kv.Put([]byte("bb"), []byte("v"), lease.NoLease)
+		}
+		r.ContinueToken = resp.ContinueToken
+	}
+	if wkeys := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(keys, wkeys) {
+		t.Errorf("keys = %v, want %v", keys, wkeys)
+	}
+
+	r = &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("c"), ContinueToken: encodeContinueToken(1, []byte("d"))}
+	if _, err := srv.applyV3.Range(noTxn, r); err != ErrInvalidContinueToken {
+		t.Errorf("err = %v, want %v", err, ErrInvalidContinueToken)
+	}
+}
+
+// TestApplyTxnNested tests that range compares hold for every key in the
+// range and that nested txns follow the branches their compares select.
+func TestApplyTxnNested(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	srv := &EtcdServer{
+		Cfg: &ServerConfig{QuotaBackendBytes: -1},
+		be:  be,
+	}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	defer srv.kv.Close()
+	srv.authStore = auth.NewAuthStore(be)
+	srv.applyV3 = srv.newApplierV3()
+
+	srv.kv.Put([]byte("a"), []byte("1"), lease.NoLease)
+	srv.kv.Put([]byte("b"), []byte("2"), lease.NoLease)
+
+	putOp := func(k, v string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(k), Value: []byte(v)}}}
+	}
+	txnOp := func(rt *pb.TxnRequest) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: rt}}
+	}
+	modGreater := func(k, end string, rev int64) *pb.Compare {
+		return &pb.Compare{
+			Key:         []byte(k),
+			RangeEnd:    []byte(end),
+			Target:      pb.Compare_MOD,
+			Result:      pb.Compare_GREATER,
+			TargetUnion: &pb.Compare_ModRevision{ModRevision: rev},
+		}
+	}
+
+	rt := &pb.TxnRequest{
+		// both keys in [a, c) were modified after revision 1
+		Compare: []*pb.Compare{modGreater("a", "c", 1)},
+		Success: []*pb.RequestOp{
+			txnOp(&pb.TxnRequest{
+				// "a" was modified at revision 2
+				Compare: []*pb.Compare{modGreater("a", "c", 2)},
+				Success: []*pb.RequestOp{putOp("x", "then")},
+				Failure: []*pb.RequestOp{putOp("x", "else")},
+			}),
+			txnOp(&pb.TxnRequest{
+				Compare: []*pb.Compare{modGreater("b", "", 2)},
+				Success: []*pb.RequestOp{putOp("y", "then")},
+			}),
+		},
+		Failure: []*pb.RequestOp{putOp("z", "else")},
+	}
+	resp, err := srv.applyV3.Txn(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Succeeded || len(resp.Responses) != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if r := resp.Responses[0].GetResponseTxn(); r == nil || r.Succeeded {
+		t.Errorf("first nested txn response = %+v, want failure", r)
+	}
+	if r := resp.Responses[1].GetResponseTxn(); r == nil || !r.Succeeded {
+		t.Errorf("second nested txn response = %+v, want success", r)
+	}
+	// both puts were executed in a single txn
+	if resp.Header.Revision != 4 {
+		t.Errorf("revision = %d, want 4", resp.Header.Revision)
+	}
+
+	wkvs := map[string]string{"x": "else", "y": "then", "z": ""}
+	for k, v := range wkvs {
+		rr, err := srv.kv.Range([]byte(k), nil, mvcc.RangeOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var val string
+		if len(rr.KVs) != 0 {
+			val = string(rr.KVs[0].Value)
+		}
+		if val != v {
+			t.Errorf("value of %q = %q, want %q", k, val, v)
+		}
+	}
+}
+
+// TestParseProposeCtxErrDropped tests that only proposals dropped because of
+// the uncommitted entries size limit are reported as too many requests.
+func TestParseProposeCtxErrDropped(t *testing.T) {
+	tests := []struct {
+		lead uint64
+		err  error
+
+		werr error
+	}{
+		{1, raft.ErrUncommittedSizeExceeded, ErrTooManyRequests},
+		{raft.None, raft.ErrProposalDropped, ErrNoLeader},
+		{1, raft.ErrProposalDropped, ErrTimeout},
+	}
+	for i, tt := range tests {
+		srv := &EtcdServer{r: raftNode{lead: tt.lead}}
+		if err := srv.parseProposeCtxErr(tt.err, time.Now()); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+	}
+}
+
 // TestAddMember tests AddMember can propose and perform node addition.
 func TestAddMember(t *testing.T) {
 	n := newNodeConfChangeCommitterRecorder()
@@ -1068,7 +1481,550 @@ func TestAddMember(t *testing.T) {
 	}
 }
 
+// TestAddLearnerMember tests AddMember can propose and perform the addition
+// of a learner.
+func TestAddLearnerMember(t *testing.T) {
+	n := newNodeConfChangeCommitterRecorder()
+	n.readyc <- raft.Ready{
+		SoftState: &raft.SoftState{RaftState: raft.StateLeader},
+	}
+	cl := newTestCluster(nil)
+	st := store.New()
+	cl.SetStore(st)
+	s := &EtcdServer{
+		r: raftNode{
+			Node:        n,
+			raftStorage: raft.NewMemoryStorage(),
+			storage:     mockstorage.NewStorageRecorder(""),
+			transport:   rafthttp.NewNopTransporter(),
+		},
+		Cfg:      &ServerConfig{StrictReconfigCheck: true},
+		store:    st,
+		cluster:  cl,
+		reqIDGen: idutil.NewGenerator(0, time.Time{}),
+	}
+	s.start()
+	m := membership.Member{ID: 1234, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"foo"}, IsLearner: true}}
+	err := s.AddMember(context.TODO(), m)
+	gaction := n.Action()
+	s.Stop()
+
+	if err != nil {
+		t.Fatalf("AddMember error: %v", err)
+	}
+	wactions := []testutil.Action{{Name: "ProposeConfChange:ConfChangeAddLearnerNode"}, {Name: "ApplyConfChange:ConfChangeAddLearnerNode"}}
+	if !reflect.DeepEqual(gaction, wactions) {
+		t.Errorf("action = %v, want %v", gaction, wactions)
+	}
+	if m := cl.Member(1234); m == nil || !m.IsLearner {
+		t.Errorf("member = %v, want learner with id 1234", m)
+	}
+}
+
+// TestAddWitnessMember tests AddMember can propose and perform the addition
+// of a witness.
+func TestAddWitnessMember(t *testing.T) {
+	n := newNodeConfChangeCommitterRecorder()
+	n.readyc <- raft.Ready{
+		SoftState: &raft.SoftState{RaftState: raft.StateLeader},
+	}
+	cl := newTestCluster(nil)
+	st := store.New()
+	cl.SetStore(st)
+	s := &EtcdServer{
+		r: raftNode{
+			Node:        n,
+			raftStorage: raft.NewMemoryStorage(),
+			storage:     mockstorage.NewStorageRecorder(""),
+			transport:   rafthttp.NewNopTransporter(),
+		},
+		Cfg:      &ServerConfig{},
+		store:    st,
+		cluster:  cl,
+		reqIDGen: idutil.NewGenerator(0, time.Time{}),
+	}
+	s.start()
+	m := membership.Member{ID: 1234, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"foo"}, IsWitness: true}}
+	err := s.AddMember(context.TODO(), m)
+	gaction := n.Action()
+	s.Stop()
+
+	if err != nil {
+		t.Fatalf("AddMember error: %v", err)
+	}
+	wactions := []testutil.Action{{Name: "ProposeConfChange:ConfChangeAddWitnessNode"}, {Name: "ApplyConfChange:ConfChangeAddWitnessNode"}}
+	if !reflect.DeepEqual(gaction, wactions) {
+		t.Errorf("action = %v, want %v", gaction, wactions)
+	}
+	if !cl.IsWitness(1234) {
+		t.Errorf("isWitness(1234) = false, want true")
+	}
+}
+
+// TestSendSnapshotToWitness tests that the snapshots sent to a witness are
+// sent as they are, without being merged with the backend.
+func TestSendSnapshotToWitness(t *testing.T) {
+	tr := newSendTransporter()
+	s := &EtcdServer{
+		r:        raftNode{transport: tr},
+		cluster:  newTestCluster([]*membership.Member{newTestWitness(2), {ID: 3}}),
+		msgSnapC: make(chan raftpb.Message, maxInFlightMsgSnap),
+	}
+	s.send([]raftpb.Message{{Type: raftpb.MsgSnap, To: 2}, {Type: raftpb.MsgSnap, To: 3}})
+
+	ms := <-tr.sendc
+	if ms[0].To != 2 || ms[1].To != 0 {
+		t.Errorf("sent to %d and %d, want %d and %d", ms[0].To, ms[1].To, 2, 0)
+	}
+	select {
+	case m := <-s.msgSnapC:
+		if m.To != 3 {
+			t.Errorf("merged snapshot to %d, want %d", m.To, 3)
+		}
+	default:
+		t.Errorf("no snapshot to merge, want one to %d", 3)
+	}
+}
+
+// TestApplyWitnessSnapshot tests that a witness retries fetching the members
+// added in a snapshot, and only saves the snapshot once it has all of them.
+func TestApplyWitnessSnapshot(t *testing.T) {
+	m2 := &membership.Member{ID: 2, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"http://10.0.0.2:2380"}}}
+	m3 := &membership.Member{ID: 3, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"http://10.0.0.3:2380"}}}
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Etcd-Cluster-ID", "1")
+		json.NewEncoder(w).Encode([]*membership.Member{m2, m3})
+	}))
+	defer ts.Close()
+
+	st := mockstorage.NewStorageRecorder("")
+	srv := &EtcdServer{
+		id:       1,
+		r:        raftNode{transport: rafthttp.NewNopTransporter(), storage: st},
+		cluster:  newTestCluster([]*membership.Member{newTestWitness(1), {ID: 2, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{ts.URL}}}}),
+		store:    store.New(),
+		peerRt:   http.DefaultTransport,
+		stopping: make(chan struct{}),
+	}
+	snap := raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 10, ConfState: raftpb.ConfState{Nodes: []uint64{1, 2, 3}}}}
+	srv.applyWitnessSnapshot(snap)
+
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("calls = %d, want 2", n)
+	}
+	if m := srv.cluster.Member(3); m == nil || !reflect.DeepEqual(m.PeerURLs, m3.PeerURLs) {
+		t.Errorf("member 3 = %+v, want %+v", m, m3)
+	}
+	wactions := []testutil.Action{{Name: "SaveSnap"}}
+	if g := st.Action(); !reflect.DeepEqual(g, wactions) {
+		t.Errorf("actions = %+v, want %+v", g, wactions)
+	}
+}
+
+// TestApplyWitnessSnapshotStopped tests that a witness does not save a
+// snapshot with an incomplete membership when it stops before it could
+// fetch the missing members.
+func TestApplyWitnessSnapshotStopped(t *testing.T) {
+	st := mockstorage.NewStorageRecorder("")
+	srv := &EtcdServer{
+		id:       1,
+		r:        raftNode{transport: rafthttp.NewNopTransporter(), storage: st},
+		cluster:  newTestCluster([]*membership.Member{newTestWitness(1)}),
+		store:    store.New(),
+		stopping: make(chan struct{}),
+	}
+	close(srv.stopping)
+	snap := raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 10, ConfState: raftpb.ConfState{Nodes: []uint64{1, 2}}}}
+	srv.applyWitnessSnapshot(snap)
+
+	if g := st.Action(); len(g) != 0 {
+		t.Errorf("actions = %+v, want none", g)
+	}
+	if srv.cluster.Member(2) != nil {
+		t.Errorf("member 2 added, want it missing")
+	}
+}
+
+func newTestWitness(id types.ID) *membership.Member {
+	return &membership.Member{ID: id, RaftAttributes: membership.RaftAttributes{IsWitness: true}}
+}
+
+// TestPromoteMember tests PromoteMember proposes and performs the promotion
+// of a learner only if it caught up with the leader.
+func TestPromoteMember(t *testing.T) {
+	tests := []struct {
+		learner bool
+		state   raft.StateType
+		match   uint64
+
+		werr     error
+		wactions []testutil.Action
+	}{
+		{
+			true, raft.StateLeader, 95,
+			nil, []testutil.Action{{Name: "ProposeConfChange:ConfChangeAddNode"}, {Name: "ApplyConfChange:ConfChangeAddNode"}},
+		},
+		{true, raft.StateLeader, 50, ErrLearnerNotReady, []testutil.Action{}},
+		// a follower without a leader cannot forward the promotion.
+		{true, raft.StateFollower, 95, ErrNoLeader, []testutil.Action{}},
+		{false, raft.StateLeader, 95, membership.ErrMemberNotLearner, []testutil.Action{}},
+	}
+	for i, tt := range tests {
+		n := &nodeStatusRecorder{nodeConfChangeCommitterRecorder: newNodeConfChangeCommitterRecorder()}
+		n.status.RaftState = tt.state
+		n.status.Progress = map[uint64]raft.Progress{1: {Match: 100}, 1234: {Match: tt.match}}
+		n.readyc <- raft.Ready{
+			SoftState: &raft.SoftState{RaftState: raft.StateLeader},
+		}
+		cl := newTestCluster(nil)
+		st := store.New()
+		cl.SetStore(st)
+		cl.AddMember(&membership.Member{ID: 1234, RaftAttributes: membership.RaftAttributes{IsLearner: tt.learner}})
+		s := &EtcdServer{
+			id: 1,
+			r: raftNode{
+				Node:        n,
+				raftStorage: raft.NewMemoryStorage(),
+				storage:     mockstorage.NewStorageRecorder(""),
+				transport:   rafthttp.NewNopTransporter(),
+			},
+			Cfg:      &ServerConfig{},
+			store:    st,
+			cluster:  cl,
+			reqIDGen: idutil.NewGenerator(0, time.Time{}),
+		}
+		s.start()
+		err := s.PromoteMember(context.TODO(), 1234)
+		gaction := n.Action()
+		s.Stop()
+
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if !reflect.DeepEqual(gaction, tt.wactions) {
+			t.Errorf("#%d: action = %v, want %v", i, gaction, tt.wactions)
+		}
+		if g := cl.Member(1234).IsLearner; g != (tt.learner && tt.werr != nil) {
+			t.Errorf("#%d: isLearner = %v, want %v", i, g, tt.learner && tt.werr != nil)
+		}
+	}
+}
+
+// TestPromoteMemberForward tests that a follower forwards PromoteMember to
+// the leader, and returns the error of the leader.
+func TestPromoteMemberForward(t *testing.T) {
+	tests := []struct {
+		code int
+		body string
+
+		werr error
+	}{
+		{http.StatusOK, "", nil},
+		{http.StatusPreconditionFailed, ErrLearnerNotReady.Error(), ErrLearnerNotReady},
+		{http.StatusNotFound, membership.ErrIDNotFound.Error(), membership.ErrIDNotFound},
+	}
+	for i, tt := range tests {
+		var path string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			if tt.code != http.StatusOK {
+				http.Error(w, tt.body, tt.code)
+			}
+		}))
+
+		n := &nodeStatusRecorder{nodeConfChangeCommitterRecorder: newNodeConfChangeCommitterRecorder()}
+		n.status.RaftState = raft.StateFollower
+		cl := newTestCluster([]*membership.Member{
+			{ID: 1234, RaftAttributes: membership.RaftAttributes{IsLearner: true}},
+			{ID: 2, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{ts.URL}}},
+		})
+		s := &EtcdServer{
+			id:      1,
+			r:       raftNode{lead: 2, Node: n},
+			Cfg:     &ServerConfig{TickMs: 1, ElectionTicks: 10},
+			cluster: cl,
+			peerRt:  http.DefaultTransport,
+		}
+		err := s.PromoteMember(context.TODO(), 1234)
+		ts.Close()
+
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if wpath := PeerMemberPromotePrefix + "1234"; path != wpath {
+			t.Errorf("#%d: path = %s, want %s", i, path, wpath)
+		}
+		if g := n.Action(); len(g) != 0 {
+			t.Errorf("#%d: action = %v, want none", i, g)
+		}
+	}
+}
+
+// TestReconfigureMembers tests ReconfigureMembers can propose and perform
+// the addition and the removal of members at once.
+func TestReconfigureMembers(t *testing.T) {
+	n := newNodeConfChangeCommitterRecorder()
+	n.readyc <- raft.Ready{
+		SoftState: &raft.SoftState{RaftState: raft.StateLeader},
+	}
+	cl := newTestCluster(nil)
+	st := store.New()
+	cl.SetStore(store.New())
+	cl.AddMember(&membership.Member{ID: 1234})
+	cl.AddMember(&membership.Member{ID: 5678})
+	s := &EtcdServer{
+		r: raftNode{
+			Node:        n,
+			raftStorage: raft.NewMemoryStorage(),
+			storage:     mockstorage.NewStorageRecorder(""),
+			transport:   rafthttp.NewNopTransporter(),
+		},
+		Cfg:      &ServerConfig{},
+		store:    st,
+		cluster:  cl,
+		reqIDGen: idutil.NewGenerator(0, time.Time{}),
+	}
+	s.start()
+	add := []membership.Member{
+		{ID: 2345, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"foo"}}},
+		{ID: 3456, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"bar"}}},
+	}
+	err := s.ReconfigureMembers(context.TODO(), add, []uint64{5678})
+	gaction := n.Action()
+	s.Stop()
+
+	if err != nil {
+		t.Fatalf("ReconfigureMembers error: %v", err)
+	}
+	wactions := []testutil.Action{{Name: "ProposeConfChange:ConfChangeV2"}, {Name: "ApplyConfChange:ConfChangeV2"}}
+	if !reflect.DeepEqual(gaction, wactions) {
+		t.Errorf("action = %v, want %v", gaction, wactions)
+	}
+	for _, id := range []types.ID{2345, 3456} {
+		if cl.Member(id) == nil {
+			t.Errorf("member with id %s is not added", id)
+		}
+	}
+	if cl.Member(5678) != nil {
+		t.Errorf("member with id 5678 is not removed")
+	}
+}
+
+// TestApplyConfChangeV2 tests that the members removed by a joint
+// configuration change are only removed when it is left, and that no other
+// change is applied meanwhile.
+func TestApplyConfChangeV2(t *testing.T) {
+	cl := membership.NewCluster("")
+	cl.SetStore(store.New())
+	for i := 1; i <= 3; i++ {
+		cl.AddMember(&membership.Member{ID: types.ID(i)})
+	}
+	n := &nodeConfStateRecorder{nodeRecorder: *newNodeRecorder()}
+	srv := &EtcdServer{
+		id: 1,
+		r: raftNode{
+			Node:      n,
+			transport: rafthttp.NewNopTransporter(),
+		},
+		cluster: cl,
+	}
+	b, err := json.Marshal([]membership.Member{{ID: 4, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"foo"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	enter := raftpb.ConfChangeV2{
+		Changes: []raftpb.ConfChangeSingle{
+			{Type: raftpb.ConfChangeAddNode, NodeID: 4},
+			{Type: raftpb.ConfChangeRemoveNode, NodeID: 1},
+		},
+		Context: b,
+	}
+
+	cs := &raftpb.ConfState{Nodes: []uint64{1, 2, 3}}
+	n.cs = raftpb.ConfState{Nodes: []uint64{2, 3, 4}, NodesJoint: []uint64{1, 2, 3}}
+	shouldStop, err := srv.applyConfChangeV2(enter, cs)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if shouldStop {
+		t.Errorf("shouldStop = %t, want %t", shouldStop, false)
+	}
+	if !reflect.DeepEqual(*cs, n.cs) {
+		t.Errorf("confState = %+v, want %+v", *cs, n.cs)
+	}
+	if cl.Member(4) == nil || cl.Member(1) == nil {
+		t.Errorf("members = %v, want 1 kept and 4 added", cl.MemberIDs())
+	}
+
+	if _, err = srv.applyConfChangeV2(enter, cs); err != ErrJointConfChangeInProgress {
+		t.Errorf("err = %v, want %v", err, ErrJointConfChangeInProgress)
+	}
+
+	n.cs = raftpb.ConfState{Nodes: []uint64{2, 3, 4}}
+	shouldStop, err = srv.applyConfChangeV2(raftpb.ConfChangeV2{}, cs)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !shouldStop {
+		t.Errorf("shouldStop = %t, want %t", shouldStop, true)
+	}
+	if cl.Member(1) != nil {
+		t.Errorf("member with id 1 is not removed")
+	}
+}
+
 // TestRemoveMember tests RemoveMember can propose and perform node removal.
+// TestMoveLeader tests MoveLeader transfers the leadership only from the
+// leader to a voting member, and waits for the transferee to be elected.
+func TestMoveLeader(t *testing.T) {
+	tests := []struct {
+		lead       uint64
+		transferee uint64
+
+		werr     error
+		wactions []testutil.Action
+	}{
+		{1, 2, nil, []testutil.Action{{Name: "TransferLeadership", Params: []interface{}{uint64(1), uint64(2)}}}},
+		{2, 3, ErrNotLeader, []testutil.Action{}},
+		{1, 3, ErrTransfereeNotVoter, []testutil.Action{}},
+		{1, 4, membership.ErrIDNotFound, []testutil.Action{}},
+	}
+	for i, tt := range tests {
+		n := &nodeLeaderTransferRecorder{nodeRecorder: *newNodeRecorder()}
+		cl := newTestCluster(nil)
+		cl.AddMember(&membership.Member{ID: 1})
+		cl.AddMember(&membership.Member{ID: 2})
+		cl.AddMember(&membership.Member{ID: 3, RaftAttributes: membership.RaftAttributes{IsLearner: true}})
+		s := &EtcdServer{
+			id:      1,
+			r:       raftNode{Node: n, lead: tt.lead},
+			Cfg:     &ServerConfig{TickMs: 1},
+			cluster: cl,
+		}
+		n.lead = &s.r.lead
+
+		err := s.MoveLeader(context.TODO(), tt.transferee)
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if g := n.Action(); !reflect.DeepEqual(g, tt.wactions) {
+			t.Errorf("#%d: action = %v, want %v", i, g, tt.wactions)
+		}
+	}
+}
+
+// TestMoveLeaderTimeout tests MoveLeader gives up once its context is done
+// if the transferee is not elected.
+func TestMoveLeaderTimeout(t *testing.T) {
+	cl := newTestCluster(nil)
+	cl.AddMember(&membership.Member{ID: 1})
+	cl.AddMember(&membership.Member{ID: 2})
+	s := &EtcdServer{
+		id:      1,
+		r:       raftNode{Node: newNodeRecorder(), lead: 1},
+		Cfg:     &ServerConfig{TickMs: 1},
+		cluster: cl,
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.MoveLeader(ctx, 2); err != ErrTimeoutLeaderTransfer {
+		t.Errorf("err = %v, want %v", err, ErrTimeoutLeaderTransfer)
+	}
+}
+
+// TestLeaseTimeToLive tests the leader serves lease information and the
+// lease list from its lessor.
+func TestLeaseTimeToLive(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+
+	le := lease.NewLessor(be)
+	defer le.Stop()
+	le.Promote(0)
+	s := &EtcdServer{
+		id:     1,
+		r:      raftNode{Node: newNodeNop(), lead: 1},
+		Cfg:    &ServerConfig{},
+		lessor: le,
+	}
+	for _, id := range []lease.LeaseID{2, 1} {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := le.Attach(1, []lease.LeaseItem{{Key: "foo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.LeaseTimeToLive(context.TODO(), &pb.LeaseTimeToLiveRequest{ID: 1, Keys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != 1 || resp.GrantedTTL != 100 {
+		t.Errorf("ID, grantedTTL = %d, %d, want 1, 100", resp.ID, resp.GrantedTTL)
+	}
+	if resp.TTL <= 0 || resp.TTL > 100 {
+		t.Errorf("TTL = %d, want in (0, 100]", resp.TTL)
+	}
+	if wkeys := [][]byte{[]byte("foo")}; !reflect.DeepEqual(resp.Keys, wkeys) {
+		t.Errorf("keys = %q, want %q", resp.Keys, wkeys)
+	}
+	if _, err = s.LeaseTimeToLive(context.TODO(), &pb.LeaseTimeToLiveRequest{ID: 3}); err != lease.ErrLeaseNotFound {
+		t.Errorf("err = %v, want %v", err, lease.ErrLeaseNotFound)
+	}
+
+	lresp, err := s.LeaseLeases(context.TODO(), &pb.LeaseLeasesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wleases := []*pb.LeaseStatus{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(lresp.Leases, wleases) {
+		t.Errorf("leases = %v, want %v", lresp.Leases, wleases)
+	}
+}
+
+// TestApplyLeaseCheckpoint tests that applying a lease checkpoint makes the
+// promoted lessor expire the lease after its remaining TTL, and that the
+// checkpoints of revoked leases are ignored.
+func TestApplyLeaseCheckpoint(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	le := lease.NewLessor(be)
+	defer le.Stop()
+	srv := &EtcdServer{
+		Cfg:    &ServerConfig{QuotaBackendBytes: -1},
+		be:     be,
+		lessor: le,
+	}
+	srv.kv = mvcc.New(be, le, &srv.consistIndex)
+	defer srv.kv.Close()
+	srv.authStore = auth.NewAuthStore(be)
+	srv.applyV3 = srv.newApplierV3()
+
+	if _, err := le.Grant(1, 100); err != nil {
+		t.Fatal(err)
+	}
+	r := &pb.InternalRaftRequest{LeaseCheckpoint: &pb.LeaseCheckpointRequest{
+		Checkpoints: []*pb.LeaseCheckpoint{{ID: 1, Remaining_TTL: 10}, {ID: 2, Remaining_TTL: 10}},
+	}}
+	if ar := srv.applyV3.Apply(r); ar.err != nil {
+		t.Fatal(ar.err)
+	}
+
+	le.Promote(0)
+	if r := le.Lookup(1).Remaining(); r <= 9*time.Second || r > 10*time.Second {
+		t.Errorf("remaining = %v, want in (9s, 10s]", r)
+	}
+}
+
 func TestRemoveMember(t *testing.T) {
 	n := newNodeConfChangeCommitterRecorder()
 	n.readyc <- raft.Ready{
@@ -1349,7 +2305,11 @@ func (n *nodeRecorder) Propose(ctx context.Context, data []byte) error {
 	n.Record(testutil.Action{Name: "Propose", Params: []interface{}{data}})
 	return nil
 }
-func (n *nodeRecorder) ProposeConfChange(ctx context.Context, conf raftpb.ConfChange) error {
+func (n *nodeRecorder) ProposeEntry(ctx context.Context, t raftpb.EntryType, data []byte) error {
+	n.Record(testutil.Action{Name: "ProposeEntry", Params: []interface{}{t, data}})
+	return nil
+}
+func (n *nodeRecorder) ProposeConfChange(ctx context.Context, conf raftpb.ConfChangeI) error {
 	n.Record(testutil.Action{Name: "ProposeConfChange"})
 	return nil
 }
@@ -1360,7 +2320,7 @@ func (n *nodeRecorder) Step(ctx context.Context, msg raftpb.Message) error {
 func (n *nodeRecorder) Status() raft.Status      { return raft.Status{} }
 func (n *nodeRecorder) Ready() <-chan raft.Ready { return nil }
 func (n *nodeRecorder) Advance()                 {}
-func (n *nodeRecorder) ApplyConfChange(conf raftpb.ConfChange) *raftpb.ConfState {
+func (n *nodeRecorder) ApplyConfChange(conf raftpb.ConfChangeI) *raftpb.ConfState {
 	n.Record(testutil.Action{Name: "ApplyConfChange", Params: []interface{}{conf}})
 	return &raftpb.ConfState{}
 }
@@ -1373,6 +2333,20 @@ func (n *nodeRecorder) ReportUnreachable(id uint64) {}
 
 func (n *nodeRecorder) ReportSnapshot(id uint64, status raft.SnapshotStatus) {}
 
+func (n *nodeRecorder) RequestCut(ctx context.Context, id uint64) error {
+	n.Record(testutil.Action{Name: "RequestCut"})
+	return nil
+}
+
+func (n *nodeRecorder) TransferLeadership(ctx context.Context, lead, transferee uint64) {
+	n.Record(testutil.Action{Name: "TransferLeadership", Params: []interface{}{lead, transferee}})
+}
+
+func (n *nodeRecorder) ReadIndex(ctx context.Context, id uint64, rctx []byte) error {
+	n.Record(testutil.Action{Name: "ReadIndex", Params: []interface{}{rctx}})
+	return nil
+}
+
 func (n *nodeRecorder) Compact(index uint64, nodes []uint64, d []byte) {
 	n.Record(testutil.Action{Name: "Compact"})
 }
@@ -1391,6 +2365,18 @@ func (n *nodeProposalBlockerRecorder) Propose(ctx context.Context, data []byte)
 	return nil
 }
 
+// nodeLeaderTransferRecorder is a nodeRecorder that elects the transferee as
+// soon as the leadership is transferred.
+type nodeLeaderTransferRecorder struct {
+	nodeRecorder
+	lead *uint64
+}
+
+func (n *nodeLeaderTransferRecorder) TransferLeadership(ctx context.Context, lead, transferee uint64) {
+	n.nodeRecorder.TransferLeadership(ctx, lead, transferee)
+	atomic.StoreUint64(n.lead, transferee)
+}
+
 // readyNode is a nodeRecorder with a user-writeable ready channel
 type readyNode struct {
 	nodeRecorder
@@ -1421,24 +2407,54 @@ func newNodeConfChangeCommitterStream() *nodeConfChangeCommitterRecorder {
 	return &nodeConfChangeCommitterRecorder{*newReadyNode(), 0}
 }
 
-func (n *nodeConfChangeCommitterRecorder) ProposeConfChange(ctx context.Context, conf raftpb.ConfChange) error {
-	data, err := conf.Marshal()
+func (n *nodeConfChangeCommitterRecorder) ProposeConfChange(ctx context.Context, conf raftpb.ConfChangeI) error {
+	typ, data, err := raftpb.MarshalConfChange(conf)
 	if err != nil {
 		return err
 	}
 	n.index++
-	n.Record(testutil.Action{Name: "ProposeConfChange:" + conf.Type.String()})
-	n.readyc <- raft.Ready{CommittedEntries: []raftpb.Entry{{Index: n.index, Type: raftpb.EntryConfChange, Data: data}}}
+	n.Record(testutil.Action{Name: "ProposeConfChange:" + confChangeType(conf)})
+	n.readyc <- raft.Ready{CommittedEntries: []raftpb.Entry{{Index: n.index, Type: typ, Data: data}}}
 	return nil
 }
 func (n *nodeConfChangeCommitterRecorder) Ready() <-chan raft.Ready {
 	return n.readyc
 }
-func (n *nodeConfChangeCommitterRecorder) ApplyConfChange(conf raftpb.ConfChange) *raftpb.ConfState {
-	n.Record(testutil.Action{Name: "ApplyConfChange:" + conf.Type.String()})
+func (n *nodeConfChangeCommitterRecorder) ApplyConfChange(conf raftpb.ConfChangeI) *raftpb.ConfState {
+	n.Record(testutil.Action{Name: "ApplyConfChange:" + confChangeType(conf)})
 	return &raftpb.ConfState{}
 }
 
+// confChangeType returns the type of a ConfChange, or ConfChangeV2.
+func confChangeType(conf raftpb.ConfChangeI) string {
+	if cc, ok := conf.AsV1(); ok {
+		return cc.Type.String()
+	}
+	return "ConfChangeV2"
+}
+
+// nodeConfStateRecorder is a nodeRecorder that returns the given ConfState
+// from ApplyConfChange.
+type nodeConfStateRecorder struct {
+	nodeRecorder
+	cs raftpb.ConfState
+}
+
+func (n *nodeConfStateRecorder) ApplyConfChange(conf raftpb.ConfChangeI) *raftpb.ConfState {
+	n.nodeRecorder.ApplyConfChange(conf)
+	cs := n.cs
+	return &cs
+}
+
+// nodeStatusRecorder is a nodeConfChangeCommitterRecorder that reports the
+// given raft status.
+type nodeStatusRecorder struct {
+	*nodeConfChangeCommitterRecorder
+	status raft.Status
+}
+
+func (n *nodeStatusRecorder) Status() raft.Status { return n.status }
+
 // nodeCommitter commits proposed data immediately.
 type nodeCommitter struct {
 	readyNode
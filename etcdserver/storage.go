@@ -34,6 +34,11 @@ type Storage interface {
 	// Save function saves ents and state to the underlying stable storage.
 	// Save MUST block until st and ents are on stable storage.
 	Save(st raftpb.HardState, ents []raftpb.Entry) error
+	// SaveNoSync writes ents and state like Save, but does not wait for
+	// them to be on stable storage.
+	SaveNoSync(st raftpb.HardState, ents []raftpb.Entry) error
+	// Sync blocks until everything written is on stable storage.
+	Sync() error
 	// SaveSnap function saves snapshot to the underlying stable storage.
 	SaveSnap(snap raftpb.Snapshot) error
 	// DBFilePath returns the file path of database snapshot saved with given
@@ -34,6 +34,23 @@ func isConnectedToQuorumSince(transport rafthttp.Transporter, since time.Time, s
 	return connectedNum >= (len(members)+1)/2
 }
 
+// longestConnected chooses the member with the longest active-since time
+// among the given members. It returns false if none of them is active.
+func longestConnected(transport rafthttp.Transporter, members []*membership.Member) (types.ID, bool) {
+	var longest types.ID
+	var oldest time.Time
+	for _, m := range members {
+		t := transport.ActiveSince(m.ID)
+		if t.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || t.Before(oldest) {
+			longest, oldest = m.ID, t
+		}
+	}
+	return longest, !oldest.IsZero()
+}
+
 // isConnectedSince checks whether the local member is connected to the
 // remote member since the given time.
 func isConnectedSince(transport rafthttp.Transporter, since time.Time, remote types.ID) bool {
@@ -56,7 +56,11 @@ func (a *v2apiStore) processRaftRequest(ctx context.Context, r *pb.Request) (Res
 	ch := a.s.w.Register(r.ID)
 
 	start := time.Now()
-	a.s.r.Propose(ctx, data)
+	if err = a.s.r.Propose(ctx, data); err != nil {
+		proposalsFailed.Inc()
+		a.s.w.Trigger(r.ID, nil) // GC wait
+		return Response{}, a.s.parseProposeCtxErr(err, start)
+	}
 	proposalsPending.Inc()
 	defer proposalsPending.Dec()
 
@@ -15,14 +15,18 @@
 package etcdserver
 
 import (
+	"bytes"
+	"encoding/binary"
 	"strconv"
 	"strings"
 	"time"
 
 	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/coreos/etcd/etcdserver/membership"
 	"github.com/coreos/etcd/lease"
 	"github.com/coreos/etcd/lease/leasehttp"
 	"github.com/coreos/etcd/mvcc"
+	"github.com/coreos/etcd/raft"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/metadata"
 )
@@ -55,6 +59,12 @@ type Lessor interface {
 	// LeaseRenew renews the lease with given ID. The renewed TTL is returned. Or an error
 	// is returned.
 	LeaseRenew(id lease.LeaseID) (int64, error)
+
+	// LeaseTimeToLive retrieves lease information.
+	LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error)
+
+	// LeaseLeases lists all leases.
+	LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error)
 }
 
 type Authenticator interface {
@@ -77,25 +87,25 @@ type Authenticator interface {
 }
 
 func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
-	var result *applyResult
-	var err error
-
-	if r.Serializable {
-		var user string
-		user, err = s.usernameFromCtx(ctx)
+	if !r.Serializable {
+		// a linearizable read is served locally once the applied index
+		// reaches the read index confirmed by the leader.
+		cctx, cancel := context.WithTimeout(ctx, maxV3RequestTimeout)
+		err := s.linearizableReadNotify(cctx)
+		cancel()
 		if err != nil {
 			return nil, err
 		}
-		result = s.applyV3.Apply(
-			&pb.InternalRaftRequest{
-				Header: &pb.RequestHeader{Username: user},
-				Range:  r})
-	} else {
-		result, err = s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{Range: r})
 	}
+
+	user, err := s.usernameFromCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
+	result := s.applyV3.Apply(
+		&pb.InternalRaftRequest{
+			Header: &pb.RequestHeader{Username: user},
+			Range:  r})
 	if result.err != nil {
 		return nil, result.err
 	}
@@ -151,18 +161,26 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 
 func isTxnSerializable(r *pb.TxnRequest) bool {
 	for _, u := range r.Success {
-		if r := u.GetRequestRange(); r == nil || !r.Serializable {
+		if !isRequestOpSerializable(u) {
 			return false
 		}
 	}
 	for _, u := range r.Failure {
-		if r := u.GetRequestRange(); r == nil || !r.Serializable {
+		if !isRequestOpSerializable(u) {
 			return false
 		}
 	}
 	return true
 }
 
+func isRequestOpSerializable(u *pb.RequestOp) bool {
+	if t := u.GetRequestTxn(); t != nil {
+		return isTxnSerializable(t)
+	}
+	r := u.GetRequestRange()
+	return r != nil && r.Serializable
+}
+
 func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
 	result, err := s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{Compaction: r})
 	if r.Physical && result != nil && result.physc != nil {
@@ -228,6 +246,73 @@ func (s *EtcdServer) LeaseRenew(id lease.LeaseID) (int64, error) {
 	}
 
 	// renewals don't go through raft; forward to leader manually
+	leader, err := s.waitLeader()
+	if err != nil {
+		return -1, err
+	}
+
+	for _, url := range leader.PeerURLs {
+		lurl := url + leasehttp.LeasePrefix
+		ttl, err = leasehttp.RenewHTTP(id, lurl, s.peerRt, s.Cfg.peerDialTimeout())
+		if err == nil {
+			break
+		}
+	}
+	return ttl, err
+}
+
+func (s *EtcdServer) LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	if s.Leader() == s.ID() {
+		// primary; the remaining TTL is only known by the leader
+		l := s.lessor.Lookup(lease.LeaseID(r.ID))
+		if l == nil {
+			return nil, lease.ErrLeaseNotFound
+		}
+		// TODO: fill out ResponseHeader
+		resp := &pb.LeaseTimeToLiveResponse{
+			Header:     &pb.ResponseHeader{},
+			ID:         r.ID,
+			TTL:        int64(l.Remaining().Seconds()),
+			GrantedTTL: l.TTL,
+		}
+		if r.Keys {
+			for _, k := range l.Keys() {
+				resp.Keys = append(resp.Keys, []byte(k))
+			}
+		}
+		return resp, nil
+	}
+
+	// like renewals, time-to-live queries are forwarded to the leader
+	leader, err := s.waitLeader()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *pb.LeaseTimeToLiveResponse
+	for _, url := range leader.PeerURLs {
+		lurl := url + leasehttp.LeaseInternalPrefix
+		resp, err = leasehttp.TimeToLiveHTTP(lease.LeaseID(r.ID), r.Keys, lurl, s.peerRt, s.Cfg.peerDialTimeout())
+		if err == nil || err == lease.ErrLeaseNotFound {
+			break
+		}
+	}
+	return resp, err
+}
+
+func (s *EtcdServer) LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+	ls := s.lessor.Leases()
+	leases := make([]*pb.LeaseStatus, len(ls))
+	for i, l := range ls {
+		leases[i] = &pb.LeaseStatus{ID: int64(l.ID)}
+	}
+	// TODO: fill out ResponseHeader
+	return &pb.LeaseLeasesResponse{Header: &pb.ResponseHeader{}, Leases: leases}, nil
+}
+
+// waitLeader returns the leader member, waiting a few elections for one to
+// be elected.
+func (s *EtcdServer) waitLeader() (*membership.Member, error) {
 	leader := s.cluster.Member(s.Leader())
 	for i := 0; i < 5 && leader == nil; i++ {
 		// wait an election
@@ -236,21 +321,13 @@ func (s *EtcdServer) LeaseRenew(id lease.LeaseID) (int64, error) {
 		case <-time.After(dur):
 			leader = s.cluster.Member(s.Leader())
 		case <-s.done:
-			return -1, ErrStopped
+			return nil, ErrStopped
 		}
 	}
 	if leader == nil || len(leader.PeerURLs) == 0 {
-		return -1, ErrNoLeader
+		return nil, ErrNoLeader
 	}
-
-	for _, url := range leader.PeerURLs {
-		lurl := url + "/leases"
-		ttl, err = leasehttp.RenewHTTP(id, lurl, s.peerRt, s.Cfg.peerDialTimeout())
-		if err == nil {
-			break
-		}
-	}
-	return ttl, err
+	return leader, nil
 }
 
 func (s *EtcdServer) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.AlarmResponse, error) {
@@ -534,7 +611,16 @@ func (s *EtcdServer) processInternalRaftRequest(ctx context.Context, r pb.Intern
 	defer cancel()
 
 	start := time.Now()
-	s.r.Propose(cctx, data)
+	if s.Cfg.BatchProposals && batchable(&r, data) {
+		err = s.batcher.submit(cctx, &proposal{req: &r, data: data, errc: make(chan error, 1)}, s.stopping)
+	} else {
+		err = s.r.Propose(cctx, data)
+	}
+	if err != nil {
+		proposalsFailed.Inc()
+		s.w.Trigger(id, nil) // GC wait
+		return nil, s.parseProposeCtxErr(err, start)
+	}
 	proposalsPending.Inc()
 	defer proposalsPending.Dec()
 
@@ -552,3 +638,111 @@ func (s *EtcdServer) processInternalRaftRequest(ctx context.Context, r pb.Intern
 
 // Watchable returns a watchable interface attached to the etcdserver.
 func (s *EtcdServer) Watchable() mvcc.WatchableKV { return s.KV() }
+
+// notifier notifies the linearizable reads waiting on it of the outcome of
+// the read index request they were batched into.
+type notifier struct {
+	c   chan struct{}
+	err error
+}
+
+func newNotifier() *notifier {
+	return &notifier{
+		c: make(chan struct{}),
+	}
+}
+
+func (nc *notifier) notify(err error) {
+	nc.err = err
+	close(nc.c)
+}
+
+// linearizableReadLoop serves the read index requests of the linearizable
+// reads. All reads waiting when a request is sent are batched into it, and
+// are notified once the applied index reaches the read index.
+func (s *EtcdServer) linearizableReadLoop() {
+	var rs raft.ReadState
+
+	for {
+		select {
+		case <-s.readwaitc:
+		case <-s.stopping:
+			return
+		}
+
+		nextnr := newNotifier()
+		s.readMu.Lock()
+		nr := s.readNotifier
+		s.readNotifier = nextnr
+		s.readMu.Unlock()
+
+		rctx := make([]byte, 8)
+		binary.BigEndian.PutUint64(rctx, s.reqIDGen.Next())
+
+		cctx, cancel := context.WithTimeout(context.Background(), s.Cfg.ReqTimeout())
+		if err := s.r.ReadIndex(cctx, uint64(s.ID()), rctx); err != nil {
+			cancel()
+			if err == raft.ErrStopped {
+				return
+			}
+			plog.Errorf("failed to get read index from raft: %v", err)
+			nr.notify(err)
+			continue
+		}
+		cancel()
+
+		var (
+			timeout bool
+			done    bool
+		)
+		for !timeout && !done {
+			select {
+			case rs = <-s.r.readStateC:
+				done = bytes.Equal(rs.RequestCtx, rctx)
+				if !done {
+					// a response to a read index request that timed out.
+					plog.Warningf("ignored out-of-date read index response (want %v, got %v)", rctx, rs.RequestCtx)
+				}
+			case <-time.After(s.Cfg.ReqTimeout()):
+				plog.Warningf("timed out waiting for read index response")
+				nr.notify(ErrTimeout)
+				timeout = true
+			case <-s.stopping:
+				return
+			}
+		}
+		if !done {
+			continue
+		}
+		select {
+		case <-s.applyWait.Wait(rs.Index):
+		case <-s.stopping:
+			return
+		}
+		nr.notify(nil)
+	}
+}
+
+// linearizableReadNotify waits until a linearizable read issued now can be
+// served from the local state.
+func (s *EtcdServer) linearizableReadNotify(ctx context.Context) error {
+	s.readMu.RLock()
+	nc := s.readNotifier
+	s.readMu.RUnlock()
+
+	// signal linearizable loop for current notify if it hasn't been already
+	select {
+	case s.readwaitc <- struct{}{}:
+	default:
+	}
+
+	// wait for read state notification
+	select {
+	case <-nc.c:
+		return nc.err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return ErrStopped
+	}
+}
@@ -222,6 +222,13 @@ func TestV3TxnDuplicateKeys(t *testing.T) {
 	},
 	}
 
+	txnOp := func(success, failure []*pb.RequestOp) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{
+			RequestTxn: &pb.TxnRequest{Success: success, Failure: failure},
+		},
+		}
+	}
+
 	kvc := toGRPC(clus.RandClient()).KV
 	tests := []struct {
 		txnSuccess []*pb.RequestOp
@@ -251,6 +258,31 @@ func TestV3TxnDuplicateKeys(t *testing.T) {
 		{
 			txnSuccess: []*pb.RequestOp{putreq, delOutOfRangeReq},
 
+			werr: nil,
+		},
+		{
+			txnSuccess: []*pb.RequestOp{putreq, txnOp([]*pb.RequestOp{putreq}, nil)},
+
+			werr: rpctypes.ErrGRPCDuplicateKey,
+		},
+		{
+			txnSuccess: []*pb.RequestOp{txnOp([]*pb.RequestOp{putreq}, nil), delInRangeReq},
+
+			werr: rpctypes.ErrGRPCDuplicateKey,
+		},
+		{
+			txnSuccess: []*pb.RequestOp{txnOp([]*pb.RequestOp{delKeyReq}, nil), txnOp(nil, []*pb.RequestOp{putreq})},
+
+			werr: rpctypes.ErrGRPCDuplicateKey,
+		},
+		{
+			txnSuccess: []*pb.RequestOp{txnOp([]*pb.RequestOp{putreq}, []*pb.RequestOp{putreq, delOutOfRangeReq})},
+
+			werr: nil,
+		},
+		{
+			txnSuccess: []*pb.RequestOp{txnOp([]*pb.RequestOp{putreq}, []*pb.RequestOp{delKeyReq})},
+
 			werr: nil,
 		},
 	}
@@ -0,0 +1,44 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+// leaseWithTime is an expiry scheduled for a lease. The expiry of a lease
+// changes whenever it is renewed; its stale entries are not removed from the
+// queue but discarded when they are popped.
+type leaseWithTime struct {
+	id LeaseID
+	// expiry time in unixnano
+	time int64
+}
+
+// leaseQueue is a min-heap of lease expiries. It implements heap.Interface.
+type leaseQueue []*leaseWithTime
+
+func (pq leaseQueue) Len() int           { return len(pq) }
+func (pq leaseQueue) Less(i, j int) bool { return pq[i].time < pq[j].time }
+func (pq leaseQueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *leaseQueue) Push(x interface{}) {
+	*pq = append(*pq, x.(*leaseWithTime))
+}
+
+func (pq *leaseQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil // avoid memory leak
+	*pq = old[:n-1]
+	return item
+}
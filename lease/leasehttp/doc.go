@@ -12,5 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package leasehttp serves lease renewals made through HTTP requests.
+// Package leasehttp serves lease renewals and time-to-live queries made
+// through HTTP requests.
 package leasehttp
@@ -25,7 +25,16 @@ import (
 	"github.com/coreos/etcd/lease"
 )
 
-// NewHandler returns an http Handler for lease renewals
+const (
+	// LeasePrefix is the path lease renewals are served on.
+	LeasePrefix = "/leases"
+	// LeaseInternalPrefix is the path lease time-to-live queries are
+	// served on.
+	LeaseInternalPrefix = "/leases/internal"
+)
+
+// NewHandler returns an http Handler for lease renewals and time-to-live
+// queries
 func NewHandler(l lease.Lessor) http.Handler {
 	return &leaseHandler{l}
 }
@@ -44,6 +53,11 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Path == LeaseInternalPrefix {
+		h.serveTimeToLive(w, b)
+		return
+	}
+
 	lreq := pb.LeaseKeepAliveRequest{}
 	if err := lreq.Unmarshal(b); err != nil {
 		http.Error(w, "error unmarshalling request", http.StatusBadRequest)
@@ -73,6 +87,41 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(v)
 }
 
+func (h *leaseHandler) serveTimeToLive(w http.ResponseWriter, b []byte) {
+	lreq := pb.LeaseTimeToLiveRequest{}
+	if err := lreq.Unmarshal(b); err != nil {
+		http.Error(w, "error unmarshalling request", http.StatusBadRequest)
+		return
+	}
+
+	l := h.l.Lookup(lease.LeaseID(lreq.ID))
+	if l == nil {
+		http.Error(w, lease.ErrLeaseNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	// TODO: fill out ResponseHeader
+	resp := &pb.LeaseTimeToLiveResponse{
+		Header:     &pb.ResponseHeader{},
+		ID:         lreq.ID,
+		TTL:        int64(l.Remaining().Seconds()),
+		GrantedTTL: l.TTL,
+	}
+	if lreq.Keys {
+		for _, k := range l.Keys() {
+			resp.Keys = append(resp.Keys, []byte(k))
+		}
+	}
+	v, err := resp.Marshal()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/protobuf")
+	w.Write(v)
+}
+
 // RenewHTTP renews a lease at a given primary server.
 // TODO: Batch request in future?
 func RenewHTTP(id lease.LeaseID, url string, rt http.RoundTripper, timeout time.Duration) (int64, error) {
@@ -110,3 +159,41 @@ func RenewHTTP(id lease.LeaseID, url string, rt http.RoundTripper, timeout time.
 	}
 	return lresp.TTL, nil
 }
+
+// TimeToLiveHTTP retrieves the information of a lease at a given primary
+// server, along with its attached keys if keys is true.
+func TimeToLiveHTTP(id lease.LeaseID, keys bool, url string, rt http.RoundTripper, timeout time.Duration) (*pb.LeaseTimeToLiveResponse, error) {
+	// will post lreq protobuf to leader
+	lreq, err := (&pb.LeaseTimeToLiveRequest{ID: int64(id), Keys: keys}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &http.Client{Transport: rt, Timeout: timeout}
+	resp, err := cc.Post(url, "application/protobuf", bytes.NewReader(lreq))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, lease.ErrLeaseNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lease: unknown error(%s)", string(b))
+	}
+
+	lresp := &pb.LeaseTimeToLiveResponse{}
+	if err := lresp.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf(`lease: %v. data = "%s"`, err, string(b))
+	}
+	if lresp.ID != int64(id) {
+		return nil, fmt.Errorf("lease: timetolive id mismatch")
+	}
+	return lresp, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leasehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc/backend"
+)
+
+func TestRenewHTTP(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	defer be.Close()
+
+	le := lease.NewLessor(be)
+	defer le.Stop()
+	le.Promote(time.Second)
+	l, err := le.Grant(1, int64(5))
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le))
+	defer ts.Close()
+
+	ttl, err := RenewHTTP(l.ID, ts.URL+LeasePrefix, http.DefaultTransport, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl != 5 {
+		t.Fatalf("ttl expected 5, got %d", ttl)
+	}
+}
+
+func TestTimeToLiveHTTP(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	defer be.Close()
+
+	le := lease.NewLessor(be)
+	defer le.Stop()
+	le.Promote(time.Second)
+	l, err := le.Grant(1, int64(5))
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	if err = le.Attach(l.ID, []lease.LeaseItem{{Key: "foo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le))
+	defer ts.Close()
+
+	resp, err := TimeToLiveHTTP(l.ID, true, ts.URL+LeaseInternalPrefix, http.DefaultTransport, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != 1 || resp.GrantedTTL != 5 {
+		t.Fatalf("ID, grantedTTL expected 1, 5, got %d, %d", resp.ID, resp.GrantedTTL)
+	}
+	if wkeys := [][]byte{[]byte("foo")}; !reflect.DeepEqual(resp.Keys, wkeys) {
+		t.Fatalf("keys expected %q, got %q", wkeys, resp.Keys)
+	}
+
+	_, err = TimeToLiveHTTP(2, false, ts.URL+LeaseInternalPrefix, http.DefaultTransport, time.Second)
+	if err != lease.ErrLeaseNotFound {
+		t.Fatalf("err expected %v, got %v", lease.ErrLeaseNotFound, err)
+	}
+}
@@ -15,14 +15,18 @@
 package lease
 
 import (
+	"container/heap"
 	"encoding/binary"
 	"errors"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/lease/leasepb"
 	"github.com/coreos/etcd/mvcc/backend"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -38,6 +42,18 @@ var (
 	// the offset of unix time (1970yr to seconds).
 	forever = time.Unix(math.MaxInt64>>1, 0)
 
+	// leaseRevokeRate is the maximum number of expired leases handed out
+	// for revocation per second.
+	leaseRevokeRate = 1000
+
+	// expiredLeaseRetryInterval is the time after which an expired lease
+	// is handed out for revocation again if it has not been revoked.
+	expiredLeaseRetryInterval = 3 * time.Second
+
+	// maxLeaseCheckpointBatchSize is the maximum number of leases
+	// checkpointed in a single proposal.
+	maxLeaseCheckpointBatchSize = 1000
+
 	ErrNotPrimary    = errors.New("not a primary lessor")
 	ErrLeaseNotFound = errors.New("lease not found")
 	ErrLeaseExists   = errors.New("lease already exists")
@@ -54,6 +70,11 @@ type RangeDeleter interface {
 	DeleteRange(key, end []byte) (int64, int64)
 }
 
+// Checkpointer permits checkpointing of lease remaining TTLs to the
+// consensus log. Checkpointing keeps a new primary lessor from extending
+// the leases to their full TTLs.
+type Checkpointer func(ctx context.Context, lc *pb.LeaseCheckpointRequest)
+
 // Lessor owns leases. It can grant, revoke, renew and modify leases for lessee.
 type Lessor interface {
 	// SetRangeDeleter sets the RangeDeleter to the Lessor.
@@ -61,6 +82,11 @@ type Lessor interface {
 	// the set RangeDeleter.
 	SetRangeDeleter(dr RangeDeleter)
 
+	// SetCheckpointer sets the Checkpointer to the Lessor. The primary
+	// lessor checkpoints the remaining TTLs of its leases every interval
+	// with the set Checkpointer.
+	SetCheckpointer(interval time.Duration, cp Checkpointer)
+
 	// Grant grants a lease that expires at least after TTL seconds.
 	Grant(id LeaseID, ttl int64) (*Lease, error)
 	// Revoke revokes a lease with given ID. The item attached to the
@@ -68,6 +94,11 @@ type Lessor interface {
 	// will be returned.
 	Revoke(id LeaseID) error
 
+	// Checkpoint applies the remainingTTL of a lease. The remainingTTL is
+	// used in Promote to set the expiry of the lease to the remainingTTL
+	// instead of the full TTL.
+	Checkpoint(id LeaseID, remainingTTL int64) error
+
 	// Attach attaches given leaseItem to the lease with given LeaseID.
 	// If the lease does not exist, an error will be returned.
 	Attach(id LeaseID, items []LeaseItem) error
@@ -78,7 +109,8 @@ type Lessor interface {
 
 	// Promote promotes the lessor to be the primary lessor. Primary lessor manages
 	// the expiration and renew of leases.
-	// Newly promoted lessor renew the TTL of all lease to extend + previous TTL.
+	// Newly promoted lessor renew the TTL of all lease to extend + previous TTL,
+	// or to extend + the checkpointed remaining TTL if any.
 	Promote(extend time.Duration)
 
 	// Demote demotes the lessor from being the primary lessor.
@@ -91,6 +123,9 @@ type Lessor interface {
 	// Lookup gives the lease at a given lease id, if any
 	Lookup(id LeaseID) *Lease
 
+	// Leases lists all leases, sorted by ID.
+	Leases() []*Lease
+
 	// ExpiredLeasesC returns a chan that is used to receive expired leases.
 	ExpiredLeasesC() <-chan []*Lease
 
@@ -122,23 +157,30 @@ type lessor struct {
 	// Usually this should not be a problem. Lease should not be that sensitive to timing.
 	primary bool
 
-	// TODO: probably this should be a heap with a secondary
-	// id index.
-	// Now it is O(N) to loop over the leases to find expired ones.
-	// We want to make Grant, Revoke, and findExpiredLeases all O(logN) and
-	// Renew O(1).
-	// findExpiredLeases and Renew should be the most frequent operations.
 	leaseMap map[LeaseID]*Lease
 
+	// leaseHeap orders the expiries of the leases while the lessor is the
+	// primary, so that findExpiredLeases only visits the expired leases.
+	leaseHeap leaseQueue
+
 	// When a lease expires, the lessor will delete the
 	// leased range (or key) by the RangeDeleter.
 	rd RangeDeleter
 
+	// cp checkpoints the remaining TTLs of the leases every
+	// checkpointInterval while the lessor is the primary.
+	cp                 Checkpointer
+	checkpointInterval time.Duration
+	lastCheckpoint     time.Time
+
 	// backend to persist leases. We only persist lease ID and expiry for now.
 	// The leased items can be recovered by iterating all the keys in kv.
 	b backend.Backend
 
 	expiredC chan []*Lease
+	// ctx is canceled when the lessor is stopped; it bounds the checkpoints.
+	ctx    context.Context
+	cancel context.CancelFunc
 	// stopC is a channel whose closure indicates that the lessor should be stopped.
 	stopC chan struct{}
 	// doneC is a channel whose closure indicates that the lessor is stopped.
@@ -158,6 +200,7 @@ func newLessor(b backend.Backend) *lessor {
 		stopC:    make(chan struct{}),
 		doneC:    make(chan struct{}),
 	}
+	l.ctx, l.cancel = context.WithCancel(context.Background())
 	l.initAndRecover()
 
 	go l.runLoop()
@@ -172,6 +215,14 @@ func (le *lessor) SetRangeDeleter(rd RangeDeleter) {
 	le.rd = rd
 }
 
+func (le *lessor) SetCheckpointer(interval time.Duration, cp Checkpointer) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	le.cp = cp
+	le.checkpointInterval = interval
+}
+
 func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	if id == NoLease {
 		return nil, ErrLeaseNotFound
@@ -190,6 +241,7 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 
 	if le.primary {
 		l.refresh(0)
+		le.scheduleExpiry(l)
 	} else {
 		l.forever()
 	}
@@ -212,8 +264,8 @@ func (le *lessor) Revoke(id LeaseID) error {
 	le.mu.Unlock()
 
 	if le.rd != nil {
-		for item := range l.itemSet {
-			le.rd.DeleteRange([]byte(item.Key), nil)
+		for _, key := range l.Keys() {
+			le.rd.DeleteRange([]byte(key), nil)
 		}
 	}
 
@@ -225,23 +277,55 @@ func (le *lessor) Revoke(id LeaseID) error {
 	return nil
 }
 
+func (le *lessor) Checkpoint(id LeaseID, remainingTTL int64) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	l := le.leaseMap[id]
+	if l == nil {
+		return ErrLeaseNotFound
+	}
+	l.remainingTTL = remainingTTL
+	l.persistTo(le.b)
+	return nil
+}
+
 // Renew renews an existing lease. If the given lease does not exist or
 // has expired, an error will be returned.
 func (le *lessor) Renew(id LeaseID) (int64, error) {
 	le.mu.Lock()
-	defer le.mu.Unlock()
 
 	if !le.primary {
+		le.mu.Unlock()
 		// forward renew request to primary instead of returning error.
 		return -1, ErrNotPrimary
 	}
 
 	l := le.leaseMap[id]
 	if l == nil {
+		le.mu.Unlock()
 		return -1, ErrLeaseNotFound
 	}
 
+	cp, checkpointed := le.cp, l.remainingTTL > 0
+	// unlock before doing external work
+	le.mu.Unlock()
+
+	// clear the checkpoint so that the renewed lease is not rolled back
+	// to its remaining TTL on the next promotion.
+	if cp != nil && checkpointed {
+		cp(le.ctx, &pb.LeaseCheckpointRequest{Checkpoints: []*pb.LeaseCheckpoint{{ID: int64(l.ID), Remaining_TTL: 0}}})
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	if !le.primary {
+		// demoted while checkpointing.
+		return -1, ErrNotPrimary
+	}
+	l.remainingTTL = 0
 	l.refresh(0)
+	le.scheduleExpiry(l)
 	return l.TTL, nil
 }
 
@@ -254,16 +338,34 @@ func (le *lessor) Lookup(id LeaseID) *Lease {
 	return nil
 }
 
+func (le *lessor) Leases() []*Lease {
+	le.mu.Lock()
+	ls := make([]*Lease, 0, len(le.leaseMap))
+	for _, l := range le.leaseMap {
+		ls = append(ls, l)
+	}
+	le.mu.Unlock()
+
+	sort.Sort(leasesByID(ls))
+	return ls
+}
+
 func (le *lessor) Promote(extend time.Duration) {
 	le.mu.Lock()
 	defer le.mu.Unlock()
 
 	le.primary = true
+	// the leases are about to be refreshed; checkpoint them an interval later.
+	le.lastCheckpoint = time.Now()
 
 	// refresh the expiries of all leases.
+	pq := make(leaseQueue, 0, len(le.leaseMap))
 	for _, l := range le.leaseMap {
 		l.refresh(extend)
+		pq = append(pq, &leaseWithTime{id: l.ID, time: l.expiryTime().UnixNano()})
 	}
+	heap.Init(&pq)
+	le.leaseHeap = pq
 }
 
 func (le *lessor) Demote() {
@@ -274,6 +376,7 @@ func (le *lessor) Demote() {
 	for _, l := range le.leaseMap {
 		l.forever()
 	}
+	le.leaseHeap = nil
 
 	le.primary = false
 }
@@ -290,9 +393,11 @@ func (le *lessor) Attach(id LeaseID, items []LeaseItem) error {
 		return ErrLeaseNotFound
 	}
 
+	l.mu.Lock()
 	for _, it := range items {
 		l.itemSet[it] = struct{}{}
 	}
+	l.mu.Unlock()
 	return nil
 }
 
@@ -307,9 +412,11 @@ func (le *lessor) Detach(id LeaseID, items []LeaseItem) error {
 		return ErrLeaseNotFound
 	}
 
+	l.mu.Lock()
 	for _, it := range items {
 		delete(l.itemSet, it)
 	}
+	l.mu.Unlock()
 	return nil
 }
 
@@ -320,6 +427,7 @@ func (le *lessor) Recover(b backend.Backend, rd RangeDeleter) {
 	le.b = b
 	le.rd = rd
 	le.leaseMap = make(map[LeaseID]*Lease)
+	le.leaseHeap = nil
 
 	le.initAndRecover()
 }
@@ -329,6 +437,7 @@ func (le *lessor) ExpiredLeasesC() <-chan []*Lease {
 }
 
 func (le *lessor) Stop() {
+	le.cancel()
 	close(le.stopC)
 	<-le.doneC
 }
@@ -338,13 +447,26 @@ func (le *lessor) runLoop() {
 
 	for {
 		var ls []*Lease
+		var cps []*pb.LeaseCheckpoint
 
 		le.mu.Lock()
 		if le.primary {
-			ls = le.findExpiredLeases()
+			// the loop runs twice a second.
+			ls = le.findExpiredLeases(leaseRevokeRate / 2)
+			cps = le.findCheckpoints()
 		}
+		cp := le.cp
 		le.mu.Unlock()
 
+		for len(cps) != 0 {
+			n := len(cps)
+			if n > maxLeaseCheckpointBatchSize {
+				n = maxLeaseCheckpointBatchSize
+			}
+			cp(le.ctx, &pb.LeaseCheckpointRequest{Checkpoints: cps[:n]})
+			cps = cps[n:]
+		}
+
 		if len(ls) != 0 {
 			select {
 			case <-le.stopC:
@@ -365,23 +487,74 @@ func (le *lessor) runLoop() {
 	}
 }
 
-// findExpiredLeases loops all the leases in the leaseMap and returns the expired
-// leases that needed to be revoked.
-func (le *lessor) findExpiredLeases() []*Lease {
+// findExpiredLeases pops the expired leases that need to be revoked off
+// leaseHeap, at most limit of them. The returned leases are scheduled to be
+// returned again after expiredLeaseRetryInterval in case their revocation
+// fails.
+func (le *lessor) findExpiredLeases(limit int) []*Lease {
 	leases := make([]*Lease, 0, 16)
 	now := time.Now()
 
-	for _, l := range le.leaseMap {
+	for len(le.leaseHeap) > 0 && len(leases) < limit {
+		item := le.leaseHeap[0]
 		// TODO: probably should change to <= 100-500 millisecond to
 		// make up committing latency.
-		if l.expiry.Sub(now) <= 0 {
-			leases = append(leases, l)
+		if item.time > now.UnixNano() {
+			break
+		}
+		heap.Pop(&le.leaseHeap)
+
+		l := le.leaseMap[item.id]
+		if l == nil {
+			// revoked
+			continue
+		}
+		if l.expiryTime().After(now) {
+			// renewed; the renewal scheduled the new expiry.
+			continue
 		}
+		leases = append(leases, l)
+
+		item.time = now.Add(expiredLeaseRetryInterval).UnixNano()
+		heap.Push(&le.leaseHeap, item)
 	}
 
 	return leases
 }
 
+// scheduleExpiry pushes the refreshed expiry of the lease onto leaseHeap.
+func (le *lessor) scheduleExpiry(l *Lease) {
+	heap.Push(&le.leaseHeap, &leaseWithTime{id: l.ID, time: l.expiryTime().UnixNano()})
+}
+
+// findCheckpoints returns the remaining TTLs of the leases to checkpoint
+// if the checkpoint interval has elapsed since the last checkpoint. The
+// leases whose TTLs do not exceed the interval are not worth checkpointing.
+func (le *lessor) findCheckpoints() []*pb.LeaseCheckpoint {
+	if le.cp == nil || le.checkpointInterval <= 0 {
+		return nil
+	}
+	now := time.Now()
+	if now.Sub(le.lastCheckpoint) < le.checkpointInterval {
+		return nil
+	}
+	le.lastCheckpoint = now
+
+	var cps []*pb.LeaseCheckpoint
+	for _, l := range le.leaseMap {
+		if time.Duration(l.TTL)*time.Second <= le.checkpointInterval {
+			continue
+		}
+		remaining := int64(math.Ceil(l.expiryTime().Sub(now).Seconds()))
+		if remaining <= 0 {
+			// expired; it is about to be revoked.
+			continue
+		}
+		cps = append(cps, &pb.LeaseCheckpoint{ID: int64(l.ID), Remaining_TTL: remaining})
+	}
+	return cps
+}
+
 // get gets the lease with given id.
 // get is a helper function for testing, at least for now.
 func (le *lessor) get(id LeaseID) *Lease {
@@ -407,8 +580,9 @@ func (le *lessor) initAndRecover() {
 		}
 		ID := LeaseID(lpb.ID)
 		le.leaseMap[ID] = &Lease{
-			ID:  ID,
-			TTL: lpb.TTL,
+			ID:           ID,
+			TTL:          lpb.TTL,
+			remainingTTL: lpb.RemainingTTL,
 			// itemSet will be filled in when recover key-value pairs
 			// set expiry to forever, refresh when promoted
 			itemSet: make(map[LeaseItem]struct{}),
@@ -423,16 +597,23 @@ func (le *lessor) initAndRecover() {
 type Lease struct {
 	ID  LeaseID
 	TTL int64 // time to live in seconds
+	// remainingTTL is the checkpointed remaining time to live in seconds,
+	// or 0 if the lease has not been checkpointed since it was renewed.
+	remainingTTL int64
 
+	// mu protects itemSet, which is read outside of the lessor lock.
+	mu      sync.RWMutex
 	itemSet map[LeaseItem]struct{}
+
+	expiryMu sync.RWMutex
 	// expiry time in unixnano
 	expiry time.Time
 }
 
-func (l Lease) persistTo(b backend.Backend) {
+func (l *Lease) persistTo(b backend.Backend) {
 	key := int64ToBytes(int64(l.ID))
 
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.TTL)}
+	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.TTL), RemainingTTL: l.remainingTTL}
 	val, err := lpb.Marshal()
 	if err != nil {
 		panic("failed to marshal lease proto item")
@@ -443,7 +624,7 @@ func (l Lease) persistTo(b backend.Backend) {
 	b.BatchTx().Unlock()
 }
 
-func (l Lease) removeFrom(b backend.Backend) {
+func (l *Lease) removeFrom(b backend.Backend) {
 	key := int64ToBytes(int64(l.ID))
 
 	b.BatchTx().Lock()
@@ -452,12 +633,18 @@ func (l Lease) removeFrom(b backend.Backend) {
 }
 
 // refresh refreshes the expiry of the lease. It extends the expiry at least
-// minLeaseTTL second.
+// minLeaseTTL second, or by the checkpointed remaining TTL if any.
 func (l *Lease) refresh(extend time.Duration) {
 	if l.TTL < minLeaseTTL {
 		l.TTL = minLeaseTTL
 	}
-	l.expiry = time.Now().Add(extend + time.Second*time.Duration(l.TTL))
+	ttl := l.TTL
+	if l.remainingTTL > 0 {
+		ttl = l.remainingTTL
+	}
+	l.expiryMu.Lock()
+	l.expiry = time.Now().Add(extend + time.Second*time.Duration(ttl))
+	l.expiryMu.Unlock()
 }
 
 // forever sets the expiry of lease to be forever.
@@ -465,9 +652,43 @@ func (l *Lease) forever() {
 	if l.TTL < minLeaseTTL {
 		l.TTL = minLeaseTTL
 	}
+	l.expiryMu.Lock()
 	l.expiry = forever
+	l.expiryMu.Unlock()
+}
+
+func (l *Lease) expiryTime() time.Time {
+	l.expiryMu.RLock()
+	defer l.expiryMu.RUnlock()
+	return l.expiry
+}
+
+// Remaining returns the remaining time of the lease. The leases of a lessor
+// that is not the primary never expire, so their remaining time is not
+// meaningful.
+func (l *Lease) Remaining() time.Duration {
+	return l.expiryTime().Sub(time.Now())
+}
+
+// Keys returns the keys attached to the lease, sorted.
+func (l *Lease) Keys() []string {
+	l.mu.RLock()
+	keys := make([]string, 0, len(l.itemSet))
+	for item := range l.itemSet {
+		keys = append(keys, item.Key)
+	}
+	l.mu.RUnlock()
+
+	sort.Strings(keys)
+	return keys
 }
 
+type leasesByID []*Lease
+
+func (ls leasesByID) Len() int           { return len(ls) }
+func (ls leasesByID) Less(i, j int) bool { return ls[i].ID < ls[j].ID }
+func (ls leasesByID) Swap(i, j int)      { ls[i], ls[j] = ls[j], ls[i] }
+
 type LeaseItem struct {
 	Key string
 }
@@ -484,10 +705,14 @@ type FakeLessor struct{}
 
 func (fl *FakeLessor) SetRangeDeleter(dr RangeDeleter) {}
 
+func (fl *FakeLessor) SetCheckpointer(interval time.Duration, cp Checkpointer) {}
+
 func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) { return nil, nil }
 
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
+func (fl *FakeLessor) Checkpoint(id LeaseID, remainingTTL int64) error { return nil }
+
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
 
 func (fl *FakeLessor) Detach(id LeaseID, items []LeaseItem) error { return nil }
@@ -500,6 +725,8 @@ func (fl *FakeLessor) Renew(id LeaseID) (int64, error) { return 10, nil }
 
 func (le *FakeLessor) Lookup(id LeaseID) *Lease { return nil }
 
+func (fl *FakeLessor) Leases() []*Lease { return nil }
+
 func (fl *FakeLessor) ExpiredLeasesC() <-chan []*Lease { return nil }
 
 func (fl *FakeLessor) Recover(b backend.Backend, rd RangeDeleter) {}
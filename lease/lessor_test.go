@@ -23,7 +23,9 @@ import (
 	"testing"
 	"time"
 
+	pb "github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/mvcc/backend"
+	"golang.org/x/net/context"
 )
 
 // TestLessorGrant ensures Lessor can grant wanted lease.
@@ -192,6 +194,117 @@ func TestLessorDetach(t *testing.T) {
 	}
 }
 
+// TestLessorLeases ensures Lessor lists all leases sorted by ID
+// with their remaining time and attached keys.
+func TestLessorLeases(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	le.Promote(0)
+
+	for _, id := range []LeaseID{3, 1, 2} {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatalf("could not grant lease %d (%v)", id, err)
+		}
+	}
+	if err := le.Attach(2, []LeaseItem{{"foo"}, {"bar"}}); err != nil {
+		t.Fatalf("failed to attach items to the lease: %v", err)
+	}
+
+	ls := le.Leases()
+	ids := make([]LeaseID, len(ls))
+	for i, l := range ls {
+		ids[i] = l.ID
+	}
+	if wids := []LeaseID{1, 2, 3}; !reflect.DeepEqual(ids, wids) {
+		t.Errorf("ids = %v, want %v", ids, wids)
+	}
+
+	l := le.Lookup(2)
+	if r := l.Remaining(); r <= 90*time.Second || r > 100*time.Second {
+		t.Errorf("remaining = %v, want in (90s, 100s]", r)
+	}
+	if keys, wkeys := l.Keys(), []string{"bar", "foo"}; !reflect.DeepEqual(keys, wkeys) {
+		t.Errorf("keys = %v, want %v", keys, wkeys)
+	}
+}
+
+// TestLessorFindExpiredLeases ensures Lessor finds the expired leases
+// that are neither revoked nor renewed, at most limit at a time, and
+// does not find them again before the retry interval.
+func TestLessorFindExpiredLeases(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	// stop the run loop so that it does not take the expired leases.
+	le.Stop()
+
+	for id := LeaseID(1); id <= 4; id++ {
+		if _, err := le.Grant(id, 10); err != nil {
+			t.Fatalf("could not grant lease %d (%v)", id, err)
+		}
+	}
+	if _, err := le.Grant(5, 100); err != nil {
+		t.Fatalf("could not grant lease 5 (%v)", err)
+	}
+	// expire the leases with 10 seconds TTL.
+	le.Promote(-30 * time.Second)
+	if err := le.Revoke(3); err != nil {
+		t.Fatalf("failed to revoke lease (%v)", err)
+	}
+	if _, err := le.Renew(4); err != nil {
+		t.Fatalf("failed to renew lease (%v)", err)
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	var ids []LeaseID
+	for _, limit := range []int{1, 10} {
+		ls := le.findExpiredLeases(limit)
+		if len(ls) > limit {
+			t.Fatalf("len(leases) = %d, want at most %d", len(ls), limit)
+		}
+		for _, l := range ls {
+			ids = append(ids, l.ID)
+		}
+	}
+	sort.Sort(leaseIDs(ids))
+	if wids := []LeaseID{1, 2}; !reflect.DeepEqual(ids, wids) {
+		t.Errorf("expired = %v, want %v", ids, wids)
+	}
+	if ls := le.findExpiredLeases(10); len(ls) != 0 {
+		t.Errorf("len(leases) = %d, want 0 before the retry interval", len(ls))
+	}
+}
+
+// TestLessorExpire ensures the primary Lessor sends the expired leases
+// to ExpiredLeasesC.
+func TestLessorExpire(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	defer le.Stop()
+	if _, err := le.Grant(1, 10); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	le.Promote(-30 * time.Second)
+
+	select {
+	case ls := <-le.ExpiredLeasesC():
+		if len(ls) != 1 || ls[0].ID != 1 {
+			t.Errorf("expired leases = %v, want lease 1", ls)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the expired lease")
+	}
+}
+
 // TestLessorRecover ensures Lessor recovers leases from
 // persist backend.
 func TestLessorRecover(t *testing.T) {
@@ -219,6 +332,151 @@ func TestLessorRecover(t *testing.T) {
 	}
 }
 
+// TestLessorCheckpoint ensures a promoted Lessor expires a checkpointed
+// lease after its remaining TTL, also after recovering it from the backend.
+func TestLessorCheckpoint(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	defer le.Stop()
+	if _, err := le.Grant(1, 100); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	if err := le.Checkpoint(1, 10); err != nil {
+		t.Fatalf("failed to checkpoint lease (%v)", err)
+	}
+	if err := le.Checkpoint(2, 10); err != ErrLeaseNotFound {
+		t.Errorf("err = %v, want %v", err, ErrLeaseNotFound)
+	}
+
+	nle := newLessor(be)
+	defer nle.Stop()
+	for i, l := range []*lessor{le, nle} {
+		l.Promote(0)
+		if r := l.get(1).Remaining(); r <= 9*time.Second || r > 10*time.Second {
+			t.Errorf("#%d: remaining = %v, want in (9s, 10s]", i, r)
+		}
+	}
+}
+
+// TestLessorRenewClearsCheckpoint ensures renewing a checkpointed lease
+// checkpoints it again with no remaining TTL, so that the next promotion
+// extends it to its full TTL.
+func TestLessorRenewClearsCheckpoint(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	defer le.Stop()
+	var cps []*pb.LeaseCheckpoint
+	le.SetCheckpointer(0, func(ctx context.Context, lc *pb.LeaseCheckpointRequest) {
+		for _, c := range lc.Checkpoints {
+			cps = append(cps, c)
+			le.Checkpoint(LeaseID(c.ID), c.Remaining_TTL)
+		}
+	})
+	le.Promote(0)
+
+	if _, err := le.Grant(1, 100); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	if _, err := le.Renew(1); err != nil {
+		t.Fatalf("failed to renew lease (%v)", err)
+	}
+	if len(cps) != 0 {
+		t.Fatalf("checkpoints = %v, want none for a lease without remaining TTL", cps)
+	}
+
+	le.Checkpoint(1, 10)
+	if _, err := le.Renew(1); err != nil {
+		t.Fatalf("failed to renew lease (%v)", err)
+	}
+	wcps := []*pb.LeaseCheckpoint{{ID: 1, Remaining_TTL: 0}}
+	if !reflect.DeepEqual(cps, wcps) {
+		t.Errorf("checkpoints = %v, want %v", cps, wcps)
+	}
+
+	le.Demote()
+	le.Promote(0)
+	if r := le.get(1).Remaining(); r <= 90*time.Second {
+		t.Errorf("remaining = %v, want more than 90s", r)
+	}
+}
+
+// TestLessorFindCheckpoints ensures the primary Lessor checkpoints the
+// remaining TTLs of the leases that outlive the checkpoint interval, once
+// per interval.
+func TestLessorFindCheckpoints(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(be)
+	defer le.Stop()
+	le.SetCheckpointer(time.Minute, func(ctx context.Context, lc *pb.LeaseCheckpointRequest) {})
+	le.Promote(0)
+
+	if _, err := le.Grant(1, 100); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	if _, err := le.Grant(2, 60); err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	if cps := le.findCheckpoints(); cps != nil {
+		t.Fatalf("checkpoints = %v, want none within the interval", cps)
+	}
+
+	le.lastCheckpoint = time.Now().Add(-time.Minute)
+	cps := le.findCheckpoints()
+	if len(cps) != 1 || cps[0].ID != 1 {
+		t.Fatalf("checkpoints = %v, want lease 1 only", cps)
+	}
+	if r := cps[0].Remaining_TTL; r <= 90 || r > 100 {
+		t.Errorf("remaining TTL = %d, want in (90, 100]", r)
+	}
+	if cps = le.findCheckpoints(); cps != nil {
+		t.Errorf("checkpoints = %v, want none within the interval", cps)
+	}
+}
+
+func BenchmarkLessorFindExpired1000(b *testing.B)   { benchmarkLessorFindExpired(1000, b) }
+func BenchmarkLessorFindExpired10000(b *testing.B)  { benchmarkLessorFindExpired(10000, b) }
+func BenchmarkLessorFindExpired100000(b *testing.B) { benchmarkLessorFindExpired(100000, b) }
+
+// benchmarkLessorFindExpired measures a run loop tick of the primary lessor
+// with size leases, none of which has expired.
+func benchmarkLessorFindExpired(size int, b *testing.B) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.RemoveAll(tmpPath)
+	defer be.Close()
+
+	le := newLessor(be)
+	le.Stop()
+	le.Promote(0)
+	for i := 0; i < size; i++ {
+		le.Grant(LeaseID(i+1), int64(100+i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		le.mu.Lock()
+		le.findExpiredLeases(leaseRevokeRate / 2)
+		le.mu.Unlock()
+	}
+}
+
+type leaseIDs []LeaseID
+
+func (ids leaseIDs) Len() int           { return len(ids) }
+func (ids leaseIDs) Less(i, j int) bool { return ids[i] < ids[j] }
+func (ids leaseIDs) Swap(i, j int)      { ids[i], ids[j] = ids[j], ids[i] }
+
 type fakeDeleter struct {
 	deleted []string
 }
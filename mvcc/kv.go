@@ -24,6 +24,14 @@ type RangeOptions struct {
 	Limit int64
 	Rev   int64
 	Count bool
+
+	// MinModRev and MaxModRev, and MinCreateRev and MaxCreateRev, bound the
+	// mod and create revisions of the returned keys. They are applied before
+	// the limit. A bound of 0 leaves that side unbounded.
+	MinModRev    int64
+	MaxModRev    int64
+	MinCreateRev int64
+	MaxCreateRev int64
 }
 
 type RangeResult struct {
@@ -70,10 +78,15 @@ type KV interface {
 	// TxnBegin begins a txn. Only Txn prefixed operation can be executed, others will be blocked
 	// until txn ends. Only one on-going txn is allowed.
 	// TxnBegin returns an int64 txn ID.
-	// All txn prefixed operations with same txn ID will be done with the same rev.
+	// All txn prefixed operations with same txn ID will be done with the same rev,
+	// unless TxnNextRev is called in between.
 	TxnBegin() int64
 	// TxnEnd ends the on-going txn with txn ID. If the on-going txn ID is not matched, error is returned.
 	TxnEnd(txnID int64) error
+	// TxnNextRev makes the following operations of the on-going txn with txn ID
+	// be done with the next rev if the txn has changed the KV so far. Readers
+	// still see none of the changes of the txn until it ends.
+	TxnNextRev(txnID int64) error
 	// TxnRange returns the current revision of the KV when the operation is executed.
 	TxnRange(txnID int64, key, end []byte, ro RangeOptions) (r *RangeResult, err error)
 	TxnPut(txnID int64, key, value []byte, lease lease.LeaseID) (rev int64, err error)
@@ -248,6 +248,52 @@ func testKVRangeLimit(t *testing.T, f rangeFunc) {
 	}
 }
 
+func TestKVRangeFilter(t *testing.T)    { testKVRangeFilter(t, normalRangeFunc) }
+func TestKVTxnRangeFilter(t *testing.T) { testKVRangeFilter(t, txnRangeFunc) }
+
+func testKVRangeFilter(t *testing.T, f rangeFunc) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	kvs := put3TestKVs(s)
+	s.Put([]byte("foo"), []byte("bar"), 1)
+	kvs[0].ModRevision, kvs[0].Version = 5, 2
+
+	tests := []struct {
+		ro   RangeOptions
+		wkvs []mvccpb.KeyValue
+	}{
+		{RangeOptions{MinModRev: 4}, []mvccpb.KeyValue{kvs[0], kvs[2]}},
+		{RangeOptions{MaxModRev: 3}, kvs[1:2]},
+		{RangeOptions{MinCreateRev: 3}, kvs[1:]},
+		{RangeOptions{MaxCreateRev: 2}, kvs[:1]},
+		{RangeOptions{MinModRev: 4, MaxCreateRev: 3}, kvs[:1]},
+		{RangeOptions{MinModRev: 3, MaxModRev: 4}, kvs[1:]},
+		// filters apply before the limit
+		{RangeOptions{MinCreateRev: 3, Limit: 1}, kvs[1:2]},
+		{RangeOptions{MinModRev: 6}, nil},
+	}
+	for i, tt := range tests {
+		r, err := f(s, []byte("foo"), []byte("foo3"), tt.ro)
+		if err != nil {
+			t.Fatalf("#%d: range error (%v)", i, err)
+		}
+		if !reflect.DeepEqual(r.KVs, tt.wkvs) {
+			t.Errorf("#%d: kvs = %+v, want %+v", i, r.KVs, tt.wkvs)
+		}
+
+		ro := tt.ro
+		ro.Count, ro.Limit = true, 0
+		if r, err = f(s, []byte("foo"), []byte("foo3"), ro); err != nil {
+			t.Fatalf("#%d: range error (%v)", i, err)
+		}
+		if tt.ro.Limit == 0 && r.Count != len(tt.wkvs) {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, len(tt.wkvs))
+		}
+	}
+}
+
 func TestKVPutMultipleTimes(t *testing.T)    { testKVPutMultipleTimes(t, normalPutFunc) }
 func TestKVTxnPutMultipleTimes(t *testing.T) { testKVPutMultipleTimes(t, txnPutFunc) }
 
@@ -151,7 +151,7 @@ func (s *store) Put(key, value []byte, lease lease.LeaseID) int64 {
 
 func (s *store) Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	id := s.TxnBegin()
-	kvs, count, rev, err := s.rangeKeys(key, end, ro.Limit, ro.Rev, ro.Count)
+	kvs, count, rev, err := s.rangeKeys(key, end, ro)
 	s.txnEnd(id)
 
 	rangeCounter.Inc()
@@ -204,22 +204,36 @@ func (s *store) txnEnd(txnID int64) error {
 	}
 
 	s.tx.Unlock()
-	if s.currentRev.sub != 0 {
-		s.currentRev.main += 1
-	}
-	s.currentRev.sub = 0
+	s.nextRev()
 
 	dbTotalSize.Set(float64(s.b.Size()))
 	s.mu.Unlock()
 	return nil
 }
 
+func (s *store) TxnNextRev(txnID int64) error {
+	if txnID != s.txnID {
+		return ErrTxnIDMismatch
+	}
+
+	s.nextRev()
+	return nil
+}
+
+// nextRev moves on to the next revision if the current one has changes.
+func (s *store) nextRev() {
+	if s.currentRev.sub != 0 {
+		s.currentRev.main += 1
+	}
+	s.currentRev.sub = 0
+}
+
 func (s *store) TxnRange(txnID int64, key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	if txnID != s.txnID {
 		return nil, ErrTxnIDMismatch
 	}
 
-	kvs, count, rev, err := s.rangeKeys(key, end, ro.Limit, ro.Rev, ro.Count)
+	kvs, count, rev, err := s.rangeKeys(key, end, ro)
 
 	r = &RangeResult{
 		KVs:   kvs,
@@ -453,7 +467,8 @@ func (a *store) Equal(b *store) bool {
 }
 
 // range is a keyword in Go, add Keys suffix.
-func (s *store) rangeKeys(key, end []byte, limit, rangeRev int64, countOnly bool) (kvs []mvccpb.KeyValue, count int, curRev int64, err error) {
+func (s *store) rangeKeys(key, end []byte, ro RangeOptions) (kvs []mvccpb.KeyValue, count int, curRev int64, err error) {
+	rangeRev := ro.Rev
 	curRev = int64(s.currentRev.main)
 	if s.currentRev.sub > 0 {
 		curRev += 1
@@ -476,11 +491,21 @@ func (s *store) rangeKeys(key, end []byte, limit, rangeRev int64, countOnly bool
 	if len(revpairs) == 0 {
 		return nil, 0, curRev, nil
 	}
-	if countOnly {
-		return nil, len(revpairs), curRev, nil
+	filterCreate := ro.MinCreateRev > 0 || ro.MaxCreateRev > 0
+	if ro.Count && !filterCreate {
+		for _, revpair := range revpairs {
+			// the revision of a key in the index is its mod revision.
+			if inRevRange(revpair.main, ro.MinModRev, ro.MaxModRev) {
+				count++
+			}
+		}
+		return nil, count, curRev, nil
 	}
 
 	for _, revpair := range revpairs {
+		if !inRevRange(revpair.main, ro.MinModRev, ro.MaxModRev) {
+			continue
+		}
 		start, end := revBytesRange(revpair)
 
 		_, vs := s.tx.UnsafeRange(keyBucketName, start, end, 0)
@@ -492,14 +517,30 @@ func (s *store) rangeKeys(key, end []byte, limit, rangeRev int64, countOnly bool
 		if err := kv.Unmarshal(vs[0]); err != nil {
 			plog.Fatalf("cannot unmarshal event: %v", err)
 		}
+		if !inRevRange(kv.CreateRevision, ro.MinCreateRev, ro.MaxCreateRev) {
+			continue
+		}
+		if ro.Count {
+			count++
+			continue
+		}
 		kvs = append(kvs, kv)
-		if limit > 0 && len(kvs) >= int(limit) {
+		if ro.Limit > 0 && len(kvs) >= int(ro.Limit) {
 			break
 		}
 	}
+	if ro.Count {
+		return nil, count, curRev, nil
+	}
 	return kvs, len(kvs), curRev, nil
 }
 
+// inRevRange returns true if rev is within [min, max]. A bound of 0 leaves
+// that side unbounded.
+func inRevRange(rev, min, max int64) bool {
+	return (min <= 0 || rev >= min) && (max <= 0 || rev <= max)
+}
+
 func (s *store) put(key, value []byte, leaseID lease.LeaseID) {
 	rev := s.currentRev.main + 1
 	c := rev
@@ -206,7 +206,7 @@ func TestStoreRange(t *testing.T) {
 		b.tx.rangeRespc <- tt.r
 		fi.indexRangeRespc <- tt.idxr
 
-		kvs, _, rev, err := s.rangeKeys([]byte("foo"), []byte("goo"), 1, 0, false)
+		kvs, _, rev, err := s.rangeKeys([]byte("foo"), []byte("goo"), RangeOptions{Limit: 1})
 		if err != nil {
 			t.Errorf("#%d: err = %v, want nil", i, err)
 		}
@@ -491,6 +491,43 @@ func TestTxnPut(t *testing.T) {
 	}
 }
 
+// TestTxnNextRev tests that the operations of a txn after TxnNextRev are done
+// with the next rev, and that a rev without changes is not skipped.
+func TestTxnNextRev(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil)
+	defer cleanup(s, b, tmpPath)
+
+	id := s.TxnBegin()
+	if rev, err := s.TxnPut(id, []byte("foo"), []byte("bar"), lease.NoLease); err != nil || rev != 2 {
+		t.Fatalf("rev, err = %d, %v, want 2, nil", rev, err)
+	}
+	if err := s.TxnNextRev(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.TxnNextRev(id); err != nil {
+		t.Fatal(err)
+	}
+	if n, rev, err := s.TxnDeleteRange(id, []byte("foo"), nil); err != nil || n != 1 || rev != 3 {
+		t.Fatalf("n, rev, err = %d, %d, %v, want 1, 3, nil", n, rev, err)
+	}
+	if err := s.TxnNextRev(id + 1); err != ErrTxnIDMismatch {
+		t.Fatalf("err = %v, want %v", err, ErrTxnIDMismatch)
+	}
+	s.TxnEnd(id)
+
+	if rev := s.Rev(); rev != 3 {
+		t.Errorf("rev = %d, want 3", rev)
+	}
+	r, err := s.Range([]byte("foo"), nil, RangeOptions{Rev: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 || string(r.KVs[0].Value) != "bar" {
+		t.Errorf("kvs at rev 2 = %+v, want foo=bar", r.KVs)
+	}
+}
+
 func TestTxnBlockBackendForceCommit(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 	s := NewStore(b, &lease.FakeLessor{}, nil)
@@ -56,10 +56,20 @@ type watchableStore struct {
 	// The key of the map is the key that the watcher watches on.
 	synced watcherGroup
 
+	// txnRevs are the revisions the on-going txn ended with TxnNextRev,
+	// notified to the watchers once the txn ends.
+	txnRevs []txnRev
+
 	stopc chan struct{}
 	wg    sync.WaitGroup
 }
 
+// txnRev holds the events of a revision of a txn.
+type txnRev struct {
+	rev int64
+	evs []mvccpb.Event
+}
+
 // cancelFunc updates unsynced and synced maps when running
 // cancel operations.
 type cancelFunc func()
@@ -141,13 +151,36 @@ func (s *watchableStore) TxnEnd(txnID int64) error {
 		return err
 	}
 
+	s.saveTxnRev(s.store.Rev())
+	for _, tr := range s.txnRevs {
+		s.notify(tr.rev, tr.evs)
+	}
+	s.txnRevs = nil
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *watchableStore) TxnNextRev(txnID int64) error {
+	err := s.store.TxnNextRev(txnID)
+	if err != nil {
+		return err
+	}
+
+	// the store is held by the txn, so its current revision is the one
+	// of the changes.
+	s.saveTxnRev(s.store.currentRev.main)
+	return nil
+}
+
+// saveTxnRev saves the changes of the txn made at rev to notify them when
+// the txn ends.
+func (s *watchableStore) saveTxnRev(rev int64) {
 	changes := s.getChanges()
 	if len(changes) == 0 {
-		s.mu.Unlock()
-		return nil
+		return
 	}
 
-	rev := s.store.Rev()
 	evs := make([]mvccpb.Event, len(changes))
 	for i, change := range changes {
 		switch change.CreateRevision {
@@ -162,11 +195,7 @@ func (s *watchableStore) TxnEnd(txnID int64) error {
 				Kv:   &changes[i]}
 		}
 	}
-
-	s.notify(rev, evs)
-	s.mu.Unlock()
-
-	return nil
+	s.txnRevs = append(s.txnRevs, txnRev{rev: rev, evs: evs})
 }
 
 func (s *watchableStore) Close() error {
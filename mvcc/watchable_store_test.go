@@ -294,6 +294,56 @@ func TestWatchFutureRev(t *testing.T) {
 	}
 }
 
+// TestWatchTxnNextRev tests that the changes of a txn made at several revs
+// are notified once the txn ends, each at its own rev.
+func TestWatchTxnNextRev(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	testKey := []byte("foo")
+	testValue := []byte("bar")
+
+	w := s.NewWatchStream()
+	w.Watch(testKey, nil, 0)
+
+	id := s.TxnBegin()
+	s.TxnPut(id, testKey, testValue, lease.NoLease)
+	s.TxnNextRev(id)
+	s.TxnDeleteRange(id, testKey, nil)
+	s.TxnNextRev(id)
+
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected response %+v before the txn ended", resp)
+	case <-time.After(10 * time.Millisecond):
+	}
+	s.TxnEnd(id)
+
+	wevs := []mvccpb.Event_EventType{mvccpb.PUT, mvccpb.DELETE}
+	for i, wev := range wevs {
+		wrev := int64(2 + i)
+		select {
+		case resp := <-w.Chan():
+			if resp.Revision != wrev {
+				t.Fatalf("#%d: rev = %d, want %d", i, resp.Revision, wrev)
+			}
+			if len(resp.Events) != 1 || resp.Events[0].Type != wev {
+				t.Fatalf("#%d: events = %+v, want one %v", i, resp.Events, wev)
+			}
+			if resp.Events[0].Kv.ModRevision != wrev {
+				t.Fatalf("#%d: kv.rev = %d, want %d", i, resp.Events[0].Kv.ModRevision, wrev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: failed to receive event in 1 second.", i)
+		}
+	}
+}
+
 // TestWatchBatchUnsynced tests batching on unsynced watchers
 func TestWatchBatchUnsynced(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
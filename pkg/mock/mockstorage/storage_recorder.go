@@ -40,6 +40,16 @@ func (p *storageRecorder) Save(st raftpb.HardState, ents []raftpb.Entry) error {
 	return nil
 }
 
+func (p *storageRecorder) SaveNoSync(st raftpb.HardState, ents []raftpb.Entry) error {
+	p.Record(testutil.Action{Name: "SaveNoSync"})
+	return nil
+}
+
+func (p *storageRecorder) Sync() error {
+	p.Record(testutil.Action{Name: "Sync"})
+	return nil
+}
+
 func (p *storageRecorder) SaveSnap(st raftpb.Snapshot) error {
 	if !raft.IsEmptySnap(st) {
 		p.Record(testutil.Action{Name: "SaveSnap"})
@@ -0,0 +1,71 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wait
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitIndex(t *testing.T) {
+	wi := NewIndexList()
+	ch1 := wi.Wait(1)
+	ch2 := wi.Wait(2)
+	ch3 := wi.Wait(2)
+	wi.Trigger(1)
+	select {
+	case <-ch1:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("cannot receive from ch1 as expected")
+	}
+	select {
+	case <-ch2:
+		t.Fatalf("unexpected to receive from ch2")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	wi.Trigger(5)
+	for i, ch := range []<-chan struct{}{ch2, ch3} {
+		select {
+		case <-ch:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("#%d: cannot receive from ch as expected", i)
+		}
+	}
+}
+
+func TestWaitIndexReached(t *testing.T) {
+	wi := NewIndexList()
+	wi.Trigger(3)
+	for i, idx := range []uint64{0, 1, 3} {
+		select {
+		case <-wi.Wait(idx):
+		default:
+			t.Errorf("#%d: wait(%d) is not triggered", i, idx)
+		}
+	}
+	select {
+	case <-wi.Wait(4):
+		t.Errorf("wait(4) is triggered")
+	default:
+	}
+	// an older index does not roll back what was reached.
+	wi.Trigger(2)
+	select {
+	case <-wi.Wait(3):
+	default:
+		t.Errorf("wait(3) is not triggered after trigger(2)")
+	}
+}
@@ -120,10 +120,30 @@ func requestOpToOp(union *pb.RequestOp) clientv3.Op {
 		if tv.RequestDeleteRange != nil {
 			return DelRequestToOp(tv.RequestDeleteRange)
 		}
+	case *pb.RequestOp_RequestTxn:
+		if tv.RequestTxn != nil {
+			return TxnRequestToOp(tv.RequestTxn)
+		}
 	}
 	panic("unknown request")
 }
 
+func TxnRequestToOp(r *pb.TxnRequest) clientv3.Op {
+	cmps := make([]clientv3.Cmp, len(r.Compare))
+	thenops := make([]clientv3.Op, len(r.Success))
+	elseops := make([]clientv3.Op, len(r.Failure))
+	for i := range r.Compare {
+		cmps[i] = (clientv3.Cmp)(*r.Compare[i])
+	}
+	for i := range r.Success {
+		thenops[i] = requestOpToOp(r.Success[i])
+	}
+	for i := range r.Failure {
+		elseops[i] = requestOpToOp(r.Failure[i])
+	}
+	return clientv3.OpTxn(cmps, thenops, elseops)
+}
+
 func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 	opts := []clientv3.OpOption{}
 	if len(r.RangeEnd) != 0 {
@@ -136,6 +156,14 @@ func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 		clientv3.SortOrder(r.SortOrder)),
 	)
 
+	opts = append(opts, clientv3.WithMinModRev(r.MinModRevision))
+	opts = append(opts, clientv3.WithMaxModRev(r.MaxModRevision))
+	opts = append(opts, clientv3.WithMinCreateRev(r.MinCreateRevision))
+	opts = append(opts, clientv3.WithMaxCreateRev(r.MaxCreateRevision))
+	if len(r.ContinueToken) != 0 {
+		opts = append(opts, clientv3.WithContinue(r.ContinueToken))
+	}
+
 	if r.Serializable {
 		opts = append(opts, clientv3.WithSerializable())
 	}
@@ -0,0 +1,149 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Types of BugEvent.
+const (
+	// BugStart is recorded when a fault point injects a bug.
+	BugStart = "start"
+	// BugCatch is recorded when an invariant check catches a violation.
+	BugCatch = "catch"
+)
+
+// processStart anchors the monotonic timestamps of bug events.
+var processStart = time.Now()
+
+// BugEvent is the record of an injected bug starting or being caught.
+type BugEvent struct {
+	Type string `json:"type"`
+	Node uint64 `json:"node"`
+	Term uint64 `json:"term"`
+	// Index is the last index of the node's log.
+	Index  uint64 `json:"index"`
+	Commit uint64 `json:"commit"`
+	// Invariant is the invariant the bug violates, or the invariant that
+	// caught it. It is empty for faults that are not paired with an
+	// invariant.
+	Invariant string `json:"invariant,omitempty"`
+	// Fault is the fault point that injected the bug.
+	Fault string `json:"fault,omitempty"`
+	// Mono is a monotonic timestamp, in nanoseconds since the process
+	// started. Only timestamps of events from the same process compare.
+	Mono int64 `json:"mono"`
+	// Time is the wall clock time of the event, used to compare events of
+	// different nodes.
+	Time time.Time `json:"time"`
+}
+
+// BugLogger records bug events.
+type BugLogger interface {
+	LogBug(e BugEvent) error
+}
+
+// BugEventLog is a BugLogger that appends each event as a JSON line to a
+// writer. It is safe for concurrent use.
+type BugEventLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	c   io.Closer
+}
+
+// NewBugEventLog returns a BugEventLog writing to w.
+func NewBugEventLog(w io.Writer) *BugEventLog {
+	return &BugEventLog{enc: json.NewEncoder(w)}
+}
+
+// OpenBugEventLog opens the file at path for appending, creating it if
+// needed, and returns a BugEventLog writing to it.
+func OpenBugEventLog(path string) (*BugEventLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l := NewBugEventLog(f)
+	l.c = f
+	return l, nil
+}
+
+func (l *BugEventLog) LogBug(e BugEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(e)
+}
+
+// Close closes the file opened by OpenBugEventLog.
+func (l *BugEventLog) Close() error {
+	if l.c == nil {
+		return nil
+	}
+	return l.c.Close()
+}
+
+// ReadBugEvents reads the JSON lines written by a BugEventLog.
+func ReadBugEvents(r io.Reader) ([]BugEvent, error) {
+	var es []BugEvent
+	dec := json.NewDecoder(r)
+	for {
+		var e BugEvent
+		err := dec.Decode(&e)
+		if err == io.EOF {
+			return es, nil
+		}
+		if err != nil {
+			return es, err
+		}
+		es = append(es, e)
+	}
+}
+
+// logBug records an event of the given type for r, if r has a bug logger.
+func (r *raft) logBug(typ, invariant, fault string) {
+	if r.bugLog == nil {
+		return
+	}
+	now := time.Now()
+	e := BugEvent{
+		Type:      typ,
+		Node:      r.id,
+		Term:      r.Term,
+		Index:     r.raftLog.lastIndex(),
+		Commit:    r.raftLog.committed,
+		Invariant: invariant,
+		Fault:     fault,
+		Mono:      int64(now.Sub(processStart)),
+		Time:      now,
+	}
+	if err := r.bugLog.LogBug(e); err != nil {
+		r.logger.Warningf("%x failed to log bug %s (%v)", r.id, typ, err)
+	}
+}
+
+// startBug records that the named fault injected a bug.
+func (r *raft) startBug(fault string) {
+	r.logBug(BugStart, faultInvariant(fault), fault)
+}
+
+// catchBug records that the named invariant caught a violation.
+func (r *raft) catchBug(invariant string) {
+	r.logBug(BugCatch, invariant, "")
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+type bugRecorder struct {
+	events []BugEvent
+}
+
+func (br *bugRecorder) LogBug(e BugEvent) error {
+	br.events = append(br.events, e)
+	return nil
+}
+
+func TestBugEventLog(t *testing.T) {
+	now := time.Unix(1470000000, 5).UTC()
+	es := []BugEvent{
+		{Type: BugStart, Node: 1, Term: 2, Index: 3, Commit: 2, Invariant: InvariantLogMatching, Fault: FaultCorruptEntry, Mono: 10, Time: now},
+		{Type: BugCatch, Node: 2, Term: 2, Index: 3, Commit: 3, Invariant: InvariantLogMatching, Mono: 20, Time: now.Add(time.Second)},
+	}
+	var buf bytes.Buffer
+	l := NewBugEventLog(&buf)
+	for _, e := range es {
+		if err := l.LogBug(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := bytes.Count(buf.Bytes(), []byte("\n")); n != len(es) {
+		t.Errorf("lines = %d, want %d", n, len(es))
+	}
+	g, err := ReadBugEvents(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, es) {
+		t.Errorf("events = %+v, want %+v", g, es)
+	}
+}
+
+func TestOpenBugEventLogAppends(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "bugevent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "bugs.jsonl")
+
+	for i := 0; i < 2; i++ {
+		l, err := OpenBugEventLog(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = l.LogBug(BugEvent{Type: BugStart, Node: uint64(i + 1)}); err != nil {
+			t.Fatal(err)
+		}
+		if err = l.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	es, err := ReadBugEvents(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 2 || es[0].Node != 1 || es[1].Node != 2 {
+		t.Errorf("events = %+v, want starts on 1 and 2", es)
+	}
+}
+
+// TestRaftLogsBugStartAndCatch ensures an injected bug and the invariant
+// violation it causes are both logged.
+func TestRaftLogsBugStartAndCatch(t *testing.T) {
+	fi := NewFaultInjector(1)
+	ir := NewInvariantRegistry()
+	ic, _ := BuiltinInvariant(InvariantLeaderAgreement)
+	ir.Register(ic, InvariantOptions{})
+	la := make(localAssertables)
+	bl := &bugRecorder{}
+	peers := make([]stateMachine, 3)
+	for i := range peers {
+		id := uint64(i + 1)
+		cfg := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		cfg.Faults, cfg.BugLog = fi, bl
+		cfg.Invariants = ir
+		cfg.Asserter = la.asserter(fmt.Sprintf("%d", id))
+		peers[i] = newRaft(cfg)
+	}
+	nt := newNetwork(peers...)
+	nt.isolate(3)
+	fi.Enable(FaultFalseLeader, FaultSpec{Role: FaultFollower, Trigger: 1})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("some data")}}})
+
+	if len(bl.events) < 2 {
+		t.Fatalf("len(events) = %d, want at least 2", len(bl.events))
+	}
+	start, catch := bl.events[0], bl.events[1]
+	if start.Type != BugStart || start.Fault != FaultFalseLeader || start.Invariant != InvariantLeaderAgreement {
+		t.Errorf("first event = %+v, want start of %s", start, FaultFalseLeader)
+	}
+	if catch.Type != BugCatch || catch.Invariant != InvariantLeaderAgreement || catch.Fault != "" {
+		t.Errorf("second event = %+v, want catch by %s", catch, InvariantLeaderAgreement)
+	}
+	if catch.Mono < start.Mono {
+		t.Errorf("catch mono %d before start mono %d", catch.Mono, start.Mono)
+	}
+}
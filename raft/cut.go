@@ -0,0 +1,321 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// A cut is a consistent global snapshot of the raft state of a cluster,
+// taken with the Chandy-Lamport algorithm. The node starting a cut records
+// its state and sends a MsgCutMarker to every peer. A node records its state
+// when it receives the first marker of a cut, forwards the marker to every
+// peer and then records the messages it receives from each peer until the
+// marker of that peer arrives. Once it has the marker of every peer, it
+// sends its state and the recorded messages to the node that started the
+// cut in a MsgCutState.
+//
+// The algorithm requires the messages between two nodes to be delivered in
+// order. The transport MUST deliver every message a node sent before a
+// marker before the marker, and every message sent after it after the
+// marker.
+//
+// In a MsgCutMarker, Index is the id of the cut and Commit the node that
+// started it. In a MsgCutState, Index is the id of the cut and Context the
+// JSON encoded NodeCut of the sender.
+
+// Cut is a consistent global snapshot of the raft state of a cluster.
+type Cut struct {
+	// ID is the id the cut was requested with.
+	ID uint64
+	// Nodes holds the state of every node, sorted by id.
+	Nodes []NodeCut
+}
+
+// NodeCut is the state of a node recorded in a cut.
+type NodeCut struct {
+	ID        uint64
+	Term      uint64
+	Vote      uint64
+	Lead      uint64
+	RaftState StateType
+	Committed uint64
+	Applied   uint64
+	Log       []pb.Entry
+	// InFlight holds the messages in flight to the node in the cut, in the
+	// order they were received: sent before their sender recorded its
+	// state and received after the node recorded its own.
+	InFlight []pb.Message
+}
+
+// invariantState returns the state an invariant is checked from.
+func (nc *NodeCut) invariantState() InvariantState {
+	return InvariantState{
+		ID:        nc.ID,
+		Term:      nc.Term,
+		Lead:      nc.Lead,
+		RaftState: nc.RaftState,
+		Committed: nc.Committed,
+		Applied:   nc.Applied,
+	}
+}
+
+// assertables returns the assertable variables of the node.
+func (nc *NodeCut) assertables() map[string]interface{} {
+	return map[string]interface{}{
+		AssertableID:        nc.ID,
+		AssertableTerm:      nc.Term,
+		AssertableState:     nc.RaftState,
+		AssertableLeader:    nc.Lead,
+		AssertableCommitted: nc.Committed,
+		AssertableApplied:   nc.Applied,
+		AssertableLog:       nc.Log,
+	}
+}
+
+// Values returns the assertable variables of every node in the cut, keyed
+// by the hexadecimal id of the node, as checked by an InvariantChecker.
+func (c *Cut) Values() map[string]map[string]interface{} {
+	values := make(map[string]map[string]interface{}, len(c.Nodes))
+	for i := range c.Nodes {
+		values[fmt.Sprintf("%x", c.Nodes[i].ID)] = c.Nodes[i].assertables()
+	}
+	return values
+}
+
+type cutKey struct {
+	initiator uint64
+	id        uint64
+}
+
+// recordingCut is a cut a node recorded its state for and still records
+// the in flight messages of.
+type recordingCut struct {
+	state NodeCut
+	// waiting are the peers the marker has not been received from yet.
+	waiting map[uint64]bool
+	elapsed int
+}
+
+// gatheringCut is a cut started by a node that still gathers the states of
+// the other nodes.
+type gatheringCut struct {
+	// requested is set if the cut was requested by the application.
+	requested bool
+	nodes     map[uint64]*NodeCut
+	waiting   map[uint64]bool
+	elapsed   int
+}
+
+// startCut starts the cut with the given id. If requested, the cut is
+// returned to the application in Ready once complete.
+func (r *raft) startCut(id uint64, requested bool) {
+	k := cutKey{initiator: r.id, id: id}
+	if _, ok := r.gathering[id]; ok {
+		r.logger.Warningf("%x ignoring cut %d that is already in progress", r.id, id)
+		return
+	}
+	if _, ok := r.cuts[k]; ok {
+		r.logger.Warningf("%x ignoring cut %d that is already in progress", r.id, id)
+		return
+	}
+	gc := &gatheringCut{requested: requested, nodes: make(map[uint64]*NodeCut), waiting: make(map[uint64]bool)}
+	for _, p := range r.allNodes() {
+		gc.waiting[p] = true
+	}
+	gc.waiting[r.id] = true
+	r.gathering[id] = gc
+	r.logger.Debugf("%x starting cut %d", r.id, id)
+	r.recordCut(k, None)
+}
+
+// recordCut records the state of r for the cut k, whose first marker r
+// received from the given peer, and forwards the marker to every peer.
+func (r *raft) recordCut(k cutKey, from uint64) {
+	rc := &recordingCut{state: r.nodeCut(), waiting: make(map[uint64]bool)}
+	for _, p := range r.allNodes() {
+		if p == r.id {
+			continue
+		}
+		if p != from {
+			rc.waiting[p] = true
+		}
+		r.msgs = append(r.msgs, pb.Message{From: r.id, To: p, Type: pb.MsgCutMarker, Index: k.id, Commit: k.initiator})
+	}
+	r.cuts[k] = rc
+	r.maybeFinishCut(k)
+}
+
+func (r *raft) nodeCut() NodeCut {
+	return NodeCut{
+		ID:        r.id,
+		Term:      r.Term,
+		Vote:      r.Vote,
+		Lead:      r.lead,
+		RaftState: r.state,
+		Committed: r.raftLog.committed,
+		Applied:   r.raftLog.applied,
+		Log:       r.raftLog.allEntries(),
+	}
+}
+
+// stepCut handles the messages of the cut protocol.
+func (r *raft) stepCut(m pb.Message) {
+	switch m.Type {
+	case pb.MsgCutMarker:
+		if m.From == None {
+			r.startCut(m.Index, true)
+			return
+		}
+		k := cutKey{initiator: m.Commit, id: m.Index}
+		rc, ok := r.cuts[k]
+		if !ok {
+			r.recordCut(k, m.From)
+			return
+		}
+		delete(rc.waiting, m.From)
+		r.maybeFinishCut(k)
+	case pb.MsgCutState:
+		gc, ok := r.gathering[m.Index]
+		if !ok {
+			r.logger.Debugf("%x ignoring state of %x for unknown cut %d", r.id, m.From, m.Index)
+			return
+		}
+		var nc NodeCut
+		if err := json.Unmarshal(m.Context, &nc); err != nil {
+			r.logger.Warningf("%x failed to decode state of %x for cut %d (%v)", r.id, m.From, m.Index, err)
+			return
+		}
+		r.addCutState(m.Index, gc, &nc)
+	}
+}
+
+// recordInFlight records m as in flight in the cuts still waiting for the
+// marker of its sender.
+func (r *raft) recordInFlight(m pb.Message) {
+	if m.From == None || m.From == r.id || IsLocalMsg(m.Type) {
+		return
+	}
+	for _, rc := range r.cuts {
+		if rc.waiting[m.From] {
+			rc.state.InFlight = append(rc.state.InFlight, m)
+		}
+	}
+}
+
+// maybeFinishCut sends the state recorded for the cut k to the node that
+// started it once the markers of every peer have been received.
+func (r *raft) maybeFinishCut(k cutKey) {
+	rc := r.cuts[k]
+	if len(rc.waiting) > 0 {
+		return
+	}
+	delete(r.cuts, k)
+	if k.initiator == r.id {
+		if gc, ok := r.gathering[k.id]; ok {
+			r.addCutState(k.id, gc, &rc.state)
+		}
+		return
+	}
+	data, err := json.Marshal(&rc.state)
+	if err != nil {
+		r.logger.Warningf("%x failed to encode state for cut %d (%v)", r.id, k.id, err)
+		return
+	}
+	r.msgs = append(r.msgs, pb.Message{From: r.id, To: k.initiator, Type: pb.MsgCutState, Index: k.id, Context: data})
+}
+
+// addCutState adds the state of a node to the cut id started by r and
+// completes the cut once every node sent its state.
+func (r *raft) addCutState(id uint64, gc *gatheringCut, nc *NodeCut) {
+	if !gc.waiting[nc.ID] {
+		return
+	}
+	delete(gc.waiting, nc.ID)
+	gc.nodes[nc.ID] = nc
+	if len(gc.waiting) > 0 {
+		return
+	}
+	delete(r.gathering, id)
+
+	c := Cut{ID: id}
+	for _, nc := range gc.nodes {
+		c.Nodes = append(c.Nodes, *nc)
+	}
+	sort.Sort(nodeCutsByID(c.Nodes))
+	r.logger.Debugf("%x completed cut %d", r.id, id)
+	r.checkCut(&c)
+	if gc.requested {
+		r.readyCuts = append(r.readyCuts, c)
+	}
+}
+
+// checkCut checks every registered invariant over the cut c.
+func (r *raft) checkCut(c *Cut) {
+	if r.invariants == nil {
+		return
+	}
+	var local InvariantState
+	for i := range c.Nodes {
+		if c.Nodes[i].ID == r.id {
+			local = c.Nodes[i].invariantState()
+		}
+	}
+	values := c.Values()
+	for _, ic := range r.invariants.checkers() {
+		r.logger.Debugf("%x checking invariant %s over cut %d", r.id, ic.Name(), c.ID)
+		if !ic.Check(local, values) {
+			r.logger.Errorf("%x invariant %s violated in cut %d", r.id, ic.Name(), c.ID)
+			r.catchBug(ic.Name())
+		}
+	}
+}
+
+// tickCuts abandons the cuts that did not complete within an election
+// timeout, for example because a marker was dropped, and starts a cut to
+// check the invariants over every cutTick ticks on the leader.
+func (r *raft) tickCuts() {
+	for k, rc := range r.cuts {
+		if rc.elapsed++; rc.elapsed >= r.electionTimeout {
+			r.logger.Warningf("%x abandoning cut %d started by %x", r.id, k.id, k.initiator)
+			delete(r.cuts, k)
+		}
+	}
+	for id, gc := range r.gathering {
+		if gc.elapsed++; gc.elapsed >= r.electionTimeout {
+			r.logger.Warningf("%x abandoning cut %d", r.id, id)
+			delete(r.gathering, id)
+		}
+	}
+
+	if r.cutTick <= 0 || r.state != StateLeader {
+		return
+	}
+	if r.cutElapsed++; r.cutElapsed >= r.cutTick {
+		r.cutElapsed = 0
+		r.cutSeq++
+		r.startCut(r.cutSeq, false)
+	}
+}
+
+type nodeCutsByID []NodeCut
+
+func (s nodeCutsByID) Len() int           { return len(s) }
+func (s nodeCutsByID) Less(i, j int) bool { return s[i].ID < s[j].ID }
+func (s nodeCutsByID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
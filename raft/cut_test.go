@@ -0,0 +1,197 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+	"golang.org/x/net/context"
+)
+
+func messagesTo(msgs []pb.Message, to uint64) []pb.Message {
+	var out []pb.Message
+	for _, m := range msgs {
+		if m.To == to {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// TestCutRecordsInFlight ensures a cut records the state of every node and
+// the messages in flight between them: the append of 1 to 2, sent before 1
+// recorded its state and received after 2 recorded its own, and the append
+// response of 3 to 1.
+func TestCutRecordsInFlight(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	r1, r2, r3 := nt.peers[1].(*raft), nt.peers[2].(*raft), nt.peers[3].(*raft)
+
+	r1.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("somedata")}}})
+	apps := r1.readMessages()
+	r2.Step(pb.Message{Type: pb.MsgCutMarker, Index: 7})
+	markers2 := r2.readMessages()
+
+	r3.Step(messagesTo(apps, 3)[0])
+	r3.Step(messagesTo(markers2, 3)[0])
+	r1.Step(messagesTo(markers2, 1)[0])
+	r2.Step(messagesTo(apps, 2)[0])
+	var rest []pb.Message
+	for _, r := range []*raft{r3, r1, r2} {
+		rest = append(rest, r.readMessages()...)
+	}
+	nt.send(rest...)
+
+	if len(r2.readyCuts) != 1 {
+		t.Fatalf("len(cuts) = %d, want 1", len(r2.readyCuts))
+	}
+	c := r2.readyCuts[0]
+	if c.ID != 7 {
+		t.Errorf("id = %d, want 7", c.ID)
+	}
+	if len(c.Nodes) != 3 {
+		t.Fatalf("len(nodes) = %d, want 3", len(c.Nodes))
+	}
+	tests := []struct {
+		wlast     uint64
+		winflight []pb.MessageType
+	}{
+		{2, []pb.MessageType{pb.MsgAppResp}},
+		{1, []pb.MessageType{pb.MsgApp}},
+		{2, nil},
+	}
+	for i, tt := range tests {
+		nc := c.Nodes[i]
+		if nc.ID != uint64(i+1) {
+			t.Errorf("#%d: id = %d, want %d", i, nc.ID, i+1)
+		}
+		if nc.Term != 1 || nc.Lead != 1 {
+			t.Errorf("#%d: term, lead = %d, %x, want 1, 1", i, nc.Term, nc.Lead)
+		}
+		if g := nc.Log[len(nc.Log)-1].Index; g != tt.wlast {
+			t.Errorf("#%d: last index = %d, want %d", i, g, tt.wlast)
+		}
+		var types []pb.MessageType
+		for _, m := range nc.InFlight {
+			types = append(types, m.Type)
+		}
+		if !reflect.DeepEqual(types, tt.winflight) {
+			t.Errorf("#%d: in flight = %v, want %v", i, types, tt.winflight)
+		}
+	}
+	if c.Nodes[0].RaftState != StateLeader {
+		t.Errorf("state = %v, want %v", c.Nodes[0].RaftState, StateLeader)
+	}
+	if len(r1.cuts) != 0 || len(r2.cuts) != 0 || len(r3.cuts) != 0 || len(r2.gathering) != 0 {
+		t.Errorf("cuts still in progress after completion")
+	}
+}
+
+// TestCutIgnoresTerm ensures cut messages do not change the term of a node.
+func TestCutIgnoresTerm(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.Step(pb.Message{From: 2, To: 1, Term: 5, Type: pb.MsgCutMarker, Index: 1, Commit: 2})
+	if r.Term != 0 {
+		t.Errorf("term = %d, want 0", r.Term)
+	}
+	var types []pb.MessageType
+	for _, m := range r.readMessages() {
+		types = append(types, m.Type)
+	}
+	if wtypes := []pb.MessageType{pb.MsgCutMarker, pb.MsgCutState}; !reflect.DeepEqual(types, wtypes) {
+		t.Errorf("msgs = %v, want %v", types, wtypes)
+	}
+}
+
+// TestCutAbandoned ensures a cut that does not complete within an election
+// timeout is abandoned.
+func TestCutAbandoned(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.Step(pb.Message{Type: pb.MsgCutMarker, Index: 1})
+	if len(r.cuts) != 1 || len(r.gathering) != 1 {
+		t.Fatalf("len(cuts), len(gathering) = %d, %d, want 1, 1", len(r.cuts), len(r.gathering))
+	}
+	for i := 0; i < r.electionTimeout; i++ {
+		r.tick()
+	}
+	if len(r.cuts) != 0 || len(r.gathering) != 0 {
+		t.Errorf("len(cuts), len(gathering) = %d, %d, want 0, 0", len(r.cuts), len(r.gathering))
+	}
+}
+
+// TestLeaderChecksInvariantsOverCuts ensures the leader checks the
+// invariants over a cut every CutTick ticks.
+func TestLeaderChecksInvariantsOverCuts(t *testing.T) {
+	ir := NewInvariantRegistry()
+	c := &countingInvariant{name: "counting"}
+	ir.Register(c, InvariantOptions{Sample: 1000})
+
+	peers := make([]stateMachine, 3)
+	for i := range peers {
+		cfg := newTestConfig(uint64(i+1), []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		cfg.Invariants = ir
+		cfg.Dinv.CutTick = 3
+		peers[i] = newRaft(cfg)
+	}
+	nt := newNetwork(peers...)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if c.checks != 0 {
+		t.Fatalf("checks = %d, want 0 before the first cut", c.checks)
+	}
+
+	r1 := nt.peers[1].(*raft)
+	for i := 0; i < 3; i++ {
+		r1.tick()
+		nt.send(r1.readMessages()...)
+	}
+	if c.checks != 1 {
+		t.Fatalf("checks = %d, want 1", c.checks)
+	}
+	if wleads := []uint64{1, 1, 1}; !reflect.DeepEqual(c.leads, wleads) {
+		t.Errorf("leads = %v, want %v", c.leads, wleads)
+	}
+	if len(r1.readyCuts) != 0 {
+		t.Errorf("len(cuts) = %d, want 0 for a cut not requested", len(r1.readyCuts))
+	}
+}
+
+func TestNodeRequestCut(t *testing.T) {
+	n := newNode()
+	s := NewMemoryStorage()
+	r := newTestRaft(1, []uint64{1}, 10, 1, s)
+	go n.run(r)
+	defer n.Stop()
+
+	if err := n.RequestCut(context.TODO(), 3); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		rd := <-n.Ready()
+		s.Append(rd.Entries)
+		n.Advance()
+		if len(rd.Cuts) == 0 {
+			continue
+		}
+		if len(rd.Cuts) != 1 || rd.Cuts[0].ID != 3 || len(rd.Cuts[0].Nodes) != 1 {
+			t.Fatalf("cuts = %+v, want cut 3 of node 1", rd.Cuts)
+		}
+		if rd.Cuts[0].Nodes[0].ID != 1 {
+			t.Errorf("id = %d, want 1", rd.Cuts[0].Nodes[0].ID)
+		}
+		return
+	}
+}
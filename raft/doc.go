@@ -164,6 +164,45 @@ raftpb.EntryConfChange will be returned. You must apply it to node through:
 	cc.Unmarshal(data)
 	n.ApplyConfChange(cc)
 
+A node added with ConfChangeAddLearnerNode is a learner: it receives the log
+from the leader like any follower, but it neither votes nor campaigns and it
+does not count toward the quorum. A new member can catch up as a learner
+without making the cluster less available. Adding the learner again with
+ConfChangeAddNode promotes it to a voter; a voter is never demoted to a
+learner.
+
+To change several nodes at once, build a ConfChangeV2 with one
+ConfChangeSingle per node and propose it the same way. It is committed as an
+entry of type raftpb.EntryConfChangeV2, which you must apply through:
+
+	var cc raftpb.ConfChangeV2
+	cc.Unmarshal(data)
+	n.ApplyConfChange(cc)
+
+A ConfChangeV2 of more than one change enters a joint configuration
+(C_old,new): until it is left, electing a leader or committing an entry needs
+a quorum of both the outgoing and the incoming voters, so no two disjoint
+majorities can form while the nodes apply the change. The voters removed by
+the change keep receiving the log meanwhile. Once the leader applies the
+change, it proposes an empty ConfChangeV2 on its own, which leaves the joint
+configuration when applied. ConfState.NodesJoint lists the outgoing voters
+while joint, and must be recorded in snapshots like the rest of the ConfState.
+No other configuration change is accepted until the joint configuration is
+left.
+
+An application replicating several kinds of commands can give each its own
+entry type instead of multiplexing them in the data of normal entries. The
+entry types from FirstApplicationEntryType on are available to applications;
+raft replicates and commits them like normal entries without looking at their
+data. Register the types and the codecs of their values in EntryTypes, and
+propose an entry with:
+
+	e, err := types.Encode(typ, v)
+	n.ProposeEntry(ctx, e.Type, e.Data)
+
+When committed, the entry is returned with its type and EntryTypes.Decode
+returns its value.
+
 Note: An ID represents a unique node in a cluster for all time. A
 given ID MUST be used only once even if the old node has been removed.
 This means that for example IP addresses make poor node IDs since they
@@ -257,6 +296,15 @@ stale log entries:
 	If candidate receives majority of votes of denials, it reverts back to
 	follower.
 
+	'MsgPreVote' and 'MsgPreVoteResp' are used in an optional two-phase
+	election protocol. When Config.PreVote is true, a node first becomes a
+	pre-candidate and sends 'MsgPreVote' for its next term, without
+	incrementing its term. A node grants it as it would grant a 'MsgVote' at
+	that term, but changes neither its term nor its vote. Only once a quorum
+	granted its pre-vote does the pre-candidate become candidate and start a
+	real election. This keeps a node that was partitioned away and rejoins
+	from deposing a healthy leader with a higher term.
+
 	'MsgSnap' requests to install a snapshot message. When a node has just
 	become a leader or the leader receives 'MsgProp' message, it calls
 	'bcastAppend' method, which then calls 'sendAppend' method to each
@@ -290,5 +338,17 @@ stale log entries:
 	indicating 'MsgApp' is lost. When follower's progress state is replicate,
 	the leader sets it back to probe.
 
+	'MsgCutMarker' takes a consistent cut of the raft state of the cluster.
+	When a node receives the first 'MsgCutMarker' of a cut, from a peer or
+	from Node.RequestCut, it records its state and sends 'MsgCutMarker' to
+	every peer. It then records the messages it receives from each peer
+	until the 'MsgCutMarker' of that peer arrives. Cut messages do not
+	change the raft state of a node, whatever their term.
+
+	'MsgCutState' sends the state recorded by a node for a cut to the node
+	that started it. Once the node that started the cut has the state of
+	every node, it checks the invariants over the cut and returns it in
+	Ready.Cuts if requested through Node.RequestCut.
+
 */
 package raft
@@ -0,0 +1,198 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"bitbucket.org/bestchai/dinv/dinvRT"
+)
+
+// TrackedVar is the value of a variable of the raft state at a program
+// point. Value is a uint64, int, bool or string.
+type TrackedVar struct {
+	Name  string
+	Value interface{}
+}
+
+// trackedVars are the variables of the raft state recorded at every program
+// point. To track another variable, add it here.
+var trackedVars = []struct {
+	name string
+	get  func(r *raft) interface{}
+}{
+	{"r.id", func(r *raft) interface{} { return r.id }},
+	{"r.Term", func(r *raft) interface{} { return r.Term }},
+	{"r.Vote", func(r *raft) interface{} { return r.Vote }},
+	{"len(r.readStates)", func(r *raft) interface{} { return len(r.readStates) }},
+	{"r.state", func(r *raft) interface{} { return r.state.String() }},
+	{"r.lead", func(r *raft) interface{} { return r.lead }},
+	{"r.leadTransferee", func(r *raft) interface{} { return r.leadTransferee }},
+	{"r.pendingConf", func(r *raft) interface{} { return r.pendingConf }},
+	{"r.electionElapsed", func(r *raft) interface{} { return r.electionElapsed }},
+	{"r.heartbeatElapsed", func(r *raft) interface{} { return r.heartbeatElapsed }},
+	{"r.checkQuorum", func(r *raft) interface{} { return r.checkQuorum }},
+	{"r.heartbeatTimeout", func(r *raft) interface{} { return r.heartbeatTimeout }},
+	{"r.electionTimeout", func(r *raft) interface{} { return r.electionTimeout }},
+	{"r.randomizedElectionTimeout", func(r *raft) interface{} { return r.randomizedElectionTimeout }},
+	{"r.raftLog.committed", func(r *raft) interface{} { return r.raftLog.committed }},
+	{"r.raftLog.applied", func(r *raft) interface{} { return r.raftLog.applied }},
+	{"r.raftLog.lastIndex", func(r *raft) interface{} { return r.raftLog.lastIndex() }},
+	{"r.raftLog.lastTerm", func(r *raft) interface{} { return r.raftLog.lastTerm() }},
+}
+
+// trackedVarNames is the comma separated list of the tracked variables, as
+// taken by dinvRT.Track.
+var trackedVarNames = func() string {
+	names := make([]string, len(trackedVars))
+	for i, v := range trackedVars {
+		names[i] = v.name
+	}
+	return strings.Join(names, ",")
+}()
+
+// trackedState returns the tracked variables of r.
+func (r *raft) trackedState() []TrackedVar {
+	vars := make([]TrackedVar, len(trackedVars))
+	for i, v := range trackedVars {
+		vars[i] = TrackedVar{Name: v.name, Value: v.get(r)}
+	}
+	return vars
+}
+
+// StateTracker records the raft state at program points.
+type StateTracker interface {
+	TrackState(point string, vars []TrackedVar) error
+}
+
+// track records the state of r at the given program point with dinv and the
+// state tracker of r. Without a state tracker it does nothing, so the program
+// points cost nothing by default.
+func (r *raft) track(point string) {
+	if r.stateTracker == nil {
+		return
+	}
+	vars := r.trackedState()
+	vals := make([]interface{}, len(vars))
+	for i, v := range vars {
+		vals[i] = v.Value
+	}
+	dinvRT.Track("", trackedVarNames, vals...)
+	if err := r.stateTracker.TrackState(point, vars); err != nil {
+		r.logger.Warningf("%x failed to track state at %s (%v)", r.id, point, err)
+	}
+}
+
+// DtraceWriter is a StateTracker that writes the state as a Daikon data
+// trace, which `java daikon.Daikon` reads to infer likely invariants. Every
+// program point is declared before its first sample. It is safe for
+// concurrent use.
+type DtraceWriter struct {
+	mu       sync.Mutex
+	w        *bufio.Writer
+	c        io.Closer
+	declared map[string]bool
+}
+
+// NewDtraceWriter returns a DtraceWriter writing to w.
+func NewDtraceWriter(w io.Writer) (*DtraceWriter, error) {
+	dw := &DtraceWriter{w: bufio.NewWriter(w), declared: make(map[string]bool)}
+	fmt.Fprintf(dw.w, "decl-version 2.0\nvar-comparability none\n\n")
+	if err := dw.w.Flush(); err != nil {
+		return nil, err
+	}
+	return dw, nil
+}
+
+// OpenDtraceWriter creates or truncates the file at path and returns a
+// DtraceWriter writing to it.
+func OpenDtraceWriter(path string) (*DtraceWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	dw, err := NewDtraceWriter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	dw.c = f
+	return dw, nil
+}
+
+func (dw *DtraceWriter) TrackState(point string, vars []TrackedVar) error {
+	typs := make([]dtraceType, len(vars))
+	reps := make([]string, len(vars))
+	for i, v := range vars {
+		var err error
+		if typs[i], reps[i], err = dtraceTypes(v.Value); err != nil {
+			return err
+		}
+	}
+
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	ppt := "raft." + point + ":::POINT"
+	if !dw.declared[ppt] {
+		fmt.Fprintf(dw.w, "ppt %s\nppt-type point\n", ppt)
+		for i, v := range vars {
+			fmt.Fprintf(dw.w, "variable %s\n  var-kind variable\n  dec-type %s\n  rep-type %s\n  comparability -1\n",
+				v.Name, typs[i].dec, typs[i].rep)
+		}
+		fmt.Fprintf(dw.w, "\n")
+		dw.declared[ppt] = true
+	}
+	fmt.Fprintf(dw.w, "%s\n", ppt)
+	for i, v := range vars {
+		fmt.Fprintf(dw.w, "%s\n%s\n1\n", v.Name, reps[i])
+	}
+	fmt.Fprintf(dw.w, "\n")
+	return dw.w.Flush()
+}
+
+// Close closes the file opened by OpenDtraceWriter.
+func (dw *DtraceWriter) Close() error {
+	if dw.c == nil {
+		return nil
+	}
+	return dw.c.Close()
+}
+
+type dtraceType struct {
+	dec, rep string
+}
+
+// dtraceTypes returns the declared and Daikon representation types of v and
+// v as represented in a sample.
+func dtraceTypes(v interface{}) (dtraceType, string, error) {
+	switch v := v.(type) {
+	case uint64:
+		return dtraceType{"uint64", "int"}, strconv.FormatUint(v, 10), nil
+	case int:
+		return dtraceType{"int", "int"}, strconv.Itoa(v), nil
+	case bool:
+		return dtraceType{"bool", "boolean"}, strconv.FormatBool(v), nil
+	case string:
+		return dtraceType{"string", "java.lang.String"}, strconv.Quote(v), nil
+	default:
+		return dtraceType{}, "", fmt.Errorf("raft: cannot track value %v of type %T", v, v)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bytes"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+type stateRecorder struct {
+	points []string
+	states [][]TrackedVar
+}
+
+func (sr *stateRecorder) TrackState(point string, vars []TrackedVar) error {
+	sr.points = append(sr.points, point)
+	sr.states = append(sr.states, vars)
+	return nil
+}
+
+func TestDtraceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	dw, err := NewDtraceWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := []struct {
+		point string
+		vars  []TrackedVar
+	}{
+		{"tick", []TrackedVar{{"r.Term", uint64(1)}, {"r.state", "StateFollower"}}},
+		{"tick", []TrackedVar{{"r.Term", uint64(2)}, {"r.state", "StateLeader"}}},
+		{"step", []TrackedVar{{"r.electionElapsed", 3}, {"r.checkQuorum", true}}},
+	}
+	for _, s := range samples {
+		if err = dw.TrackState(s.point, s.vars); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := `decl-version 2.0
+var-comparability none
+
+ppt raft.tick:::POINT
+ppt-type point
+variable r.Term
+  var-kind variable
+  dec-type uint64
+  rep-type int
+  comparability -1
+variable r.state
+  var-kind variable
+  dec-type string
+  rep-type java.lang.String
+  comparability -1
+
+raft.tick:::POINT
+r.Term
+1
+1
+r.state
+"StateFollower"
+1
+
+raft.tick:::POINT
+r.Term
+2
+1
+r.state
+"StateLeader"
+1
+
+ppt raft.step:::POINT
+ppt-type point
+variable r.electionElapsed
+  var-kind variable
+  dec-type int
+  rep-type int
+  comparability -1
+variable r.checkQuorum
+  var-kind variable
+  dec-type bool
+  rep-type boolean
+  comparability -1
+
+raft.step:::POINT
+r.electionElapsed
+3
+1
+r.checkQuorum
+true
+1
+
+`
+	if g := buf.String(); g != w {
+		t.Errorf("dtrace = %s, want %s", g, w)
+	}
+}
+
+func TestDtraceWriterUnsupportedType(t *testing.T) {
+	dw, err := NewDtraceWriter(&bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dw.TrackState("tick", []TrackedVar{{"r.prs", []uint64{1}}}); err == nil {
+		t.Errorf("err = nil, want error")
+	}
+}
+
+func TestRaftTracksState(t *testing.T) {
+	sr := &stateRecorder{}
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	cfg.StateTracker = sr
+	r := newRaft(cfg)
+	r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	var leader []TrackedVar
+	for i, p := range sr.points {
+		if len(sr.states[i]) != len(trackedVars) {
+			t.Fatalf("#%d: len(vars) = %d, want %d", i, len(sr.states[i]), len(trackedVars))
+		}
+		if p == "becomeLeader" {
+			leader = sr.states[i]
+		}
+	}
+	if len(sr.points) == 0 || sr.points[0] != "reset" {
+		t.Errorf("points = %v, want starting with reset", sr.points)
+	}
+	if leader == nil {
+		t.Fatalf("points = %v, want becomeLeader", sr.points)
+	}
+	wvals := map[string]interface{}{
+		"r.id":    uint64(1),
+		"r.Term":  uint64(1),
+		"r.lead":  uint64(1),
+		"r.state": "StateLeader",
+	}
+	for _, v := range leader {
+		if w, ok := wvals[v.Name]; ok && v.Value != w {
+			t.Errorf("%s = %v, want %v", v.Name, v.Value, w)
+		}
+	}
+}
+
+func TestRaftTrackNoStateTracker(t *testing.T) {
+	r := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	if n := testing.AllocsPerRun(100, func() { r.track("send") }); n != 0 {
+		t.Errorf("allocs = %v, want 0", n)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// FirstApplicationEntryType is the first entry type available to
+// applications. Raft replicates the entries of the types from it on like
+// normal entries and never interprets their data; the types before it are
+// reserved by raft.
+const FirstApplicationEntryType pb.EntryType = 64
+
+var (
+	ErrReservedEntryType   = errors.New("raft: entry type is reserved by raft")
+	ErrEntryTypeRegistered = errors.New("raft: entry type is already registered")
+	ErrUnknownEntryType    = errors.New("raft: unknown entry type")
+)
+
+// IsApplicationEntryType returns true if t is available to applications.
+func IsApplicationEntryType(t pb.EntryType) bool { return t >= FirstApplicationEntryType }
+
+// EntryCodec encodes the values carried by the entries of an application
+// entry type into entry data, and decodes them back.
+type EntryCodec interface {
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// EntryTypes is a registry of application entry types. It lets an
+// application replicate several kinds of commands, each with its own
+// encoding, without multiplexing them in the data of normal entries.
+type EntryTypes struct {
+	mu    sync.RWMutex
+	types map[pb.EntryType]entryType
+}
+
+type entryType struct {
+	name  string
+	codec EntryCodec
+}
+
+func NewEntryTypes() *EntryTypes {
+	return &EntryTypes{types: make(map[pb.EntryType]entryType)}
+}
+
+// Register registers the application entry type t under name, with the
+// codec of its values.
+func (et *EntryTypes) Register(t pb.EntryType, name string, c EntryCodec) error {
+	if !IsApplicationEntryType(t) {
+		return ErrReservedEntryType
+	}
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if _, ok := et.types[t]; ok {
+		return ErrEntryTypeRegistered
+	}
+	et.types[t] = entryType{name: name, codec: c}
+	return nil
+}
+
+// Name returns the name t was registered under.
+func (et *EntryTypes) Name(t pb.EntryType) (string, bool) {
+	et.mu.RLock()
+	defer et.mu.RUnlock()
+	typ, ok := et.types[t]
+	return typ.name, ok
+}
+
+// Encode returns the entry of type t carrying v.
+func (et *EntryTypes) Encode(t pb.EntryType, v interface{}) (pb.Entry, error) {
+	c, err := et.codec(t)
+	if err != nil {
+		return pb.Entry{}, err
+	}
+	data, err := c.Encode(v)
+	if err != nil {
+		return pb.Entry{}, err
+	}
+	return pb.Entry{Type: t, Data: data}, nil
+}
+
+// Decode returns the value carried by the application entry e.
+func (et *EntryTypes) Decode(e pb.Entry) (interface{}, error) {
+	c, err := et.codec(e.Type)
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(e.Data)
+}
+
+// Describe returns a concise human-readable description of the application
+// entry e for debugging.
+func (et *EntryTypes) Describe(e pb.Entry) string {
+	name, ok := et.Name(e.Type)
+	if !ok {
+		return DescribeEntry(e, nil)
+	}
+	v, err := et.Decode(e)
+	if err != nil {
+		return fmt.Sprintf("%d/%d %s %q", e.Term, e.Index, name, e.Data)
+	}
+	return fmt.Sprintf("%d/%d %s %v", e.Term, e.Index, name, v)
+}
+
+func (et *EntryTypes) codec(t pb.EntryType) (EntryCodec, error) {
+	et.mu.RLock()
+	defer et.mu.RUnlock()
+	typ, ok := et.types[t]
+	if !ok {
+		return nil, ErrUnknownEntryType
+	}
+	return typ.codec, nil
+}
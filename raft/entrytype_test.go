@@ -0,0 +1,94 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// intCodec encodes ints in decimal.
+type intCodec struct{}
+
+func (intCodec) Encode(v interface{}) ([]byte, error) {
+	i, ok := v.(int)
+	if !ok {
+		return nil, errors.New("not an int")
+	}
+	return []byte(strconv.Itoa(i)), nil
+}
+
+func (intCodec) Decode(data []byte) (interface{}, error) { return strconv.Atoi(string(data)) }
+
+func TestEntryTypesRegister(t *testing.T) {
+	tests := []struct {
+		t    pb.EntryType
+		werr error
+	}{
+		{pb.EntryNormal, ErrReservedEntryType},
+		{pb.EntryConfChangeV2, ErrReservedEntryType},
+		{FirstApplicationEntryType - 1, ErrReservedEntryType},
+		{FirstApplicationEntryType, nil},
+		{FirstApplicationEntryType, ErrEntryTypeRegistered},
+		{FirstApplicationEntryType + 1, nil},
+	}
+	et := NewEntryTypes()
+	for i, tt := range tests {
+		if err := et.Register(tt.t, "int", intCodec{}); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+	}
+}
+
+func TestEntryTypesEncodeDecode(t *testing.T) {
+	et := NewEntryTypes()
+	typ := FirstApplicationEntryType
+	if err := et.Register(typ, "int", intCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := et.Encode(typ, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if we := (pb.Entry{Type: typ, Data: []byte("42")}); !reflect.DeepEqual(e, we) {
+		t.Errorf("entry = %+v, want %+v", e, we)
+	}
+	v, err := et.Decode(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 42 {
+		t.Errorf("value = %v, want 42", v)
+	}
+	e.Index, e.Term = 2, 1
+	if g, w := et.Describe(e), "1/2 int 42"; g != w {
+		t.Errorf("describe = %q, want %q", g, w)
+	}
+
+	if _, err = et.Encode(typ, "42"); err == nil {
+		t.Errorf("err = nil, want the codec error")
+	}
+	if _, err = et.Encode(typ+1, 42); err != ErrUnknownEntryType {
+		t.Errorf("err = %v, want %v", err, ErrUnknownEntryType)
+	}
+	if _, err = et.Decode(pb.Entry{Type: typ + 1}); err != ErrUnknownEntryType {
+		t.Errorf("err = %v, want %v", err, ErrUnknownEntryType)
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Names of the fault points built into raft.
+const (
+	// FaultCommitWithoutLeader makes a follower append entries and commit
+	// them without waiting for the leader. It violates strong leadership.
+	FaultCommitWithoutLeader = "commit-without-leader"
+	// FaultCorruptEntry makes a follower corrupt the data of an entry it
+	// accepted from the leader. It violates log matching.
+	FaultCorruptEntry = "corrupt-entry"
+	// FaultFalseLeader makes a node that becomes a follower believe another
+	// node is the leader. It violates leader agreement.
+	FaultFalseLeader = "false-leader"
+)
+
+var (
+	ErrFaultExists   = errors.New("raft: fault point already registered")
+	ErrFaultNotFound = errors.New("raft: fault point not found")
+)
+
+// FaultRole selects the nodes a fault is aimed at.
+type FaultRole uint64
+
+// Possible values for FaultRole.
+const (
+	FaultAnyRole FaultRole = iota
+	FaultLeader
+	FaultFollower
+)
+
+var faultRoles = [...]string{
+	"any",
+	"leader",
+	"follower",
+}
+
+func (fr FaultRole) String() string {
+	return faultRoles[uint64(fr)]
+}
+
+// matches returns true if a node in the given state is aimed at. Candidates
+// count as followers.
+func (fr FaultRole) matches(st StateType) bool {
+	switch fr {
+	case FaultLeader:
+		return st == StateLeader
+	case FaultFollower:
+		return st != StateLeader
+	}
+	return true
+}
+
+// FaultSpec describes when an enabled fault point fires. A spec either fires
+// on every eligible hit with Probability, or fires once on the Trigger-th
+// eligible hit.
+type FaultSpec struct {
+	// Role restricts the fault to the nodes in the given role.
+	Role FaultRole
+	// Probability is the chance, in [0, 1], that an eligible hit fires.
+	Probability float64
+	// Trigger, if positive, fires the fault once on the Trigger-th eligible
+	// hit and then disables it.
+	Trigger int
+}
+
+func (fs FaultSpec) validate() error {
+	if fs.Role > FaultFollower {
+		return fmt.Errorf("unknown fault role %d", fs.Role)
+	}
+	if fs.Probability < 0 || fs.Probability > 1 {
+		return fmt.Errorf("fault probability %v must be in [0, 1]", fs.Probability)
+	}
+	if fs.Trigger < 0 {
+		return fmt.Errorf("fault trigger %d must not be negative", fs.Trigger)
+	}
+	if (fs.Probability > 0) == (fs.Trigger > 0) {
+		return errors.New("fault must set exactly one of probability and trigger")
+	}
+	return nil
+}
+
+// String returns the spec in the format read by ParseFaultSpec.
+func (fs FaultSpec) String() string {
+	if fs.Trigger > 0 {
+		return fmt.Sprintf("role=%s,trigger=%d", fs.Role, fs.Trigger)
+	}
+	return fmt.Sprintf("role=%s,probability=%v", fs.Role, fs.Probability)
+}
+
+// ParseFaultSpec parses a comma separated list of key=value pairs into a
+// FaultSpec, e.g. "role=follower,probability=0.05" or "role=leader,trigger=3".
+// The role defaults to any.
+func ParseFaultSpec(s string) (FaultSpec, error) {
+	var fs FaultSpec
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return fs, fmt.Errorf("invalid fault spec field %q", kv)
+		}
+		k, v := kv[:i], kv[i+1:]
+		var err error
+		switch k {
+		case "role":
+			fs.Role, err = parseFaultRole(v)
+		case "probability":
+			fs.Probability, err = strconv.ParseFloat(v, 64)
+		case "trigger":
+			fs.Trigger, err = strconv.Atoi(v)
+		default:
+			err = fmt.Errorf("unknown fault spec field %q", k)
+		}
+		if err != nil {
+			return fs, err
+		}
+	}
+	return fs, fs.validate()
+}
+
+func parseFaultRole(s string) (FaultRole, error) {
+	for i, name := range faultRoles {
+		if name == s {
+			return FaultRole(i), nil
+		}
+	}
+	return FaultAnyRole, fmt.Errorf("unknown fault role %q", s)
+}
+
+// FaultStatus is the state of a registered fault point.
+type FaultStatus struct {
+	Name        string
+	Description string
+	Enabled     bool
+	Spec        FaultSpec
+	// Hits is the number of eligible hits since the fault was enabled.
+	Hits int
+	// Fired is the number of times the fault fired since it was registered.
+	Fired int
+}
+
+type faultPoint struct {
+	desc    string
+	enabled bool
+	spec    FaultSpec
+	hits    int
+	fired   int
+}
+
+// FaultInjector holds named fault points that raft consults at the places
+// bugs can be injected. Faults are disabled until enabled at runtime. All the
+// random choices are drawn from a source seeded at creation, so a run that
+// hits the fault points in the same order fires the same faults.
+//
+// A FaultInjector may be shared by the nodes of a cluster and is safe for
+// concurrent use.
+type FaultInjector struct {
+	mu      sync.Mutex
+	rand    *rand.Rand
+	points  map[string]*faultPoint
+	enabled int
+}
+
+// NewFaultInjector returns a FaultInjector seeded with seed, with the
+// built-in fault points registered.
+func NewFaultInjector(seed int64) *FaultInjector {
+	fi := &FaultInjector{
+		rand:   rand.New(rand.NewSource(seed)),
+		points: make(map[string]*faultPoint),
+	}
+	fi.Register(FaultCommitWithoutLeader, "follower commits entries without the leader")
+	fi.Register(FaultCorruptEntry, "follower corrupts an entry accepted from the leader")
+	fi.Register(FaultFalseLeader, "new follower believes another node is the leader")
+	return fi
+}
+
+// Register adds a disabled fault point.
+func (fi *FaultInjector) Register(name, desc string) error {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if _, ok := fi.points[name]; ok {
+		return ErrFaultExists
+	}
+	fi.points[name] = &faultPoint{desc: desc}
+	return nil
+}
+
+// Enable enables the named fault point with the given spec, replacing any
+// spec it was enabled with and resetting its hit count.
+func (fi *FaultInjector) Enable(name string, spec FaultSpec) error {
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fp, ok := fi.points[name]
+	if !ok {
+		return ErrFaultNotFound
+	}
+	if !fp.enabled {
+		fi.enabled++
+	}
+	fp.enabled, fp.spec, fp.hits = true, spec, 0
+	return nil
+}
+
+// Disable disables the named fault point.
+func (fi *FaultInjector) Disable(name string) error {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fp, ok := fi.points[name]
+	if !ok {
+		return ErrFaultNotFound
+	}
+	fi.disable(fp)
+	return nil
+}
+
+func (fi *FaultInjector) disable(fp *faultPoint) {
+	if fp.enabled {
+		fi.enabled--
+	}
+	fp.enabled = false
+}
+
+// Status returns the state of the registered fault points, sorted by name.
+func (fi *FaultInjector) Status() []FaultStatus {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fss := make([]FaultStatus, 0, len(fi.points))
+	for name, fp := range fi.points {
+		fss = append(fss, FaultStatus{
+			Name:        name,
+			Description: fp.desc,
+			Enabled:     fp.enabled,
+			Spec:        fp.spec,
+			Hits:        fp.hits,
+			Fired:       fp.fired,
+		})
+	}
+	sort.Sort(faultStatusByName(fss))
+	return fss
+}
+
+// Fire reports whether the named fault point fires for a node in the given
+// state. It is called at the place the fault is injected. Fire on a nil
+// FaultInjector never fires.
+func (fi *FaultInjector) Fire(name string, st StateType) bool {
+	if fi == nil {
+		return false
+	}
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if fi.enabled == 0 {
+		return false
+	}
+	fp, ok := fi.points[name]
+	if !ok || !fp.enabled || !fp.spec.Role.matches(st) {
+		return false
+	}
+	fp.hits++
+	if fp.spec.Trigger > 0 {
+		if fp.hits < fp.spec.Trigger {
+			return false
+		}
+		fi.disable(fp)
+	} else if fi.rand.Float64() >= fp.spec.Probability {
+		return false
+	}
+	fp.fired++
+	return true
+}
+
+// Intn returns a random number in [0, n) from the seeded source. Fault points
+// use it to make their random choices.
+func (fi *FaultInjector) Intn(n int) int {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.rand.Intn(n)
+}
+
+type faultStatusByName []FaultStatus
+
+func (s faultStatusByName) Len() int           { return len(s) }
+func (s faultStatusByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s faultStatusByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// faultInvariant returns the invariant violated by the named fault, or "" if
+// the fault is not paired with an invariant.
+func faultInvariant(fault string) string {
+	for invariant, bf := range bugFaults {
+		if bf.name == fault {
+			return invariant
+		}
+	}
+	return ""
+}
+
+// bugFaults pairs the built-in invariants with the fault that violates them
+// and the spec the fault is enabled with by DinvConfig.InjectBugs.
+var bugFaults = map[string]struct {
+	name string
+	spec FaultSpec
+}{
+	InvariantStrongLeadership: {FaultCommitWithoutLeader, FaultSpec{Role: FaultFollower, Probability: 0.05}},
+	InvariantLogMatching:      {FaultCorruptEntry, FaultSpec{Role: FaultFollower, Probability: 0.05}},
+	InvariantLeaderAgreement:  {FaultFalseLeader, FaultSpec{Role: FaultFollower, Probability: 1}},
+}
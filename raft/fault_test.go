@@ -0,0 +1,307 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// enabledFaults returns the names of the enabled faults of fi.
+func enabledFaults(fi *FaultInjector) []string {
+	if fi == nil {
+		return nil
+	}
+	var names []string
+	for _, fs := range fi.Status() {
+		if fs.Enabled {
+			names = append(names, fs.Name)
+		}
+	}
+	return names
+}
+
+func TestParseFaultSpec(t *testing.T) {
+	tests := []struct {
+		s    string
+		w    FaultSpec
+		werr bool
+	}{
+		{"probability=0.5", FaultSpec{Probability: 0.5}, false},
+		{"role=leader,trigger=3", FaultSpec{Role: FaultLeader, Trigger: 3}, false},
+		{" role=follower , probability=1", FaultSpec{Role: FaultFollower, Probability: 1}, false},
+		{"role=any,trigger=1", FaultSpec{Trigger: 1}, false},
+
+		{"", FaultSpec{}, true},
+		{"role=leader", FaultSpec{}, true},
+		{"probability=0.5,trigger=1", FaultSpec{}, true},
+		{"probability=2", FaultSpec{}, true},
+		{"trigger=-1", FaultSpec{}, true},
+		{"role=candidate,trigger=1", FaultSpec{}, true},
+		{"delay=1", FaultSpec{}, true},
+		{"trigger", FaultSpec{}, true},
+	}
+	for i, tt := range tests {
+		fs, err := ParseFaultSpec(tt.s)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if err != nil {
+			continue
+		}
+		if fs != tt.w {
+			t.Errorf("#%d: spec = %+v, want %+v", i, fs, tt.w)
+		}
+		if rfs, err := ParseFaultSpec(fs.String()); err != nil || rfs != fs {
+			t.Errorf("#%d: parse(%q) = %+v, %v, want %+v", i, fs.String(), rfs, err, fs)
+		}
+	}
+}
+
+func TestFaultInjectorRegister(t *testing.T) {
+	fi := NewFaultInjector(1)
+	if err := fi.Register(FaultFalseLeader, ""); err != ErrFaultExists {
+		t.Errorf("err = %v, want %v", err, ErrFaultExists)
+	}
+	if err := fi.Register("custom", "a custom fault"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fi.Enable("unknown", FaultSpec{Trigger: 1}); err != ErrFaultNotFound {
+		t.Errorf("err = %v, want %v", err, ErrFaultNotFound)
+	}
+	if err := fi.Disable("unknown"); err != ErrFaultNotFound {
+		t.Errorf("err = %v, want %v", err, ErrFaultNotFound)
+	}
+	if err := fi.Enable("custom", FaultSpec{}); err == nil {
+		t.Errorf("expected error enabling a fault that never fires")
+	}
+	if fi.Fire("custom", StateFollower) {
+		t.Errorf("disabled fault fired")
+	}
+
+	if err := fi.Enable("custom", FaultSpec{Probability: 1}); err != nil {
+		t.Fatal(err)
+	}
+	wnames := []string{FaultCommitWithoutLeader, FaultCorruptEntry, "custom", FaultFalseLeader}
+	var names []string
+	for _, fs := range fi.Status() {
+		names = append(names, fs.Name)
+	}
+	if !reflect.DeepEqual(names, wnames) {
+		t.Errorf("names = %v, want %v", names, wnames)
+	}
+	if g := enabledFaults(fi); !reflect.DeepEqual(g, []string{"custom"}) {
+		t.Errorf("enabled = %v, want [custom]", g)
+	}
+	if !fi.Fire("custom", StateFollower) {
+		t.Errorf("fault with probability 1 did not fire")
+	}
+	if err := fi.Disable("custom"); err != nil {
+		t.Fatal(err)
+	}
+	if fi.Fire("custom", StateFollower) {
+		t.Errorf("disabled fault fired")
+	}
+
+	var nilfi *FaultInjector
+	if nilfi.Fire(FaultFalseLeader, StateFollower) {
+		t.Errorf("nil injector fired")
+	}
+}
+
+// TestFaultInjectorTrigger ensures a triggered fault fires once, on the
+// Trigger-th hit by a node in the targeted role.
+func TestFaultInjectorTrigger(t *testing.T) {
+	tests := []struct {
+		role   FaultRole
+		states []StateType
+		wfire  []bool
+	}{
+		{
+			FaultLeader,
+			[]StateType{StateLeader, StateFollower, StateLeader, StateCandidate, StateLeader, StateLeader},
+			[]bool{false, false, false, false, true, false},
+		},
+		{
+			FaultFollower,
+			[]StateType{StateFollower, StateLeader, StateCandidate, StateFollower, StateFollower},
+			[]bool{false, false, false, true, false},
+		},
+		{
+			FaultAnyRole,
+			[]StateType{StateFollower, StateLeader, StateCandidate, StateFollower},
+			[]bool{false, false, true, false},
+		},
+	}
+	for i, tt := range tests {
+		fi := NewFaultInjector(1)
+		fi.Enable(FaultFalseLeader, FaultSpec{Role: tt.role, Trigger: 3})
+		for j, st := range tt.states {
+			if g := fi.Fire(FaultFalseLeader, st); g != tt.wfire[j] {
+				t.Errorf("#%d.%d: fire = %v, want %v", i, j, g, tt.wfire[j])
+			}
+		}
+		fs := fi.Status()[2]
+		if fs.Enabled || fs.Fired != 1 {
+			t.Errorf("#%d: enabled = %v, fired = %d, want false, 1", i, fs.Enabled, fs.Fired)
+		}
+	}
+}
+
+// TestFaultInjectorSeed ensures injectors with the same seed fire the same
+// probabilistic faults.
+func TestFaultInjectorSeed(t *testing.T) {
+	fire := func(seed int64) []bool {
+		fi := NewFaultInjector(seed)
+		fi.Enable(FaultCorruptEntry, FaultSpec{Probability: 0.5})
+		fired := make([]bool, 100)
+		for i := range fired {
+			fired[i] = fi.Fire(FaultCorruptEntry, StateFollower)
+		}
+		return fired
+	}
+	a, b, c := fire(1), fire(1), fire(2)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed fired %v and %v", a, b)
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Errorf("different seeds fired the same faults")
+	}
+	n := 0
+	for _, f := range a {
+		if f {
+			n++
+		}
+	}
+	if n == 0 || n == len(a) {
+		t.Errorf("fired %d of %d faults with probability 0.5", n, len(a))
+	}
+}
+
+// newFaultNetwork returns a network of three rafts sharing fi and bl.
+func newFaultNetwork(fi *FaultInjector, bl BugLogger) *network {
+	peers := make([]stateMachine, 3)
+	for i := range peers {
+		cfg := newTestConfig(uint64(i+1), []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		cfg.Faults = fi
+		cfg.BugLog = bl
+		peers[i] = newRaft(cfg)
+	}
+	return newNetwork(peers...)
+}
+
+// checkBugStart ensures the only recorded bug event is the start of the named
+// fault on node id.
+func checkBugStart(t *testing.T, bl *bugRecorder, id uint64, fault string) {
+	if len(bl.events) != 1 {
+		t.Fatalf("len(events) = %d, want 1", len(bl.events))
+	}
+	e := bl.events[0]
+	if e.Type != BugStart || e.Node != id || e.Fault != fault || e.Invariant != faultInvariant(fault) {
+		t.Errorf("event = %+v, want start of %s on %x", e, fault, id)
+	}
+}
+
+// The fault tests isolate node 3 so the fault can only hit node 2.
+
+func TestRaftFaultFalseLeader(t *testing.T) {
+	fi := NewFaultInjector(1)
+	bl := &bugRecorder{}
+	nt := newFaultNetwork(fi, bl)
+	nt.isolate(3)
+	fi.Enable(FaultFalseLeader, FaultSpec{Role: FaultFollower, Trigger: 1})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("some data")}}})
+
+	if g := nt.peers[1].(*raft).state; g != StateLeader {
+		t.Fatalf("state of 1 = %s, want %s", g, StateLeader)
+	}
+	f := nt.peers[2].(*raft)
+	if !f.falseLead || f.lead == 1 {
+		t.Errorf("lead of 2 = %x, want a false leader", f.lead)
+	}
+	checkBugStart(t, bl, 2, FaultFalseLeader)
+}
+
+func TestRaftFaultCorruptEntry(t *testing.T) {
+	fi := NewFaultInjector(1)
+	bl := &bugRecorder{}
+	nt := newFaultNetwork(fi, bl)
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	fi.Enable(FaultCorruptEntry, FaultSpec{Role: FaultFollower, Trigger: 1})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("some data")}}})
+
+	l, f := nt.peers[1].(*raft).raftLog.allEntries(), nt.peers[2].(*raft).raftLog.allEntries()
+	if len(l) != len(f) {
+		t.Fatalf("len(log of 2) = %d, want %d", len(f), len(l))
+	}
+	last := len(l) - 1
+	if !reflect.DeepEqual(l[:last], f[:last]) {
+		t.Errorf("log of 2 = %+v, want %+v", f[:last], l[:last])
+	}
+	if l[last].Term != f[last].Term || reflect.DeepEqual(l[last].Data, f[last].Data) {
+		t.Errorf("entry of 2 = %+v, want a corrupt copy of %+v", f[last], l[last])
+	}
+	checkBugStart(t, bl, 2, FaultCorruptEntry)
+}
+
+func TestRaftFaultCommitWithoutLeader(t *testing.T) {
+	fi := NewFaultInjector(1)
+	bl := &bugRecorder{}
+	nt := newFaultNetwork(fi, bl)
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	fi.Enable(FaultCommitWithoutLeader, FaultSpec{Role: FaultFollower, Trigger: 1})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgBeat})
+
+	lead, f := nt.peers[1].(*raft), nt.peers[2].(*raft)
+	if f.raftLog.lastIndex() <= lead.raftLog.lastIndex() {
+		t.Errorf("last index of 2 = %d, want more than the leader's %d", f.raftLog.lastIndex(), lead.raftLog.lastIndex())
+	}
+	if g := fi.Status()[0].Fired; g != 1 {
+		t.Errorf("fired = %d, want 1", g)
+	}
+	checkBugStart(t, bl, 2, FaultCommitWithoutLeader)
+}
+
+// TestDinvInjectBugsSeed ensures InjectBugs enables the paired faults on the
+// configured injector, or on one seeded with FaultSeed.
+func TestDinvInjectBugsSeed(t *testing.T) {
+	dc := DinvConfig{Invariants: []string{InvariantLeaderAgreement, InvariantLogMatching}, InjectBugs: true, FaultSeed: 7}
+	wfaults := []string{FaultCorruptEntry, FaultFalseLeader}
+
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.Dinv = dc
+	cfg.Asserter = make(localAssertables).asserter("1")
+	r := newRaft(cfg)
+	if g := enabledFaults(r.faults); !reflect.DeepEqual(g, wfaults) {
+		t.Errorf("faults = %v, want %v", g, wfaults)
+	}
+
+	fi := NewFaultInjector(1)
+	cfg = newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.Dinv = dc
+	cfg.Asserter = make(localAssertables).asserter("1")
+	cfg.Faults = fi
+	if r = newRaft(cfg); r.faults != fi {
+		t.Errorf("faults = %p, want the configured injector %p", r.faults, fi)
+	}
+	if g := enabledFaults(fi); !reflect.DeepEqual(g, wfaults) {
+		t.Errorf("faults = %v, want %v", g, wfaults)
+	}
+}
@@ -0,0 +1,641 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"bitbucket.org/bestchai/dinv/dinvRT"
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// Names of the assertable variables every raft node exposes to distributed
+// invariant checks.
+const (
+	AssertableID        = "id"
+	AssertableTerm      = "term"
+	AssertableState     = "state"
+	AssertableLeader    = "leader"
+	AssertableCommitted = "commited"
+	AssertableApplied   = "applied"
+	AssertableLog       = "log"
+)
+
+// Names of the built-in invariants.
+const (
+	InvariantLeaderAgreement    = "leader-agreement"
+	InvariantStrongLeadership   = "strong-leadership"
+	InvariantLogMatching        = "log-matching"
+	InvariantElectionSafety     = "election-safety"
+	InvariantLeaderCompleteness = "leader-completeness"
+	InvariantStateMachineSafety = "state-machine-safety"
+)
+
+var ErrInvariantExists = errors.New("raft: invariant already registered")
+
+// InvariantState is the local raft state an invariant is checked from.
+type InvariantState struct {
+	ID        uint64
+	Term      uint64
+	Lead      uint64
+	RaftState StateType
+	Committed uint64
+	Applied   uint64
+}
+
+// InvariantChecker is a named safety property of a raft cluster. It is
+// evaluated over the assertable variables gathered from every node.
+type InvariantChecker interface {
+	// Name returns the name the invariant is registered and reported under.
+	Name() string
+	// Variables returns the assertable variables Check reads from every node.
+	Variables() []string
+	// Check evaluates the invariant. local is the state of the node running
+	// the check and values maps every node to its assertable variables.
+	// Check returns false if the invariant is violated.
+	Check(local InvariantState, values map[string]map[string]interface{}) bool
+}
+
+// Asserter distributes invariant checks across a cluster. It exposes local
+// variables to the checks of remote nodes and gathers remote variables for
+// local checks.
+type Asserter interface {
+	// AddAssertable makes the variable pointed to by ptr readable by remote
+	// checks under name.
+	AddAssertable(name string, ptr interface{})
+	// Assert gathers vars from every node and evaluates check over them.
+	Assert(vars []string, check func(values map[string]map[string]interface{}) bool)
+}
+
+// InvariantOptions controls when a registered invariant is checked.
+type InvariantOptions struct {
+	// Sample checks the invariant on one in Sample steps, chosen at random.
+	// Values less than or equal to 1 check it on every step.
+	Sample int
+	// LeaderOnly restricts checking to a node that believes it is the leader.
+	LeaderOnly bool
+}
+
+type registeredInvariant struct {
+	checker InvariantChecker
+	opts    InvariantOptions
+}
+
+// InvariantRegistry is a set of invariants checked by raft while stepping
+// messages. It is safe to register and unregister invariants concurrently
+// with raft using the registry.
+type InvariantRegistry struct {
+	mu         sync.Mutex
+	invariants []registeredInvariant
+}
+
+func NewInvariantRegistry() *InvariantRegistry {
+	return &InvariantRegistry{}
+}
+
+// Register adds c to the registry. It returns ErrInvariantExists if an
+// invariant with the same name is already registered.
+func (ir *InvariantRegistry) Register(c InvariantChecker, opts InvariantOptions) error {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	for _, ri := range ir.invariants {
+		if ri.checker.Name() == c.Name() {
+			return ErrInvariantExists
+		}
+	}
+	ir.invariants = append(ir.invariants, registeredInvariant{checker: c, opts: opts})
+	return nil
+}
+
+// Unregister removes the invariant with the given name. It returns false if
+// no such invariant is registered.
+func (ir *InvariantRegistry) Unregister(name string) bool {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	for i, ri := range ir.invariants {
+		if ri.checker.Name() == name {
+			ir.invariants = append(ir.invariants[:i], ir.invariants[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the names of the registered invariants in registration order.
+func (ir *InvariantRegistry) Names() []string {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	names := make([]string, len(ir.invariants))
+	for i, ri := range ir.invariants {
+		names[i] = ri.checker.Name()
+	}
+	return names
+}
+
+func (ir *InvariantRegistry) empty() bool {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	return len(ir.invariants) == 0
+}
+
+// checkers returns every registered invariant.
+func (ir *InvariantRegistry) checkers() []InvariantChecker {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	cs := make([]InvariantChecker, len(ir.invariants))
+	for i, ri := range ir.invariants {
+		cs[i] = ri.checker
+	}
+	return cs
+}
+
+// sample returns the invariants due to be checked by a node in the given
+// local state.
+func (ir *InvariantRegistry) sample(local InvariantState) []InvariantChecker {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	var due []InvariantChecker
+	for _, ri := range ir.invariants {
+		if ri.opts.LeaderOnly && local.ID != local.Lead {
+			continue
+		}
+		if ri.opts.Sample > 1 && rand.Intn(ri.opts.Sample) != 0 {
+			continue
+		}
+		due = append(due, ri.checker)
+	}
+	return due
+}
+
+// DinvConfig configures the built-in invariants checked by a raft node and
+// the bugs injected to violate them.
+type DinvConfig struct {
+	// Invariants names the built-in invariants to check.
+	Invariants []string
+	// LeaderOnly restricts the checks to the node that believes it is the
+	// leader.
+	LeaderOnly bool
+	// Sample checks the invariants on one in Sample steps, chosen at random.
+	// Values less than or equal to 1 check them on every step.
+	Sample int
+	// InjectBugs enables the fault paired with each configured invariant.
+	InjectBugs bool
+	// FaultSeed seeds the fault injector created for InjectBugs.
+	FaultSeed int64
+	// CutTick is the number of ticks between the consistent cuts the leader
+	// takes to check the invariants over, instead of gathering the state of
+	// the other nodes when the checks are due. LeaderOnly and Sample do not
+	// apply to the checks over cuts. 0 disables the cuts.
+	CutTick int
+}
+
+// Validate returns an error if the configuration names an unknown invariant
+// or a bug that does not exist.
+func (c *DinvConfig) Validate() error {
+	if c.Sample < 0 {
+		return fmt.Errorf("dinv sample %d must not be negative", c.Sample)
+	}
+	if c.CutTick < 0 {
+		return fmt.Errorf("dinv cut tick %d must not be negative", c.CutTick)
+	}
+	seen := make(map[string]bool)
+	for _, name := range c.Invariants {
+		if _, ok := BuiltinInvariant(name); !ok {
+			return fmt.Errorf("unknown dinv invariant %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate dinv invariant %q", name)
+		}
+		seen[name] = true
+		if _, ok := bugFaults[name]; c.InjectBugs && !ok {
+			return fmt.Errorf("dinv invariant %q has no bug to inject", name)
+		}
+	}
+	if c.InjectBugs && len(c.Invariants) == 0 {
+		return errors.New("dinv bug injection requires an invariant")
+	}
+	return nil
+}
+
+// registry returns a registry holding the configured invariants, or nil if
+// none are configured.
+func (c *DinvConfig) registry() *InvariantRegistry {
+	if len(c.Invariants) == 0 {
+		return nil
+	}
+	ir := NewInvariantRegistry()
+	for _, name := range c.Invariants {
+		ic, _ := BuiltinInvariant(name)
+		ir.Register(ic, InvariantOptions{Sample: c.Sample, LeaderOnly: c.LeaderOnly})
+	}
+	return ir
+}
+
+// injectBugs enables the fault paired with each configured invariant on fi.
+func (c *DinvConfig) injectBugs(fi *FaultInjector) {
+	for _, name := range c.Invariants {
+		bf := bugFaults[name]
+		fi.Enable(bf.name, bf.spec)
+	}
+}
+
+// BuiltinInvariant returns the built-in invariant with the given name.
+func BuiltinInvariant(name string) (InvariantChecker, bool) {
+	switch name {
+	case InvariantLeaderAgreement:
+		return leaderAgreement{}, true
+	case InvariantStrongLeadership:
+		return strongLeadership{}, true
+	case InvariantLogMatching:
+		return logMatching{}, true
+	case InvariantElectionSafety:
+		return electionSafety{}, true
+	case InvariantLeaderCompleteness:
+		return leaderCompleteness{}, true
+	case InvariantStateMachineSafety:
+		return stateMachineSafety{}, true
+	}
+	return nil, false
+}
+
+// dinvAsserter is the Asserter backed by the dinv runtime.
+type dinvAsserter struct{}
+
+var initDinvAssertOnce sync.Once
+
+func newDinvAsserter() Asserter {
+	initDinvAssertOnce.Do(func() { dinvRT.InitDistributedAssert("", nil, "raft") })
+	return dinvAsserter{}
+}
+
+func (dinvAsserter) AddAssertable(name string, ptr interface{}) {
+	dinvRT.AddAssertable(name, ptr, nil)
+}
+
+func (dinvAsserter) Assert(vars []string, check func(values map[string]map[string]interface{}) bool) {
+	req := make(map[string][]string)
+	for _, p := range dinvRT.GetPeers() {
+		req[p] = vars
+	}
+	dinvRT.Assert(check, req)
+}
+
+// addAssertables exposes the state of r to remote invariant checks.
+func (r *raft) addAssertables() {
+	r.asserter.AddAssertable(AssertableID, &r.id)
+	r.asserter.AddAssertable(AssertableTerm, &r.Term)
+	r.asserter.AddAssertable(AssertableState, &r.state)
+	r.asserter.AddAssertable(AssertableLeader, &r.lead)
+	r.asserter.AddAssertable(AssertableCommitted, &r.raftLog.committed)
+	r.asserter.AddAssertable(AssertableApplied, &r.raftLog.applied)
+	r.asserter.AddAssertable(AssertableLog, &r.assertLog)
+}
+
+func (r *raft) invariantState() InvariantState {
+	return InvariantState{
+		ID:        r.id,
+		Term:      r.Term,
+		Lead:      r.lead,
+		RaftState: r.state,
+		Committed: r.raftLog.committed,
+		Applied:   r.raftLog.applied,
+	}
+}
+
+// checkInvariants refreshes the assertable log of r and checks the
+// invariants that are due. It does nothing if the invariants are checked
+// over cuts instead.
+func (r *raft) checkInvariants() {
+	if r.invariants == nil || r.invariants.empty() || r.cutTick > 0 {
+		return
+	}
+	r.assertLog = r.raftLog.allEntries()
+
+	local := r.invariantState()
+	for _, c := range r.invariants.sample(local) {
+		c := c
+		r.logger.Debugf("%x checking invariant %s", r.id, c.Name())
+		r.asserter.Assert(c.Variables(), func(values map[string]map[string]interface{}) bool {
+			if ok := c.Check(local, values); !ok {
+				r.logger.Errorf("%x invariant %s violated at term %d", r.id, c.Name(), local.Term)
+				r.catchBug(c.Name())
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// leaderAgreement checks that all nodes that know of a leader agree on it.
+type leaderAgreement struct{}
+
+func (leaderAgreement) Name() string        { return InvariantLeaderAgreement }
+func (leaderAgreement) Variables() []string { return []string{AssertableLeader} }
+
+func (leaderAgreement) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	lead := None
+	for _, p := range sortedPeers(values) {
+		l, ok := uint64Value(values[p][AssertableLeader])
+		if !ok || l == None {
+			continue
+		}
+		if lead != None && l != lead {
+			return false
+		}
+		lead = l
+	}
+	return true
+}
+
+// strongLeadership checks that no node has committed or applied past the
+// leader.
+type strongLeadership struct{}
+
+func (strongLeadership) Name() string { return InvariantStrongLeadership }
+
+func (strongLeadership) Variables() []string {
+	return []string{AssertableCommitted, AssertableApplied, AssertableLeader, AssertableID}
+}
+
+func (strongLeadership) Check(local InvariantState, values map[string]map[string]interface{}) bool {
+	var (
+		leader                     bool
+		leadCommitted, leadApplied uint64
+	)
+	if local.ID == local.Lead {
+		leader, leadCommitted, leadApplied = true, local.Committed, local.Applied
+	}
+	for _, p := range sortedPeers(values) {
+		lead, ok1 := uint64Value(values[p][AssertableLeader])
+		id, ok2 := uint64Value(values[p][AssertableID])
+		if !ok1 || !ok2 || lead == None || lead != id {
+			continue
+		}
+		c, ok1 := uint64Value(values[p][AssertableCommitted])
+		a, ok2 := uint64Value(values[p][AssertableApplied])
+		if !ok1 || !ok2 {
+			return true
+		}
+		leader, leadCommitted, leadApplied = true, c, a
+	}
+	if !leader {
+		return true
+	}
+	for _, p := range sortedPeers(values) {
+		if c, ok := uint64Value(values[p][AssertableCommitted]); ok && c > leadCommitted {
+			return false
+		}
+		if a, ok := uint64Value(values[p][AssertableApplied]); ok && a > leadApplied {
+			return false
+		}
+	}
+	return true
+}
+
+// logMatching checks that if two logs contain an entry with the same index
+// and term, the logs are identical in all entries up through that index.
+type logMatching struct{}
+
+func (logMatching) Name() string        { return InvariantLogMatching }
+func (logMatching) Variables() []string { return []string{AssertableLog} }
+
+func (logMatching) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	logs := peerLogs(values)
+	for i := range logs {
+		for j := i + 1; j < len(logs); j++ {
+			var match uint64
+			for idx, e := range logs[i] {
+				if o, ok := logs[j][idx]; ok && o.Term == e.Term && idx > match {
+					match = idx
+				}
+			}
+			for idx, e := range logs[i] {
+				if o, ok := logs[j][idx]; ok && idx <= match && !entriesEqual(e, o) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// electionSafety checks that at most one leader is elected in a term.
+type electionSafety struct{}
+
+func (electionSafety) Name() string { return InvariantElectionSafety }
+
+func (electionSafety) Variables() []string {
+	return []string{AssertableID, AssertableTerm, AssertableState}
+}
+
+func (electionSafety) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	leaders := make(map[uint64]uint64)
+	for _, p := range sortedPeers(values) {
+		st, ok := uint64Value(values[p][AssertableState])
+		if !ok || StateType(st) != StateLeader {
+			continue
+		}
+		id, ok1 := uint64Value(values[p][AssertableID])
+		term, ok2 := uint64Value(values[p][AssertableTerm])
+		if !ok1 || !ok2 {
+			continue
+		}
+		if l, ok := leaders[term]; ok && l != id {
+			return false
+		}
+		leaders[term] = id
+	}
+	return true
+}
+
+// leaderCompleteness checks that the leader of the highest term contains
+// every entry committed on any node.
+type leaderCompleteness struct{}
+
+func (leaderCompleteness) Name() string { return InvariantLeaderCompleteness }
+
+func (leaderCompleteness) Variables() []string {
+	return []string{AssertableTerm, AssertableState, AssertableCommitted, AssertableLog}
+}
+
+func (leaderCompleteness) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	var (
+		lead     string
+		leadTerm uint64
+	)
+	for _, p := range sortedPeers(values) {
+		st, ok1 := uint64Value(values[p][AssertableState])
+		term, ok2 := uint64Value(values[p][AssertableTerm])
+		if ok1 && ok2 && StateType(st) == StateLeader && term >= leadTerm {
+			lead, leadTerm = p, term
+		}
+	}
+	if lead == "" {
+		return true
+	}
+	leadLog, ok := entriesValue(values[lead][AssertableLog])
+	if !ok || len(leadLog) == 0 {
+		return true
+	}
+	first, last := leadLog[0].Index, leadLog[len(leadLog)-1].Index
+	for _, p := range sortedPeers(values) {
+		committed, ok1 := uint64Value(values[p][AssertableCommitted])
+		ents, ok2 := entriesValue(values[p][AssertableLog])
+		if !ok1 || !ok2 {
+			continue
+		}
+		for _, e := range ents {
+			if e.Index > committed || e.Index < first {
+				continue
+			}
+			if e.Index > last || !entriesEqual(leadLog[e.Index-first], e) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stateMachineSafety checks that no two nodes apply different entries at the
+// same index.
+type stateMachineSafety struct{}
+
+func (stateMachineSafety) Name() string { return InvariantStateMachineSafety }
+
+func (stateMachineSafety) Variables() []string {
+	return []string{AssertableApplied, AssertableLog}
+}
+
+func (stateMachineSafety) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	applied := make(map[uint64]pb.Entry)
+	for _, p := range sortedPeers(values) {
+		a, ok1 := uint64Value(values[p][AssertableApplied])
+		ents, ok2 := entriesValue(values[p][AssertableLog])
+		if !ok1 || !ok2 {
+			continue
+		}
+		for _, e := range ents {
+			if e.Index > a {
+				break
+			}
+			if o, ok := applied[e.Index]; ok && !entriesEqual(o, e) {
+				return false
+			}
+			applied[e.Index] = e
+		}
+	}
+	return true
+}
+
+func sortedPeers(values map[string]map[string]interface{}) []string {
+	peers := make([]string, 0, len(values))
+	for p := range values {
+		peers = append(peers, p)
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// peerLogs returns the log of every node, indexed by entry index.
+func peerLogs(values map[string]map[string]interface{}) []map[uint64]pb.Entry {
+	var logs []map[uint64]pb.Entry
+	for _, p := range sortedPeers(values) {
+		ents, ok := entriesValue(values[p][AssertableLog])
+		if !ok {
+			continue
+		}
+		l := make(map[uint64]pb.Entry, len(ents))
+		for _, e := range ents {
+			l[e.Index] = e
+		}
+		logs = append(logs, l)
+	}
+	return logs
+}
+
+func entriesEqual(a, b pb.Entry) bool {
+	return a.Index == b.Index && a.Term == b.Term && bytes.Equal(a.Data, b.Data)
+}
+
+// uint64Value converts an assertable value to uint64. The dinv runtime
+// decodes small integers as int64 and large ones as uint64.
+func uint64Value(v interface{}) (uint64, bool) {
+	switch n := v.(type) {
+	case uint64:
+		return n, true
+	case int64:
+		return uint64(n), n >= 0
+	case int:
+		return uint64(n), n >= 0
+	case StateType:
+		return uint64(n), true
+	case *uint64:
+		return *n, true
+	case *StateType:
+		return uint64(*n), true
+	}
+	return 0, false
+}
+
+// entriesValue converts an assertable log to entries. Logs gathered through
+// the dinv runtime arrive as a list of generic maps.
+func entriesValue(v interface{}) ([]pb.Entry, bool) {
+	switch ents := v.(type) {
+	case []pb.Entry:
+		return ents, true
+	case *[]pb.Entry:
+		return *ents, true
+	case []interface{}:
+		out := make([]pb.Entry, 0, len(ents))
+		for _, ie := range ents {
+			e, err := entryValue(ie)
+			if err != nil {
+				return nil, false
+			}
+			out = append(out, e)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func entryValue(v interface{}) (pb.Entry, error) {
+	field := func(name string) interface{} { return nil }
+	switch m := v.(type) {
+	case map[interface{}]interface{}:
+		field = func(name string) interface{} { return m[name] }
+	case map[string]interface{}:
+		field = func(name string) interface{} { return m[name] }
+	default:
+		return pb.Entry{}, fmt.Errorf("unexpected entry type %T", v)
+	}
+	var (
+		e   pb.Entry
+		ok1 bool
+		ok2 bool
+	)
+	e.Term, ok1 = uint64Value(field("Term"))
+	e.Index, ok2 = uint64Value(field("Index"))
+	if !ok1 || !ok2 {
+		return pb.Entry{}, errors.New("entry without term or index")
+	}
+	e.Data, _ = field("Data").([]byte)
+	return e, nil
+}
@@ -0,0 +1,361 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// localAssertables shares the assertable variables of in-process rafts.
+type localAssertables map[string]map[string]interface{}
+
+func (la localAssertables) asserter(node string) *localAsserter {
+	la[node] = make(map[string]interface{})
+	return &localAsserter{node: node, all: la}
+}
+
+type localAsserter struct {
+	node string
+	all  localAssertables
+	// failed holds the variables of the failed assertions.
+	failed [][]string
+}
+
+func (a *localAsserter) AddAssertable(name string, ptr interface{}) { a.all[a.node][name] = ptr }
+
+func (a *localAsserter) Assert(vars []string, check func(map[string]map[string]interface{}) bool) {
+	values := make(map[string]map[string]interface{})
+	for node, vs := range a.all {
+		values[node] = make(map[string]interface{})
+		for _, v := range vars {
+			values[node][v] = vs[v]
+		}
+	}
+	if !check(values) {
+		a.failed = append(a.failed, vars)
+	}
+}
+
+type countingInvariant struct {
+	name   string
+	checks int
+	leads  []uint64
+}
+
+func (c *countingInvariant) Name() string        { return c.name }
+func (c *countingInvariant) Variables() []string { return []string{AssertableLeader} }
+
+func (c *countingInvariant) Check(_ InvariantState, values map[string]map[string]interface{}) bool {
+	c.checks++
+	c.leads = c.leads[:0]
+	for _, p := range sortedPeers(values) {
+		l, _ := uint64Value(values[p][AssertableLeader])
+		c.leads = append(c.leads, l)
+	}
+	return true
+}
+
+func TestInvariantRegistry(t *testing.T) {
+	ir := NewInvariantRegistry()
+	for _, name := range []string{InvariantLogMatching, InvariantElectionSafety} {
+		c, ok := BuiltinInvariant(name)
+		if !ok {
+			t.Fatalf("builtin invariant %s not found", name)
+		}
+		if err := ir.Register(c, InvariantOptions{}); err != nil {
+			t.Fatalf("unexpected register error: %v", err)
+		}
+	}
+	if err := ir.Register(electionSafety{}, InvariantOptions{}); err != ErrInvariantExists {
+		t.Errorf("err = %v, want %v", err, ErrInvariantExists)
+	}
+	wnames := []string{InvariantLogMatching, InvariantElectionSafety}
+	if g := ir.Names(); !reflect.DeepEqual(g, wnames) {
+		t.Errorf("names = %v, want %v", g, wnames)
+	}
+	if !ir.Unregister(InvariantLogMatching) {
+		t.Errorf("unregister %s = false, want true", InvariantLogMatching)
+	}
+	if ir.Unregister(InvariantLogMatching) {
+		t.Errorf("second unregister %s = true, want false", InvariantLogMatching)
+	}
+	if _, ok := BuiltinInvariant("unknown"); ok {
+		t.Errorf("unexpected builtin invariant unknown")
+	}
+}
+
+func TestInvariantRegistrySampleLeaderOnly(t *testing.T) {
+	ir := NewInvariantRegistry()
+	ir.Register(&countingInvariant{name: "all"}, InvariantOptions{})
+	ir.Register(&countingInvariant{name: "leader"}, InvariantOptions{LeaderOnly: true})
+
+	tests := []struct {
+		st     InvariantState
+		wnames []string
+	}{
+		{InvariantState{ID: 1, Lead: 2}, []string{"all"}},
+		{InvariantState{ID: 1, Lead: 1}, []string{"all", "leader"}},
+	}
+	for i, tt := range tests {
+		var names []string
+		for _, c := range ir.sample(tt.st) {
+			names = append(names, c.Name())
+		}
+		if !reflect.DeepEqual(names, tt.wnames) {
+			t.Errorf("#%d: sampled = %v, want %v", i, names, tt.wnames)
+		}
+	}
+}
+
+// TestRaftChecksInvariants ensures invariants registered through Config are
+// checked against the state of every node as messages are stepped.
+func TestRaftChecksInvariants(t *testing.T) {
+	tests := []struct {
+		// breakLeader makes the third node follow another leader.
+		breakLeader bool
+		wfailed     bool
+	}{
+		{false, false},
+		{true, true},
+	}
+	for i, tt := range tests {
+		ir := NewInvariantRegistry()
+		c := &countingInvariant{name: "counting"}
+		ir.Register(c, InvariantOptions{})
+		for _, name := range []string{InvariantLeaderAgreement, InvariantElectionSafety, InvariantLogMatching,
+			InvariantLeaderCompleteness, InvariantStateMachineSafety, InvariantStrongLeadership} {
+			bc, _ := BuiltinInvariant(name)
+			ir.Register(bc, InvariantOptions{})
+		}
+
+		la := make(localAssertables)
+		peers := make([]stateMachine, 3)
+		asserters := make([]*localAsserter, 3)
+		for j := range peers {
+			id := uint64(j + 1)
+			cfg := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+			cfg.Invariants = ir
+			asserters[j] = la.asserter(fmt.Sprintf("%d", id))
+			cfg.Asserter = asserters[j]
+			peers[j] = newRaft(cfg)
+		}
+		nt := newNetwork(peers...)
+		nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+		if tt.breakLeader {
+			peers[2].(*raft).lead = 2
+		}
+		nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("some data")}}})
+
+		if c.checks == 0 {
+			t.Fatalf("#%d: invariant was never checked", i)
+		}
+		if wleads := []uint64{1, 1, 1}; !reflect.DeepEqual(c.leads, wleads) {
+			t.Errorf("#%d: leads = %v, want %v", i, c.leads, wleads)
+		}
+		var failed [][]string
+		for _, a := range asserters {
+			failed = append(failed, a.failed...)
+		}
+		if g := len(failed) != 0; g != tt.wfailed {
+			t.Fatalf("#%d: failed = %v, want failures %v", i, failed, tt.wfailed)
+		}
+		for j, vars := range failed {
+			if wvars := []string{AssertableLeader}; !reflect.DeepEqual(vars, wvars) {
+				t.Errorf("#%d.%d: failed on %v, want %v", i, j, vars, wvars)
+			}
+		}
+	}
+}
+
+func TestBuiltinInvariants(t *testing.T) {
+	leader, follower := uint64(StateLeader), uint64(StateFollower)
+	ents := func(terms ...uint64) []pb.Entry {
+		es := make([]pb.Entry, len(terms))
+		for i, term := range terms {
+			es[i] = pb.Entry{Index: uint64(i + 1), Term: term}
+		}
+		return es
+	}
+
+	tests := []struct {
+		name   string
+		local  InvariantState
+		values map[string]map[string]interface{}
+		w      bool
+	}{
+		{
+			InvariantLeaderAgreement, InvariantState{},
+			map[string]map[string]interface{}{"a": {"leader": uint64(1)}, "b": {"leader": int64(0)}, "c": {"leader": uint64(1)}},
+			true,
+		},
+		{
+			InvariantLeaderAgreement, InvariantState{},
+			map[string]map[string]interface{}{"a": {"leader": uint64(1)}, "b": {"leader": uint64(2)}},
+			false,
+		},
+		{
+			InvariantElectionSafety, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"id": uint64(1), "term": uint64(2), "state": leader},
+				"b": {"id": uint64(2), "term": uint64(3), "state": leader},
+			},
+			true,
+		},
+		{
+			InvariantElectionSafety, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"id": uint64(1), "term": uint64(2), "state": leader},
+				"b": {"id": uint64(2), "term": uint64(2), "state": leader},
+			},
+			false,
+		},
+		{
+			InvariantStrongLeadership, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"id": uint64(1), "leader": uint64(1), "commited": uint64(5), "applied": uint64(4)},
+				"b": {"id": uint64(2), "leader": uint64(1), "commited": uint64(4), "applied": uint64(4)},
+			},
+			true,
+		},
+		{
+			InvariantStrongLeadership, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"id": uint64(1), "leader": uint64(1), "commited": uint64(5), "applied": uint64(4)},
+				"b": {"id": uint64(2), "leader": uint64(1), "commited": uint64(6), "applied": uint64(4)},
+			},
+			false,
+		},
+		{
+			InvariantStrongLeadership, InvariantState{ID: 2, Lead: 2, Committed: 3, Applied: 3},
+			map[string]map[string]interface{}{
+				"a": {"id": uint64(1), "leader": uint64(2), "commited": uint64(4), "applied": uint64(3)},
+			},
+			false,
+		},
+		{
+			InvariantLogMatching, InvariantState{},
+			map[string]map[string]interface{}{"a": {"log": ents(1, 1, 2)}, "b": {"log": ents(1, 1, 3, 3)}},
+			true,
+		},
+		{
+			InvariantLogMatching, InvariantState{},
+			map[string]map[string]interface{}{"a": {"log": ents(1, 1, 2)}, "b": {"log": ents(1, 2, 2)}},
+			false,
+		},
+		{
+			InvariantLogMatching, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"log": []interface{}{map[interface{}]interface{}{"Index": int64(1), "Term": int64(1), "Data": []byte("x")}}},
+				"b": {"log": []interface{}{map[interface{}]interface{}{"Index": int64(1), "Term": int64(1), "Data": []byte("y")}}},
+			},
+			false,
+		},
+		{
+			InvariantLeaderCompleteness, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"term": uint64(2), "state": leader, "commited": uint64(2), "log": ents(1, 1, 2)},
+				"b": {"term": uint64(2), "state": follower, "commited": uint64(2), "log": ents(1, 1)},
+			},
+			true,
+		},
+		{
+			InvariantLeaderCompleteness, InvariantState{},
+			map[string]map[string]interface{}{
+				"a": {"term": uint64(2), "state": leader, "commited": uint64(1), "log": ents(1)},
+				"b": {"term": uint64(2), "state": follower, "commited": uint64(2), "log": ents(1, 1)},
+			},
+			false,
+		},
+		{
+			InvariantStateMachineSafety, InvariantState{},
+			map[string]map[string]interface{}{"a": {"applied": uint64(2), "log": ents(1, 1, 2)}, "b": {"applied": uint64(2), "log": ents(1, 1, 3)}},
+			true,
+		},
+		{
+			InvariantStateMachineSafety, InvariantState{},
+			map[string]map[string]interface{}{"a": {"applied": uint64(3), "log": ents(1, 1, 2)}, "b": {"applied": uint64(3), "log": ents(1, 1, 3)}},
+			false,
+		},
+	}
+	for i, tt := range tests {
+		c, ok := BuiltinInvariant(tt.name)
+		if !ok {
+			t.Fatalf("#%d: builtin invariant %s not found", i, tt.name)
+		}
+		if g := c.Check(tt.local, tt.values); g != tt.w {
+			t.Errorf("#%d: %s = %v, want %v", i, tt.name, g, tt.w)
+		}
+	}
+}
+
+func TestDinvConfigValidate(t *testing.T) {
+	tests := []struct {
+		c    DinvConfig
+		werr bool
+	}{
+		{DinvConfig{}, false},
+		{DinvConfig{Invariants: []string{InvariantLogMatching, InvariantElectionSafety}, Sample: 10}, false},
+		{DinvConfig{Invariants: []string{InvariantStrongLeadership}, InjectBugs: true}, false},
+		{DinvConfig{Invariants: []string{"unknown"}}, true},
+		{DinvConfig{Invariants: []string{InvariantLogMatching, InvariantLogMatching}}, true},
+		{DinvConfig{Invariants: []string{InvariantLogMatching}, Sample: -1}, true},
+		{DinvConfig{Invariants: []string{InvariantElectionSafety}, InjectBugs: true}, true},
+		{DinvConfig{InjectBugs: true}, true},
+	}
+	for i, tt := range tests {
+		if err := tt.c.Validate(); (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+	}
+}
+
+// TestNewRaftDinvConfig ensures rafts in one process keep their own dinv
+// configuration.
+func TestNewRaftDinvConfig(t *testing.T) {
+	la := make(localAssertables)
+	cfgs := []DinvConfig{
+		{Invariants: []string{InvariantStrongLeadership}, InjectBugs: true},
+		{Invariants: []string{InvariantLogMatching, InvariantElectionSafety}, LeaderOnly: true},
+		{},
+	}
+	wfaults := [][]string{{FaultCommitWithoutLeader}, nil, nil}
+	for i, dc := range cfgs {
+		cfg := newTestConfig(uint64(i+1), []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		cfg.Dinv = dc
+		cfg.Asserter = la.asserter(fmt.Sprintf("%d", i+1))
+		r := newRaft(cfg)
+		if g := enabledFaults(r.faults); !reflect.DeepEqual(g, wfaults[i]) {
+			t.Errorf("#%d: faults = %v, want %v", i, g, wfaults[i])
+		}
+		var names []string
+		if r.invariants != nil {
+			names = r.invariants.Names()
+		}
+		if !reflect.DeepEqual(names, dc.Invariants) {
+			t.Errorf("#%d: invariants = %v, want %v", i, names, dc.Invariants)
+		}
+	}
+
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	cfg.Dinv = DinvConfig{Invariants: []string{InvariantLogMatching}}
+	cfg.Invariants = NewInvariantRegistry()
+	if err := cfg.validate(); err == nil {
+		t.Errorf("expected error when setting both invariants and dinv invariants")
+	}
+}
@@ -29,7 +29,7 @@ import (
 func TestNodeStep(t *testing.T) {
 	for i, msgn := range raftpb.MessageType_name {
 		n := &node{
-			propc:	make(chan raftpb.Message, 1),
+			propc:	make(chan msgWithResult, 1),
 			recvc:	make(chan raftpb.Message, 1),
 		}
 		msgt := raftpb.MessageType(i)
@@ -63,7 +63,7 @@ func TestNodeStep(t *testing.T) {
 func TestNodeStepUnblock(t *testing.T) {
 	// a node without buffer to block step
 	n := &node{
-		propc:	make(chan raftpb.Message),
+		propc:	make(chan msgWithResult),
 		done:	make(chan struct{}),
 	}
 
@@ -108,8 +108,9 @@ func TestNodeStepUnblock(t *testing.T) {
 // TestNodePropose ensures that node.Propose sends the given proposal to the underlying raft.
 func TestNodePropose(t *testing.T) {
 	msgs := []raftpb.Message{}
-	appendStep := func(r *raft, m raftpb.Message) {
+	appendStep := func(r *raft, m raftpb.Message) error {
 		msgs = append(msgs, m)
+		return nil
 	}
 
 	n := newNode()
@@ -142,31 +143,106 @@ func TestNodePropose(t *testing.T) {
 	}
 }
 
+// TestNodeProposeEntry ensures that node.ProposeEntry sends the given entry
+// type and data to the underlying raft, and rejects the reserved types.
+func TestNodeProposeEntry(t *testing.T) {
+	msgs := []raftpb.Message{}
+	appendStep := func(r *raft, m raftpb.Message) error {
+		msgs = append(msgs, m)
+		return nil
+	}
+
+	n := newNode()
+	s := NewMemoryStorage()
+	r := newTestRaft(1, []uint64{1}, 10, 1, s)
+	go n.run(r)
+	n.Campaign(context.TODO())
+	for {
+		rd := <-n.Ready()
+		s.Append(rd.Entries)
+		if rd.SoftState.Lead == r.id {
+			r.step = appendStep
+			n.Advance()
+			break
+		}
+		n.Advance()
+	}
+	if err := n.ProposeEntry(context.TODO(), raftpb.EntryConfChange, nil); err != ErrReservedEntryType {
+		t.Errorf("err = %v, want %v", err, ErrReservedEntryType)
+	}
+	typ := FirstApplicationEntryType + 1
+	if err := n.ProposeEntry(context.TODO(), typ, []byte("somedata")); err != nil {
+		t.Fatal(err)
+	}
+	n.Stop()
+
+	if len(msgs) != 1 {
+		t.Fatalf("len(msgs) = %d, want %d", len(msgs), 1)
+	}
+	we := raftpb.Entry{Type: typ, Data: []byte("somedata")}
+	if !reflect.DeepEqual(msgs[0].Entries, []raftpb.Entry{we}) {
+		t.Errorf("entries = %+v, want %+v", msgs[0].Entries, []raftpb.Entry{we})
+	}
+}
+
+// TestNodeProposeDropped ensures that node.Propose returns the error of a
+// proposal dropped by the underlying raft.
+func TestNodeProposeDropped(t *testing.T) {
+	n := newNode()
+	s := NewMemoryStorage()
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, s)
+	cfg.MaxUncommittedEntriesSize = 4
+	r := newRaft(cfg)
+	go n.run(r)
+	defer n.Stop()
+	n.Campaign(context.TODO())
+	for {
+		rd := <-n.Ready()
+		s.Append(rd.Entries)
+		if rd.SoftState.Lead == r.id {
+			n.Advance()
+			break
+		}
+		n.Advance()
+	}
+
+	// the first proposal is accepted even though it is beyond the limit.
+	if err := n.Propose(context.TODO(), []byte("somedata")); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+	// it is committed but not handed to the application yet.
+	if err := n.Propose(context.TODO(), []byte("somedata")); err != ErrUncommittedSizeExceeded {
+		t.Fatalf("err = %v, want %v", err, ErrUncommittedSizeExceeded)
+	}
+
+	rd := <-n.Ready()
+	s.Append(rd.Entries)
+	n.Advance()
+	if err := n.Propose(context.TODO(), []byte("somedata")); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
+
 // TestNodeReadIndex ensures that node.ReadIndex sends the MsgReadIndex message to the underlying raft.
 // It also ensures that ReadState can be read out through ready chan.
 func TestNodeReadIndex(t *testing.T) {
 	msgs := []raftpb.Message{}
-	appendStep := func(r *raft, m raftpb.Message) {
+	appendStep := func(r *raft, m raftpb.Message) error {
 		msgs = append(msgs, m)
+		return nil
 	}
-	wreadIndex := uint64(1)
-	wrequestCtx := []byte("somedata")
+	wrs := []ReadState{{Index: uint64(1), RequestCtx: []byte("somedata")}}
 
 	n := newNode()
 	s := NewMemoryStorage()
 	r := newTestRaft(1, []uint64{1}, 10, 1, s)
-	r.readState.Index = wreadIndex
-	r.readState.RequestCtx = wrequestCtx
+	r.readStates = wrs
 	go n.run(r)
 	n.Campaign(context.TODO())
 	for {
 		rd := <-n.Ready()
-		if rd.Index != wreadIndex {
-			t.Errorf("ReadIndex = %d, want %d", rd.Index, wreadIndex)
-		}
-
-		if !reflect.DeepEqual(rd.RequestCtx, wrequestCtx) {
-			t.Errorf("RequestCtx = %v, want %v", rd.RequestCtx, wrequestCtx)
+		if !reflect.DeepEqual(rd.ReadStates, wrs) {
+			t.Errorf("ReadStates = %v, want %v", rd.ReadStates, wrs)
 		}
 
 		s.Append(rd.Entries)
@@ -179,7 +255,7 @@ func TestNodeReadIndex(t *testing.T) {
 	}
 
 	r.step = appendStep
-	wrequestCtx = []byte("somedata2")
+	wrequestCtx := []byte("somedata2")
 	n.ReadIndex(context.TODO(), r.id, wrequestCtx)
 	n.Stop()
 
@@ -198,8 +274,9 @@ func TestNodeReadIndex(t *testing.T) {
 // to the underlying raft.
 func TestNodeProposeConfig(t *testing.T) {
 	msgs := []raftpb.Message{}
-	appendStep := func(r *raft, m raftpb.Message) {
+	appendStep := func(r *raft, m raftpb.Message) error {
 		msgs = append(msgs, m)
+		return nil
 	}
 
 	n := newNode()
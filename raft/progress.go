@@ -63,6 +63,22 @@ type Progress struct {
 	// RecentActive can be reset to false after an election timeout.
 	RecentActive	bool
 
+	// IsLearner is true if the follower is a learner. A learner receives
+	// the log like any follower, but does not vote and does not count
+	// toward the quorum.
+	IsLearner	bool
+
+	// IsWitness is true if the follower is a witness. A witness votes and
+	// counts toward the quorum like any voter, but keeps no application
+	// state: it is never sent the data of a snapshot, and never becomes
+	// the leader.
+	IsWitness	bool
+
+	// Leaving is true if the follower is a voter of the outgoing
+	// configuration only. It is removed when the joint configuration is
+	// left.
+	Leaving	bool
+
 	// inflights is a sliding window for the inflight messages.
 	// When inflights is full, no more message should be sent.
 	// When a leader sends out a message, the index of the last
@@ -173,8 +189,17 @@ func (pr *Progress) needSnapshotAbort() bool {
 	return pr.State == ProgressStateSnapshot && pr.Match >= pr.PendingSnapshot
 }
 
+// InflightBytes returns the total size of the entries of the append messages
+// in flight to the follower.
+func (pr *Progress) InflightBytes() uint64 {
+	if pr.ins == nil {
+		return 0
+	}
+	return pr.ins.bytes
+}
+
 func (pr *Progress) String() string {
-	return fmt.Sprintf("next = %d, match = %d, state = %s, waiting = %v, pendingSnapshot = %d", pr.Next, pr.Match, pr.State, pr.isPaused(), pr.PendingSnapshot)
+	return fmt.Sprintf("next = %d, match = %d, state = %s, waiting = %v, pendingSnapshot = %d, learner = %v, witness = %v", pr.Next, pr.Match, pr.State, pr.isPaused(), pr.PendingSnapshot, pr.IsLearner, pr.IsWitness)
 }
 
 type inflights struct {
@@ -182,21 +207,33 @@ type inflights struct {
 	start	int
 	// number of inflights in the buffer
 	count	int
+	// bytes is the total size of the inflights in the buffer
+	bytes	uint64
 
 	// the size of the buffer
 	size	int
-	buffer	[]uint64
+	// maxBytes limits bytes; 0 for no limit
+	maxBytes	uint64
+	buffer		[]inflight
 }
 
-func newInflights(size int) *inflights {
+// inflight is the last index of an append message in flight and the size of
+// its entries.
+type inflight struct {
+	index	uint64
+	bytes	uint64
+}
+
+func newInflights(size int, maxBytes uint64) *inflights {
 	return &inflights{
-		size:	size,
-		buffer:	make([]uint64, size),
+		size:		size,
+		maxBytes:	maxBytes,
+		buffer:		make([]inflight, size),
 	}
 }
 
-// add adds an inflight into inflights
-func (in *inflights) add(inflight uint64) {
+// add adds an inflight of the given size into inflights
+func (in *inflights) add(index, bytes uint64) {
 	if in.full() {
 		panic("cannot add into a full inflights")
 	}
@@ -204,22 +241,25 @@ func (in *inflights) add(inflight uint64) {
 	if next >= in.size {
 		next -= in.size
 	}
-	in.buffer[next] = inflight
+	in.buffer[next] = inflight{index: index, bytes: bytes}
 	in.count++
+	in.bytes += bytes
 }
 
 // freeTo frees the inflights smaller or equal to the given `to` flight.
 func (in *inflights) freeTo(to uint64) {
-	if in.count == 0 || to < in.buffer[in.start] {
+	if in.count == 0 || to < in.buffer[in.start].index {
 		// out of the left side of the window
 		return
 	}
 
 	i, idx := 0, in.start
+	var bytes uint64
 	for i = 0; i < in.count; i++ {
-		if to < in.buffer[idx] {	// found the first large inflight
+		if to < in.buffer[idx].index {	// found the first large inflight
 			break
 		}
+		bytes += in.buffer[idx].bytes
 
 		// increase index and maybe rotate
 		if idx++; idx >= in.size {
@@ -228,18 +268,28 @@ func (in *inflights) freeTo(to uint64) {
 	}
 	// free i inflights and set new start index
 	in.count -= i
+	in.bytes -= bytes
 	in.start = idx
 }
 
-func (in *inflights) freeFirstOne()	{ in.freeTo(in.buffer[in.start]) }
+func (in *inflights) freeFirstOne()	{ in.freeTo(in.buffer[in.start].index) }
 
-// full returns true if the inflights is full.
+// full returns true if no more messages can be sent at the moment, because
+// either the inflights is full or its bytes reached maxBytes.
 func (in *inflights) full() bool {
-	return in.count == in.size
+	return in.count == in.size || (in.maxBytes != 0 && in.bytes >= in.maxBytes)
+}
+
+// clone returns a copy of the inflights that does not share its buffer.
+func (in *inflights) clone() *inflights {
+	ins := *in
+	ins.buffer = append([]inflight(nil), in.buffer...)
+	return &ins
 }
 
 // resets frees all inflights.
 func (in *inflights) reset() {
 	in.count = 0
 	in.start = 0
+	in.bytes = 0
 }
@@ -23,19 +23,19 @@ func TestInflightsAdd(t *testing.T) {
 	// no rotating case
 	in := &inflights{
 		size:	10,
-		buffer:	make([]uint64, 10),
+		buffer:	make([]inflight, 10),
 	}
 
 	for i := 0; i < 5; i++ {
-		in.add(uint64(i))
+		in.add(uint64(i), 0)
 	}
 
 	wantIn := &inflights{
 		start:	0,
 		count:	5,
 		size:	10,
-		//               ↓------------
-		buffer:	[]uint64{0, 1, 2, 3, 4, 0, 0, 0, 0, 0},
+		//                      ↓------------
+		buffer:	inflightsBuffer(0, 1, 2, 3, 4, 0, 0, 0, 0, 0),
 	}
 
 	if !reflect.DeepEqual(in, wantIn) {
@@ -43,15 +43,15 @@ func TestInflightsAdd(t *testing.T) {
 	}
 
 	for i := 5; i < 10; i++ {
-		in.add(uint64(i))
+		in.add(uint64(i), 0)
 	}
 
 	wantIn2 := &inflights{
 		start:	0,
 		count:	10,
 		size:	10,
-		//               ↓---------------------------
-		buffer:	[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		//                      ↓---------------------------
+		buffer:	inflightsBuffer(0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn2) {
@@ -62,19 +62,19 @@ func TestInflightsAdd(t *testing.T) {
 	in2 := &inflights{
 		start:	5,
 		size:	10,
-		buffer:	make([]uint64, 10),
+		buffer:	make([]inflight, 10),
 	}
 
 	for i := 0; i < 5; i++ {
-		in2.add(uint64(i))
+		in2.add(uint64(i), 0)
 	}
 
 	wantIn21 := &inflights{
 		start:	5,
 		count:	5,
 		size:	10,
-		//                              ↓------------
-		buffer:	[]uint64{0, 0, 0, 0, 0, 0, 1, 2, 3, 4},
+		//                                     ↓------------
+		buffer:	inflightsBuffer(0, 0, 0, 0, 0, 0, 1, 2, 3, 4),
 	}
 
 	if !reflect.DeepEqual(in2, wantIn21) {
@@ -82,15 +82,15 @@ func TestInflightsAdd(t *testing.T) {
 	}
 
 	for i := 5; i < 10; i++ {
-		in2.add(uint64(i))
+		in2.add(uint64(i), 0)
 	}
 
 	wantIn22 := &inflights{
 		start:	5,
 		count:	10,
 		size:	10,
-		//               -------------- ↓------------
-		buffer:	[]uint64{5, 6, 7, 8, 9, 0, 1, 2, 3, 4},
+		//                      -------------- ↓------------
+		buffer:	inflightsBuffer(5, 6, 7, 8, 9, 0, 1, 2, 3, 4),
 	}
 
 	if !reflect.DeepEqual(in2, wantIn22) {
@@ -100,9 +100,9 @@ func TestInflightsAdd(t *testing.T) {
 
 func TestInflightFreeTo(t *testing.T) {
 	// no rotating case
-	in := newInflights(10)
+	in := newInflights(10, 0)
 	for i := 0; i < 10; i++ {
-		in.add(uint64(i))
+		in.add(uint64(i), 0)
 	}
 
 	in.freeTo(4)
@@ -111,8 +111,8 @@ func TestInflightFreeTo(t *testing.T) {
 		start:	5,
 		count:	5,
 		size:	10,
-		//                              ↓------------
-		buffer:	[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		//                                     ↓------------
+		buffer:	inflightsBuffer(0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn) {
@@ -125,8 +125,8 @@ func TestInflightFreeTo(t *testing.T) {
 		start:	9,
 		count:	1,
 		size:	10,
-		//                                          ↓
-		buffer:	[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		//                                                 ↓
+		buffer:	inflightsBuffer(0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn2) {
@@ -135,7 +135,7 @@ func TestInflightFreeTo(t *testing.T) {
 
 	// rotating case
 	for i := 10; i < 15; i++ {
-		in.add(uint64(i))
+		in.add(uint64(i), 0)
 	}
 
 	in.freeTo(12)
@@ -144,8 +144,8 @@ func TestInflightFreeTo(t *testing.T) {
 		start:	3,
 		count:	2,
 		size:	10,
-		//                           ↓-----
-		buffer:	[]uint64{10, 11, 12, 13, 14, 5, 6, 7, 8, 9},
+		//                                  ↓-----
+		buffer:	inflightsBuffer(10, 11, 12, 13, 14, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn3) {
@@ -158,8 +158,8 @@ func TestInflightFreeTo(t *testing.T) {
 		start:	5,
 		count:	0,
 		size:	10,
-		//                                   ↓
-		buffer:	[]uint64{10, 11, 12, 13, 14, 5, 6, 7, 8, 9},
+		//                                          ↓
+		buffer:	inflightsBuffer(10, 11, 12, 13, 14, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn4) {
@@ -168,9 +168,9 @@ func TestInflightFreeTo(t *testing.T) {
 }
 
 func TestInflightFreeFirstOne(t *testing.T) {
-	in := newInflights(10)
+	in := newInflights(10, 0)
 	for i := 0; i < 10; i++ {
-		in.add(uint64(i))
+		in.add(uint64(i), 0)
 	}
 
 	in.freeFirstOne()
@@ -179,11 +179,53 @@ func TestInflightFreeFirstOne(t *testing.T) {
 		start:	1,
 		count:	9,
 		size:	10,
-		//                  ↓------------------------
-		buffer:	[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		//                         ↓------------------------
+		buffer:	inflightsBuffer(0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
 	}
 
 	if !reflect.DeepEqual(in, wantIn) {
 		t.Fatalf("in = %+v, want %+v", in, wantIn)
 	}
 }
+
+func TestInflightsBytes(t *testing.T) {
+	in := newInflights(10, 1000)
+	for i := 0; i < 4; i++ {
+		in.add(uint64(i), 300)
+		if w := i == 3; in.full() != w {
+			t.Fatalf("#%d: full = %t, want %t", i, in.full(), w)
+		}
+	}
+	if in.bytes != 1200 {
+		t.Fatalf("bytes = %d, want %d", in.bytes, 1200)
+	}
+
+	in.freeTo(1)
+	if in.bytes != 600 {
+		t.Fatalf("bytes = %d, want %d", in.bytes, 600)
+	}
+	if in.full() {
+		t.Fatalf("full = %t, want %t", in.full(), false)
+	}
+
+	in.freeFirstOne()
+	in.freeFirstOne()
+	if in.bytes != 0 || in.count != 0 {
+		t.Fatalf("bytes = %d, count = %d, want 0, 0", in.bytes, in.count)
+	}
+
+	// no byte limit
+	in = newInflights(10, 0)
+	in.add(0, 1<<40)
+	if in.full() {
+		t.Fatalf("full = %t, want %t", in.full(), false)
+	}
+}
+
+func inflightsBuffer(indexes ...uint64) []inflight {
+	buffer := make([]inflight, len(indexes))
+	for i, index := range indexes {
+		buffer[i].index = index
+	}
+	return buffer
+}
@@ -19,150 +19,31 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"os"
 	"sort"
-	"strconv"
 	"strings"
-	"time"
 
-	"bitbucket.org/bestchai/dinv/dinvRT"
 	pb "github.com/coreos/etcd/raft/raftpb"
 )
 
-//dinv added global variable, bad form but I need it
-var CommitedEntries []pb.Entry
-
-//dinv assert stuff
-//dinv asserts and bugs
-var (
-	DOANYTHING = false //false to avoid asserts and bugs
-	DOASSERT   = false //perform asserts at all
-	LEADER     = false //Set to true if only the leader should be asserting
-	SAMPLE     = 100   //how frequently should asserts be performed
-	//asserts
-	StrongLeaderAssert = false
-	//
-	leaderCommited uint64
-	leaderApplied  uint64
-	rid            uint64
-	lid            uint64
-
-	LogMatchingAssert     = false
-	LeaderAgreementAssert = false
-
-	//Determines if bugs should be run
-	DOBUG = false
-	//strong leadership bug, one of the hosts will commit without
-	//waiting for the leader to tell them to commit
-	DB1 = false
-	//Log matching bug, a node will inject a false entry past the wall
-	//of committed bugs
-	DB2 = false
-	//Leadership agreement failure, a node will randomly elect itelf a
-	//leader upon becomming a follower.
-	DB3 = false
-
-	BUGSTART = "bugstart"
-	BUGCATCH = "bugcatch"
-	//NODE IDS
-	F1 = uint64(7362438363220176534)
-	F2 = uint64(15174457587357059016)
-	L  = uint64(7362438363220176896)
-)
-
-func startbug() {
-	bs, err := os.Create(fmt.Sprintf("%s-%d.txt", BUGSTART, rand.Int()))
-	if err != nil {
-		os.Exit(1)
-	}
-	t := time.Now()
-	bs.WriteString(fmt.Sprintf("%d.%d", t.Second(), t.Nanosecond()))
-}
-
-func catchbug() {
-	bc, err := os.Create(fmt.Sprintf("%s-%d.txt", BUGCATCH, rand.Int()))
-	if err != nil {
-		os.Exit(1)
-	}
-	t := time.Now()
-	bc.WriteString(fmt.Sprintf("%d.%d", t.Second(), t.Nanosecond()))
-}
-
-func getAssertEnv() {
-	//check if anything should be done
-	if !DOANYTHING {
-		return
-	}
-	//leader config
-	tmpLeader := os.Getenv("LEADER")
-	if tmpLeader == "true" {
-		LEADER = true
-	} else if tmpLeader == "false" {
-		LEADER = false
-	} else {
-		fmt.Printf("EXITING BAD LEADER %s\n", tmpLeader)
-		os.Exit(1)
-	}
-	//assert type config
-	tmpASSERTTYPE := os.Getenv("ASSERTTYPE")
-	if tmpASSERTTYPE == "STRONGLEADER" {
-		DOASSERT = true
-		StrongLeaderAssert = true
-	} else if tmpASSERTTYPE == "LOGMATCHING" {
-		DOASSERT = true
-		LogMatchingAssert = true
-	} else if tmpASSERTTYPE == "LEADERAGREEMENT" {
-		DOASSERT = true
-		LeaderAgreementAssert = true
-	} else if tmpASSERTTYPE == "NONE" {
-		DOASSERT = false
-	} else {
-		fmt.Printf("EXITING BAD ASSERT %s\n", tmpASSERTTYPE)
-		os.Exit(1)
-	}
-	//bug config
-	tmpBUG := os.Getenv("DINVBUG")
-	if tmpBUG == "true" {
-		DOBUG = true
-		if StrongLeaderAssert == true {
-			DB1 = true
-		} else if LogMatchingAssert == true {
-			DB2 = true
-		} else if LeaderAgreementAssert == true {
-			DB3 = true
-		} else {
-			fmt.Printf("EXITING BAD BUG CONFIG DOES NOT MAP TO AN INVARIANT%s\n", tmpLeader)
-		}
-	} else if tmpBUG == "false" {
-		DOBUG = false
-	} else {
-		fmt.Printf("EXITING BAD BUG CONFIG %s\n", tmpLeader)
-		os.Exit(1)
-	}
-	//sample rate config
-	tmpSAMPLE := os.Getenv("SAMPLE")
-	s, err := strconv.Atoi(tmpSAMPLE)
-	if err != nil {
-		fmt.Printf("EXITING BAD SAMPLE %s\n", tmpSAMPLE)
-		os.Exit(1)
-	} else {
-		SAMPLE = s
-	}
-	fmt.Printf("config ASSERT%s LEADER:%s  SAMPLE%s\n", tmpASSERTTYPE, tmpASSERTTYPE, tmpSAMPLE)
-
-}
-
-//END DINV BOOTSTRAPPING
-
 // None is a placeholder node ID used when there is no leader.
 const None uint64 = 0
 const noLimit = math.MaxUint64
 
+// ErrProposalDropped is returned when the proposal is ignored by some cases,
+// so that the proposer can be notified and fail fast.
+var ErrProposalDropped = errors.New("raft proposal dropped")
+
+// ErrUncommittedSizeExceeded is returned when the leader drops a proposal
+// because its uncommitted entries would exceed MaxUncommittedEntriesSize.
+// Unlike ErrProposalDropped, it means that the leader is overloaded.
+var ErrUncommittedSizeExceeded = errors.New("raft proposal dropped: uncommitted entries size limit exceeded")
+
 // Possible values for StateType.
 const (
 	StateFollower StateType = iota
 	StateCandidate
 	StateLeader
+	StatePreCandidate
 )
 
 // StateType represents the role of a node in a cluster.
@@ -172,6 +53,7 @@ var stmap = [...]string{
 	"StateFollower",
 	"StateCandidate",
 	"StateLeader",
+	"StatePreCandidate",
 }
 
 func (st StateType) String() string {
@@ -189,6 +71,12 @@ type Config struct {
 	// used for testing right now.
 	peers []uint64
 
+	// learners contains the IDs of all learner nodes (including self if the
+	// local node is a learner) in the raft cluster. Learners only receive
+	// entries from the leader; they do not vote or promote themselves.
+	// Like peers, learners is private and only used for testing right now.
+	learners []uint64
+
 	// ElectionTick is the number of Node.Tick invocations that must pass between
 	// elections. That is, if a follower does not receive any message from the
 	// leader of current term before ElectionTick has elapsed, it will become
@@ -224,14 +112,73 @@ type Config struct {
 	// overflowing that sending buffer. TODO (xiangli): feedback to application to
 	// limit the proposal rate?
 	MaxInflightMsgs int
+	// MaxInflightBytes limits the total size of the entries of the in-flight
+	// append messages to a follower, in addition to MaxInflightMsgs. It keeps
+	// followers fed with large entries from piling up data in flight. 0 for
+	// no limit.
+	MaxInflightBytes uint64
+	// MaxUncommittedEntriesSize limits the aggregate byte size of the
+	// uncommitted entries that may be appended to a leader's log. Once this
+	// limit is exceeded, proposals will begin to return
+	// ErrUncommittedSizeExceeded errors. 0 for no limit.
+	MaxUncommittedEntriesSize uint64
 
 	// CheckQuorum specifies if the leader should check quorum activity. Leader
 	// steps down when quorum is not active for an electionTimeout.
 	CheckQuorum bool
 
+	// ReadOnlyOption specifies how the read only requests are processed.
+	//
+	// ReadOnlySafe guarantees the linearizability of the read only requests
+	// by confirming the leadership with a quorum of heartbeats before the
+	// read index is released. It is the default.
+	//
+	// ReadOnlyLeaseBased relies on the leader lease instead. It skips the
+	// round of heartbeats but can be affected by clock drift or by the
+	// process pausing. CheckQuorum MUST be enabled with ReadOnlyLeaseBased.
+	ReadOnlyOption ReadOnlyOption
+
+	// PreVote enables the Pre-Vote algorithm described in raft thesis section
+	// 9.6. This prevents disruption when a node that has been partitioned away
+	// rejoins the cluster: before incrementing its term and starting an
+	// election, a node asks the others whether it could win one, and only
+	// campaigns if a quorum agrees.
+	PreVote bool
+
+	// Witness is true if the local node is a witness, added to the cluster
+	// with ConfChangeAddWitnessNode. A witness only keeps the terms and
+	// indexes of the entries: it drops the data of the entries other than
+	// configuration changes and the data of snapshots. It never campaigns.
+	Witness bool
+
 	// Logger is the logger used for raft log. For multinode which can host
 	// multiple raft group, each raft group can have its own logger
 	Logger Logger
+
+	// Dinv configures the built-in invariants checked by the node and the
+	// bugs injected to violate them.
+	Dinv DinvConfig
+	// Invariants is the set of distributed invariants checked while stepping
+	// messages. If nil, the invariants named in Dinv are checked. It cannot be
+	// set together with Dinv.Invariants.
+	Invariants *InvariantRegistry
+	// Asserter gathers the assertable variables of the cluster for
+	// Invariants. If nil, the dinv runtime is used.
+	Asserter Asserter
+	// Faults holds the fault points consulted by the node. If nil and
+	// Dinv.InjectBugs is set, an injector seeded with Dinv.FaultSeed is
+	// created. Otherwise no fault is injected.
+	Faults *FaultInjector
+	// BugLog records the bugs injected by Faults and caught by Invariants.
+	// If nil, they are not recorded.
+	BugLog BugLogger
+	// Tracer records the state transitions of the node with a vector
+	// clock. If nil, they are not recorded.
+	Tracer Tracer
+	// StateTracker records the state of the node at the program points
+	// tracked by dinv, along with dinv itself. If nil, the state is not
+	// tracked.
+	StateTracker StateTracker
 }
 
 func (c *Config) validate() error {
@@ -255,6 +202,18 @@ func (c *Config) validate() error {
 		return errors.New("max inflight messages must be greater than 0")
 	}
 
+	if c.ReadOnlyOption == ReadOnlyLeaseBased && !c.CheckQuorum {
+		return errors.New("CheckQuorum must be enabled when ReadOnlyOption is ReadOnlyLeaseBased")
+	}
+
+	if err := c.Dinv.Validate(); err != nil {
+		return err
+	}
+
+	if c.Invariants != nil && len(c.Dinv.Invariants) > 0 {
+		return errors.New("cannot set both invariants and dinv invariants")
+	}
+
 	if c.Logger == nil {
 		c.Logger = raftLogger
 	}
@@ -277,14 +236,24 @@ type raft struct {
 	Term uint64
 	Vote uint64
 
-	readState ReadState
+	readStates []ReadState
+	// readOnly tracks the read index requests the leader is confirming
+	// its leadership for.
+	readOnly *readOnly
 
 	// the log
 	raftLog *raftLog
 
-	maxInflight int
-	maxMsgSize  uint64
-	prs         map[uint64]*Progress
+	maxInflight      int
+	maxInflightBytes uint64
+	maxMsgSize       uint64
+	prs              map[uint64]*Progress
+
+	// uncommittedSize is the size of the payloads of the uncommitted entries
+	// appended by the leader; proposals are dropped beyond
+	// maxUncommittedSize.
+	uncommittedSize    uint64
+	maxUncommittedSize uint64
 
 	state StateType
 
@@ -299,6 +268,10 @@ type raft struct {
 	leadTransferee uint64
 	// New configuration is ignored if there exists unapplied configuration.
 	pendingConf bool
+	// outgoing are the voters of the outgoing configuration while the node
+	// is in a joint configuration, and nil otherwise. Decisions then need a
+	// quorum of both the incoming and the outgoing voters.
+	outgoing map[uint64]bool
 
 	// number of ticks since it reached last electionTimeout when it is leader
 	// or candidate.
@@ -311,6 +284,9 @@ type raft struct {
 	heartbeatElapsed int
 
 	checkQuorum bool
+	preVote     bool
+	// witness is true if the local node is a witness.
+	witness bool
 
 	heartbeatTimeout int
 	electionTimeout  int
@@ -324,6 +300,33 @@ type raft struct {
 	step stepFunc
 
 	logger Logger
+
+	invariants *InvariantRegistry
+	asserter   Asserter
+	faults     *FaultInjector
+	bugLog     BugLogger
+	tracer     Tracer
+	// stateTracker records the state at program points.
+	stateTracker StateTracker
+	// falseLead is set when the false leader fault fired, so the leader is
+	// not corrected until the node becomes a follower again.
+	falseLead bool
+	// assertLog is the copy of the log exposed to remote invariant checks.
+	assertLog []pb.Entry
+
+	// cuts are the consistent cuts the node records in flight messages for.
+	cuts map[cutKey]*recordingCut
+	// gathering are the cuts started by the node that still gather the
+	// state of other nodes.
+	gathering map[uint64]*gatheringCut
+	// readyCuts are the cuts requested by the application that completed.
+	readyCuts []Cut
+	// cutTick is the number of ticks between the cuts the leader checks the
+	// invariants over. If 0, the invariants are checked through the
+	// asserter.
+	cutTick    int
+	cutElapsed int
+	cutSeq     uint64
 }
 
 func newRaft(c *Config) *raft {
@@ -336,41 +339,72 @@ func newRaft(c *Config) *raft {
 		panic(err) // TODO(bdarnell)
 	}
 	peers := c.peers
-	if len(cs.Nodes) > 0 {
-		if len(peers) > 0 {
+	learners := c.learners
+	if len(cs.Nodes) > 0 || len(cs.Learners) > 0 {
+		if len(peers) > 0 || len(learners) > 0 {
 			// TODO(bdarnell): the peers argument is always nil except in
 			// tests; the argument should be removed and these tests should be
 			// updated to specify their nodes through a snapshot.
-			panic("cannot specify both newRaft(peers) and ConfState.Nodes)")
+			panic("cannot specify both newRaft(peers, learners) and ConfState.(Nodes, Learners))")
 		}
 		peers = cs.Nodes
+		learners = cs.Learners
 	}
 	r := &raft{
 		id:               c.ID,
 		lead:             None,
-		readState:        ReadState{Index: None, RequestCtx: nil},
+		stateTracker:     c.StateTracker,
 		raftLog:          raftlog,
 		maxMsgSize:       c.MaxSizePerMsg,
 		maxInflight:      c.MaxInflightMsgs,
+		maxInflightBytes: c.MaxInflightBytes,
+		maxUncommittedSize: c.MaxUncommittedEntriesSize,
 		prs:              make(map[uint64]*Progress),
 		electionTimeout:  c.ElectionTick,
 		heartbeatTimeout: c.HeartbeatTick,
 		logger:           c.Logger,
 		checkQuorum:      c.CheckQuorum,
+		readOnly:         newReadOnly(c.ReadOnlyOption),
+		preVote:          c.PreVote,
+		witness:          c.Witness,
+		cuts:             make(map[cutKey]*recordingCut),
+		gathering:        make(map[uint64]*gatheringCut),
+		cutTick:          c.Dinv.CutTick,
+	}
+	r.invariants, r.asserter = c.Invariants, c.Asserter
+	if r.invariants == nil {
+		r.invariants = c.Dinv.registry()
+	}
+	r.faults, r.bugLog, r.tracer = c.Faults, c.BugLog, c.Tracer
+	if c.Dinv.InjectBugs {
+		if r.faults == nil {
+			r.faults = NewFaultInjector(c.Dinv.FaultSeed)
+		}
+		c.Dinv.injectBugs(r.faults)
 	}
-	/////////////////////////////////////////////////////////////////////
-	//DINV dinv
-	//INITALIZATION ASSERT////////////////////////////////////////
-	getAssertEnv()
-	if DOASSERT {
-		dinvRT.InitDistributedAssert("", nil, "raft")
+	if r.invariants != nil && r.cutTick == 0 {
+		if r.asserter == nil {
+			r.asserter = newDinvAsserter()
+		}
+		r.addAssertables()
 	}
-	///END DINV INIT
-	/////////////////////////////////////////////////////////////
 	r.rand = rand.New(rand.NewSource(int64(c.ID)))
 	for _, p := range peers {
-		r.prs[p] = &Progress{Next: 1, ins: newInflights(r.maxInflight)}
+		r.prs[p] = &Progress{Next: 1, ins: newInflights(r.maxInflight, r.maxInflightBytes)}
 	}
+	for _, p := range learners {
+		if _, ok := r.prs[p]; ok {
+			panic(fmt.Sprintf("node %x is in both learner and peer list", p))
+		}
+		r.prs[p] = &Progress{Next: 1, ins: newInflights(r.maxInflight, r.maxInflightBytes), IsLearner: true}
+	}
+	for _, p := range cs.NodesJoint {
+		if _, ok := r.prs[p]; !ok {
+			r.prs[p] = &Progress{Next: 1, ins: newInflights(r.maxInflight, r.maxInflightBytes), Leaving: true}
+		}
+	}
+	r.setWitnesses(cs.Witnesses)
+	r.setOutgoing(cs.NodesJoint)
 	if !isHardStateEqual(hs, emptyState) {
 		r.loadState(hs)
 	}
@@ -383,8 +417,12 @@ func newRaft(c *Config) *raft {
 	for _, n := range r.nodes() {
 		nodesStrs = append(nodesStrs, fmt.Sprintf("%x", n))
 	}
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
-	r.logger.Infof("newRaft %x [peers: [%s], term: %d, commit: %d, applied: %d, lastindex: %d, lastterm: %d]", r.id, strings.Join(nodesStrs, ","), r.Term, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	var learnerStrs []string
+	for _, n := range r.learnerNodes() {
+		learnerStrs = append(learnerStrs, fmt.Sprintf("%x", n))
+	}
+	r.track("newRaft")
+	r.logger.Infof("newRaft %x [peers: [%s], learners: [%s], term: %d, commit: %d, applied: %d, lastindex: %d, lastterm: %d]", r.id, strings.Join(nodesStrs, ","), strings.Join(learnerStrs, ","), r.Term, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
 	return r
 }
 
@@ -400,9 +438,43 @@ func (r *raft) hardState() pb.HardState {
 	}
 }
 
-func (r *raft) quorum() int { return len(r.prs)/2 + 1 }
+// quorum is the number of votes needed to elect a leader or commit an
+// entry. Learners do not count. In a joint configuration, it is the
+// quorum of the incoming voters; a quorum of the outgoing voters is
+// needed as well.
+func (r *raft) quorum() int { return len(r.nodes())/2 + 1 }
 
+// nodes returns the sorted IDs of the voters. In a joint configuration,
+// they are the voters of the incoming configuration.
 func (r *raft) nodes() []uint64 {
+	nodes := make([]uint64, 0, len(r.prs))
+	for id, pr := range r.prs {
+		if !pr.IsLearner && !pr.Leaving {
+			nodes = append(nodes, id)
+		}
+	}
+	sort.Sort(uint64Slice(nodes))
+	return nodes
+}
+
+// outgoingNodes returns the sorted IDs of the voters of the outgoing
+// configuration, or nil if the node is not in a joint configuration.
+func (r *raft) outgoingNodes() []uint64 {
+	if !r.isJoint() {
+		return nil
+	}
+	nodes := make([]uint64, 0, len(r.outgoing))
+	for id := range r.outgoing {
+		nodes = append(nodes, id)
+	}
+	sort.Sort(uint64Slice(nodes))
+	return nodes
+}
+
+// allNodes returns the sorted IDs of every node the log is replicated to:
+// the voters, the learners and, in a joint configuration, the voters
+// leaving the configuration.
+func (r *raft) allNodes() []uint64 {
 	nodes := make([]uint64, 0, len(r.prs))
 	for id := range r.prs {
 		nodes = append(nodes, id)
@@ -411,18 +483,102 @@ func (r *raft) nodes() []uint64 {
 	return nodes
 }
 
+// isJoint returns true if the node is in a joint configuration.
+func (r *raft) isJoint() bool { return r.outgoing != nil }
+
+// voterSets returns the sets of voters a decision needs a quorum of: the
+// voters, and the outgoing voters in a joint configuration.
+func (r *raft) voterSets() [][]uint64 {
+	if r.isJoint() {
+		return [][]uint64{r.nodes(), r.outgoingNodes()}
+	}
+	return [][]uint64{r.nodes()}
+}
+
+// hasQuorum returns true if the nodes for which f returns true make a
+// quorum of every set of voters.
+func (r *raft) hasQuorum(f func(id uint64) bool) bool {
+	for _, voters := range r.voterSets() {
+		if len(voters) == 0 {
+			continue
+		}
+		n := 0
+		for _, id := range voters {
+			if f(id) {
+				n++
+			}
+		}
+		if n < len(voters)/2+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// learnerNodes returns the sorted IDs of the learners.
+func (r *raft) learnerNodes() []uint64 {
+	nodes := make([]uint64, 0)
+	for id, pr := range r.prs {
+		if pr.IsLearner {
+			nodes = append(nodes, id)
+		}
+	}
+	sort.Sort(uint64Slice(nodes))
+	return nodes
+}
+
+// isLearner returns true if id is a learner.
+func (r *raft) isLearner(id uint64) bool {
+	pr, ok := r.prs[id]
+	return ok && pr.IsLearner
+}
+
+// witnessNodes returns the sorted IDs of the witnesses.
+func (r *raft) witnessNodes() []uint64 {
+	var nodes []uint64
+	for id, pr := range r.prs {
+		if pr.IsWitness {
+			nodes = append(nodes, id)
+		}
+	}
+	sort.Sort(uint64Slice(nodes))
+	return nodes
+}
+
+// setWitnesses marks the given voters as witnesses.
+func (r *raft) setWitnesses(nodes []uint64) {
+	for _, n := range nodes {
+		if pr, ok := r.prs[n]; ok {
+			pr.IsWitness = true
+		}
+	}
+}
+
+func (r *raft) confState() pb.ConfState {
+	return pb.ConfState{Nodes: r.nodes(), Learners: r.learnerNodes(), NodesJoint: r.outgoingNodes(), Witnesses: r.witnessNodes()}
+}
+
 // send persists state to stable storage and then sends to its mailbox.
 func (r *raft) send(m pb.Message) {
-	m.From = r.id
-	// do not attach term to MsgProp
-	// proposals are a way to forward to the leader and
-	// should be treated as local message.
-	if m.Type != pb.MsgProp {
+	// a forwarded MsgTransferLeader keeps the transferee as its sender.
+	if m.Type != pb.MsgTransferLeader || m.From == None {
+		m.From = r.id
+	}
+	if m.Type == pb.MsgPreVote || m.Type == pb.MsgPreVoteResp {
+		// pre-votes are sent for the term the pre-candidate would campaign
+		// at, and granted pre-votes are answered at that term, so their
+		// term is set by the caller.
+		if m.Type == pb.MsgPreVote && m.Term == 0 {
+			r.logger.Panicf("term should be set when sending %s", m.Type)
+		}
+	} else if m.Type != pb.MsgProp {
+		// do not attach term to MsgProp
+		// proposals are a way to forward to the leader and
+		// should be treated as local message.
 		m.Term = r.Term
 	}
 	r.msgs = append(r.msgs, m)
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
-	//@Track
+	r.track("send")
 }
 
 // sendAppend sends RPC, with entries to the given peer.
@@ -455,6 +611,10 @@ func (r *raft) sendAppend(to uint64) {
 		if IsEmptySnap(snapshot) {
 			panic("need non-empty snapshot")
 		}
+		if pr.IsWitness {
+			// a witness only needs to know where the log starts.
+			snapshot.Data = nil
+		}
 		m.Snapshot = snapshot
 		sindex, sterm := snapshot.Metadata.Index, snapshot.Metadata.Term
 		r.logger.Debugf("%x [firstindex: %d, commit: %d] sent snapshot[index: %d, term: %d] to %x [%s]",
@@ -473,7 +633,7 @@ func (r *raft) sendAppend(to uint64) {
 			case ProgressStateReplicate:
 				last := m.Entries[n-1].Index
 				pr.optimisticUpdate(last)
-				pr.ins.add(last)
+				pr.ins.add(last, payloadsSize(m.Entries))
 			case ProgressStateProbe:
 				pr.pause()
 			default:
@@ -481,13 +641,12 @@ func (r *raft) sendAppend(to uint64) {
 			}
 		}
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("sendAppend")
 	r.send(m)
 }
 
 // sendHeartbeat sends an empty MsgApp
-func (r *raft) sendHeartbeat(to uint64) {
+func (r *raft) sendHeartbeat(to uint64, ctx []byte) {
 	// Attach the commit as min(to.matched, r.committed).
 	// When the leader sends out heartbeat message,
 	// the receiver(follower) might not be matched with the leader
@@ -497,11 +656,11 @@ func (r *raft) sendHeartbeat(to uint64) {
 	commit := min(r.prs[to].Match, r.raftLog.committed)
 	m := pb.Message{
 		To:     to,
-		Type:   pb.MsgHeartbeat,
-		Commit: commit,
+		Type:    pb.MsgHeartbeat,
+		Commit:  commit,
+		Context: ctx,
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("sendHeartbeat")
 	r.send(m)
 }
 
@@ -514,21 +673,30 @@ func (r *raft) bcastAppend() {
 		}
 		r.sendAppend(id)
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("bcastAppend")
 }
 
-// bcastHeartbeat sends RPC, without entries to all the peers.
+// bcastHeartbeat sends RPC, without entries to all the peers. The heartbeats
+// carry the context of the last pending read index request, so that their
+// responses confirm the leadership for it.
 func (r *raft) bcastHeartbeat() {
+	lastCtx := r.readOnly.lastPendingRequestCtx()
+	if len(lastCtx) == 0 {
+		r.bcastHeartbeatWithCtx(nil)
+	} else {
+		r.bcastHeartbeatWithCtx([]byte(lastCtx))
+	}
+}
+
+func (r *raft) bcastHeartbeatWithCtx(ctx []byte) {
 	for id := range r.prs {
 		if id == r.id {
 			continue
 		}
-		r.sendHeartbeat(id)
+		r.sendHeartbeat(id, ctx)
 		r.prs[id].resume()
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("bcastHeartbeat")
 }
 
 // maybeCommit attempts to advance the commit index. Returns true if
@@ -536,12 +704,25 @@ func (r *raft) bcastHeartbeat() {
 // r.bcastAppend).
 func (r *raft) maybeCommit() bool {
 	// TODO(bmizerany): optimize.. Currently naive
-	mis := make(uint64Slice, 0, len(r.prs))
-	for id := range r.prs {
-		mis = append(mis, r.prs[id].Match)
+	// In a joint configuration, the index must be committed by a quorum of
+	// both sets of voters.
+	mci := uint64(math.MaxUint64)
+	for _, voters := range r.voterSets() {
+		if len(voters) == 0 {
+			continue
+		}
+		mis := make(uint64Slice, 0, len(voters))
+		for _, id := range voters {
+			mis = append(mis, r.prs[id].Match)
+		}
+		sort.Sort(sort.Reverse(mis))
+		if mi := mis[len(voters)/2]; mi < mci {
+			mci = mi
+		}
+	}
+	if mci == math.MaxUint64 {
+		return false
 	}
-	sort.Sort(sort.Reverse(mis))
-	mci := mis[r.quorum()-1]
 
 	//Changed for dinv DB1 debugging
 	commited := r.raftLog.maybeCommit(mci, r.Term)
@@ -567,15 +748,16 @@ func (r *raft) reset(term uint64) {
 	r.abortLeaderTransfer()
 
 	r.votes = make(map[uint64]bool)
-	for id := range r.prs {
-		r.prs[id] = &Progress{Next: r.raftLog.lastIndex() + 1, ins: newInflights(r.maxInflight)}
+	r.uncommittedSize = 0
+	r.readOnly = newReadOnly(r.readOnly.option)
+	for id, pr := range r.prs {
+		r.prs[id] = &Progress{Next: r.raftLog.lastIndex() + 1, ins: newInflights(r.maxInflight, r.maxInflightBytes), IsLearner: pr.IsLearner, IsWitness: pr.IsWitness, Leaving: pr.Leaving}
 		if id == r.id {
 			r.prs[id].Match = r.raftLog.lastIndex()
 		}
 	}
 	r.pendingConf = false
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("reset")
 }
 
 func (r *raft) appendEntry(es ...pb.Entry) {
@@ -592,18 +774,19 @@ func (r *raft) appendEntry(es ...pb.Entry) {
 
 // tickElection is run by followers and candidates after r.electionTimeout.
 func (r *raft) tickElection() {
+	r.tickCuts()
 	r.electionElapsed++
 
 	if r.promotable() && r.pastElectionTimeout() {
 		r.electionElapsed = 0
 		r.Step(pb.Message{From: r.id, Type: pb.MsgHup})
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("tickElection")
 }
 
 // tickHeartbeat is run by leaders to send a MsgBeat after r.heartbeatTimeout.
 func (r *raft) tickHeartbeat() {
+	r.tickCuts()
 	r.heartbeatElapsed++
 	r.electionElapsed++
 
@@ -626,8 +809,7 @@ func (r *raft) tickHeartbeat() {
 		r.heartbeatElapsed = 0
 		r.Step(pb.Message{From: r.id, Type: pb.MsgBeat})
 	}
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.track("tickHeartbeat")
 }
 
 func (r *raft) becomeFollower(term uint64, lead uint64) {
@@ -637,17 +819,24 @@ func (r *raft) becomeFollower(term uint64, lead uint64) {
 	r.lead = lead
 	r.state = StateFollower
 	r.logger.Infof("%x became follower at term %d", r.id, r.Term)
+	r.falseLead = false
 	//DB3 Leadership agreement
-	if DB3 {
-		for id := range r.prs {
-			if id == lead {
-				continue
+	if r.faults.Fire(FaultFalseLeader, r.state) {
+		var ids []uint64
+		for _, id := range r.nodes() {
+			if id != lead {
+				ids = append(ids, id)
 			}
-			r.lead = id
-			startbug()
+		}
+		if len(ids) > 0 {
+			r.startBug(FaultFalseLeader)
+			r.lead = ids[r.faults.Intn(len(ids))]
+			r.falseLead = true
+			r.logger.Infof("%x injecting false leader %x at term %d", r.id, r.lead, r.Term)
 		}
 	}
 	//End DB3
+	r.trace("%x became follower at term %d with leader %x", r.id, r.Term, r.lead)
 }
 
 func (r *raft) becomeCandidate() {
@@ -661,8 +850,24 @@ func (r *raft) becomeCandidate() {
 	r.Vote = r.id
 	r.state = StateCandidate
 	r.logger.Infof("%x became candidate at term %d", r.id, r.Term)
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.trace("%x became candidate at term %d", r.id, r.Term)
+	r.track("becomeCandidate")
+}
+
+func (r *raft) becomePreCandidate() {
+	// TODO(xiangli) remove the panic when the raft implementation is stable
+	if r.state == StateLeader {
+		panic("invalid transition [leader -> pre-candidate]")
+	}
+	// Becoming a pre-candidate changes the step functions and state, but
+	// neither the term nor the vote.
+	r.step = stepCandidate
+	r.votes = make(map[uint64]bool)
+	r.tick = r.tickElection
+	r.state = StatePreCandidate
+	r.logger.Infof("%x became pre-candidate at term %d", r.id, r.Term)
+	r.trace("%x became pre-candidate at term %d", r.id, r.Term)
+	r.track("becomePreCandidate")
 }
 
 func (r *raft) becomeLeader() {
@@ -681,7 +886,7 @@ func (r *raft) becomeLeader() {
 	}
 
 	for _, e := range ents {
-		if e.Type != pb.EntryConfChange {
+		if e.Type != pb.EntryConfChange && e.Type != pb.EntryConfChangeV2 {
 			continue
 		}
 		if r.pendingConf {
@@ -690,34 +895,102 @@ func (r *raft) becomeLeader() {
 		r.pendingConf = true
 	}
 	r.appendEntry(pb.Entry{Data: nil})
+	// The leader that entered the joint configuration may have failed
+	// before leaving it.
+	if r.isJoint() && !r.pendingConf {
+		r.appendLeaveJoint()
+	}
 	r.logger.Infof("%x became leader at term %d", r.id, r.Term)
-	//@Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+	r.trace("%x became leader at term %d", r.id, r.Term)
+	r.track("becomeLeader")
 }
 
-func (r *raft) campaign() {
-	r.becomeCandidate()
-	if r.quorum() == r.poll(r.id, true) {
-		r.becomeLeader()
+// campaignType is the kind of election a node campaigns in.
+type campaignType string
+
+const (
+	// campaignPreElection asks the other nodes whether the node could win
+	// an election at the next term, without incrementing its term.
+	campaignPreElection campaignType = "CampaignPreElection"
+	// campaignElection is a normal election.
+	campaignElection campaignType = "CampaignElection"
+)
+
+func (r *raft) campaign(t campaignType) {
+	var (
+		term    uint64
+		voteMsg pb.MessageType
+	)
+	if t == campaignPreElection {
+		r.becomePreCandidate()
+		voteMsg = pb.MsgPreVote
+		// pre-votes are sent for the next term before the term is
+		// incremented.
+		term = r.Term + 1
+	} else {
+		r.becomeCandidate()
+		voteMsg = pb.MsgVote
+		term = r.Term
+	}
+	r.poll(r.id, voteRespMsgType(voteMsg), true)
+	if r.voteResult() == voteWon {
+		// The node won by voting for itself, so it is a single node
+		// cluster. Advance to the next state.
+		if t == campaignPreElection {
+			r.campaign(campaignElection)
+		} else {
+			r.becomeLeader()
+		}
 		return
 	}
-	for id := range r.prs {
-		if id == r.id {
+	for id, pr := range r.prs {
+		if id == r.id || pr.IsLearner {
 			continue
 		}
-		r.logger.Infof("%x [logterm: %d, index: %d] sent vote request to %x at term %d",
-			r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), id, r.Term)
-		r.send(pb.Message{To: id, Type: pb.MsgVote, Index: r.raftLog.lastIndex(), LogTerm: r.raftLog.lastTerm()})
+		r.logger.Infof("%x [logterm: %d, index: %d] sent %s request to %x at term %d",
+			r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), voteMsg, id, r.Term)
+		r.send(pb.Message{Term: term, To: id, Type: voteMsg, Index: r.raftLog.lastIndex(), LogTerm: r.raftLog.lastTerm()})
+	}
+}
+
+// voteResultType is the outcome of an election.
+type voteResultType int
+
+const (
+	votePending voteResultType = iota
+	voteWon
+	voteLost
+)
+
+// voteResult returns whether the election is won, lost or still pending
+// from the votes received so far. It is won with a quorum of every set of
+// voters, and lost when a quorum of a set rejected the node.
+func (r *raft) voteResult() voteResultType {
+	if r.hasQuorum(func(id uint64) bool { return r.votes[id] }) {
+		return voteWon
+	}
+	for _, voters := range r.voterSets() {
+		rejected := 0
+		for _, id := range voters {
+			if v, ok := r.votes[id]; ok && !v {
+				rejected++
+			}
+		}
+		if rejected >= len(voters)/2+1 {
+			return voteLost
+		}
 	}
+	return votePending
 }
 
-func (r *raft) poll(id uint64, v bool) (granted int) {
+func (r *raft) poll(id uint64, t pb.MessageType, v bool) (granted int) {
 	if v {
-		r.logger.Infof("%x received vote from %x at term %d", r.id, id, r.Term)
+		r.logger.Infof("%x received %s from %x at term %d", r.id, t, id, r.Term)
 	} else {
-		r.logger.Infof("%x received vote rejection from %x at term %d", r.id, id, r.Term)
+		r.logger.Infof("%x received %s rejection from %x at term %d", r.id, t, id, r.Term)
 	}
-	if _, ok := r.votes[id]; !ok {
+	// learners do not vote.
+	if _, ok := r.votes[id]; !ok && !r.isLearner(id) {
 		r.votes[id] = v
 	}
 	for _, vv := range r.votes {
@@ -731,10 +1004,26 @@ func (r *raft) poll(id uint64, v bool) (granted int) {
 var dumps = 0
 
 func (r *raft) Step(m pb.Message) error {
+	// Cut messages are not part of the raft protocol and must not change
+	// its state, whatever their term.
+	if m.Type == pb.MsgCutMarker || m.Type == pb.MsgCutState {
+		r.stepCut(m)
+		return nil
+	}
+	r.recordInFlight(m)
+
 	if m.Type == pb.MsgHup {
+		if !r.promotable() {
+			r.logger.Debugf("%x ignoring MsgHup because it is not a voter", r.id)
+			return nil
+		}
 		if r.state != StateLeader {
 			r.logger.Infof("%x is starting a new election at term %d", r.id, r.Term)
-			r.campaign()
+			if r.preVote {
+				r.campaign(campaignPreElection)
+			} else {
+				r.campaign(campaignElection)
+			}
 		} else {
 			r.logger.Debugf("%x ignoring MsgHup because already leader", r.id)
 		}
@@ -745,88 +1034,53 @@ func (r *raft) Step(m pb.Message) error {
 			r.logger.Debugf("%x [term %d state %v] ignoring MsgTransferLeader to %x", r.id, r.Term, r.state, m.From)
 		}
 	}
-	/////////////////////////////////////////////////////////////////////
-	//DINV dinv
-	//INITALIZATION ASSERT////////////////////////////////////////
-	//dinvRT.Initalize(string((r.id%3)+65))
-	//Initialize assertable variables
-	//fmt.Println(r.lead)
-	if DOASSERT {
-		dinvRT.AddAssertable("leader", &(r.lead), nil)
-		dinvRT.AddAssertable("commited", &(r.raftLog.committed), nil)
-		dinvRT.AddAssertable("applied", &(r.raftLog.applied), nil)
-		dinvRT.AddAssertable("id", &(r.id), nil)
-		dinvRT.AddAssertable("log", &(CommitedEntries), nil)
-		if LeaderAgreementAssert && rand.Int()%100 == 1 {
-			r.logger.Info("Asserting Leader Matching")
-			dinvRT.Assert(assertLeaderMatching, getAssertLeaderMatchingValues())
-		}
-		if StrongLeaderAssert {
-			//DB1
-			if LEADER && r.id == r.lead && rand.Int()%SAMPLE == 0 ||
-				(!LEADER && r.id == F1 && rand.Int()%SAMPLE == 0) {
-				r.logger.Info("Asserting Stong Leadership")
-				//set up globals
-				leaderApplied = r.raftLog.applied
-				leaderCommited = r.raftLog.committed
-				rid = r.id
-				lid = r.lead
-				dinvRT.Assert(assertStrongLeadership, getAssertStrongLeaderhipValues())
-			}
-		}
-
-		if LogMatchingAssert {
-			if LEADER && r.id == r.lead && rand.Int()%SAMPLE == 0 ||
-				(!LEADER && r.id == F1 && rand.Int()%SAMPLE == 0) {
-				r.logger.Info("Asserting Log Matching")
-				dinvRT.Assert(assertLogMatching, getAssertLogMatchingValues())
-			}
-		}
-	}
-
-	if DB1 {
-		startbug()
-		if r.id != r.lead && r.raftLog.applied > 5 && rand.Int()%20 == 10 {
-			//Bugs caused by higher level functions not wanting
-			//new elements in the log
-			r.logger.Info("Appling bad data")
-			//Inject bad entries
-			for i := 0; i < 20; i++ {
-				e := DinvEntry(r)
-				r.appendEntry(e)
-				_, _ = r.raftLog.maybeAppend(r.raftLog.lastIndex(), r.raftLog.lastTerm(), r.raftLog.committed+1, DinvEntry(r))
-			}
+	//DB1 Strong leadership
+	if r.lead != None && r.id != r.lead && r.faults.Fire(FaultCommitWithoutLeader, r.state) {
+		r.startBug(FaultCommitWithoutLeader)
+		//Bugs caused by higher level functions not wanting
+		//new elements in the log
+		r.logger.Info("Appling bad data")
+		//Inject bad entries
+		for i := 0; i < 20; i++ {
+			e := DinvEntry(r)
+			r.appendEntry(e)
+			_, _ = r.raftLog.maybeAppend(r.raftLog.lastIndex(), r.raftLog.lastTerm(), r.raftLog.committed+1, DinvEntry(r))
 		}
 	}
+	//End DB1
 
-	UpdateCommitedEntries(r)
-	///END DINV INIT
-	/////////////////////////////////////////////////////////////
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
-	/*
-		lcommit, err := r.raftLog.slice(r.raftLog.committed,r.raftLog.committed,1)
-		if err !=nil {
-		}
-	*/
+	r.checkInvariants()
+	r.track("Step")
 
 	switch {
 	case m.Term == 0:
 		// local message
 	case m.Term > r.Term:
 		lead := m.From
-		if m.Type == pb.MsgVote {
-			if r.checkQuorum && r.state != StateCandidate && r.electionElapsed < r.electionTimeout {
+		if m.Type == pb.MsgVote || m.Type == pb.MsgPreVote {
+			if r.checkQuorum && r.state != StateCandidate && r.state != StatePreCandidate && r.electionElapsed < r.electionTimeout {
 				// If a server receives a RequestVote request within the minimum election timeout
 				// of hearing from a current leader, it does not update its term or grant its vote
-				r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] ignored vote from %x [logterm: %d, index: %d] at term %d: lease is not expired (remaining ticks: %d)",
-					r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.From, m.LogTerm, m.Index, r.Term, r.electionTimeout-r.electionElapsed)
+				r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] ignored %s from %x [logterm: %d, index: %d] at term %d: lease is not expired (remaining ticks: %d)",
+					r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.Type, m.From, m.LogTerm, m.Index, r.Term, r.electionTimeout-r.electionElapsed)
 				return nil
 			}
 			lead = None
 		}
-		r.logger.Infof("%x [term: %d] received a %s message with higher term from %x [term: %d]",
-			r.id, r.Term, m.Type, m.From, m.Term)
-		r.becomeFollower(m.Term, lead)
+		switch {
+		case m.Type == pb.MsgPreVote:
+			// Never change the term in response to a pre-vote.
+		case m.Type == pb.MsgPreVoteResp && !m.Reject:
+			// Pre-votes are sent for a term in the future of the
+			// pre-candidate, which increments its term once a quorum
+			// granted them. A rejection carries the term of the node that
+			// rejected it, so the pre-candidate becomes a follower at that
+			// term.
+		default:
+			r.logger.Infof("%x [term: %d] received a %s message with higher term from %x [term: %d]",
+				r.id, r.Term, m.Type, m.From, m.Term)
+			r.becomeFollower(m.Term, lead)
+		}
 	case m.Term < r.Term:
 		if r.checkQuorum && (m.Type == pb.MsgHeartbeat || m.Type == pb.MsgApp) {
 			// We have received messages from a leader at a lower term. It is possible that these messages were
@@ -839,6 +1093,14 @@ func (r *raft) Step(m pb.Message) error {
 			// will send MsgVotes which will be ignored, but it will not receive MsgApp or MsgHeartbeat, so it will not
 			// create disruptive term increases
 			r.send(pb.Message{To: m.From, Type: pb.MsgAppResp})
+		} else if m.Type == pb.MsgPreVote {
+			// Reject the pre-vote so that the pre-candidate learns the
+			// higher term. A node campaigning with a higher term but a
+			// shorter log before pre-vote was enabled would otherwise never
+			// hear from the nodes with a lower term.
+			r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] rejected %s from %x [logterm: %d, index: %d] at term %d",
+				r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.Type, m.From, m.LogTerm, m.Index, r.Term)
+			r.send(pb.Message{To: m.From, Term: r.Term, Type: pb.MsgPreVoteResp, Reject: true})
 		} else {
 			// ignore other cases
 			r.logger.Infof("%x [term: %d] ignored a %s message with lower term from %x [term: %d]",
@@ -846,27 +1108,42 @@ func (r *raft) Step(m pb.Message) error {
 		}
 		return nil
 	}
-	r.step(r, m)
-	return nil
+
+	if m.Type == pb.MsgPreVote {
+		// A pre-vote is granted by a node in any state, as long as it could
+		// vote for the pre-candidate at the term of the pre-vote. m.Term is
+		// greater than r.Term unless the node already campaigns or voted at
+		// that term.
+		if (r.Vote == None || m.Term > r.Term || r.Vote == m.From) && r.raftLog.isUpToDate(m.Index, m.LogTerm) {
+			r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] granted %s from %x [logterm: %d, index: %d] at term %d",
+				r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.Type, m.From, m.LogTerm, m.Index, r.Term)
+			r.send(pb.Message{To: m.From, Term: m.Term, Type: pb.MsgPreVoteResp})
+		} else {
+			r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] rejected %s from %x [logterm: %d, index: %d] at term %d",
+				r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.Type, m.From, m.LogTerm, m.Index, r.Term)
+			r.send(pb.Message{To: m.From, Term: r.Term, Type: pb.MsgPreVoteResp, Reject: true})
+		}
+		return nil
+	}
+	return r.step(r, m)
 }
 
-type stepFunc func(r *raft, m pb.Message)
+type stepFunc func(r *raft, m pb.Message) error
 
-func stepLeader(r *raft, m pb.Message) {
-	//Track
-	dinvRT.Track("", "r.id,r.Term,r.Vote,r.readState,r.state,r.lead,r.leadTransferee,r.pendingConf,r.electionElapsed,r.heartbeatElapsed,r.checkQuorum,r.heartbeatTimeout,r.electionTimeout,r.randomizedElectionTimeout,r.raftLog.committed,r.raftLog.applied,r.raftLog.lastIndex,r.raftLog.lastTerm", r.id, r.Term, r.Vote, r.readState, string(r.state), r.lead, r.leadTransferee, r.pendingConf, r.electionElapsed, r.heartbeatElapsed, r.checkQuorum, r.heartbeatTimeout, r.electionTimeout, r.randomizedElectionTimeout, r.raftLog.committed, r.raftLog.applied, r.raftLog.lastIndex(), r.raftLog.lastTerm())
+func stepLeader(r *raft, m pb.Message) error {
+	r.track("stepLeader")
 
 	// These message types do not require any progress for m.From.
 	switch m.Type {
 	case pb.MsgBeat:
 		r.bcastHeartbeat()
-		return
+		return nil
 	case pb.MsgCheckQuorum:
 		if !r.checkQuorumActive() {
 			r.logger.Warningf("%x stepped down to follower since quorum is not active", r.id)
 			r.becomeFollower(r.Term, None)
 		}
-		return
+		return nil
 	case pb.MsgProp:
 		if len(m.Entries) == 0 {
 			r.logger.Panicf("%x stepped empty MsgProp", r.id)
@@ -875,16 +1152,22 @@ func stepLeader(r *raft, m pb.Message) {
 			// If we are not currently a member of the range (i.e. this node
 			// was removed from the configuration while serving as leader),
 			// drop any new proposals.
-			return
+			return ErrProposalDropped
 		}
 		if r.leadTransferee != None {
 			r.logger.Debugf("%x [term %d] transfer leadership to %x is in progress; dropping proposal", r.id, r.Term, r.leadTransferee)
-			return
+			return ErrProposalDropped
+		}
+		if !r.increaseUncommittedSize(m.Entries) {
+			r.logger.Debugf("%x [term %d] uncommitted entries size limit %d reached; dropping proposal", r.id, r.Term, r.maxUncommittedSize)
+			return ErrUncommittedSizeExceeded
 		}
 
 		for i, e := range m.Entries {
-			if e.Type == pb.EntryConfChange {
-				if r.pendingConf {
+			if e.Type == pb.EntryConfChange || e.Type == pb.EntryConfChangeV2 {
+				// The joint configuration must be left before the next
+				// configuration change.
+				if r.pendingConf || r.isJoint() {
 					m.Entries[i] = pb.Entry{Type: pb.EntryNormal}
 				}
 				r.pendingConf = true
@@ -892,27 +1175,46 @@ func stepLeader(r *raft, m pb.Message) {
 		}
 		r.appendEntry(m.Entries...)
 		r.bcastAppend()
-		return
+		return nil
 	case pb.MsgVote:
 		r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] rejected vote from %x [logterm: %d, index: %d] at term %d",
 			r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.From, m.LogTerm, m.Index, r.Term)
 		r.send(pb.Message{To: m.From, Type: pb.MsgVoteResp, Reject: true})
-		return
+		return nil
 	case pb.MsgReadIndex:
-		ri := None
-		if r.checkQuorum {
-			ri = r.raftLog.committed
+		if len(m.Entries) != 1 {
+			r.logger.Errorf("%x invalid format of MsgReadIndex from %x, entries count: %d", r.id, m.From, len(m.Entries))
+			return nil
 		}
-
-		r.send(pb.Message{To: m.From, Type: pb.MsgReadIndexResp, Index: ri, Entries: m.Entries})
-		return
+		// The leader only knows the latest committed index once it has
+		// committed an entry of its own term. Reject the read until then
+		// rather than answering with a stale index.
+		if r.raftLog.zeroTermOnErrCompacted(r.raftLog.term(r.raftLog.committed)) != r.Term {
+			r.logger.Debugf("%x [term %d] has not committed an entry in its term; dropping index reading msg", r.id, r.Term)
+			return nil
+		}
+		ri := r.raftLog.committed
+
+		switch {
+		case r.hasQuorum(func(id uint64) bool { return id == r.id }):
+			// the leader alone is a quorum.
+			r.releaseReadIndex(m, ri)
+		case r.readOnly.option == ReadOnlySafe:
+			// release the read index once a quorum acknowledged a
+			// heartbeat sent after the request.
+			r.readOnly.addRequest(ri, m)
+			r.bcastHeartbeatWithCtx(m.Entries[0].Data)
+		case r.readOnly.option == ReadOnlyLeaseBased:
+			r.releaseReadIndex(m, ri)
+		}
+		return nil
 	}
 
 	// All other message types require a progress for m.From (pr).
 	pr, prOk := r.prs[m.From]
 	if !prOk {
 		r.logger.Debugf("%x no progress available for %x", r.id, m.From)
-		return
+		return nil
 	}
 	switch m.Type {
 	case pb.MsgAppResp:
@@ -965,9 +1267,26 @@ func stepLeader(r *raft, m pb.Message) {
 		if pr.Match < r.raftLog.lastIndex() {
 			r.sendAppend(m.From)
 		}
+
+		if r.readOnly.option != ReadOnlySafe || len(m.Context) == 0 {
+			return nil
+		}
+		acks := r.readOnly.recvAck(m)
+		if acks == nil {
+			return nil
+		}
+		if !r.hasQuorum(func(id uint64) bool {
+			_, ok := acks[id]
+			return ok || id == r.id
+		}) {
+			return nil
+		}
+		for _, rs := range r.readOnly.advance(m) {
+			r.releaseReadIndex(rs.req, rs.index)
+		}
 	case pb.MsgSnapStatus:
 		if pr.State != ProgressStateSnapshot {
-			return
+			return nil
 		}
 		if !m.Reject {
 			pr.becomeProbe()
@@ -995,14 +1314,22 @@ func stepLeader(r *raft, m pb.Message) {
 			if lastLeadTransferee == leadTransferee {
 				r.logger.Infof("%x [term %d] transfer leadership to %x is in progress, ignores request to same node %x",
 					r.id, r.Term, leadTransferee, leadTransferee)
-				return
+				return nil
 			}
 			r.abortLeaderTransfer()
 			r.logger.Infof("%x [term %d] abort previous transferring leadership to %x", r.id, r.Term, lastLeadTransferee)
 		}
 		if leadTransferee == r.id {
 			r.logger.Debugf("%x is already leader. Ignored transferring leadership to self", r.id)
-			return
+			return nil
+		}
+		if pr.IsLearner {
+			r.logger.Debugf("%x is learner. Ignored transferring leadership", leadTransferee)
+			return nil
+		}
+		if pr.IsWitness {
+			r.logger.Debugf("%x is witness. Ignored transferring leadership", leadTransferee)
+			return nil
 		}
 		// Transfer leadership to third party.
 		r.logger.Infof("%x [term %d] starts to transfer leadership to %x", r.id, r.Term, leadTransferee)
@@ -1016,13 +1343,14 @@ func stepLeader(r *raft, m pb.Message) {
 			r.sendAppend(leadTransferee)
 		}
 	}
+	return nil
 }
 
-func stepCandidate(r *raft, m pb.Message) {
+func stepCandidate(r *raft, m pb.Message) error {
 	switch m.Type {
 	case pb.MsgProp:
 		r.logger.Infof("%x no leader at term %d; dropping proposal", r.id, r.Term)
-		return
+		return ErrProposalDropped
 	case pb.MsgApp:
 		r.becomeFollower(r.Term, m.From)
 		r.handleAppendEntries(m)
@@ -1036,39 +1364,53 @@ func stepCandidate(r *raft, m pb.Message) {
 		r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] rejected vote from %x [logterm: %d, index: %d] at term %d",
 			r.id, r.raftLog.lastTerm(), r.raftLog.lastIndex(), r.Vote, m.From, m.LogTerm, m.Index, r.Term)
 		r.send(pb.Message{To: m.From, Type: pb.MsgVoteResp, Reject: true})
-	case pb.MsgVoteResp:
-		gr := r.poll(m.From, !m.Reject)
-		r.logger.Infof("%x [quorum:%d] has received %d votes and %d vote rejections", r.id, r.quorum(), gr, len(r.votes)-gr)
-		switch r.quorum() {
-		case gr:
-			r.becomeLeader()
-			r.bcastAppend()
-		case len(r.votes) - gr:
+	case pb.MsgVoteResp, pb.MsgPreVoteResp:
+		// Only handle the responses to the votes of the current state; a
+		// pre-candidate ignores stale vote responses and vice versa.
+		myVoteRespType := pb.MsgVoteResp
+		if r.state == StatePreCandidate {
+			myVoteRespType = pb.MsgPreVoteResp
+		}
+		if m.Type != myVoteRespType {
+			return nil
+		}
+		gr := r.poll(m.From, m.Type, !m.Reject)
+		r.logger.Infof("%x [quorum:%d] has received %d %s votes and %d vote rejections", r.id, r.quorum(), gr, m.Type, len(r.votes)-gr)
+		switch r.voteResult() {
+		case voteWon:
+			if r.state == StatePreCandidate {
+				r.campaign(campaignElection)
+			} else {
+				r.becomeLeader()
+				r.bcastAppend()
+			}
+		case voteLost:
 			r.becomeFollower(r.Term, None)
 		}
 	case pb.MsgTimeoutNow:
 		r.logger.Debugf("%x [term %d state %v] ignored MsgTimeoutNow from %x", r.id, r.Term, r.state, m.From)
 	}
+	return nil
 }
 
-func stepFollower(r *raft, m pb.Message) {
+func stepFollower(r *raft, m pb.Message) error {
 	switch m.Type {
 	case pb.MsgProp:
 		if r.lead == None {
 			r.logger.Infof("%x no leader at term %d; dropping proposal", r.id, r.Term)
-			return
+			return ErrProposalDropped
 		}
 		m.To = r.lead
 		r.send(m)
 	case pb.MsgApp:
 		r.electionElapsed = 0
-		if !DB3 {
+		if !r.falseLead {
 			r.lead = m.From
 		}
 		r.handleAppendEntries(m)
 	case pb.MsgHeartbeat:
 		r.electionElapsed = 0
-		if !DB3 {
+		if !r.falseLead {
 			r.lead = m.From
 		}
 		r.handleHeartbeat(m)
@@ -1088,24 +1430,41 @@ func stepFollower(r *raft, m pb.Message) {
 			r.send(pb.Message{To: m.From, Type: pb.MsgVoteResp, Reject: true})
 		}
 	case pb.MsgTimeoutNow:
+		if !r.promotable() {
+			r.logger.Infof("%x [term %d] ignored MsgTimeoutNow from %x due to not being a voter", r.id, r.Term, m.From)
+			return nil
+		}
 		r.logger.Infof("%x [term %d] received MsgTimeoutNow from %x and starts an election to get leadership.", r.id, r.Term, m.From)
-		r.campaign()
+		// Leadership transfers never use pre-vote since the transfer is
+		// requested by the current leader.
+		r.campaign(campaignElection)
+	case pb.MsgTransferLeader:
+		if r.lead == None {
+			r.logger.Infof("%x no leader at term %d; dropping leader transfer msg", r.id, r.Term)
+			return nil
+		}
+		m.To = r.lead
+		r.send(m)
 	case pb.MsgReadIndex:
+		if r.witness {
+			r.logger.Infof("%x is witness and cannot serve reads; dropping index reading msg", r.id)
+			return nil
+		}
 		if r.lead == None {
 			r.logger.Infof("%x no leader at term %d; dropping index reading msg", r.id, r.Term)
-			return
+			return nil
 		}
 		m.To = r.lead
 		r.send(m)
 	case pb.MsgReadIndexResp:
 		if len(m.Entries) != 1 {
 			r.logger.Errorf("%x invalid format of MsgReadIndexResp from %x, entries count: %d", r.id, m.From, len(m.Entries))
-			return
+			return nil
 		}
 
-		r.readState.Index = m.Index
-		r.readState.RequestCtx = m.Entries[0].Data
+		r.readStates = append(r.readStates, ReadState{Index: m.Index, RequestCtx: m.Entries[0].Data})
 	}
+	return nil
 }
 
 func (r *raft) handleAppendEntries(m pb.Message) {
@@ -1114,7 +1473,14 @@ func (r *raft) handleAppendEntries(m pb.Message) {
 		return
 	}
 	//fmt.Printf("lastIndex %d, lastTerm %d, committed %d m.Index %d, m.LogTerm %d, m.Commit %d\n", r.raftLog.lastIndex(), r.raftLog.lastTerm(), r.raftLog.committed, m.Index, m.LogTerm, m.Commit)
+	if r.witness {
+		m.Entries = witnessEntries(m.Entries)
+	}
 	if mlastIndex, ok := r.raftLog.maybeAppend(m.Index, m.LogTerm, m.Commit, m.Entries...); ok {
+		//DB2 Log matching
+		if len(m.Entries) > 0 && r.faults.Fire(FaultCorruptEntry, r.state) {
+			r.corruptUnstableEntry()
+		}
 		r.send(pb.Message{To: m.From, Type: pb.MsgAppResp, Index: mlastIndex})
 	} else {
 		r.logger.Debugf("%x [logterm: %d, index: %d] rejected msgApp [logterm: %d, index: %d] from %x",
@@ -1125,11 +1491,14 @@ func (r *raft) handleAppendEntries(m pb.Message) {
 
 func (r *raft) handleHeartbeat(m pb.Message) {
 	r.raftLog.commitTo(m.Commit)
-	r.send(pb.Message{To: m.From, Type: pb.MsgHeartbeatResp})
+	r.send(pb.Message{To: m.From, Type: pb.MsgHeartbeatResp, Context: m.Context})
 }
 
 func (r *raft) handleSnapshot(m pb.Message) {
 	sindex, sterm := m.Snapshot.Metadata.Index, m.Snapshot.Metadata.Term
+	if r.witness {
+		m.Snapshot.Data = nil
+	}
 	if r.restore(m.Snapshot) {
 		r.logger.Infof("%x [commit: %d] restored snapshot [index: %d, term: %d]",
 			r.id, r.raftLog.committed, sindex, sterm)
@@ -1159,35 +1528,100 @@ func (r *raft) restore(s pb.Snapshot) bool {
 
 	r.raftLog.restore(s)
 	r.prs = make(map[uint64]*Progress)
-	for _, n := range s.Metadata.ConfState.Nodes {
+	r.restoreNodes(s.Metadata.ConfState.Nodes, false)
+	r.restoreNodes(s.Metadata.ConfState.Learners, true)
+	r.setWitnesses(s.Metadata.ConfState.Witnesses)
+	for _, n := range s.Metadata.ConfState.NodesJoint {
+		if _, ok := r.prs[n]; !ok {
+			r.restoreNodes([]uint64{n}, false)
+			r.prs[n].Leaving = true
+		}
+	}
+	r.setOutgoing(s.Metadata.ConfState.NodesJoint)
+	return true
+}
+
+// setOutgoing sets the voters of the outgoing configuration. The node is
+// in a joint configuration if there are any.
+func (r *raft) setOutgoing(nodes []uint64) {
+	r.outgoing = nil
+	if len(nodes) == 0 {
+		return
+	}
+	r.outgoing = make(map[uint64]bool)
+	for _, n := range nodes {
+		r.outgoing[n] = true
+	}
+}
+
+func (r *raft) restoreNodes(nodes []uint64, isLearner bool) {
+	for _, n := range nodes {
 		match, next := uint64(0), uint64(r.raftLog.lastIndex())+1
 		if n == r.id {
 			match = next - 1
-		} else {
-			match = 0
 		}
-		r.setProgress(n, match, next)
+		r.setProgress(n, match, next, isLearner)
 		r.logger.Infof("%x restored progress of %x [%s]", r.id, n, r.prs[n])
 	}
-	return true
 }
 
 // promotable indicates whether state machine can be promoted to leader,
-// which is true when its own id is in progress list.
+// which is true when its own id is in progress list and it is neither a
+// learner nor a witness.
 func (r *raft) promotable() bool {
-	_, ok := r.prs[r.id]
-	return ok
+	pr, ok := r.prs[r.id]
+	return ok && !pr.IsLearner && !pr.IsWitness && !r.witness
 }
 
+// addNode adds id as a voter. Adding a learner promotes it to a voter.
 func (r *raft) addNode(id uint64) {
-	if _, ok := r.prs[id]; ok {
+	r.addNodeOrLearnerNode(id, false)
+}
+
+// addLearner adds id as a learner.
+func (r *raft) addLearner(id uint64) {
+	r.addNodeOrLearnerNode(id, true)
+}
+
+func (r *raft) addNodeOrLearnerNode(id uint64, isLearner bool) {
+	r.pendingConf = false
+	pr, ok := r.prs[id]
+	if !ok {
+		r.setProgress(id, 0, r.raftLog.lastIndex()+1, isLearner)
+		return
+	}
+	if pr.Leaving && !isLearner {
+		// a voter removed and added back by the same joint change stays.
+		pr.Leaving = false
+		return
+	}
+	if isLearner && !pr.IsLearner {
+		// can only change Learner to Voter
+		r.logger.Infof("%x ignored addLearner: do not support changing %x from voter to learner", r.id, id)
+		return
+	}
+	if isLearner == pr.IsLearner {
 		// Ignore any redundant addNode calls (which can happen because the
 		// initial bootstrapping entries are applied twice).
 		return
 	}
+	// promote the learner to a voter.
+	pr.IsLearner = false
+	// The voter is considered active by the leader until the next check
+	// quorum, so promoting a learner does not make the leader step down.
+	pr.RecentActive = true
+}
 
-	r.setProgress(id, 0, r.raftLog.lastIndex()+1)
+// addWitness adds id as a witness voter. An existing node cannot become a
+// witness.
+func (r *raft) addWitness(id uint64) {
 	r.pendingConf = false
+	if _, ok := r.prs[id]; ok {
+		r.logger.Infof("%x ignored addWitness: %x is already in the configuration", r.id, id)
+		return
+	}
+	r.setProgress(id, 0, r.raftLog.lastIndex()+1, false)
+	r.prs[id].IsWitness = true
 }
 
 func (r *raft) removeNode(id uint64) {
@@ -1212,8 +1646,119 @@ func (r *raft) removeNode(id uint64) {
 
 func (r *raft) resetPendingConf() { r.pendingConf = false }
 
-func (r *raft) setProgress(id, match, next uint64) {
-	r.prs[id] = &Progress{Next: next, Match: match, ins: newInflights(r.maxInflight)}
+// applyConfChange applies cc to the configuration. A change of a single node
+// is applied at once. A change of several nodes enters a joint
+// configuration, in which the leader appends the empty change that leaves
+// it as soon as it applies the change.
+func (r *raft) applyConfChange(cc pb.ConfChangeV2) {
+	switch {
+	case cc.LeaveJoint():
+		r.leaveJoint()
+	case cc.EnterJoint() || r.isJoint():
+		r.enterJoint(cc.Changes)
+	default:
+		r.applyConfChangeSingle(cc.Changes[0])
+	}
+}
+
+func (r *raft) applyConfChangeSingle(cc pb.ConfChangeSingle) {
+	if cc.NodeID == None {
+		r.resetPendingConf()
+		return
+	}
+	switch cc.Type {
+	case pb.ConfChangeAddNode:
+		r.addNode(cc.NodeID)
+	case pb.ConfChangeAddLearnerNode:
+		r.addLearner(cc.NodeID)
+	case pb.ConfChangeAddWitnessNode:
+		r.addWitness(cc.NodeID)
+	case pb.ConfChangeRemoveNode:
+		r.removeNode(cc.NodeID)
+	case pb.ConfChangeUpdateNode:
+		r.resetPendingConf()
+	default:
+		panic("unexpected conf type")
+	}
+}
+
+func (r *raft) enterJoint(ccs []pb.ConfChangeSingle) {
+	r.pendingConf = false
+	if r.isJoint() {
+		r.logger.Warningf("%x ignored entering a joint configuration while in one", r.id)
+		return
+	}
+	r.setOutgoing(r.nodes())
+	for _, cc := range ccs {
+		switch cc.Type {
+		case pb.ConfChangeAddNode:
+			r.addNode(cc.NodeID)
+		case pb.ConfChangeAddLearnerNode:
+			r.addLearner(cc.NodeID)
+		case pb.ConfChangeAddWitnessNode:
+			r.addWitness(cc.NodeID)
+		case pb.ConfChangeRemoveNode:
+			if r.outgoing[cc.NodeID] {
+				// the voter is removed when the joint configuration is left.
+				r.prs[cc.NodeID].Leaving = true
+			} else {
+				r.delProgress(cc.NodeID)
+			}
+		case pb.ConfChangeUpdateNode:
+		default:
+			panic("unexpected conf type")
+		}
+	}
+	r.logger.Infof("%x entered joint configuration [incoming: %v, outgoing: %v]", r.id, r.nodes(), r.outgoingNodes())
+	if r.state != StateLeader {
+		return
+	}
+	if r.maybeCommit() {
+		r.bcastAppend()
+	}
+	r.appendLeaveJoint()
+	r.bcastAppend()
+}
+
+// appendLeaveJoint appends the change that leaves the joint configuration.
+func (r *raft) appendLeaveJoint() {
+	data, err := (&pb.ConfChangeV2{}).Marshal()
+	if err != nil {
+		r.logger.Panicf("%x unexpected marshal error (%v)", r.id, err)
+	}
+	r.appendEntry(pb.Entry{Type: pb.EntryConfChangeV2, Data: data})
+	r.pendingConf = true
+}
+
+func (r *raft) leaveJoint() {
+	r.pendingConf = false
+	if !r.isJoint() {
+		r.logger.Infof("%x ignored leaving a joint configuration while not in one", r.id)
+		return
+	}
+	for id, pr := range r.prs {
+		if !pr.Leaving {
+			continue
+		}
+		r.delProgress(id)
+		if r.state == StateLeader && r.leadTransferee == id {
+			r.abortLeaderTransfer()
+		}
+	}
+	r.outgoing = nil
+	r.logger.Infof("%x left joint configuration [voters: %v]", r.id, r.nodes())
+	if len(r.prs) == 0 {
+		return
+	}
+	// The outgoing voters do not need a quorum anymore, so see if any
+	// pending entries can be committed.
+	if r.maybeCommit() {
+		r.bcastAppend()
+	}
+}
+
+func (r *raft) setProgress(id, match, next uint64, isLearner bool) {
+	r.prs[id] = &Progress{Next: next, Match: match, ins: newInflights(r.maxInflight, r.maxInflightBytes), IsLearner: isLearner}
 }
 
 func (r *raft) delProgress(id uint64) {
@@ -1240,27 +1785,29 @@ func (r *raft) resetRandomizedElectionTimeout() {
 	r.randomizedElectionTimeout = r.electionTimeout + r.rand.Intn(r.electionTimeout)
 }
 
+// releaseReadIndex answers the read index request m with the read index ri.
+// A read requested on the leader itself is answered locally.
+func (r *raft) releaseReadIndex(m pb.Message, ri uint64) {
+	if m.From == None || m.From == r.id {
+		r.readStates = append(r.readStates, ReadState{Index: ri, RequestCtx: m.Entries[0].Data})
+		return
+	}
+	r.send(pb.Message{To: m.From, Type: pb.MsgReadIndexResp, Index: ri, Entries: m.Entries})
+}
+
 // checkQuorumActive returns true if the quorum is active from
 // the view of the local raft state machine. Otherwise, it returns
 // false.
 // checkQuorumActive also resets all RecentActive to false.
 func (r *raft) checkQuorumActive() bool {
-	var act int
+	// self is always active
+	act := r.hasQuorum(func(id uint64) bool { return id == r.id || r.prs[id].RecentActive })
 
 	for id := range r.prs {
-		if id == r.id { // self is always active
-			act++
-			continue
-		}
-
-		if r.prs[id].RecentActive {
-			act++
-		}
-
 		r.prs[id].RecentActive = false
 	}
 
-	return act >= r.quorum()
+	return act
 }
 
 func (r *raft) sendTimeoutNow(to uint64) {
@@ -1271,81 +1818,82 @@ func (r *raft) abortLeaderTransfer() {
 	r.leadTransferee = None
 }
 
-///////DINV dinv DINV ASSERT FUNCTIONS //////////////////////
-
-func getAssertLeaderMatchingValues() map[string][]string {
-	peers := dinvRT.GetPeers()
-	values := make(map[string][]string)
-	for _, p := range peers {
-		values[p] = append(values[p], "leader")
+// increaseUncommittedSize computes the size of the proposed entries and
+// determines whether they would push leader over its maxUncommittedSize limit.
+// If the new entries would exceed the limit, the method returns false. If not,
+// the increase in uncommitted entry size is recorded and the method returns
+// true.
+//
+// Empty payloads are never refused, and a proposal is always accepted when
+// there are no uncommitted entries, so that a single entry larger than the
+// limit can still make progress.
+func (r *raft) increaseUncommittedSize(ents []pb.Entry) bool {
+	s := payloadsSize(ents)
+	if r.uncommittedSize > 0 && s > 0 && r.maxUncommittedSize > 0 && r.uncommittedSize+s > r.maxUncommittedSize {
+		return false
 	}
-	return values
+	r.uncommittedSize += s
+	return true
 }
 
-func assertLeaderMatching(values map[string]map[string]interface{}) bool {
-	//fmt.Println("Asserting Leaders Match")
-	peers := dinvRT.GetPeers()
-
-	//true if the leaders are actually a uint
-	leadersUint := false
-	leaders64 := make([]int64, 0)
-	leadersu64 := make([]uint64, 0)
-	//fmt.Println(values)
+// reduceUncommittedSize accounts for the newly committed entries by decreasing
+// the uncommitted entry size limit.
+func (r *raft) reduceUncommittedSize(ents []pb.Entry) {
+	if r.uncommittedSize == 0 {
+		// Fast-path for followers, who do not track or enforce the limit.
+		return
+	}
+	s := payloadsSize(ents)
+	if s > r.uncommittedSize {
+		// The uncommitted entry size may underestimate the true size of the
+		// uncommitted log tail but will never overestimate it. Saturate at 0
+		// instead of allowing overflow.
+		r.uncommittedSize = 0
+	} else {
+		r.uncommittedSize -= s
+	}
+}
 
-	for _, p := range peers {
-		if _, ok := values[p]["leader"]; ok {
-			//The inital value of leader is 0. When marshalling this
-			//via the assert library the 0 comes through as an int64.
-			//When the first leader is chosen it is set to a large
-			//random uint64. If I cast the 0 to uint64 I get an
-			//typecast error. If I cast the 9292391232939239 to an
-			//int64 I get a typecast error. I (stew) made this
-			//switching function to dynamically handle this issue
-			switch values[p]["leader"].(type) {
-			case int64:
-				leadersUint = false
-				leaders64 = append(leaders64, values[p]["leader"].(int64))
-			case uint64:
-				leadersUint = true
-				leadersu64 = append(leadersu64, values[p]["leader"].(uint64))
-			}
-		}
+// payloadsSize returns the total size of the data of ents.
+func payloadsSize(ents []pb.Entry) uint64 {
+	var s uint64
+	for _, e := range ents {
+		s += uint64(len(e.Data))
 	}
+	return s
+}
 
-	if leadersUint {
-		for i := range leadersu64 {
-			for j := i; j < len(leadersu64); j++ {
-				if leadersu64[i] != leadersu64[j] {
-					fmt.Println("ASSERTION FAILURE: LEADERS DONT MATCH")
-					catchbug()
-					return false
-				}
-			}
-		}
-	} else {
-		for i := range leaders64 {
-			for j := i; j < len(leaders64); j++ {
-				if leaders64[i] != leaders64[j] {
-					fmt.Println("ASSERTION FAILURE: LEADERS DONT MATCH")
-					catchbug()
-					return false
-				}
-			}
+// witnessEntries returns a copy of ents without the data of the entries a
+// witness does not keep. The configuration changes are kept, since a witness
+// applies them like any member.
+func witnessEntries(ents []pb.Entry) []pb.Entry {
+	if len(ents) == 0 {
+		return ents
+	}
+	stripped := make([]pb.Entry, len(ents))
+	for i, e := range ents {
+		if e.Type != pb.EntryConfChange && e.Type != pb.EntryConfChangeV2 {
+			e.Data = nil
 		}
+		stripped[i] = e
 	}
-	return true
+	return stripped
 }
 
-func getAssertStrongLeaderhipValues() map[string][]string {
-	peers := dinvRT.GetPeers()
-	values := make(map[string][]string)
-	for _, p := range peers {
-		values[p] = append(values[p], "commited")
-		values[p] = append(values[p], "applied")
-		values[p] = append(values[p], "leader")
-		values[p] = append(values[p], "id")
+// corruptUnstableEntry replaces the data of the last unstable entry, so the
+// local log no longer matches the leader's at that index and term.
+func (r *raft) corruptUnstableEntry() {
+	ents := r.raftLog.unstable.entries
+	if len(ents) == 0 {
+		return
 	}
-	return values
+	r.startBug(FaultCorruptEntry)
+	e := &ents[len(ents)-1]
+	data := make([]byte, len(e.Data)+1)
+	copy(data, e.Data)
+	data[len(e.Data)] = byte(r.faults.Intn(256))
+	e.Data = data
+	r.logger.Infof("%x injecting corrupt entry at index %d", r.id, e.Index)
 }
 
 //DINV FAKE ENTRY
@@ -1358,299 +1906,15 @@ func DinvEntry(r *raft) pb.Entry {
 	return e
 }
 
-func assertStrongLeadership(values map[string]map[string]interface{}) bool {
-	fmt.Println("Asserting Strong Leadership")
-	fmt.Println(values)
-	peers := dinvRT.GetPeers()
-	commited := make([]uint64, 0)
-	applied := make([]uint64, 0)
-	leader := false
-	//this check ensures that only the leader is making the assert
-	//reguardless of who requested the assert.
-	for _, p := range peers {
-		//make sure the values exist
-		_, ok1 := values[p]["leader"]
-		_, ok2 := values[p]["id"]
-		if ok1 && ok2 {
-			//fmt.Println(values[p]["leader"])
-			//fmt.Println(values[p]["id"])
-			switch values[p]["leader"].(type) {
-			case int64:
-				//leader not yet known (bootstraping election)
-				return true
-			case uint64:
-				//get the leaders commit value
-				if values[p]["leader"].(uint64) == values[p]["id"].(uint64) {
-					leader = true
-					switch values[p]["commited"].(type) {
-					case int64:
-						//again this is the base case, there is no
-						//need to handel this I think
-						return true
-					case uint64:
-						switch values[p]["applied"].(type) {
-						case int64:
-							//again this is the base case, there is no
-							//need to handel this I think
-							return true
-						case uint64:
-							leaderCommited = values[p]["commited"].(uint64)
-							leaderApplied = values[p]["applied"].(uint64)
-							//fmt.Printf("leaderApplied %d, leaderCommitted %d\n", leaderApplied, leaderCommited)
-						}
-					}
-
-				}
-			}
-		}
-	}
-	if rid == lid {
-		leader = true
-	}
-	if leader {
-		for _, p := range peers {
-			if _, ok := values[p]["commited"]; ok {
-				//fmt.Println(values[p]["commited"])
-				switch values[p]["commited"].(type) {
-				case int64:
-					return true
-				case uint64:
-					commited = append(commited, values[p]["commited"].(uint64))
-				}
-			}
-			if _, ok := values[p]["applied"]; ok {
-				//fmt.Println(values[p]["applied"])
-				switch values[p]["applied"].(type) {
-				case int64:
-					return true
-				case uint64:
-					applied = append(applied, values[p]["applied"].(uint64))
-				}
-			}
-		}
-		//fmt.Printf("#commited %d, #applied %d\n", len(commited), len(applied))
-		for i := range commited {
-			//fmt.Printf("committed %d , leader committed %d", commited[i], leaderCommited)
-			if commited[i] > leaderCommited {
-				catchbug()
-				fmt.Println("STRONG LEADERSHIP FAILED")
-				return false
-			}
-		}
-		for i := range applied {
-			//fmt.Printf("applied %d , leader applied %d", commited[i], leaderCommited)
-			if applied[i] > leaderApplied {
-				catchbug()
-				fmt.Println("STRONG LEADERSHIP FAILED")
-				return false
-			}
-		}
-	}
-	return true
-}
-
-func getAssertLogMatchingValues() map[string][]string {
-	peers := dinvRT.GetPeers()
-	values := make(map[string][]string)
-	for _, p := range peers {
-		values[p] = append(values[p], "log")
-	}
-	return values
-}
-
-func assertLogMatching(values map[string]map[string]interface{}) bool {
-	fmt.Println("Asserting Log Matching")
-	peers := dinvRT.GetPeers()
-	logs := make([][]pb.Entry, len(peers))
-	for i, p := range peers {
-		if _, ok := values[p]["log"]; ok {
-			intarray := values[p]["log"].([]interface{})
-			//fmt.Println(len(intarray))
-			for j := range intarray {
-				var entry pb.Entry
-				mapper := intarray[j].(map[interface{}]interface{})
-				//fmt.Println(mapper)
-				//Term
-				if _, ok := mapper["Term"]; ok {
-					switch mapper["Term"].(type) {
-					case int64:
-						entry.Term = uint64(mapper["Term"].(int64))
-					case uint64:
-						entry.Term = mapper["Term"].(uint64)
-					default:
-						continue
-					}
-				}
-				//Index
-				if _, ok := mapper["Index"]; ok {
-					switch mapper["Index"].(type) {
-					case int64:
-						entry.Index = uint64(mapper["Index"].(int64))
-					case uint64:
-						entry.Index = mapper["Index"].(uint64)
-					default:
-						continue
-					}
-				}
-				//Data
-				//entry.Data = mapper["Data"].([]byte)
-				if _, ok := mapper["Data"]; ok {
-					switch mapper["Data"].(type) {
-					case []byte:
-						entry.Data = mapper["Data"].([]byte)
-					case nil:
-						//fmt.Printf("Why is the data nil %s", mapper)
-						continue
-					default:
-						//fmt.Printf("Datatype :%s", mapper["Data"])
-					}
-				}
-				logs[i] = append(logs[i], entry)
-				//fmt.Printf("Parsed Entry %s\n", entry.String())
-				//fmt.Println(intarray[j])
-				//fmt.Println(i)
-			}
-		}
-	}
-	//At this point the logs have been converted to entry arrays.
-	//Now for each find the higest matching
-	//fmt.Printf("MATCHING LOGS of size%d\n", len(logs))
-	for i := range logs {
-		for j := i + 1; j < len(logs); j++ {
-			if len(logs[i]) == 0 || len(logs[j]) == 0 {
-				fmt.Printf("one log has size zero")
-				continue
-			}
-			//check log matching
-			//find the min log len
-			min := len(logs[i]) - 1
-			if len(logs[j])-1 < min {
-				min = len(logs[j]) - 1
-			}
-			//fmt.Printf("min :%d\n logs_i %d logs_j", min, len(logs[i]), len(logs[j]))
-			matched := false
-			for k := min; k >= 0; k-- {
-				//fmt.Println(k)
-				//fmt.Printf("E_i, %s\nE_j%s\n", logs[i][k].String(), logs[j][k].String())
-				//find the first occurance of a matching index and
-				//term
-				if logs[i][k].Index == logs[j][k].Index && logs[i][k].Term == logs[j][k].Term {
-					for d := range logs[i][k].Data {
-						if logs[i][k].Data[d] != logs[j][k].Data[d] {
-							continue
-						}
-					}
-					matched = true
-				}
-				//After finding the match all prior entries must be
-				//correct
-				if matched {
-					for d := range logs[i][k].Data {
-						if logs[i][k].Data[d] != logs[j][k].Data[d] {
-							fmt.Println("LOG MATCHING FAILED")
-							catchbug()
-							return false
-						}
-					}
-				}
-			}
-			return true
-		}
-	}
-	fmt.Println(logs)
-	return true
-}
-
-//Dinv function for grabbing state, grab the first 100 entries and
-//save it to a variable.
-func UpdateCommitedEntries(r *raft) {
-	CommitedEntries = r.raftLog.allEntries()
-	//fmt.Println(CommitedEntries)
-}
-
-//abriged asertions
-/*
-func assertStrongLeadershipAbridged(values map[string]map[string]interface{}) bool {
-	peers, commited, applied, leader, leaderCommited, leaderApplied := dinvRT.GetPeers(),  make([]uint64, 0), make([]uint64, 0), false, getUnit64("leader", "commited"), getUnit64("leader", "applied")
-	if rid == lid {
-		leader = true
-	}
-	if leader {
-		for _, p := range peers {
-			if getUint64(p,"committed") > leaderApplied {
-				return false
-			}
-	}
-	return true
-}
-*/
-func getUnit64(id, varName string) uint64 {
-	return uint64(0)
-}
-
-/*
-func assertLeaderMatchingAbridged(values map[string]map[string]interface{}) bool {
-	//fmt.Println("Asserting Leaders Match")
-	peers, leaders64, leadersu64 := dinvRT.GetPeers(),  make([]int64, 0), make([]uint64, 0)
-	for _, p := range peers {
-		leadersu64 = append(leadersu64, getUint64(p,"leader")
-	}
-	for i := range leadersu64 {
-		for j := i; j < len(leadersu64); j++ {
-			if leadersu64[i] != leadersu64[j] {
-				return false
-			}
-		}
-	}
-}
-*/
-
-/* 75 statements
-func assertLogMatchingAbridged(values map[string]map[string]interface{}) bool {
-	peers,logs := dinvRT.GetPeers(), make([][]pb.Entry, len(peers))
-	for i, p := range peers {
-		if _, ok := values[p]["log"]; ok {
-			intarray := values[p]["log"].([]interface{})
-			for j := range intarray {
-				var entry pb.Entry
-				mapper := intarray[j].(map[interface{}]interface{}); entry.Term = uint64(mapper["Term"].(int64)) ; entry.Index = mapper["Index"].(uint64); entry.Data = mapper["Data"].([]byte)
-				}
-				logs[i] = append(logs[i], entry)
-			}
-		}
-	}
-	for i := range logs {
-		for j := i + 1; j < len(logs); j++ {
-			if len(logs[i]) == 0 || len(logs[j]) == 0 {
-				continue
-			}
-			min := len(logs[i]) - 1
-			if len(logs[j])-1 < min {
-				min = len(logs[j]) - 1
-			}
-			matched := false
-			for k := min; k >= 0; k-- {
-				if logs[i][k].Index == logs[j][k].Index && logs[i][k].Term == logs[j][k].Term {
-					for d := range logs[i][k].Data {
-						if logs[i][k].Data[d] != logs[j][k].Data[d] {
-							continue
-						}
-					}
-					matched = true
-				}
-				if matched {
-					for d := range logs[i][k].Data {
-						if logs[i][k].Data[d] != logs[j][k].Data[d] {
-							return false
-						}
-					}
-				}
-			}
-			return true
-		}
+// voteRespMsgType maps vote and pre-vote message types to their
+// corresponding responses.
+func voteRespMsgType(t pb.MessageType) pb.MessageType {
+	switch t {
+	case pb.MsgVote:
+		return pb.MsgVoteResp
+	case pb.MsgPreVote:
+		return pb.MsgPreVoteResp
+	default:
+		panic(fmt.Sprintf("not a vote message: %s", t))
 	}
-	return true
 }
-*/
-
-/////END DINV ASSERTIONS //////////////////////
@@ -153,3 +153,48 @@ func TestMsgAppFlowControlRecvHeartbeat(t *testing.T) {
 		r.readMessages()
 	}
 }
+
+// TestMsgAppFlowControlBytes ensures the leader stops sending append
+// messages once the size of the entries in flight reaches MaxInflightBytes,
+// and resumes once the follower acknowledges them.
+func TestMsgAppFlowControlBytes(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2}, 5, 1, NewMemoryStorage())
+	cfg.MaxInflightBytes = 3 * 100
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+
+	pr2 := r.prs[2]
+	// force the progress to be in replicate state
+	pr2.becomeReplicate()
+	data := make([]byte, 100)
+	for i := 0; i < 3; i++ {
+		r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: data}}})
+		ms := r.readMessages()
+		if len(ms) != 1 {
+			t.Fatalf("#%d: len(ms) = %d, want 1", i, len(ms))
+		}
+	}
+	if !pr2.ins.full() {
+		t.Fatalf("inflights.full = %t, want %t", pr2.ins.full(), true)
+	}
+	if g := pr2.InflightBytes(); g != 300 {
+		t.Fatalf("inflight bytes = %d, want %d", g, 300)
+	}
+
+	r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: data}}})
+	if ms := r.readMessages(); len(ms) != 0 {
+		t.Fatalf("len(ms) = %d, want 0", len(ms))
+	}
+
+	// acknowledging the first two append messages frees their bytes, and the
+	// pending entry is sent.
+	r.Step(pb.Message{From: 2, To: 1, Type: pb.MsgAppResp, Index: pr2.Match + 3})
+	ms := r.readMessages()
+	if len(ms) != 1 || len(ms[0].Entries) != 1 {
+		t.Fatalf("ms = %+v, want one append of one entry", ms)
+	}
+	if g := pr2.InflightBytes(); g != 200 {
+		t.Fatalf("inflight bytes = %d, want %d", g, 200)
+	}
+}
@@ -55,17 +55,17 @@ func TestProgressBecomeProbe(t *testing.T) {
 		wnext	uint64
 	}{
 		{
-			&Progress{State: ProgressStateReplicate, Match: match, Next: 5, ins: newInflights(256)},
+			&Progress{State: ProgressStateReplicate, Match: match, Next: 5, ins: newInflights(256, 0)},
 			2,
 		},
 		{
 			// snapshot finish
-			&Progress{State: ProgressStateSnapshot, Match: match, Next: 5, PendingSnapshot: 10, ins: newInflights(256)},
+			&Progress{State: ProgressStateSnapshot, Match: match, Next: 5, PendingSnapshot: 10, ins: newInflights(256, 0)},
 			11,
 		},
 		{
 			// snapshot failure
-			&Progress{State: ProgressStateSnapshot, Match: match, Next: 5, PendingSnapshot: 0, ins: newInflights(256)},
+			&Progress{State: ProgressStateSnapshot, Match: match, Next: 5, PendingSnapshot: 0, ins: newInflights(256, 0)},
 			2,
 		},
 	}
@@ -84,7 +84,7 @@ func TestProgressBecomeProbe(t *testing.T) {
 }
 
 func TestProgressBecomeReplicate(t *testing.T) {
-	p := &Progress{State: ProgressStateProbe, Match: 1, Next: 5, ins: newInflights(256)}
+	p := &Progress{State: ProgressStateProbe, Match: 1, Next: 5, ins: newInflights(256, 0)}
 	p.becomeReplicate()
 
 	if p.State != ProgressStateReplicate {
@@ -99,7 +99,7 @@ func TestProgressBecomeReplicate(t *testing.T) {
 }
 
 func TestProgressBecomeSnapshot(t *testing.T) {
-	p := &Progress{State: ProgressStateProbe, Match: 1, Next: 5, ins: newInflights(256)}
+	p := &Progress{State: ProgressStateProbe, Match: 1, Next: 5, ins: newInflights(256, 0)}
 	p.becomeSnapshot(10)
 
 	if p.State != ProgressStateSnapshot {
@@ -234,7 +234,7 @@ func TestProgressIsPaused(t *testing.T) {
 		p := &Progress{
 			State:	tt.state,
 			Paused:	tt.paused,
-			ins:	newInflights(256),
+			ins:	newInflights(256, 0),
 		}
 		if g := p.isPaused(); g != tt.w {
 			t.Errorf("#%d: paused= %t, want %t", i, g, tt.w)
@@ -317,6 +317,83 @@ func TestLeaderElection(t *testing.T) {
 	}
 }
 
+func TestLeaderElectionPreVote(t *testing.T) {
+	tests := []struct {
+		*network
+		state	StateType
+		wterm	uint64
+	}{
+		{newPreVoteNetwork(nil, nil, nil), StateLeader, 1},
+		{newPreVoteNetwork(nil, nil, nopStepper), StateLeader, 1},
+		// a pre-candidate that cannot win the election keeps its term.
+		{newPreVoteNetwork(nil, nopStepper, nopStepper), StatePreCandidate, 0},
+		{newPreVoteNetwork(nil, nopStepper, nopStepper, nil), StatePreCandidate, 0},
+		{newPreVoteNetwork(nil, nopStepper, nopStepper, nil, nil), StateLeader, 1},
+
+		// three logs further along than 0
+		{newPreVoteNetwork(nil, ents(1), ents(2), ents(1, 3), nil), StateFollower, 0},
+
+		// logs converge
+		{newPreVoteNetwork(ents(1), nil, ents(2), ents(1), nil), StateLeader, 1},
+	}
+
+	for i, tt := range tests {
+		tt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+		sm := tt.network.peers[1].(*raft)
+		if sm.state != tt.state {
+			t.Errorf("#%d: state = %s, want %s", i, sm.state, tt.state)
+		}
+		if g := sm.Term; g != tt.wterm {
+			t.Errorf("#%d: term = %d, want %d", i, g, tt.wterm)
+		}
+	}
+}
+
+// TestPreVoteRejoin tests that a node partitioned away does not increment
+// its term with pre-vote, so it does not depose the leader when it rejoins.
+func TestPreVoteRejoin(t *testing.T) {
+	nt := newPreVoteNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	nt.isolate(3)
+	for i := 0; i < 3; i++ {
+		nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	}
+	c := nt.peers[3].(*raft)
+	if c.state != StatePreCandidate || c.Term != 1 {
+		t.Errorf("state, term = %s, %d, want %s, %d", c.state, c.Term, StatePreCandidate, 1)
+	}
+
+	nt.recover()
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgBeat})
+	a := nt.peers[1].(*raft)
+	if a.state != StateLeader || a.Term != 1 {
+		t.Errorf("leader state, term = %s, %d, want %s, %d", a.state, a.Term, StateLeader, 1)
+	}
+	if c.state != StateFollower || c.lead != 1 {
+		t.Errorf("state, lead = %s, %x, want %s, %x", c.state, c.lead, StateFollower, 1)
+	}
+
+	// once back, the node campaigns normally after a pre-vote.
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	if c.state != StateLeader || c.Term != 2 {
+		t.Errorf("state, term = %s, %d, want %s, %d", c.state, c.Term, StateLeader, 2)
+	}
+}
+
+func TestSingleNodePreCandidate(t *testing.T) {
+	tt := newPreVoteNetwork(nil)
+	tt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	sm := tt.peers[1].(*raft)
+	if sm.state != StateLeader {
+		t.Errorf("state = %s, want %s", sm.state, StateLeader)
+	}
+	if sm.Term != 1 {
+		t.Errorf("term = %d, want %d", sm.Term, 1)
+	}
+}
+
 func TestLogReplication(t *testing.T) {
 	tests := []struct {
 		*network
@@ -376,6 +453,293 @@ func TestLogReplication(t *testing.T) {
 	}
 }
 
+// TestLearnerElectionTimeout verifies that the leader should not start election even
+// when times out.
+func TestLearnerElectionTimeout(t *testing.T) {
+	n1 := newTestLearnerRaft(1, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	n2 := newTestLearnerRaft(2, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+
+	n1.becomeFollower(1, None)
+	n2.becomeFollower(1, None)
+
+	// n2 is learner. Learner should not start election even when times out.
+	n2.randomizedElectionTimeout = n2.electionTimeout
+	for i := 0; i < n2.electionTimeout; i++ {
+		n2.tick()
+	}
+
+	if n2.state != StateFollower {
+		t.Errorf("peer 2 state: %s, want %s", n2.state, StateFollower)
+	}
+	if len(n2.readMessages()) != 0 {
+		t.Errorf("learner sent messages after its election timeout")
+	}
+}
+
+// TestLearnerPromotion verifies that the learner should not election until
+// it is promoted to a normal peer.
+func TestLearnerPromotion(t *testing.T) {
+	n1 := newTestLearnerRaft(1, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	n2 := newTestLearnerRaft(2, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+
+	n1.becomeFollower(1, None)
+	n2.becomeFollower(1, None)
+
+	nt := newNetwork(n1, n2)
+
+	if n1.state == StateLeader {
+		t.Error("peer 1 state is leader, want not", n1.state)
+	}
+
+	// n1 should become leader
+	n1.randomizedElectionTimeout = n1.electionTimeout
+	for i := 0; i < n1.electionTimeout; i++ {
+		n1.tick()
+	}
+
+	if n1.state != StateLeader {
+		t.Errorf("peer 1 state: %s, want %s", n1.state, StateLeader)
+	}
+	if n2.state != StateFollower {
+		t.Errorf("peer 2 state: %s, want %s", n2.state, StateFollower)
+	}
+
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgBeat})
+
+	n1.addNode(2)
+	n2.addNode(2)
+	if n2.isLearner(2) {
+		t.Error("peer 2 is learner, want not")
+	}
+
+	// n2 start election, should become leader
+	n2.randomizedElectionTimeout = n2.electionTimeout
+	for i := 0; i < n2.electionTimeout; i++ {
+		n2.tick()
+	}
+
+	nt.send(pb.Message{From: 2, To: 2, Type: pb.MsgBeat})
+
+	if n1.state != StateFollower {
+		t.Errorf("peer 1 state: %s, want %s", n1.state, StateFollower)
+	}
+	if n2.state != StateLeader {
+		t.Errorf("peer 2 state: %s, want %s", n2.state, StateLeader)
+	}
+}
+
+// TestLearnerCannotVote checks that a vote granted by a learner is not
+// counted toward the quorum.
+func TestLearnerCannotVote(t *testing.T) {
+	n1 := newTestLearnerRaft(1, []uint64{1, 3}, []uint64{2}, 10, 1, NewMemoryStorage())
+	n1.Step(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	for _, m := range n1.readMessages() {
+		if m.To == 2 {
+			t.Errorf("vote requested from learner 2")
+		}
+	}
+	n1.Step(pb.Message{From: 2, To: 1, Term: n1.Term, Type: pb.MsgVoteResp})
+	if n1.state != StateCandidate {
+		t.Errorf("state = %s, want %s", n1.state, StateCandidate)
+	}
+	n1.Step(pb.Message{From: 3, To: 1, Term: n1.Term, Type: pb.MsgVoteResp})
+	if n1.state != StateLeader {
+		t.Errorf("state = %s, want %s", n1.state, StateLeader)
+	}
+}
+
+// TestLearnerQuorum checks that the learners do not count toward the quorum
+// of the leader.
+func TestLearnerQuorum(t *testing.T) {
+	tests := []struct {
+		peers    []uint64
+		learners []uint64
+		wquorum  int
+	}{
+		{[]uint64{1}, []uint64{2, 3}, 1},
+		{[]uint64{1, 2}, []uint64{3}, 2},
+		{[]uint64{1, 2, 3}, []uint64{4, 5}, 2},
+	}
+	for i, tt := range tests {
+		r := newTestLearnerRaft(1, tt.peers, tt.learners, 10, 1, NewMemoryStorage())
+		if q := r.quorum(); q != tt.wquorum {
+			t.Errorf("#%d: quorum = %d, want %d", i, q, tt.wquorum)
+		}
+	}
+
+	// a single voter commits without its learners.
+	n1 := newTestLearnerRaft(1, []uint64{1}, []uint64{2, 3}, 10, 1, NewMemoryStorage())
+	n1.becomeCandidate()
+	n1.becomeLeader()
+	n1.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("somedata")}}})
+	if n1.raftLog.committed != n1.raftLog.lastIndex() {
+		t.Errorf("committed = %d, want %d", n1.raftLog.committed, n1.raftLog.lastIndex())
+	}
+}
+
+// TestLearnerLogReplication tests that a learner can receive entries from the leader.
+func TestLearnerLogReplication(t *testing.T) {
+	n1 := newTestLearnerRaft(1, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	n2 := newTestLearnerRaft(2, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+
+	nt := newNetwork(n1, n2)
+
+	n1.becomeFollower(1, None)
+	n2.becomeFollower(1, None)
+
+	n1.randomizedElectionTimeout = n1.electionTimeout
+	for i := 0; i < n1.electionTimeout; i++ {
+		n1.tick()
+	}
+
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgBeat})
+
+	// n1 is leader and n2 is learner
+	if n1.state != StateLeader {
+		t.Errorf("peer 1 state: %s, want %s", n1.state, StateLeader)
+	}
+	if !n2.isLearner(2) {
+		t.Error("peer 2 state: not learner, want yes")
+	}
+
+	nextCommitted := n1.raftLog.committed + 1
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Data: []byte("somedata")}}})
+	if n1.raftLog.committed != nextCommitted {
+		t.Errorf("peer 1 wants committed to %d, but still %d", nextCommitted, n1.raftLog.committed)
+	}
+
+	if n1.raftLog.committed != n2.raftLog.committed {
+		t.Errorf("peer 2 wants committed to %d, but still %d", n1.raftLog.committed, n2.raftLog.committed)
+	}
+
+	match := n1.prs[2].Match
+	if match != n2.raftLog.committed {
+		t.Errorf("progress 2 of leader 1 wants match %d, but got %d", n2.raftLog.committed, match)
+	}
+}
+
+// TestLearnerTransferLeadership checks that leadership is never transferred
+// to a learner.
+func TestLearnerTransferLeadership(t *testing.T) {
+	n1 := newTestLearnerRaft(1, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	n2 := newTestLearnerRaft(2, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	nt := newNetwork(n1, n2)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	nt.send(pb.Message{From: 2, To: 1, Type: pb.MsgTransferLeader})
+	if n1.state != StateLeader || n1.leadTransferee != None {
+		t.Errorf("state = %s, leadTransferee = %x, want %s, %x", n1.state, n1.leadTransferee, StateLeader, None)
+	}
+
+	// a learner ignores MsgTimeoutNow.
+	nt.send(pb.Message{From: 1, To: 2, Type: pb.MsgTimeoutNow})
+	if n2.state != StateFollower {
+		t.Errorf("peer 2 state: %s, want %s", n2.state, StateFollower)
+	}
+}
+
+// TestWitnessStripsEntryData tests that a witness drops the data of the
+// appended entries except the configuration changes, without changing the
+// entries of the message.
+func TestWitnessStripsEntryData(t *testing.T) {
+	cfg := newTestConfig(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.Witness = true
+	r := newRaft(cfg)
+	r.becomeFollower(1, 1)
+
+	ents := []pb.Entry{
+		{Term: 1, Index: 1, Data: []byte("foo")},
+		{Term: 1, Index: 2, Type: pb.EntryConfChange, Data: []byte("cc")},
+		{Term: 1, Index: 3, Type: FirstApplicationEntryType, Data: []byte("bar")},
+	}
+	r.Step(pb.Message{From: 1, To: 2, Type: pb.MsgApp, Term: 1, Entries: ents})
+
+	wents := []pb.Entry{
+		{Term: 1, Index: 1},
+		{Term: 1, Index: 2, Type: pb.EntryConfChange, Data: []byte("cc")},
+		{Term: 1, Index: 3, Type: FirstApplicationEntryType},
+	}
+	if g := r.raftLog.unstableEntries(); !reflect.DeepEqual(g, wents) {
+		t.Errorf("entries = %+v, want %+v", g, wents)
+	}
+	if string(ents[0].Data) != "foo" {
+		t.Errorf("message data = %q, want %q", ents[0].Data, "foo")
+	}
+}
+
+// TestWitnessNeverCampaigns tests that a witness votes but never becomes a
+// candidate.
+func TestWitnessNeverCampaigns(t *testing.T) {
+	n1 := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	n2 := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg := newTestConfig(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.Witness = true
+	n3 := newRaft(cfg)
+	nt := newNetwork(n1, n2, n3)
+
+	nt.send(pb.Message{From: 3, To: 3, Type: pb.MsgHup})
+	if n3.state != StateFollower {
+		t.Errorf("peer 3 state = %s, want %s", n3.state, StateFollower)
+	}
+	for i := 0; i < 2*n3.electionTimeout; i++ {
+		n3.tick()
+	}
+	if n3.state != StateFollower {
+		t.Errorf("peer 3 state = %s, want %s", n3.state, StateFollower)
+	}
+
+	// the witness votes, so the leader gets elected without peer 2.
+	nt.isolate(2)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if n1.state != StateLeader {
+		t.Errorf("peer 1 state = %s, want %s", n1.state, StateLeader)
+	}
+}
+
+func TestWitnessTransferLeadership(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.prs[2].IsWitness = true
+	r.becomeCandidate()
+	r.becomeLeader()
+
+	r.Step(pb.Message{From: 2, To: 1, Type: pb.MsgTransferLeader})
+	if r.leadTransferee != None {
+		t.Errorf("leadTransferee = %x, want %x", r.leadTransferee, None)
+	}
+}
+
+// TestSendSnapshotToWitness tests that the leader sends a witness the
+// metadata of the snapshot without its data.
+func TestSendSnapshotToWitness(t *testing.T) {
+	s := pb.Snapshot{
+		Data: []byte("data"),
+		Metadata: pb.SnapshotMetadata{
+			Index:     11, // magic number
+			Term:      11, // magic number
+			ConfState: pb.ConfState{Nodes: []uint64{1, 2}, Witnesses: []uint64{2}},
+		},
+	}
+	sm := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	sm.restore(s)
+	sm.becomeCandidate()
+	sm.becomeLeader()
+
+	// force set the next of node 2, so that node 2 needs a snapshot
+	sm.prs[2].Next = sm.raftLog.firstIndex()
+	sm.Step(pb.Message{From: 2, To: 1, Type: pb.MsgAppResp, Index: sm.prs[2].Next - 1, Reject: true})
+
+	msgs := sm.readMessages()
+	if len(msgs) != 1 || msgs[0].Type != pb.MsgSnap {
+		t.Fatalf("msgs = %+v, want a single MsgSnap", msgs)
+	}
+	if msgs[0].Snapshot.Data != nil {
+		t.Errorf("snapshot data = %q, want nil", msgs[0].Snapshot.Data)
+	}
+	if msgs[0].Snapshot.Metadata.Index != 11 {
+		t.Errorf("snapshot index = %d, want %d", msgs[0].Snapshot.Metadata.Index, 11)
+	}
+}
+
 func TestSingleNodeCommit(t *testing.T) {
 	tt := newNetwork(nil)
 	tt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
@@ -719,6 +1083,85 @@ func TestProposalByProxy(t *testing.T) {
 	}
 }
 
+// TestUncommittedEntryLimit ensures that the leader drops the proposals once
+// the size of its uncommitted entries reaches MaxUncommittedEntriesSize, and
+// accepts them again once the entries are committed.
+func TestUncommittedEntryLimit(t *testing.T) {
+	const maxEntries = 16
+	testEntry := pb.Entry{Data: []byte("testdata")}
+	maxEntrySize := maxEntries * payloadsSize([]pb.Entry{testEntry})
+
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 5, 1, NewMemoryStorage())
+	cfg.MaxUncommittedEntriesSize = maxEntrySize
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+	if r.uncommittedSize != 0 {
+		t.Fatalf("uncommittedSize = %d, want 0", r.uncommittedSize)
+	}
+
+	// Set the two followers to the replicate state.
+	const numFollowers = 2
+	r.prs[2].becomeReplicate()
+	r.prs[3].becomeReplicate()
+
+	// Send proposals to r1. The first 16 entries should be appended to the log.
+	propMsg := pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{testEntry}}
+	propEnts := make([]pb.Entry, maxEntries)
+	for i := 0; i < maxEntries; i++ {
+		if err := r.Step(propMsg); err != nil {
+			t.Fatalf("#%d: proposal resulted in error: %v", i, err)
+		}
+		propEnts[i] = testEntry
+	}
+
+	// Send one more proposal to r1. It should be rejected.
+	if err := r.Step(propMsg); err != ErrUncommittedSizeExceeded {
+		t.Fatalf("proposal not dropped: %v", err)
+	}
+
+	// Read messages and reduce the uncommitted size as if we had committed
+	// these entries.
+	ms := r.readMessages()
+	if e := maxEntries * numFollowers; len(ms) != e {
+		t.Fatalf("expected %d messages, got %d", e, len(ms))
+	}
+	r.reduceUncommittedSize(propEnts)
+	if r.uncommittedSize != 0 {
+		t.Fatalf("committed everything, but still tracking %d", r.uncommittedSize)
+	}
+
+	// Send a single large proposal to r1. Should be accepted even though it
+	// pushes us above the limit because we were beneath it before the proposal.
+	propEnts = make([]pb.Entry, 2*maxEntries)
+	for i := range propEnts {
+		propEnts[i] = testEntry
+	}
+	propMsgLarge := pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: propEnts}
+	if err := r.Step(propMsgLarge); err != nil {
+		t.Fatalf("proposal resulted in error: %v", err)
+	}
+
+	// Send one more proposal to r1. It should be rejected, again.
+	if err := r.Step(propMsg); err != ErrUncommittedSizeExceeded {
+		t.Fatalf("proposal not dropped: %v", err)
+	}
+
+	// Read messages and reduce the uncommitted size as if we had committed
+	// these entries.
+	r.readMessages()
+	r.reduceUncommittedSize(propEnts)
+	if r.uncommittedSize != 0 {
+		t.Fatalf("committed everything, but still tracking %d", r.uncommittedSize)
+	}
+
+	// An empty proposal is never dropped.
+	r.uncommittedSize = maxEntrySize
+	if err := r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{}}}); err != nil {
+		t.Fatalf("empty proposal resulted in error: %v", err)
+	}
+}
+
 func TestCommit(t *testing.T) {
 	tests := []struct {
 		matches	[]uint64
@@ -754,7 +1197,7 @@ func TestCommit(t *testing.T) {
 
 		sm := newTestRaft(1, []uint64{1}, 5, 1, storage)
 		for j := 0; j < len(tt.matches); j++ {
-			sm.setProgress(uint64(j)+1, tt.matches[j], tt.matches[j]+1)
+			sm.setProgress(uint64(j)+1, tt.matches[j], tt.matches[j]+1, false)
 		}
 		sm.maybeCommit()
 		if g := sm.raftLog.committed; g != tt.w {
@@ -801,8 +1244,9 @@ func TestPastElectionTimeout(t *testing.T) {
 // actual stepX function.
 func TestStepIgnoreOldTermMsg(t *testing.T) {
 	called := false
-	fakeStep := func(r *raft, m pb.Message) {
+	fakeStep := func(r *raft, m pb.Message) error {
 		called = true
+		return nil
 	}
 	sm := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
 	sm.step = fakeStep
@@ -1083,6 +1527,73 @@ func TestRecvMsgVote(t *testing.T) {
 	}
 }
 
+func TestRecvMsgPreVote(t *testing.T) {
+	tests := []struct {
+		state	StateType
+		i, term	uint64
+		voteFor	uint64
+		wreject	bool
+	}{
+		{StateFollower, 0, 0, None, true},
+		{StateFollower, 2, 1, None, true},
+		{StateFollower, 2, 2, None, false},
+		{StateFollower, 3, 2, None, false},
+		{StateFollower, 1, 3, None, false},
+
+		// a pre-vote is for the next term, so a vote in the current
+		// term does not prevent granting it.
+		{StateFollower, 3, 2, 3, false},
+
+		{StateLeader, 3, 2, 1, false},
+		{StateCandidate, 3, 2, 1, false},
+		{StatePreCandidate, 3, 2, None, false},
+		{StatePreCandidate, 1, 2, None, true},
+	}
+
+	for i, tt := range tests {
+		sm := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
+		sm.state = tt.state
+		switch tt.state {
+		case StateFollower:
+			sm.step = stepFollower
+		case StateCandidate, StatePreCandidate:
+			sm.step = stepCandidate
+		case StateLeader:
+			sm.step = stepLeader
+		}
+		sm.Term = 2
+		sm.Vote = tt.voteFor
+		sm.raftLog = &raftLog{
+			storage:	&MemoryStorage{ents: []pb.Entry{{}, {Index: 1, Term: 2}, {Index: 2, Term: 2}}},
+			unstable:	unstable{offset: 3},
+		}
+
+		sm.Step(pb.Message{Type: pb.MsgPreVote, From: 2, Term: 3, Index: tt.i, LogTerm: tt.term})
+
+		msgs := sm.readMessages()
+		if g := len(msgs); g != 1 {
+			t.Fatalf("#%d: len(msgs) = %d, want 1", i, g)
+		}
+		if g := msgs[0].Type; g != pb.MsgPreVoteResp {
+			t.Errorf("#%d: m.Type = %s, want %s", i, g, pb.MsgPreVoteResp)
+		}
+		if g := msgs[0].Reject; g != tt.wreject {
+			t.Errorf("#%d: m.Reject = %v, want %v", i, g, tt.wreject)
+		}
+		wterm := uint64(3)
+		if tt.wreject {
+			wterm = 2
+		}
+		if g := msgs[0].Term; g != wterm {
+			t.Errorf("#%d: m.Term = %d, want %d", i, g, wterm)
+		}
+		// a pre-vote changes neither the term nor the vote.
+		if sm.Term != 2 || sm.Vote != tt.voteFor || sm.state != tt.state {
+			t.Errorf("#%d: term, vote, state = %d, %x, %s, want %d, %x, %s", i, sm.Term, sm.Vote, sm.state, 2, tt.voteFor, tt.state)
+		}
+	}
+}
+
 func TestStateTransition(t *testing.T) {
 	tests := []struct {
 		from	StateType
@@ -1102,6 +1613,15 @@ func TestStateTransition(t *testing.T) {
 		{StateLeader, StateFollower, true, 1, None},
 		{StateLeader, StateCandidate, false, 1, None},
 		{StateLeader, StateLeader, true, 0, 1},
+
+		{StateFollower, StatePreCandidate, true, 0, None},
+		{StateCandidate, StatePreCandidate, true, 0, None},
+		{StateLeader, StatePreCandidate, false, 0, None},
+
+		{StatePreCandidate, StateFollower, true, 0, None},
+		{StatePreCandidate, StatePreCandidate, true, 0, None},
+		{StatePreCandidate, StateCandidate, true, 1, None},
+		{StatePreCandidate, StateLeader, true, 0, 1},
 	}
 
 	for i, tt := range tests {
@@ -1122,6 +1642,8 @@ func TestStateTransition(t *testing.T) {
 				sm.becomeFollower(tt.wterm, tt.wlead)
 			case StateCandidate:
 				sm.becomeCandidate()
+			case StatePreCandidate:
+				sm.becomePreCandidate()
 			case StateLeader:
 				sm.becomeLeader()
 			}
@@ -1436,7 +1958,7 @@ func TestReadIndexWithCheckQuorum(t *testing.T) {
 		{c, 10, 41, []byte("ctx4")},
 	}
 
-	for _, tt := range tests {
+	for i, tt := range tests {
 		for j := 0; j < tt.proposals; j++ {
 			nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{}}})
 		}
@@ -1444,16 +1966,24 @@ func TestReadIndexWithCheckQuorum(t *testing.T) {
 		nt.send(pb.Message{From: tt.sm.id, To: tt.sm.id, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: tt.wctx}}})
 
 		r := tt.sm
-		if r.readState.Index != tt.wri {
-			t.Errorf("readIndex = %d, want %d", r.readState.Index, tt.wri)
+		if len(r.readStates) == 0 {
+			t.Fatalf("#%d: len(readStates) = 0, want non-zero", i)
+		}
+		rs := r.readStates[0]
+		if rs.Index != tt.wri {
+			t.Errorf("#%d: readIndex = %d, want %d", i, rs.Index, tt.wri)
 		}
 
-		if !bytes.Equal(r.readState.RequestCtx, tt.wctx) {
-			t.Errorf("requestCtx = %v, want %v", r.readState.RequestCtx, tt.wctx)
+		if !bytes.Equal(rs.RequestCtx, tt.wctx) {
+			t.Errorf("#%d: requestCtx = %v, want %v", i, rs.RequestCtx, tt.wctx)
 		}
+		r.readStates = nil
 	}
 }
 
+// TestReadIndexWithoutCheckQuorum ensures that the leader serves read index
+// requests without CheckQuorum by confirming its leadership with a round of
+// heartbeats.
 func TestReadIndexWithoutCheckQuorum(t *testing.T) {
 	a := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
 	b := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
@@ -1465,12 +1995,139 @@ func TestReadIndexWithoutCheckQuorum(t *testing.T) {
 	ctx := []byte("ctx1")
 	nt.send(pb.Message{From: 2, To: 2, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: ctx}}})
 
-	if b.readState.Index != None {
-		t.Errorf("readIndex = %d, want %d", b.readState.Index, None)
+	if len(b.readStates) != 1 {
+		t.Fatalf("len(readStates) = %d, want 1", len(b.readStates))
 	}
+	rs := b.readStates[0]
+	if rs.Index != a.raftLog.committed {
+		t.Errorf("readIndex = %d, want %d", rs.Index, a.raftLog.committed)
+	}
+
+	if !bytes.Equal(rs.RequestCtx, ctx) {
+		t.Errorf("requestCtx = %v, want %v", rs.RequestCtx, ctx)
+	}
+}
 
-	if !bytes.Equal(b.readState.RequestCtx, ctx) {
-		t.Errorf("requestCtx = %v, want %v", b.readState.RequestCtx, ctx)
+// TestReadOnlySafe ensures that with ReadOnlySafe the leader only releases a
+// read index once a quorum acknowledged a heartbeat sent after the request,
+// and that the acknowledgment also releases the requests before it.
+func TestReadOnlySafe(t *testing.T) {
+	a := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	b := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	c := newTestRaft(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+
+	nt := newNetwork(a, b, c)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if a.state != StateLeader {
+		t.Fatalf("state = %s, want %s", a.state, StateLeader)
+	}
+
+	// without the heartbeat responses, the reads stay pending.
+	nt.isolate(2)
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: []byte("ctx1")}}})
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: []byte("ctx2")}}})
+	if len(a.readStates) != 0 {
+		t.Fatalf("len(readStates) = %d, want 0", len(a.readStates))
+	}
+
+	// a single acknowledgment of the last heartbeat makes a quorum with the
+	// leader.
+	nt.recover()
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgBeat})
+	wrs := []ReadState{
+		{Index: a.raftLog.committed, RequestCtx: []byte("ctx1")},
+		{Index: a.raftLog.committed, RequestCtx: []byte("ctx2")},
+	}
+	if !reflect.DeepEqual(a.readStates, wrs) {
+		t.Errorf("readStates = %+v, want %+v", a.readStates, wrs)
+	}
+	if len(a.readOnly.pendingReadIndex) != 0 || len(a.readOnly.readIndexQueue) != 0 {
+		t.Errorf("pending reads = %d, want 0", len(a.readOnly.readIndexQueue))
+	}
+}
+
+// TestReadOnlyLeaseBased ensures that with ReadOnlyLeaseBased the leader
+// releases the read index without a round of heartbeats.
+func TestReadOnlyLeaseBased(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.CheckQuorum = true
+	cfg.ReadOnlyOption = ReadOnlyLeaseBased
+	a := newRaft(cfg)
+	b := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	c := newTestRaft(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+
+	nt := newNetwork(a, b, c)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if a.state != StateLeader {
+		t.Fatalf("state = %s, want %s", a.state, StateLeader)
+	}
+
+	nt.isolate(2)
+	nt.isolate(3)
+	ctx := []byte("ctx1")
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: ctx}}})
+	wrs := []ReadState{{Index: a.raftLog.committed, RequestCtx: ctx}}
+	if !reflect.DeepEqual(a.readStates, wrs) {
+		t.Errorf("readStates = %+v, want %+v", a.readStates, wrs)
+	}
+}
+
+// TestReadOnlyLeaseBasedRequiresCheckQuorum ensures that ReadOnlyLeaseBased
+// cannot be configured without CheckQuorum.
+func TestReadOnlyLeaseBasedRequiresCheckQuorum(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	cfg.ReadOnlyOption = ReadOnlyLeaseBased
+	if err := cfg.validate(); err == nil {
+		t.Errorf("err = nil, want an error")
+	}
+	cfg.CheckQuorum = true
+	if err := cfg.validate(); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
+
+// TestReadIndexOnLeader ensures that a read requested on the leader itself is
+// answered locally, and only once the leader has committed an entry in its
+// term.
+func TestReadIndexOnLeader(t *testing.T) {
+	a := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	b := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	c := newTestRaft(3, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	a.checkQuorum = true
+	b.checkQuorum = true
+	c.checkQuorum = true
+
+	nt := newNetwork(a, b, c)
+	for i := 0; i < b.electionTimeout; i++ {
+		b.tick()
+		c.tick()
+	}
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+	if a.state != StateLeader {
+		t.Fatalf("state = %s, want %s", a.state, StateLeader)
+	}
+
+	ctx := []byte("ctx1")
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: ctx}}})
+	wrs := []ReadState{{Index: a.raftLog.committed, RequestCtx: ctx}}
+	if !reflect.DeepEqual(a.readStates, wrs) {
+		t.Errorf("readStates = %+v, want %+v", a.readStates, wrs)
+	}
+	if len(a.msgs) != 0 {
+		t.Errorf("len(msgs) = %d, want 0", len(a.msgs))
+	}
+
+	// a new leader that has not committed an entry in its term drops the
+	// read.
+	a.readStates = nil
+	a.becomeFollower(a.Term+1, None)
+	a.becomeCandidate()
+	a.becomeLeader()
+	a.Step(pb.Message{From: 1, To: 1, Type: pb.MsgReadIndex, Entries: []pb.Entry{{Data: ctx}}})
+	if len(a.readStates) != 0 {
+		t.Errorf("len(readStates) = %d, want 0", len(a.readStates))
 	}
 }
 
@@ -1795,6 +2452,56 @@ func TestRestore(t *testing.T) {
 	}
 }
 
+func TestRestoreWithLearner(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: pb.SnapshotMetadata{
+			Index:     11, // magic number
+			Term:      11, // magic number
+			ConfState: pb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}},
+		},
+	}
+
+	storage := NewMemoryStorage()
+	sm := newTestLearnerRaft(3, []uint64{1, 2}, []uint64{3}, 10, 1, storage)
+	if ok := sm.restore(s); !ok {
+		t.Fatal("restore fail, want succeed")
+	}
+
+	if sg := sm.nodes(); !reflect.DeepEqual(sg, s.Metadata.ConfState.Nodes) {
+		t.Errorf("sm.Nodes = %+v, want %+v", sg, s.Metadata.ConfState.Nodes)
+	}
+	if sg := sm.learnerNodes(); !reflect.DeepEqual(sg, s.Metadata.ConfState.Learners) {
+		t.Errorf("sm.LearnerNodes = %+v, want %+v", sg, s.Metadata.ConfState.Learners)
+	}
+	if sm.promotable() {
+		t.Errorf("promotable = true, want false")
+	}
+}
+
+func TestRestoreWithWitness(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: pb.SnapshotMetadata{
+			Index:     11, // magic number
+			Term:      11, // magic number
+			ConfState: pb.ConfState{Nodes: []uint64{1, 2, 3}, Witnesses: []uint64{3}},
+		},
+	}
+
+	sm := newTestRaft(3, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	if ok := sm.restore(s); !ok {
+		t.Fatal("restore fail, want succeed")
+	}
+	if sg := sm.nodes(); !reflect.DeepEqual(sg, s.Metadata.ConfState.Nodes) {
+		t.Errorf("sm.Nodes = %+v, want %+v", sg, s.Metadata.ConfState.Nodes)
+	}
+	if sg := sm.witnessNodes(); !reflect.DeepEqual(sg, s.Metadata.ConfState.Witnesses) {
+		t.Errorf("sm.WitnessNodes = %+v, want %+v", sg, s.Metadata.ConfState.Witnesses)
+	}
+	if sm.promotable() {
+		t.Errorf("promotable = true, want false")
+	}
+}
+
 func TestRestoreIgnoreSnapshot(t *testing.T) {
 	previousEnts := []pb.Entry{{Term: 1, Index: 1}, {Term: 1, Index: 2}, {Term: 1, Index: 3}}
 	commit := uint64(1)
@@ -2034,6 +2741,81 @@ func TestAddNode(t *testing.T) {
 	}
 }
 
+// TestAddLearner tests that addLearner could update pendingConf and nodes
+// correctly, and that adding the learner again as a node promotes it.
+func TestAddLearner(t *testing.T) {
+	r := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	r.pendingConf = true
+	r.addLearner(2)
+	if r.pendingConf {
+		t.Errorf("pendingConf = %v, want false", r.pendingConf)
+	}
+	if nodes, wnodes := r.nodes(), []uint64{1}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("nodes = %v, want %v", nodes, wnodes)
+	}
+	if nodes, wnodes := r.learnerNodes(), []uint64{2}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("learnerNodes = %v, want %v", nodes, wnodes)
+	}
+
+	r.addNode(2)
+	if nodes, wnodes := r.nodes(), []uint64{1, 2}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("nodes = %v, want %v", nodes, wnodes)
+	}
+	if nodes := r.learnerNodes(); len(nodes) != 0 {
+		t.Errorf("learnerNodes = %v, want []", nodes)
+	}
+
+	// a voter is never demoted.
+	r.addLearner(2)
+	if r.isLearner(2) {
+		t.Errorf("isLearner(2) = true, want false")
+	}
+}
+
+func TestAddWitness(t *testing.T) {
+	r := newTestRaft(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	r.pendingConf = true
+	r.addWitness(2)
+	if r.pendingConf {
+		t.Errorf("pendingConf = %v, want false", r.pendingConf)
+	}
+	if nodes, wnodes := r.nodes(), []uint64{1, 2}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("nodes = %v, want %v", nodes, wnodes)
+	}
+	if nodes, wnodes := r.witnessNodes(), []uint64{2}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("witnessNodes = %v, want %v", nodes, wnodes)
+	}
+
+	// an existing member never becomes a witness.
+	r.addWitness(1)
+	if r.prs[1].IsWitness {
+		t.Errorf("peer 1 is witness, want not")
+	}
+	// a witness is kept across terms.
+	r.becomeCandidate()
+	r.becomeLeader()
+	if cs := r.confState(); !reflect.DeepEqual(cs.Witnesses, []uint64{2}) {
+		t.Errorf("confState witnesses = %v, want %v", cs.Witnesses, []uint64{2})
+	}
+}
+
+// TestRemoveLearner tests that removeNode could update pendingConf, nodes and
+// and removed list correctly.
+func TestRemoveLearner(t *testing.T) {
+	r := newTestLearnerRaft(1, []uint64{1}, []uint64{2}, 10, 1, NewMemoryStorage())
+	r.pendingConf = true
+	r.removeNode(2)
+	if r.pendingConf {
+		t.Errorf("pendingConf = %v, want false", r.pendingConf)
+	}
+	if nodes, wnodes := r.nodes(), []uint64{1}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("nodes = %v, want %v", nodes, wnodes)
+	}
+	if nodes := r.learnerNodes(); len(nodes) != 0 {
+		t.Errorf("learnerNodes = %v, want []", nodes)
+	}
+}
+
 // TestRemoveNode tests that removeNode could update pendingConf, nodes and
 // and removed list correctly.
 func TestRemoveNode(t *testing.T) {
@@ -2056,6 +2838,179 @@ func TestRemoveNode(t *testing.T) {
 	}
 }
 
+// TestJointConfig tests that a change of several nodes enters a joint
+// configuration, in which the removed voters stay until it is left.
+func TestJointConfig(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.pendingConf = true
+	r.applyConfChange(pb.ConfChangeV2{Changes: []pb.ConfChangeSingle{
+		{Type: pb.ConfChangeAddNode, NodeID: 4},
+		{Type: pb.ConfChangeRemoveNode, NodeID: 3},
+	}})
+	if r.pendingConf {
+		t.Errorf("pendingConf = %v, want false", r.pendingConf)
+	}
+	wcs := pb.ConfState{Nodes: []uint64{1, 2, 4}, Learners: []uint64{}, NodesJoint: []uint64{1, 2, 3}}
+	if cs := r.confState(); !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("confState = %+v, want %+v", cs, wcs)
+	}
+	if nodes, wnodes := r.allNodes(), []uint64{1, 2, 3, 4}; !reflect.DeepEqual(nodes, wnodes) {
+		t.Errorf("allNodes = %v, want %v", nodes, wnodes)
+	}
+
+	// no other change is made while joint.
+	r.applyConfChange(pb.ConfChange{Type: pb.ConfChangeAddNode, NodeID: 5}.AsV2())
+	if cs := r.confState(); !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("confState = %+v, want %+v", cs, wcs)
+	}
+
+	r.applyConfChange(pb.ConfChangeV2{})
+	wcs = pb.ConfState{Nodes: []uint64{1, 2, 4}, Learners: []uint64{}}
+	if cs := r.confState(); !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("confState = %+v, want %+v", cs, wcs)
+	}
+	if _, ok := r.prs[3]; ok {
+		t.Errorf("progress of 3 is kept after leaving the joint configuration")
+	}
+}
+
+// TestJointConfigCommit tests that an entry is committed in a joint
+// configuration only with a quorum of both the incoming and the outgoing
+// voters.
+func TestJointConfigCommit(t *testing.T) {
+	tests := []struct {
+		matches	map[uint64]uint64
+		wcommit	uint64
+	}{
+		// a quorum of the incoming voters only
+		{map[uint64]uint64{4: 2, 5: 2}, 1},
+		// a quorum of the outgoing voters only
+		{map[uint64]uint64{2: 2, 3: 2}, 1},
+		{map[uint64]uint64{2: 2, 4: 2}, 2},
+		{map[uint64]uint64{2: 2, 3: 1, 4: 2, 5: 1}, 2},
+	}
+	for i, tt := range tests {
+		r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		r.becomeCandidate()
+		r.becomeLeader()
+		r.applyConfChange(pb.ConfChangeV2{Changes: []pb.ConfChangeSingle{
+			{Type: pb.ConfChangeAddNode, NodeID: 4},
+			{Type: pb.ConfChangeAddNode, NodeID: 5},
+			{Type: pb.ConfChangeRemoveNode, NodeID: 2},
+			{Type: pb.ConfChangeRemoveNode, NodeID: 3},
+		}})
+		r.raftLog.committed = 1
+		r.prs[1].Match = 2
+		for id, m := range tt.matches {
+			r.prs[id].Match = m
+		}
+		r.maybeCommit()
+		if r.raftLog.committed != tt.wcommit {
+			t.Errorf("#%d: committed = %d, want %d", i, r.raftLog.committed, tt.wcommit)
+		}
+	}
+}
+
+// TestJointConfigVote tests that a candidate in a joint configuration needs
+// the votes of a quorum of both the incoming and the outgoing voters.
+func TestJointConfigVote(t *testing.T) {
+	tests := []struct {
+		votes	map[uint64]bool
+		w	voteResultType
+	}{
+		{map[uint64]bool{1: true, 4: true}, votePending},
+		{map[uint64]bool{1: true, 2: true}, votePending},
+		{map[uint64]bool{1: true, 2: true, 4: true}, voteWon},
+		{map[uint64]bool{1: true, 2: false, 3: false}, voteLost},
+		{map[uint64]bool{1: true, 4: false, 5: false}, voteLost},
+	}
+	for i, tt := range tests {
+		r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		r.applyConfChange(pb.ConfChangeV2{Changes: []pb.ConfChangeSingle{
+			{Type: pb.ConfChangeAddNode, NodeID: 4},
+			{Type: pb.ConfChangeAddNode, NodeID: 5},
+			{Type: pb.ConfChangeRemoveNode, NodeID: 2},
+			{Type: pb.ConfChangeRemoveNode, NodeID: 3},
+		}})
+		r.votes = tt.votes
+		if g := r.voteResult(); g != tt.w {
+			t.Errorf("#%d: voteResult = %d, want %d", i, g, tt.w)
+		}
+	}
+}
+
+// TestJointConfigLeave tests that the leader appends the change that leaves
+// the joint configuration when it enters it or is elected in it, and that it
+// drops other configuration changes until then.
+func TestJointConfigLeave(t *testing.T) {
+	leave := pb.ConfChangeV2{}
+	wdata, err := leave.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enter := pb.ConfChangeV2{Changes: []pb.ConfChangeSingle{
+		{Type: pb.ConfChangeAddNode, NodeID: 4},
+		{Type: pb.ConfChangeRemoveNode, NodeID: 3},
+	}}
+
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.applyConfChange(enter)
+	wents := []pb.Entry{{Type: pb.EntryConfChangeV2, Term: 1, Index: 2, Data: wdata}}
+	ents, err := r.raftLog.entries(2, noLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ents, wents) {
+		t.Errorf("ents = %+v, want %+v", ents, wents)
+	}
+	if !r.pendingConf {
+		t.Errorf("pendingConf = %v, want true", r.pendingConf)
+	}
+
+	// the leave is not pending anymore once applied, but the next change
+	// is dropped until the joint configuration is left.
+	r.pendingConf = false
+	r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{Type: pb.EntryConfChange}}})
+	if ents, _ = r.raftLog.entries(3, noLimit); ents[0].Type != pb.EntryNormal {
+		t.Errorf("type = %v, want %v", ents[0].Type, pb.EntryNormal)
+	}
+
+	// a leader elected in the joint configuration leaves it.
+	r = newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.applyConfChange(enter)
+	r.becomeCandidate()
+	r.becomeLeader()
+	wents = []pb.Entry{{Term: 1, Index: 1}, {Type: pb.EntryConfChangeV2, Term: 1, Index: 2, Data: wdata}}
+	if ents, _ = r.raftLog.entries(1, noLimit); !reflect.DeepEqual(ents, wents) {
+		t.Errorf("ents = %+v, want %+v", ents, wents)
+	}
+}
+
+// TestRestoreJointConfig tests that a joint configuration is restored from a
+// snapshot.
+func TestRestoreJointConfig(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: pb.SnapshotMetadata{
+			Index:     11,
+			Term:      11,
+			ConfState: pb.ConfState{Nodes: []uint64{1, 2, 4}, NodesJoint: []uint64{1, 2, 3}},
+		},
+	}
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	if ok := r.restore(s); !ok {
+		t.Fatal("restore fail, want succeed")
+	}
+	wcs := pb.ConfState{Nodes: []uint64{1, 2, 4}, Learners: []uint64{}, NodesJoint: []uint64{1, 2, 3}}
+	if cs := r.confState(); !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("confState = %+v, want %+v", cs, wcs)
+	}
+	if !r.prs[3].Leaving {
+		t.Errorf("leaving = false, want true")
+	}
+}
+
 func TestPromotable(t *testing.T) {
 	id := uint64(1)
 	tests := []struct {
@@ -2208,6 +3163,20 @@ func TestLeaderTransferToUpToDateNode(t *testing.T) {
 	checkLeaderTransferState(t, lead, StateLeader, 1)
 }
 
+// TestLeaderTransferByFollower verifies a follower forwards a transfer request
+// to the leader.
+func TestLeaderTransferByFollower(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	lead := nt.peers[1].(*raft)
+
+	// Ask follower 2 to transfer leadership to 3.
+	nt.send(pb.Message{From: 3, To: 2, Type: pb.MsgTransferLeader})
+
+	checkLeaderTransferState(t, lead, StateFollower, 3)
+}
+
 func TestLeaderTransferToSlowFollower(t *testing.T) {
 	defaultLogger.EnableDebug()
 	nt := newNetwork(nil, nil, nil)
@@ -2316,6 +3285,10 @@ func TestLeaderTransferIgnoreProposal(t *testing.T) {
 	}
 
 	nt.send(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{}}})
+	err := lead.Step(pb.Message{From: 1, To: 1, Type: pb.MsgProp, Entries: []pb.Entry{{}}})
+	if err != ErrProposalDropped {
+		t.Fatalf("should return drop proposal error while transferring")
+	}
 
 	if lead.prs[1].Match != 1 {
 		t.Fatalf("node 1 has match %x, want %x", lead.prs[1].Match, 1)
@@ -2474,10 +3447,16 @@ func newNetwork(peers ...stateMachine) *network {
 			sm := newTestRaft(id, peerAddrs, 10, 1, nstorage[id])
 			npeers[id] = sm
 		case *raft:
+			learners := make(map[uint64]bool)
+			for i, pr := range v.prs {
+				if pr.IsLearner {
+					learners[i] = true
+				}
+			}
 			v.id = id
 			v.prs = make(map[uint64]*Progress)
 			for i := 0; i < size; i++ {
-				v.prs[peerAddrs[i]] = &Progress{}
+				v.prs[peerAddrs[i]] = &Progress{IsLearner: learners[peerAddrs[i]]}
 			}
 			v.reset(0)
 			npeers[id] = v
@@ -2495,6 +3474,18 @@ func newNetwork(peers ...stateMachine) *network {
 	}
 }
 
+// newPreVoteNetwork is like newNetwork, with pre-vote enabled on every
+// raft peer.
+func newPreVoteNetwork(peers ...stateMachine) *network {
+	nw := newNetwork(peers...)
+	for _, p := range nw.peers {
+		if sm, ok := p.(*raft); ok {
+			sm.preVote = true
+		}
+	}
+	return nw
+}
+
 func (nw *network) send(msgs ...pb.Message) {
 	for len(msgs) > 0 {
 		m := msgs[0]
@@ -2587,3 +3578,9 @@ func newTestConfig(id uint64, peers []uint64, election, heartbeat int, storage S
 func newTestRaft(id uint64, peers []uint64, election, heartbeat int, storage Storage) *raft {
 	return newRaft(newTestConfig(id, peers, election, heartbeat, storage))
 }
+
+func newTestLearnerRaft(id uint64, peers []uint64, learners []uint64, election, heartbeat int, storage Storage) *raft {
+	cfg := newTestConfig(id, peers, election, heartbeat, storage)
+	cfg.learners = learners
+	return newRaft(cfg)
+}
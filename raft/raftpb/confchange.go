@@ -0,0 +1,62 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftpb
+
+// ConfChangeI is a configuration change, either a ConfChange or a
+// ConfChangeV2.
+type ConfChangeI interface {
+	// AsV2 returns the change as a ConfChangeV2.
+	AsV2() ConfChangeV2
+	// AsV1 returns the change as a ConfChange, and false if it cannot be
+	// expressed as one.
+	AsV1() (ConfChange, bool)
+}
+
+// AsV2 returns a ConfChangeV2 making the single change of c.
+func (c ConfChange) AsV2() ConfChangeV2 {
+	return ConfChangeV2{
+		ID:      c.ID,
+		Changes: []ConfChangeSingle{{Type: c.Type, NodeID: c.NodeID}},
+		Context: c.Context,
+	}
+}
+
+// AsV1 returns c.
+func (c ConfChange) AsV1() (ConfChange, bool) { return c, true }
+
+// AsV2 returns c.
+func (c ConfChangeV2) AsV2() ConfChangeV2 { return c }
+
+// AsV1 returns false; a ConfChangeV2 is always proposed as such.
+func (c ConfChangeV2) AsV1() (ConfChange, bool) { return ConfChange{}, false }
+
+// EnterJoint returns true if c enters a joint configuration, which it does
+// when it makes more than one change.
+func (c ConfChangeV2) EnterJoint() bool { return len(c.Changes) > 1 }
+
+// LeaveJoint returns true if c leaves a joint configuration, which it does
+// when it makes no change.
+func (c ConfChangeV2) LeaveJoint() bool { return len(c.Changes) == 0 }
+
+// MarshalConfChange returns the type and the data of the entry proposing c.
+func MarshalConfChange(c ConfChangeI) (EntryType, []byte, error) {
+	if ccv1, ok := c.AsV1(); ok {
+		data, err := ccv1.Marshal()
+		return EntryConfChange, data, err
+	}
+	ccv2 := c.AsV2()
+	data, err := ccv2.Marshal()
+	return EntryConfChangeV2, data, err
+}
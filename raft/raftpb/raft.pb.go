@@ -16,6 +16,8 @@
 		HardState
 		ConfState
 		ConfChange
+		ConfChangeSingle
+		ConfChangeV2
 */
 package raftpb
 
@@ -41,17 +43,20 @@ const _ = proto.ProtoPackageIsVersion1
 type EntryType int32
 
 const (
-	EntryNormal     EntryType = 0
-	EntryConfChange EntryType = 1
+	EntryNormal       EntryType = 0
+	EntryConfChange   EntryType = 1
+	EntryConfChangeV2 EntryType = 2
 )
 
 var EntryType_name = map[int32]string{
 	0: "EntryNormal",
 	1: "EntryConfChange",
+	2: "EntryConfChangeV2",
 }
 var EntryType_value = map[string]int32{
-	"EntryNormal":     0,
-	"EntryConfChange": 1,
+	"EntryNormal":       0,
+	"EntryConfChange":   1,
+	"EntryConfChangeV2": 2,
 }
 
 func (x EntryType) Enum() *EntryType {
@@ -92,6 +97,10 @@ const (
 	MsgTimeoutNow     MessageType = 14
 	MsgReadIndex      MessageType = 15
 	MsgReadIndexResp  MessageType = 16
+	MsgCutMarker      MessageType = 17
+	MsgCutState       MessageType = 18
+	MsgPreVote        MessageType = 19
+	MsgPreVoteResp    MessageType = 20
 )
 
 var MessageType_name = map[int32]string{
@@ -112,6 +121,10 @@ var MessageType_name = map[int32]string{
 	14: "MsgTimeoutNow",
 	15: "MsgReadIndex",
 	16: "MsgReadIndexResp",
+	17: "MsgCutMarker",
+	18: "MsgCutState",
+	19: "MsgPreVote",
+	20: "MsgPreVoteResp",
 }
 var MessageType_value = map[string]int32{
 	"MsgHup":            0,
@@ -131,6 +144,10 @@ var MessageType_value = map[string]int32{
 	"MsgTimeoutNow":     14,
 	"MsgReadIndex":      15,
 	"MsgReadIndexResp":  16,
+	"MsgCutMarker":      17,
+	"MsgCutState":       18,
+	"MsgPreVote":        19,
+	"MsgPreVoteResp":    20,
 }
 
 func (x MessageType) Enum() *MessageType {
@@ -154,20 +171,26 @@ func (MessageType) EnumDescriptor() ([]byte, []int) { return fileDescriptorRaft,
 type ConfChangeType int32
 
 const (
-	ConfChangeAddNode    ConfChangeType = 0
-	ConfChangeRemoveNode ConfChangeType = 1
-	ConfChangeUpdateNode ConfChangeType = 2
+	ConfChangeAddNode        ConfChangeType = 0
+	ConfChangeRemoveNode     ConfChangeType = 1
+	ConfChangeUpdateNode     ConfChangeType = 2
+	ConfChangeAddLearnerNode ConfChangeType = 3
+	ConfChangeAddWitnessNode ConfChangeType = 4
 )
 
 var ConfChangeType_name = map[int32]string{
 	0: "ConfChangeAddNode",
 	1: "ConfChangeRemoveNode",
 	2: "ConfChangeUpdateNode",
+	3: "ConfChangeAddLearnerNode",
+	4: "ConfChangeAddWitnessNode",
 }
 var ConfChangeType_value = map[string]int32{
-	"ConfChangeAddNode":    0,
-	"ConfChangeRemoveNode": 1,
-	"ConfChangeUpdateNode": 2,
+	"ConfChangeAddNode":        0,
+	"ConfChangeRemoveNode":     1,
+	"ConfChangeUpdateNode":     2,
+	"ConfChangeAddLearnerNode": 3,
+	"ConfChangeAddWitnessNode": 4,
 }
 
 func (x ConfChangeType) Enum() *ConfChangeType {
@@ -259,6 +282,9 @@ func (*HardState) Descriptor() ([]byte, []int) { return fileDescriptorRaft, []in
 
 type ConfState struct {
 	Nodes            []uint64 `protobuf:"varint,1,rep,name=nodes" json:"nodes,omitempty"`
+	Learners         []uint64 `protobuf:"varint,2,rep,name=learners" json:"learners,omitempty"`
+	NodesJoint       []uint64 `protobuf:"varint,3,rep,name=nodes_joint,json=nodesJoint" json:"nodes_joint,omitempty"`
+	Witnesses        []uint64 `protobuf:"varint,4,rep,name=witnesses" json:"witnesses,omitempty"`
 	XXX_unrecognized []byte   `json:"-"`
 }
 
@@ -280,6 +306,29 @@ func (m *ConfChange) String() string            { return proto.CompactTextString
 func (*ConfChange) ProtoMessage()               {}
 func (*ConfChange) Descriptor() ([]byte, []int) { return fileDescriptorRaft, []int{6} }
 
+type ConfChangeSingle struct {
+	Type             ConfChangeType `protobuf:"varint,1,opt,name=Type,json=type,enum=raftpb.ConfChangeType" json:"Type"`
+	NodeID           uint64         `protobuf:"varint,2,opt,name=NodeID,json=nodeID" json:"NodeID"`
+	XXX_unrecognized []byte         `json:"-"`
+}
+
+func (m *ConfChangeSingle) Reset()                    { *m = ConfChangeSingle{} }
+func (m *ConfChangeSingle) String() string            { return proto.CompactTextString(m) }
+func (*ConfChangeSingle) ProtoMessage()               {}
+func (*ConfChangeSingle) Descriptor() ([]byte, []int) { return fileDescriptorRaft, []int{7} }
+
+type ConfChangeV2 struct {
+	ID               uint64             `protobuf:"varint,1,opt,name=ID,json=iD" json:"ID"`
+	Changes          []ConfChangeSingle `protobuf:"bytes,2,rep,name=Changes,json=changes" json:"Changes"`
+	Context          []byte             `protobuf:"bytes,3,opt,name=Context,json=context" json:"Context,omitempty"`
+	XXX_unrecognized []byte             `json:"-"`
+}
+
+func (m *ConfChangeV2) Reset()                    { *m = ConfChangeV2{} }
+func (m *ConfChangeV2) String() string            { return proto.CompactTextString(m) }
+func (*ConfChangeV2) ProtoMessage()               {}
+func (*ConfChangeV2) Descriptor() ([]byte, []int) { return fileDescriptorRaft, []int{8} }
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "raftpb.Entry")
 	proto.RegisterType((*SnapshotMetadata)(nil), "raftpb.SnapshotMetadata")
@@ -288,6 +337,8 @@ func init() {
 	proto.RegisterType((*HardState)(nil), "raftpb.HardState")
 	proto.RegisterType((*ConfState)(nil), "raftpb.ConfState")
 	proto.RegisterType((*ConfChange)(nil), "raftpb.ConfChange")
+	proto.RegisterType((*ConfChangeSingle)(nil), "raftpb.ConfChangeSingle")
+	proto.RegisterType((*ConfChangeV2)(nil), "raftpb.ConfChangeV2")
 	proto.RegisterEnum("raftpb.EntryType", EntryType_name, EntryType_value)
 	proto.RegisterEnum("raftpb.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterEnum("raftpb.ConfChangeType", ConfChangeType_name, ConfChangeType_value)
@@ -529,6 +580,27 @@ func (m *ConfState) MarshalTo(data []byte) (int, error) {
 			i = encodeVarintRaft(data, i, uint64(num))
 		}
 	}
+	if len(m.Learners) > 0 {
+		for _, num := range m.Learners {
+			data[i] = 0x10
+			i++
+			i = encodeVarintRaft(data, i, uint64(num))
+		}
+	}
+	if len(m.NodesJoint) > 0 {
+		for _, num := range m.NodesJoint {
+			data[i] = 0x18
+			i++
+			i = encodeVarintRaft(data, i, uint64(num))
+		}
+	}
+	if len(m.Witnesses) > 0 {
+		for _, num := range m.Witnesses {
+			data[i] = 0x20
+			i++
+			i = encodeVarintRaft(data, i, uint64(num))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -571,6 +643,75 @@ func (m *ConfChange) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ConfChangeSingle) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ConfChangeSingle) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintRaft(data, i, uint64(m.Type))
+	data[i] = 0x10
+	i++
+	i = encodeVarintRaft(data, i, uint64(m.NodeID))
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConfChangeV2) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ConfChangeV2) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintRaft(data, i, uint64(m.ID))
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			data[i] = 0x12
+			i++
+			i = encodeVarintRaft(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Context != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintRaft(data, i, uint64(len(m.Context)))
+		i += copy(data[i:], m.Context)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeFixed64Raft(data []byte, offset int, v uint64) int {
 	data[offset] = uint8(v)
 	data[offset+1] = uint8(v >> 8)
@@ -692,6 +833,21 @@ func (m *ConfState) Size() (n int) {
 			n += 1 + sovRaft(uint64(e))
 		}
 	}
+	if len(m.Learners) > 0 {
+		for _, e := range m.Learners {
+			n += 1 + sovRaft(uint64(e))
+		}
+	}
+	if len(m.NodesJoint) > 0 {
+		for _, e := range m.NodesJoint {
+			n += 1 + sovRaft(uint64(e))
+		}
+	}
+	if len(m.Witnesses) > 0 {
+		for _, e := range m.Witnesses {
+			n += 1 + sovRaft(uint64(e))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -714,6 +870,37 @@ func (m *ConfChange) Size() (n int) {
 	return n
 }
 
+func (m *ConfChangeSingle) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovRaft(uint64(m.Type))
+	n += 1 + sovRaft(uint64(m.NodeID))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfChangeV2) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovRaft(uint64(m.ID))
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovRaft(uint64(l))
+		}
+	}
+	if m.Context != nil {
+		l = len(m.Context)
+		n += 1 + l + sovRaft(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovRaft(x uint64) (n int) {
 	for {
 		n++
@@ -1569,6 +1756,66 @@ func (m *ConfState) Unmarshal(data []byte) error {
 				}
 			}
 			m.Nodes = append(m.Nodes, v)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learners", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Learners = append(m.Learners, v)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodesJoint", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NodesJoint = append(m.NodesJoint, v)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witnesses", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Witnesses = append(m.Witnesses, v)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaft(data[iNdEx:])
@@ -1730,6 +1977,227 @@ func (m *ConfChange) Unmarshal(data []byte) error {
 	}
 	return nil
 }
+func (m *ConfChangeSingle) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaft
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfChangeSingle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfChangeSingle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Type |= (ConfChangeType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			m.NodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NodeID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaft(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaft
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfChangeV2) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaft
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfChangeV2: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfChangeV2: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaft
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, ConfChangeSingle{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaft
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaft
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Context = append(m.Context[:0], data[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaft(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaft
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipRaft(data []byte) (n int, err error) {
 	l := len(data)
 	iNdEx := 0
@@ -1836,54 +2304,63 @@ var (
 )
 
 var fileDescriptorRaft = []byte{
-	// 776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x54, 0xcd, 0x6e, 0xdb, 0x38,
-	0x10, 0xb6, 0x64, 0xf9, 0x6f, 0xe4, 0x38, 0x0c, 0xe3, 0x5d, 0x10, 0x41, 0xe0, 0xf5, 0x1a, 0x7b,
-	0x30, 0xb2, 0x48, 0x76, 0xd7, 0x87, 0x3d, 0xf4, 0x96, 0xd8, 0x05, 0x12, 0xa0, 0x0e, 0x5a, 0xc7,
-	0xe9, 0xa1, 0x45, 0x51, 0x30, 0x16, 0x2d, 0xbb, 0x8d, 0x44, 0x81, 0xa2, 0xd3, 0xe4, 0x52, 0xf4,
-	0x01, 0xfa, 0x00, 0xbd, 0xf4, 0x19, 0xfa, 0x1a, 0x39, 0xe6, 0x09, 0x8a, 0x26, 0x7d, 0x91, 0x82,
-	0x14, 0x65, 0x4b, 0xf1, 0x8d, 0xfc, 0xbe, 0xe1, 0xcc, 0x37, 0xdf, 0x8c, 0x04, 0x20, 0xe8, 0x54,
-	0x1e, 0x44, 0x82, 0x4b, 0x8e, 0xcb, 0xea, 0x1c, 0x5d, 0xec, 0x34, 0x7d, 0xee, 0x73, 0x0d, 0xfd,
-	0xa3, 0x4e, 0x09, 0xdb, 0xf9, 0x08, 0xa5, 0xa7, 0xa1, 0x14, 0x37, 0xf8, 0x6f, 0x70, 0xc6, 0x37,
-	0x11, 0x23, 0x56, 0xdb, 0xea, 0x36, 0x7a, 0x5b, 0x07, 0xc9, 0xab, 0x03, 0x4d, 0x2a, 0xe2, 0xc8,
-	0xb9, 0xfd, 0xfe, 0x47, 0x61, 0xe4, 0xc8, 0x9b, 0x88, 0x61, 0x02, 0xce, 0x98, 0x89, 0x80, 0xd8,
-	0x6d, 0xab, 0xeb, 0x2c, 0x19, 0x26, 0x02, 0xbc, 0x03, 0xa5, 0x93, 0xd0, 0x63, 0xd7, 0xa4, 0x98,
-	0xa1, 0x4a, 0x73, 0x05, 0x61, 0x0c, 0xce, 0x80, 0x4a, 0x4a, 0x9c, 0xb6, 0xd5, 0xad, 0x8f, 0x1c,
-	0x8f, 0x4a, 0xda, 0xf9, 0x64, 0x01, 0x3a, 0x0b, 0x69, 0x14, 0xcf, 0xb8, 0x1c, 0x32, 0x49, 0x15,
-	0x88, 0xff, 0x07, 0x98, 0xf0, 0x70, 0xfa, 0x36, 0x96, 0x54, 0x26, 0x8a, 0xdc, 0x95, 0xa2, 0x3e,
-	0x0f, 0xa7, 0x67, 0x8a, 0x30, 0xc9, 0x6b, 0x93, 0x14, 0x50, 0xc5, 0x75, 0xa5, 0x9c, 0x2e, 0x53,
-	0x9c, 0x80, 0x16, 0x98, 0xd3, 0xa5, 0x91, 0xce, 0x2b, 0xa8, 0xa6, 0x0a, 0x94, 0x44, 0xa5, 0x40,
-	0xd7, 0x34, 0x12, 0xf1, 0x13, 0xa8, 0x06, 0x46, 0x99, 0x4e, 0xec, 0xf6, 0x48, 0xaa, 0xe5, 0xb1,
-	0x72, 0x93, 0x77, 0x19, 0xdf, 0xf9, 0x5a, 0x84, 0xca, 0x90, 0xc5, 0x31, 0xf5, 0x19, 0xde, 0x07,
-	0x6d, 0x9e, 0x71, 0x78, 0x3b, 0xcd, 0x61, 0xe8, 0x35, 0x8f, 0x9b, 0x60, 0x4b, 0x9e, 0xeb, 0xc4,
-	0x96, 0x5c, 0xb5, 0x31, 0x15, 0xfc, 0x51, 0x1b, 0x0a, 0x59, 0x36, 0xe8, 0xac, 0xcd, 0xa4, 0x05,
-	0x95, 0x4b, 0xee, 0xeb, 0x81, 0x95, 0x32, 0x64, 0x0a, 0xae, 0x6c, 0x2b, 0xaf, 0xdb, 0xb6, 0x0f,
-	0x15, 0x16, 0x4a, 0x31, 0x67, 0x31, 0xa9, 0xb4, 0x8b, 0x5d, 0xb7, 0xb7, 0x91, 0xdb, 0x8c, 0x34,
-	0x95, 0x89, 0xc1, 0xbb, 0x50, 0x9e, 0xf0, 0x20, 0x98, 0x4b, 0x52, 0xcd, 0xe4, 0x32, 0x18, 0xee,
-	0x41, 0x35, 0x36, 0x8e, 0x91, 0x9a, 0x76, 0x12, 0x3d, 0x76, 0x32, 0x75, 0x30, 0x8d, 0x53, 0x19,
-	0x05, 0x7b, 0xc7, 0x26, 0x92, 0x40, 0xdb, 0xea, 0x56, 0xd3, 0x8c, 0x09, 0x86, 0xff, 0x02, 0x48,
-	0x4e, 0xc7, 0xf3, 0x50, 0x12, 0x37, 0x53, 0x33, 0x83, 0x63, 0x02, 0x95, 0x09, 0x0f, 0x25, 0xbb,
-	0x96, 0xa4, 0xae, 0x07, 0x9b, 0x5e, 0x3b, 0x6f, 0xa0, 0x76, 0x4c, 0x85, 0x97, 0xac, 0x4f, 0xea,
-	0xa0, 0xb5, 0xe6, 0x20, 0x01, 0xe7, 0x8a, 0x4b, 0x96, 0xdf, 0x77, 0x85, 0x64, 0x1a, 0x2e, 0xae,
-	0x37, 0xdc, 0xf9, 0x13, 0x6a, 0xcb, 0x75, 0xc5, 0x4d, 0x28, 0x85, 0xdc, 0x63, 0x31, 0xb1, 0xda,
-	0xc5, 0xae, 0x33, 0x4a, 0x2e, 0x9d, 0xcf, 0x16, 0x80, 0x8a, 0xe9, 0xcf, 0x68, 0xe8, 0xeb, 0xa9,
-	0x9f, 0x0c, 0x72, 0x0a, 0xec, 0xf9, 0x00, 0xff, 0x6b, 0x3e, 0x4e, 0x5b, 0xaf, 0xce, 0xef, 0xd9,
-	0x4f, 0x21, 0x79, 0xb7, 0xb6, 0x3d, 0xbb, 0x50, 0x3e, 0xe5, 0x1e, 0x3b, 0x19, 0xe4, 0x75, 0x85,
-	0x1a, 0x53, 0x86, 0xf4, 0x8d, 0x21, 0x4e, 0xce, 0x90, 0xbd, 0xff, 0xa0, 0xb6, 0xfc, 0xe4, 0xf1,
-	0x26, 0xb8, 0xfa, 0x72, 0xca, 0x45, 0x40, 0x2f, 0x51, 0x01, 0x6f, 0xc3, 0xa6, 0x06, 0x56, 0x85,
-	0x91, 0xb5, 0xf7, 0xcd, 0x06, 0x37, 0xb3, 0xc4, 0x18, 0xa0, 0x3c, 0x8c, 0xfd, 0xe3, 0x45, 0x84,
-	0x0a, 0xd8, 0x85, 0xca, 0x30, 0xf6, 0x8f, 0x18, 0x95, 0xc8, 0x32, 0x97, 0xe7, 0x82, 0x47, 0xc8,
-	0x36, 0x51, 0x87, 0x51, 0x84, 0x8a, 0xb8, 0x01, 0x90, 0x9c, 0x47, 0x2c, 0x8e, 0x90, 0x63, 0x02,
-	0x5f, 0x72, 0xc9, 0x50, 0x49, 0x89, 0x30, 0x17, 0xcd, 0x96, 0x0d, 0xab, 0x16, 0x06, 0x55, 0x30,
-	0x82, 0xba, 0x2a, 0xc6, 0xa8, 0x90, 0x17, 0xaa, 0x4a, 0x15, 0x37, 0x01, 0x65, 0x11, 0xfd, 0xa8,
-	0x86, 0x31, 0x34, 0x86, 0xb1, 0x7f, 0x1e, 0x0a, 0x46, 0x27, 0x33, 0x7a, 0x71, 0xc9, 0x10, 0xe0,
-	0x2d, 0xd8, 0x30, 0x89, 0xd4, 0x80, 0x16, 0x31, 0x72, 0x4d, 0x58, 0x7f, 0xc6, 0x26, 0xef, 0x5f,
-	0x2c, 0xb8, 0x58, 0x04, 0xa8, 0x8e, 0x7f, 0x83, 0xad, 0x61, 0xec, 0x8f, 0x05, 0x0d, 0xe3, 0x29,
-	0x13, 0xcf, 0x18, 0xf5, 0x98, 0x40, 0x1b, 0xe6, 0xf5, 0x78, 0x1e, 0x30, 0xbe, 0x90, 0xa7, 0xfc,
-	0x03, 0x6a, 0x18, 0x31, 0x23, 0x46, 0x3d, 0xfd, 0x0f, 0x44, 0x9b, 0x46, 0xcc, 0x12, 0xd1, 0x62,
-	0xd0, 0xde, 0x6b, 0x68, 0xe4, 0x47, 0xa7, 0x6a, 0xac, 0x90, 0x43, 0xcf, 0x53, 0xb3, 0x43, 0x05,
-	0x4c, 0xa0, 0xb9, 0x82, 0x47, 0x2c, 0xe0, 0x57, 0x4c, 0x33, 0x56, 0x9e, 0x39, 0x8f, 0x3c, 0x2a,
-	0x13, 0xc6, 0x3e, 0x22, 0xb7, 0xf7, 0xad, 0xc2, 0xdd, 0x7d, 0xab, 0x70, 0xfb, 0xd0, 0xb2, 0xee,
-	0x1e, 0x5a, 0xd6, 0x8f, 0x87, 0x96, 0xf5, 0xe5, 0x67, 0xab, 0xf0, 0x2b, 0x00, 0x00, 0xff, 0xff,
-	0xdc, 0x70, 0xcb, 0xda, 0x16, 0x06, 0x00, 0x00,
+	// 923 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x55, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x16, 0x29, 0xea, 0xdf, 0x48, 0x96, 0x57, 0x6b, 0xfd, 0x7e, 0x58, 0x04, 0x86, 0x22, 0x08,
+	0x3d, 0x08, 0x2e, 0xe2, 0x14, 0x3a, 0xf4, 0xd0, 0x5b, 0x2c, 0x17, 0xb0, 0x8b, 0xc8, 0x48, 0x65,
+	0xc5, 0x05, 0x0a, 0x14, 0xc1, 0x5a, 0x1c, 0xd1, 0x4a, 0x24, 0x2e, 0xb1, 0x5c, 0x25, 0xf1, 0xa5,
+	0xe8, 0x03, 0xf4, 0xde, 0x5e, 0xfa, 0x3e, 0x3e, 0xe6, 0x09, 0x8a, 0xc6, 0x7d, 0x87, 0x9e, 0x8b,
+	0x5d, 0x2e, 0x45, 0x52, 0x02, 0x7a, 0xdb, 0xfd, 0xbe, 0xd9, 0x99, 0x6f, 0x3e, 0xce, 0x48, 0x00,
+	0x92, 0x2f, 0xd4, 0x69, 0x24, 0x85, 0x12, 0xb4, 0xaa, 0xcf, 0xd1, 0xed, 0x93, 0x6e, 0x20, 0x02,
+	0x61, 0xa0, 0xe7, 0xfa, 0x94, 0xb0, 0x83, 0x9f, 0xa1, 0xf2, 0x6d, 0xa8, 0xe4, 0x3d, 0xfd, 0x12,
+	0xbc, 0xd9, 0x7d, 0x84, 0xcc, 0xe9, 0x3b, 0xc3, 0xf6, 0xa8, 0x73, 0x9a, 0xbc, 0x3a, 0x35, 0xa4,
+	0x26, 0xce, 0xbc, 0x87, 0x3f, 0x9f, 0x96, 0xa6, 0x9e, 0xba, 0x8f, 0x90, 0x32, 0xf0, 0x66, 0x28,
+	0xd7, 0xcc, 0xed, 0x3b, 0x43, 0x6f, 0xcb, 0xa0, 0x5c, 0xd3, 0x27, 0x50, 0xb9, 0x0c, 0x7d, 0xfc,
+	0xc8, 0xca, 0x39, 0xaa, 0xb2, 0xd4, 0x10, 0xa5, 0xe0, 0x9d, 0x73, 0xc5, 0x99, 0xd7, 0x77, 0x86,
+	0xad, 0xa9, 0xe7, 0x73, 0xc5, 0x07, 0xbf, 0x38, 0x40, 0xae, 0x43, 0x1e, 0xc5, 0x77, 0x42, 0x4d,
+	0x50, 0x71, 0x0d, 0xd2, 0xaf, 0x01, 0xe6, 0x22, 0x5c, 0xbc, 0x89, 0x15, 0x57, 0x89, 0xa2, 0x66,
+	0xa6, 0x68, 0x2c, 0xc2, 0xc5, 0xb5, 0x26, 0x6c, 0xf2, 0xc6, 0x3c, 0x05, 0x74, 0x71, 0x53, 0xa9,
+	0xa0, 0xcb, 0x16, 0x67, 0x60, 0x04, 0x16, 0x74, 0x19, 0x64, 0xf0, 0x23, 0xd4, 0x53, 0x05, 0x5a,
+	0xa2, 0x56, 0xc0, 0x9c, 0x4c, 0x22, 0xfd, 0x06, 0xea, 0x6b, 0xab, 0xcc, 0x24, 0x6e, 0x8e, 0x58,
+	0xaa, 0x65, 0x57, 0xb9, 0xcd, 0xbb, 0x8d, 0x1f, 0xfc, 0x51, 0x86, 0xda, 0x04, 0xe3, 0x98, 0x07,
+	0x48, 0x9f, 0x81, 0x31, 0xcf, 0x3a, 0x7c, 0x94, 0xe6, 0xb0, 0xf4, 0x9e, 0xc7, 0x5d, 0x70, 0x95,
+	0x28, 0x74, 0xe2, 0x2a, 0xa1, 0xdb, 0x58, 0x48, 0xb1, 0xd3, 0x86, 0x46, 0xb6, 0x0d, 0x7a, 0x7b,
+	0xdf, 0xa4, 0x07, 0xb5, 0x95, 0x08, 0xcc, 0x07, 0xab, 0xe4, 0xc8, 0x14, 0xcc, 0x6c, 0xab, 0xee,
+	0xdb, 0xf6, 0x0c, 0x6a, 0x18, 0x2a, 0xb9, 0xc4, 0x98, 0xd5, 0xfa, 0xe5, 0x61, 0x73, 0x74, 0x50,
+	0x98, 0x8c, 0x34, 0x95, 0x8d, 0xa1, 0xc7, 0x50, 0x9d, 0x8b, 0xf5, 0x7a, 0xa9, 0x58, 0x3d, 0x97,
+	0xcb, 0x62, 0x74, 0x04, 0xf5, 0xd8, 0x3a, 0xc6, 0x1a, 0xc6, 0x49, 0xb2, 0xeb, 0x64, 0xea, 0x60,
+	0x1a, 0xa7, 0x33, 0x4a, 0x7c, 0x8b, 0x73, 0xc5, 0xa0, 0xef, 0x0c, 0xeb, 0x69, 0xc6, 0x04, 0xa3,
+	0x5f, 0x00, 0x24, 0xa7, 0x8b, 0x65, 0xa8, 0x58, 0x33, 0x57, 0x33, 0x87, 0x53, 0x06, 0xb5, 0xb9,
+	0x08, 0x15, 0x7e, 0x54, 0xac, 0x65, 0x3e, 0x6c, 0x7a, 0x1d, 0xfc, 0x04, 0x8d, 0x0b, 0x2e, 0xfd,
+	0x64, 0x7c, 0x52, 0x07, 0x9d, 0x3d, 0x07, 0x19, 0x78, 0xef, 0x85, 0xc2, 0xe2, 0xbc, 0x6b, 0x24,
+	0xd7, 0x70, 0x79, 0xbf, 0xe1, 0x41, 0x00, 0x8d, 0xed, 0xb8, 0xd2, 0x2e, 0x54, 0x42, 0xe1, 0x63,
+	0xcc, 0x9c, 0x7e, 0x79, 0xe8, 0x4d, 0x93, 0x0b, 0x25, 0x50, 0x5f, 0x21, 0x97, 0x21, 0xca, 0x98,
+	0xb9, 0x9a, 0xa0, 0x4f, 0xa1, 0x69, 0xa8, 0x37, 0x6f, 0x85, 0x6e, 0xaa, 0x6c, 0xa2, 0xc1, 0x40,
+	0xdf, 0x69, 0x84, 0x76, 0xa0, 0xf1, 0x61, 0xa9, 0x42, 0x8c, 0x63, 0x8c, 0x99, 0xa7, 0xe9, 0xc1,
+	0xaf, 0x0e, 0x80, 0xae, 0x34, 0xbe, 0xe3, 0x61, 0x60, 0x66, 0xe7, 0xf2, 0xbc, 0xd0, 0x87, 0xbb,
+	0x3c, 0xa7, 0x5f, 0xd9, 0x15, 0x77, 0xcd, 0x00, 0xfe, 0x3f, 0xbf, 0x50, 0xc9, 0xbb, 0xbd, 0x19,
+	0x3c, 0x86, 0xea, 0x95, 0xf0, 0xf1, 0xf2, 0xbc, 0xd8, 0x5d, 0x68, 0x30, 0x6d, 0xeb, 0xd8, 0xda,
+	0xea, 0x15, 0x6d, 0x9d, 0x01, 0xc9, 0xb2, 0x5e, 0x2f, 0xc3, 0x60, 0x85, 0xf4, 0xa4, 0xf0, 0x03,
+	0xf3, 0x9f, 0xd5, 0x69, 0x77, 0x5b, 0x37, 0xe7, 0xf8, 0xe0, 0x16, 0x5a, 0x59, 0xf4, 0xcd, 0x88,
+	0x92, 0xdd, 0x2e, 0xe9, 0x73, 0xa8, 0x25, 0x6c, 0xe2, 0x65, 0x6e, 0x53, 0x77, 0xe5, 0xd8, 0x07,
+	0x87, 0x59, 0x0b, 0xba, 0xc3, 0xd6, 0xc9, 0x05, 0x34, 0xb6, 0x3f, 0x79, 0xf4, 0x10, 0x9a, 0xe6,
+	0x72, 0x25, 0xe4, 0x9a, 0xaf, 0x48, 0x89, 0x1e, 0xc1, 0xa1, 0x01, 0xb2, 0x6c, 0xc4, 0xa1, 0xff,
+	0x83, 0xce, 0x0e, 0x78, 0x33, 0x22, 0xee, 0xc9, 0x3f, 0x2e, 0x34, 0x73, 0xbb, 0x4d, 0x01, 0xaa,
+	0x93, 0x38, 0xb8, 0xd8, 0x44, 0xa4, 0x44, 0x9b, 0x50, 0x9b, 0xc4, 0xc1, 0x19, 0x72, 0x45, 0x1c,
+	0x7b, 0x79, 0x25, 0x45, 0x44, 0x5c, 0x1b, 0xf5, 0x22, 0x8a, 0x48, 0x99, 0xb6, 0x01, 0x92, 0xf3,
+	0x14, 0xe3, 0x88, 0x78, 0x36, 0xf0, 0x46, 0x28, 0x24, 0x15, 0xad, 0xcd, 0x5e, 0x0c, 0x5b, 0xb5,
+	0xac, 0xde, 0x23, 0x52, 0xa3, 0x04, 0x5a, 0xba, 0x18, 0x72, 0xa9, 0x6e, 0x75, 0x95, 0x3a, 0xed,
+	0x02, 0xc9, 0x23, 0xe6, 0x51, 0x83, 0x52, 0x68, 0x4f, 0xe2, 0xe0, 0x75, 0x28, 0x91, 0xcf, 0xef,
+	0xf8, 0xed, 0x0a, 0x09, 0xd0, 0x0e, 0x1c, 0xd8, 0x44, 0x7a, 0x6e, 0x37, 0x31, 0x69, 0xda, 0xb0,
+	0xf1, 0x1d, 0xce, 0xdf, 0x7d, 0xbf, 0x11, 0x72, 0xb3, 0x26, 0x2d, 0xdd, 0xf6, 0x24, 0x0e, 0x66,
+	0x92, 0x87, 0xf1, 0x02, 0xe5, 0x4b, 0xe4, 0x3e, 0x4a, 0x72, 0x60, 0x5f, 0xcf, 0x96, 0x6b, 0x14,
+	0x1b, 0x75, 0x25, 0x3e, 0x90, 0xb6, 0x15, 0x33, 0x45, 0xee, 0x9b, 0xbf, 0x06, 0x72, 0x68, 0xc5,
+	0x6c, 0x11, 0x23, 0x86, 0xd8, 0xb8, 0xf1, 0x46, 0x4d, 0xb8, 0x7c, 0x87, 0x92, 0x74, 0x6c, 0x93,
+	0xe3, 0x8d, 0x32, 0x1b, 0x44, 0xa8, 0xb5, 0xe4, 0x95, 0x44, 0xe3, 0xc2, 0x91, 0x15, 0x66, 0xef,
+	0x26, 0x4d, 0xf7, 0xe4, 0x37, 0x07, 0xda, 0xc5, 0xa9, 0xd2, 0x5a, 0x33, 0xe4, 0x85, 0xef, 0xeb,
+	0xe1, 0x22, 0x25, 0xca, 0xa0, 0x9b, 0xc1, 0x53, 0x5c, 0x8b, 0xf7, 0x68, 0x18, 0xa7, 0xc8, 0xbc,
+	0x8e, 0x7c, 0xae, 0x12, 0xc6, 0xa5, 0xc7, 0xc0, 0x0a, 0xa9, 0x5e, 0x26, 0xcb, 0x6b, 0xd8, 0xf2,
+	0x1e, 0xfb, 0x43, 0xb2, 0xa7, 0x86, 0xf5, 0xce, 0xd8, 0xc3, 0xe7, 0x5e, 0xe9, 0xd3, 0xe7, 0x5e,
+	0xe9, 0xe1, 0xb1, 0xe7, 0x7c, 0x7a, 0xec, 0x39, 0x7f, 0x3d, 0xf6, 0x9c, 0xdf, 0xff, 0xee, 0x95,
+	0xfe, 0x1d, 0x00, 0x24, 0xa5, 0x8f, 0xd4, 0xb1, 0x07, 0x00, 0x00,
 }
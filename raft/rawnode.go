@@ -132,37 +132,39 @@ func (rn *RawNode) Propose(data []byte) error {
 		}})
 }
 
+// ProposeEntry proposes an entry of the application entry type t carrying
+// data be appended to the raft log.
+func (rn *RawNode) ProposeEntry(t pb.EntryType, data []byte) error {
+	if !IsApplicationEntryType(t) {
+		return ErrReservedEntryType
+	}
+	return rn.raft.Step(pb.Message{
+		Type:	pb.MsgProp,
+		From:	rn.raft.id,
+		Entries: []pb.Entry{
+			{Type: t, Data: data},
+		}})
+}
+
 // ProposeConfChange proposes a config change.
-func (rn *RawNode) ProposeConfChange(cc pb.ConfChange) error {
-	data, err := cc.Marshal()
+func (rn *RawNode) ProposeConfChange(cc pb.ConfChangeI) error {
+	typ, data, err := pb.MarshalConfChange(cc)
 	if err != nil {
 		return err
 	}
 	return rn.raft.Step(pb.Message{
 		Type:	pb.MsgProp,
 		Entries: []pb.Entry{
-			{Type: pb.EntryConfChange, Data: data},
+			{Type: typ, Data: data},
 		},
 	})
 }
 
 // ApplyConfChange applies a config change to the local node.
-func (rn *RawNode) ApplyConfChange(cc pb.ConfChange) *pb.ConfState {
-	if cc.NodeID == None {
-		rn.raft.resetPendingConf()
-		return &pb.ConfState{Nodes: rn.raft.nodes()}
-	}
-	switch cc.Type {
-	case pb.ConfChangeAddNode:
-		rn.raft.addNode(cc.NodeID)
-	case pb.ConfChangeRemoveNode:
-		rn.raft.removeNode(cc.NodeID)
-	case pb.ConfChangeUpdateNode:
-		rn.raft.resetPendingConf()
-	default:
-		panic("unexpected conf type")
-	}
-	return &pb.ConfState{Nodes: rn.raft.nodes()}
+func (rn *RawNode) ApplyConfChange(cc pb.ConfChangeI) *pb.ConfState {
+	rn.raft.applyConfChange(cc.AsV2())
+	cs := rn.raft.confState()
+	return &cs
 }
 
 // Step advances the state machine using the given message.
@@ -181,6 +183,9 @@ func (rn *RawNode) Step(m pb.Message) error {
 func (rn *RawNode) Ready() Ready {
 	rd := rn.newReady()
 	rn.raft.msgs = nil
+	rn.raft.readyCuts = nil
+	rn.raft.readStates = nil
+	rn.raft.reduceUncommittedSize(rd.CommittedEntries)
 	return rd
 }
 
@@ -197,7 +202,7 @@ func (rn *RawNode) HasReady() bool {
 	if r.raftLog.unstable.snapshot != nil && !IsEmptySnap(*r.raftLog.unstable.snapshot) {
 		return true
 	}
-	if len(r.msgs) > 0 || len(r.raftLog.unstableEntries()) > 0 || r.raftLog.hasNextEnts() {
+	if len(r.msgs) > 0 || len(r.raftLog.unstableEntries()) > 0 || r.raftLog.hasNextEnts() || len(r.readyCuts) > 0 || len(r.readStates) != 0 {
 		return true
 	}
 	return false
@@ -215,6 +220,14 @@ func (rn *RawNode) Status() *Status {
 	return &status
 }
 
+// ReadIndex requests a read state. The read state will be set in ready.
+// Read State has a read index. Once the application advances further than the read
+// index, any linearizable read requests issued before the read request can be
+// processed safely. The read state will have the same rctx attached.
+func (rn *RawNode) ReadIndex(rctx []byte) {
+	_ = rn.raft.Step(pb.Message{Type: pb.MsgReadIndex, From: rn.raft.id, Entries: []pb.Entry{{Data: rctx}}})
+}
+
 // ReportUnreachable reports the given node is not reachable for the last send.
 func (rn *RawNode) ReportUnreachable(id uint64) {
 	_ = rn.raft.Step(pb.Message{Type: pb.MsgUnreachable, From: id})
@@ -231,3 +244,10 @@ func (rn *RawNode) ReportSnapshot(id uint64, status SnapshotStatus) {
 func (rn *RawNode) TransferLeader(transferee uint64) {
 	_ = rn.raft.Step(pb.Message{Type: pb.MsgTransferLeader, From: transferee})
 }
+
+// RequestCut starts a consistent cut of the raft state of the cluster. The
+// cut is returned in Ready.Cuts with the given id once every node recorded
+// its state.
+func (rn *RawNode) RequestCut(id uint64) error {
+	return rn.raft.Step(pb.Message{Type: pb.MsgCutMarker, Index: id})
+}
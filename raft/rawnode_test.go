@@ -104,6 +104,88 @@ func TestRawNodeProposeAndConfChange(t *testing.T) {
 	}
 }
 
+// TestRawNodeJointConfChange ensures that a ConfChangeV2 proposed through
+// RawNode.ProposeConfChange enters a joint configuration which the leader
+// then leaves on its own.
+func TestRawNodeJointConfChange(t *testing.T) {
+	s := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, nil, 10, 1, s), []Peer{{ID: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Campaign()
+	proposed := false
+	var cs *raftpb.ConfState
+	for rawNode.HasReady() {
+		rd := rawNode.Ready()
+		s.Append(rd.Entries)
+		for _, e := range rd.CommittedEntries {
+			switch e.Type {
+			case raftpb.EntryConfChange:
+				var cc raftpb.ConfChange
+				cc.Unmarshal(e.Data)
+				cs = rawNode.ApplyConfChange(cc)
+			case raftpb.EntryConfChangeV2:
+				var cc raftpb.ConfChangeV2
+				cc.Unmarshal(e.Data)
+				cs = rawNode.ApplyConfChange(cc)
+			}
+		}
+		if !proposed && rd.SoftState != nil && rd.SoftState.Lead == rawNode.raft.id {
+			cc := raftpb.ConfChangeV2{Changes: []raftpb.ConfChangeSingle{
+				{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 2},
+				{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 3},
+			}}
+			if err = rawNode.ProposeConfChange(cc); err != nil {
+				t.Fatal(err)
+			}
+			proposed = true
+		}
+		rawNode.Advance(rd)
+	}
+
+	wcs := &raftpb.ConfState{Nodes: []uint64{1}, Learners: []uint64{2, 3}}
+	if !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("confState = %+v, want %+v", cs, wcs)
+	}
+}
+
+// TestRawNodeReadIndex ensures that RawNode.ReadIndex returns the read state
+// of the request through Ready.
+func TestRawNodeReadIndex(t *testing.T) {
+	s := NewMemoryStorage()
+	c := newTestConfig(1, nil, 10, 1, s)
+	c.CheckQuorum = true
+	rawNode, err := NewRawNode(c, []Peer{{ID: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Campaign()
+	for rawNode.HasReady() {
+		rd := rawNode.Ready()
+		s.Append(rd.Entries)
+		rawNode.Advance(rd)
+	}
+	if rawNode.raft.state != StateLeader {
+		t.Fatalf("state = %s, want %s", rawNode.raft.state, StateLeader)
+	}
+
+	rctx := []byte("somedata")
+	rawNode.ReadIndex(rctx)
+	if !rawNode.HasReady() {
+		t.Fatalf("HasReady() = false, want true")
+	}
+	rd := rawNode.Ready()
+	wrs := []ReadState{{Index: rawNode.raft.raftLog.committed, RequestCtx: rctx}}
+	if !reflect.DeepEqual(rd.ReadStates, wrs) {
+		t.Errorf("ReadStates = %+v, want %+v", rd.ReadStates, wrs)
+	}
+	rawNode.Advance(rd)
+	if rawNode.HasReady() {
+		t.Errorf("HasReady() = true, want false")
+	}
+}
+
 // TestBlockProposal from node_test.go has no equivalent in rawNode because there is
 // no leader check in RawNode.
 
@@ -0,0 +1,158 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Tracer records the events of a node with a vector clock, which the
+// transport exchanges with the messages between nodes.
+type Tracer interface {
+	// LogLocal records an event local to the node.
+	LogLocal(event string) error
+	// PrepareSend records the sending of a message and returns the encoded
+	// vector clock to send with it.
+	PrepareSend(event string) ([]byte, error)
+	// UnpackReceive merges the encoded vector clock received with a message
+	// into the local one and records the receipt. A nil clock records a
+	// receipt from a node that did not send its clock.
+	UnpackReceive(event string, clock []byte) error
+}
+
+// ShiVizRegexp is the regular expression that parses the logs written by a
+// ShiVizLog in ShiViz (https://bestchai.bitbucket.io/shiviz/).
+const ShiVizRegexp = `(?<host>\S*) (?<clock>{.*})\n(?<event>.*)`
+
+// VClock is a vector clock, mapping host names to their logical time.
+type VClock map[string]uint64
+
+// Merge sets every entry of c to the maximum of it and the entry in o.
+func (c VClock) Merge(o VClock) {
+	for h, t := range o {
+		if t > c[h] {
+			c[h] = t
+		}
+	}
+}
+
+// ShiVizLog is a Tracer that writes every event as the host, the vector
+// clock as JSON and the event text, in the format parsed by ShiVizRegexp.
+// Logs of the nodes of a cluster concatenated together make one ShiViz
+// input. It is safe for concurrent use.
+type ShiVizLog struct {
+	mu    sync.Mutex
+	host  string
+	clock VClock
+	w     *bufio.Writer
+	c     io.Closer
+}
+
+// NewShiVizLog returns a ShiVizLog of the given host writing to w. It
+// records an initial event, so the host shows up even if it logs nothing
+// else.
+func NewShiVizLog(host string, w io.Writer) (*ShiVizLog, error) {
+	l := &ShiVizLog{host: host, clock: VClock{}, w: bufio.NewWriter(w)}
+	if err := l.LogLocal("Initialization Complete"); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// OpenShiVizLog opens the file at path for appending, creating it if needed,
+// and returns a ShiVizLog of the given host writing to it.
+func OpenShiVizLog(host, path string) (*ShiVizLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l, err := NewShiVizLog(host, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	l.c = f
+	return l, nil
+}
+
+func (l *ShiVizLog) LogLocal(event string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock[l.host]++
+	return l.write(event)
+}
+
+func (l *ShiVizLog) PrepareSend(event string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock[l.host]++
+	if err := l.write(event); err != nil {
+		return nil, err
+	}
+	return json.Marshal(l.clock)
+}
+
+func (l *ShiVizLog) UnpackReceive(event string, clock []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if clock != nil {
+		var c VClock
+		if err := json.Unmarshal(clock, &c); err != nil {
+			return err
+		}
+		l.clock.Merge(c)
+	}
+	l.clock[l.host]++
+	return l.write(event)
+}
+
+// write writes event with the current clock and flushes it, so the log is
+// complete if the process is killed. l.mu must be held.
+func (l *ShiVizLog) write(event string) error {
+	b, err := json.Marshal(l.clock)
+	if err != nil {
+		return err
+	}
+	// an event spans a single line
+	event = strings.Replace(event, "\n", " ", -1)
+	if _, err = fmt.Fprintf(l.w, "%s %s\n%s\n", l.host, b, event); err != nil {
+		return err
+	}
+	return l.w.Flush()
+}
+
+// Close closes the file opened by OpenShiVizLog.
+func (l *ShiVizLog) Close() error {
+	if l.c == nil {
+		return nil
+	}
+	return l.c.Close()
+}
+
+// trace records a local event of r, if r has a tracer.
+func (r *raft) trace(format string, args ...interface{}) {
+	if r.tracer == nil {
+		return
+	}
+	if err := r.tracer.LogLocal(fmt.Sprintf(format, args...)); err != nil {
+		r.logger.Warningf("%x failed to trace event (%v)", r.id, err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+type traceRecorder struct {
+	events []string
+}
+
+func (tr *traceRecorder) LogLocal(event string) error {
+	tr.events = append(tr.events, event)
+	return nil
+}
+
+func (tr *traceRecorder) PrepareSend(event string) ([]byte, error) {
+	tr.events = append(tr.events, event)
+	return nil, nil
+}
+
+func (tr *traceRecorder) UnpackReceive(event string, clock []byte) error {
+	tr.events = append(tr.events, event)
+	return nil
+}
+
+func TestShiVizLog(t *testing.T) {
+	var ba, bb bytes.Buffer
+	a, err := NewShiVizLog("a", &ba)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewShiVizLog("b", &bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock, err := a.PrepareSend("send MsgApp\nto b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b.UnpackReceive("receive MsgApp from a", clock); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.UnpackReceive("receive MsgApp from c", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// ShiViz uses named groups of the form (?<name>re)
+	re := regexp.MustCompile("(?m)" + strings.Replace(ShiVizRegexp, "(?<", "(?P<", -1))
+	tests := []struct {
+		log *bytes.Buffer
+
+		whosts  []string
+		wclocks []VClock
+		wevents []string
+	}{
+		{
+			&ba,
+			[]string{"a", "a"},
+			[]VClock{{"a": 1}, {"a": 2}},
+			[]string{"Initialization Complete", "send MsgApp to b"},
+		},
+		{
+			&bb,
+			[]string{"b", "b", "b"},
+			[]VClock{{"b": 1}, {"a": 2, "b": 2}, {"a": 2, "b": 3}},
+			[]string{"Initialization Complete", "receive MsgApp from a", "receive MsgApp from c"},
+		},
+	}
+	for i, tt := range tests {
+		ms := re.FindAllStringSubmatch(tt.log.String(), -1)
+		if len(ms) != len(tt.wevents) {
+			t.Fatalf("#%d: len(events) = %d, want %d", i, len(ms), len(tt.wevents))
+		}
+		for j, m := range ms {
+			if m[1] != tt.whosts[j] {
+				t.Errorf("#%d.%d: host = %s, want %s", i, j, m[1], tt.whosts[j])
+			}
+			var c VClock
+			if err := json.Unmarshal([]byte(m[2]), &c); err != nil {
+				t.Fatalf("#%d.%d: unexpected clock error: %v", i, j, err)
+			}
+			if !reflect.DeepEqual(c, tt.wclocks[j]) {
+				t.Errorf("#%d.%d: clock = %v, want %v", i, j, c, tt.wclocks[j])
+			}
+			if m[3] != tt.wevents[j] {
+				t.Errorf("#%d.%d: event = %q, want %q", i, j, m[3], tt.wevents[j])
+			}
+		}
+	}
+}
+
+func TestRaftTracesTransitions(t *testing.T) {
+	tr := &traceRecorder{}
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	cfg.Tracer = tr
+	r := newRaft(cfg)
+	r.Step(pb.Message{From: 1, To: 1, Type: pb.MsgHup})
+
+	wevents := []string{
+		"1 became follower at term 0 with leader 0",
+		"1 became candidate at term 1",
+		"1 became leader at term 1",
+	}
+	if !reflect.DeepEqual(tr.events, wevents) {
+		t.Errorf("events = %q, want %q", tr.events, wevents)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/coreos/etcd/raft"
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// InvariantPanic is the name a violation is reported under when a node
+// panics.
+const InvariantPanic = "panic"
+
+var discardLogger = &raft.DefaultLogger{Logger: log.New(ioutil.Discard, "", 0)}
+
+type node struct {
+	id      uint64
+	rn      *raft.RawNode
+	storage *raft.MemoryStorage
+	// applied are the entries the node applied since the last check.
+	applied []pb.Entry
+}
+
+// cluster is a set of nodes and the messages in flight between them. It
+// applies events deterministically.
+type cluster struct {
+	cfg      *Config
+	nodes    []*node
+	inflight []pb.Message
+	// partition is the side of the partition, if any.
+	partition map[uint64]bool
+
+	// leaders is the leader elected in every term so far.
+	leaders map[uint64]uint64
+	// applied is the entry applied at every index so far.
+	applied map[uint64]pb.Entry
+}
+
+func newCluster(cfg *Config) *cluster {
+	c := &cluster{
+		cfg:     cfg,
+		leaders: make(map[uint64]uint64),
+		applied: make(map[uint64]pb.Entry),
+	}
+	ids := make([]uint64, cfg.Nodes)
+	for i := range ids {
+		ids[i] = uint64(i + 1)
+	}
+	for _, id := range ids {
+		s := raft.NewMemoryStorage()
+		s.ApplySnapshot(pb.Snapshot{Metadata: pb.SnapshotMetadata{
+			Index:     1,
+			Term:      1,
+			ConfState: pb.ConfState{Nodes: ids},
+		}})
+		n := &node{id: id, storage: s}
+		c.nodes = append(c.nodes, n)
+		c.start(n)
+	}
+	return c
+}
+
+func (c *cluster) node(id uint64) *node {
+	if id == raft.None || id > uint64(len(c.nodes)) {
+		return nil
+	}
+	return c.nodes[id-1]
+}
+
+// start starts n from its storage.
+func (c *cluster) start(n *node) {
+	rc := &raft.Config{
+		ID:              n.id,
+		ElectionTick:    c.cfg.ElectionTick,
+		HeartbeatTick:   c.cfg.HeartbeatTick,
+		Storage:         n.storage,
+		MaxSizePerMsg:   math.MaxUint64,
+		MaxInflightMsgs: 256,
+		Logger:          discardLogger,
+	}
+	if c.cfg.Configure != nil {
+		c.cfg.Configure(rc)
+	}
+	rn, err := raft.NewRawNode(rc, nil)
+	if err != nil {
+		panic(err)
+	}
+	n.rn = rn
+}
+
+// connected returns true if a message can be delivered from one node to the
+// other.
+func (c *cluster) connected(from, to uint64) bool {
+	return c.partition == nil || c.partition[from] == c.partition[to]
+}
+
+// find returns the index of the in flight message equal to m.
+func (c *cluster) find(m pb.Message) int {
+	for i := range c.inflight {
+		if reflect.DeepEqual(c.inflight[i], m) {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply applies e to the cluster. It returns false if e has no effect in the
+// current state, e.g. a message that is not in flight is delivered.
+func (c *cluster) apply(e Event) (ok bool, v *Violation) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok, v = true, &Violation{Invariant: InvariantPanic, Detail: fmt.Sprint(r)}
+		}
+	}()
+
+	var n *node
+	switch e.Type {
+	case EventTick, EventPropose, EventCrash:
+		if n = c.node(e.Node); n == nil || n.rn == nil {
+			return false, nil
+		}
+	case EventRestart:
+		if n = c.node(e.Node); n == nil || n.rn != nil {
+			return false, nil
+		}
+	}
+
+	switch e.Type {
+	case EventTick:
+		n.rn.Tick()
+	case EventPropose:
+		n.rn.Propose(e.Data)
+	case EventDeliver, EventDrop, EventDuplicate:
+		i := c.find(e.Msg)
+		if i < 0 {
+			return false, nil
+		}
+		m := c.inflight[i]
+		if e.Type == EventDuplicate {
+			c.inflight = append(c.inflight, m)
+			break
+		}
+		c.inflight = append(c.inflight[:i], c.inflight[i+1:]...)
+		if e.Type == EventDrop || !c.connected(m.From, m.To) {
+			break
+		}
+		if n = c.node(m.To); n == nil || n.rn == nil {
+			n = nil
+			break
+		}
+		// raft may modify the entries it steps; keep the message in the
+		// trace intact.
+		m.Entries = append([]pb.Entry(nil), m.Entries...)
+		n.rn.Step(m)
+	case EventPartition:
+		c.partition = make(map[uint64]bool)
+		for _, id := range e.Partition {
+			c.partition[id] = true
+		}
+	case EventHeal:
+		if c.partition == nil {
+			return false, nil
+		}
+		c.partition = nil
+	case EventCrash:
+		n.rn = nil
+		n = nil
+	case EventRestart:
+		c.start(n)
+	}
+	if n != nil {
+		c.process(n)
+	}
+	return true, nil
+}
+
+// process handles the Ready of n until it has none: it persists the state
+// and entries, sends the messages and applies the committed entries.
+func (c *cluster) process(n *node) {
+	for n.rn.HasReady() {
+		rd := n.rn.Ready()
+		if !raft.IsEmptySnap(rd.Snapshot) {
+			n.storage.ApplySnapshot(rd.Snapshot)
+		}
+		n.storage.Append(rd.Entries)
+		if !raft.IsEmptyHardState(rd.HardState) {
+			n.storage.SetHardState(rd.HardState)
+		}
+		// raft sends to its peers in map order; sort the messages by
+		// recipient, keeping their order to every peer, for the
+		// simulation to be deterministic.
+		sort.Stable(messagesByTo(rd.Messages))
+		for _, m := range rd.Messages {
+			m.Entries = append([]pb.Entry(nil), m.Entries...)
+			c.inflight = append(c.inflight, m)
+		}
+		for _, e := range rd.CommittedEntries {
+			switch e.Type {
+			case pb.EntryConfChange:
+				var cc pb.ConfChange
+				cc.Unmarshal(e.Data)
+				n.rn.ApplyConfChange(cc)
+			case pb.EntryConfChangeV2:
+				var cc pb.ConfChangeV2
+				cc.Unmarshal(e.Data)
+				n.rn.ApplyConfChange(cc)
+			}
+			n.applied = append(n.applied, e)
+		}
+		n.rn.Advance(rd)
+	}
+}
+
+// check checks the invariants over the state of every node, and that no
+// two leaders were elected in a term and no two entries were applied at an
+// index over the whole simulation.
+func (c *cluster) check() *Violation {
+	cut := raft.Cut{}
+	for _, n := range c.nodes {
+		cut.Nodes = append(cut.Nodes, c.nodeCut(n))
+		if n.rn == nil {
+			continue
+		}
+		st := n.rn.Status()
+		if st.RaftState == raft.StateLeader {
+			if l, ok := c.leaders[st.Term]; ok && l != n.id {
+				return &Violation{
+					Invariant: raft.InvariantElectionSafety,
+					Detail:    fmt.Sprintf("%x and %x elected at term %d", l, n.id, st.Term),
+				}
+			}
+			c.leaders[st.Term] = n.id
+		}
+		for _, e := range n.applied {
+			if o, ok := c.applied[e.Index]; ok && (o.Term != e.Term || !bytes.Equal(o.Data, e.Data)) {
+				return &Violation{
+					Invariant: raft.InvariantStateMachineSafety,
+					Detail:    fmt.Sprintf("%x applied a different entry at index %d", n.id, e.Index),
+				}
+			}
+			c.applied[e.Index] = e
+		}
+		n.applied = nil
+	}
+
+	values := cut.Values()
+	for _, ic := range c.cfg.Invariants {
+		if !ic.Check(raft.InvariantState{}, values) {
+			return &Violation{Invariant: ic.Name()}
+		}
+	}
+	return nil
+}
+
+// nodeCut returns the state of n. The state of a crashed node is the one in
+// its storage.
+func (c *cluster) nodeCut(n *node) raft.NodeCut {
+	nc := raft.NodeCut{ID: n.id}
+	first, _ := n.storage.FirstIndex()
+	last, _ := n.storage.LastIndex()
+	nc.Log, _ = n.storage.Entries(first, last+1, math.MaxUint64)
+	if n.rn == nil {
+		hs, _, _ := n.storage.InitialState()
+		nc.Term, nc.Vote, nc.Committed = hs.Term, hs.Vote, hs.Commit
+		return nc
+	}
+	st := n.rn.Status()
+	nc.Term, nc.Vote, nc.Committed = st.Term, st.Vote, st.Commit
+	nc.Lead, nc.RaftState, nc.Applied = st.Lead, st.RaftState, st.Applied
+	return nc
+}
+
+type messagesByTo []pb.Message
+
+func (ms messagesByTo) Len() int           { return len(ms) }
+func (ms messagesByTo) Less(i, j int) bool { return ms[i].To < ms[j].To }
+func (ms messagesByTo) Swap(i, j int)      { ms[i], ms[j] = ms[j], ms[i] }
@@ -0,0 +1,121 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"fmt"
+
+	"github.com/coreos/etcd/raft"
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// EventType is the kind of an event of the simulation.
+type EventType int
+
+// Possible values for EventType.
+const (
+	// EventTick ticks Node.
+	EventTick EventType = iota
+	// EventPropose proposes Data on Node.
+	EventPropose
+	// EventDeliver delivers the in flight message Msg to its recipient.
+	// Messages are delivered in any order.
+	EventDeliver
+	// EventDrop drops the in flight message Msg.
+	EventDrop
+	// EventDuplicate sends the in flight message Msg once more.
+	EventDuplicate
+	// EventPartition drops the messages between the nodes in Partition and
+	// the other nodes until the next EventHeal.
+	EventPartition
+	// EventHeal heals the partition.
+	EventHeal
+	// EventCrash crashes Node. Its in memory state is lost and the messages
+	// sent to it are dropped until it restarts.
+	EventCrash
+	// EventRestart restarts Node from its storage.
+	EventRestart
+)
+
+var eventTypes = [...]string{
+	"tick",
+	"propose",
+	"deliver",
+	"drop",
+	"duplicate",
+	"partition",
+	"heal",
+	"crash",
+	"restart",
+}
+
+func (et EventType) String() string {
+	return eventTypes[et]
+}
+
+// Event is a step of the simulation.
+type Event struct {
+	Type      EventType
+	Node      uint64
+	Data      []byte
+	Msg       pb.Message
+	Partition []uint64
+}
+
+func (e Event) String() string {
+	switch e.Type {
+	case EventTick, EventCrash, EventRestart:
+		return fmt.Sprintf("%s %x", e.Type, e.Node)
+	case EventPropose:
+		return fmt.Sprintf("%s %x %q", e.Type, e.Node, e.Data)
+	case EventDeliver, EventDrop, EventDuplicate:
+		return fmt.Sprintf("%s %s", e.Type, raft.DescribeMessage(e.Msg, nil))
+	case EventPartition:
+		return fmt.Sprintf("%s %x", e.Type, e.Partition)
+	}
+	return e.Type.String()
+}
+
+// Weights are the relative chances of the scheduler picking each kind of
+// event. An event that cannot happen, such as delivering a message when none
+// is in flight, is never picked.
+type Weights struct {
+	Tick      int
+	Propose   int
+	Deliver   int
+	Drop      int
+	Duplicate int
+	Partition int
+	Heal      int
+	Crash     int
+	Restart   int
+}
+
+// DefaultWeights are the weights used if Config.Weights is zero.
+var DefaultWeights = Weights{
+	Tick:      30,
+	Propose:   8,
+	Deliver:   50,
+	Drop:      3,
+	Duplicate: 2,
+	Partition: 1,
+	Heal:      2,
+	Crash:     1,
+	Restart:   3,
+}
+
+func (w Weights) of(et EventType) int {
+	return [...]int{w.Tick, w.Propose, w.Deliver, w.Drop, w.Duplicate, w.Partition, w.Heal, w.Crash, w.Restart}[et]
+}
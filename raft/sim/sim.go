@@ -0,0 +1,307 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sim simulates a raft cluster deterministically to find
+// violations of its safety invariants.
+//
+// A Simulator drives the RawNodes of a cluster with a seeded scheduler. Every
+// step ticks a node, proposes on a node, delivers, drops or duplicates a
+// message in flight, partitions or heals the network, or crashes or restarts
+// a node. Messages are delivered in any order. A crashed node loses its in
+// memory state and restarts from its MemoryStorage, which holds what the
+// node persisted from its Ready before sending its messages.
+//
+// The invariants are checked over the state of every node after every step.
+// A violation is shrunk to a minimal trace of events that still violates the
+// same invariant, which Replay reproduces:
+//
+//	s := sim.New(sim.Config{Nodes: 3, Seed: seed, Steps: 10000})
+//	if v := s.Run(); v != nil {
+//		for _, e := range v.Trace {
+//			fmt.Println(e)
+//		}
+//		log.Fatal(v)
+//	}
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/coreos/etcd/raft"
+)
+
+// Config configures a simulation.
+type Config struct {
+	// Nodes is the size of the cluster. It defaults to 3.
+	Nodes int
+	// Seed seeds the scheduler. Simulations with the same configuration
+	// take the same steps.
+	Seed int64
+	// Steps is the number of steps Run takes. It defaults to 1000.
+	Steps int
+	// ElectionTick and HeartbeatTick configure every node. They default to
+	// 10 and 1.
+	ElectionTick  int
+	HeartbeatTick int
+	// Weights are the relative chances of every kind of event. They default
+	// to DefaultWeights.
+	Weights Weights
+	// Invariants are checked after every step. They default to the built-in
+	// election safety, log matching, leader completeness and state machine
+	// safety invariants. Election safety and state machine safety are also
+	// checked over the whole history of the simulation.
+	Invariants []raft.InvariantChecker
+	// Configure, if set, is called on the raft configuration of a node every
+	// time it starts, for example to inject faults. It must be
+	// deterministic for the simulation to be.
+	Configure func(c *raft.Config)
+}
+
+var defaultInvariants = []string{
+	raft.InvariantElectionSafety,
+	raft.InvariantLogMatching,
+	raft.InvariantLeaderCompleteness,
+	raft.InvariantStateMachineSafety,
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Nodes == 0 {
+		cfg.Nodes = 3
+	}
+	if cfg.Steps == 0 {
+		cfg.Steps = 1000
+	}
+	if cfg.ElectionTick == 0 {
+		cfg.ElectionTick = 10
+	}
+	if cfg.HeartbeatTick == 0 {
+		cfg.HeartbeatTick = 1
+	}
+	if cfg.Weights == (Weights{}) {
+		cfg.Weights = DefaultWeights
+	}
+	if cfg.Invariants == nil {
+		for _, name := range defaultInvariants {
+			ic, _ := raft.BuiltinInvariant(name)
+			cfg.Invariants = append(cfg.Invariants, ic)
+		}
+	}
+	return cfg
+}
+
+// Violation is an invariant violated by a simulation.
+type Violation struct {
+	// Invariant is the name of the violated invariant, or InvariantPanic.
+	Invariant string
+	// Detail describes the violation, if known.
+	Detail string
+	// Step is the step that violated the invariant.
+	Step int
+	// Trace are the events of the simulation up to and including Step.
+	Trace []Event
+}
+
+func (v *Violation) Error() string {
+	if v.Detail == "" {
+		return fmt.Sprintf("sim: invariant %s violated at step %d", v.Invariant, v.Step)
+	}
+	return fmt.Sprintf("sim: invariant %s violated at step %d: %s", v.Invariant, v.Step, v.Detail)
+}
+
+// Simulator is a simulation of a raft cluster.
+type Simulator struct {
+	cfg       Config
+	rand      *rand.Rand
+	c         *cluster
+	trace     []Event
+	proposals int
+}
+
+// New starts a simulation of a cluster with the given configuration.
+func New(cfg Config) *Simulator {
+	cfg = cfg.withDefaults()
+	return &Simulator{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.Seed)),
+		c:    newCluster(&cfg),
+	}
+}
+
+// Trace returns the events of the simulation so far.
+func (s *Simulator) Trace() []Event { return s.trace }
+
+// Step takes a step of the simulation and checks the invariants. It returns
+// the event of the step and the violation, if any.
+func (s *Simulator) Step() (Event, *Violation) {
+	e := s.next()
+	s.trace = append(s.trace, e)
+	ok, v := s.c.apply(e)
+	if ok && v == nil {
+		v = s.c.check()
+	}
+	if v != nil {
+		v.Step = len(s.trace) - 1
+		v.Trace = append([]Event(nil), s.trace...)
+	}
+	return e, v
+}
+
+// Run takes the configured number of steps. It stops at the first violation
+// and returns it shrunk.
+func (s *Simulator) Run() *Violation {
+	for i := 0; i < s.cfg.Steps; i++ {
+		if _, v := s.Step(); v != nil {
+			return Shrink(s.cfg, v)
+		}
+	}
+	return nil
+}
+
+// next picks the event of the next step among the events that can happen.
+func (s *Simulator) next() Event {
+	var up, down []uint64
+	for _, n := range s.c.nodes {
+		if n.rn != nil {
+			up = append(up, n.id)
+		} else {
+			down = append(down, n.id)
+		}
+	}
+
+	var possible []EventType
+	if len(up) > 0 {
+		possible = append(possible, EventTick, EventPropose, EventCrash)
+	}
+	if len(s.c.inflight) > 0 {
+		possible = append(possible, EventDeliver, EventDrop, EventDuplicate)
+	}
+	if s.c.partition == nil && len(s.c.nodes) > 1 {
+		possible = append(possible, EventPartition)
+	}
+	if s.c.partition != nil {
+		possible = append(possible, EventHeal)
+	}
+	if len(down) > 0 {
+		possible = append(possible, EventRestart)
+	}
+
+	total := 0
+	for _, et := range possible {
+		total += s.cfg.Weights.of(et)
+	}
+	if total == 0 {
+		return Event{Type: EventHeal}
+	}
+	et, pick := possible[0], s.rand.Intn(total)
+	for _, et = range possible {
+		if pick -= s.cfg.Weights.of(et); pick < 0 {
+			break
+		}
+	}
+
+	e := Event{Type: et}
+	switch et {
+	case EventTick, EventPropose, EventCrash:
+		e.Node = up[s.rand.Intn(len(up))]
+	case EventRestart:
+		e.Node = down[s.rand.Intn(len(down))]
+	case EventDeliver, EventDrop, EventDuplicate:
+		// delivering the oldest message half the time keeps the cluster
+		// making progress; the others are reordered.
+		i := 0
+		if s.rand.Intn(2) == 0 {
+			i = s.rand.Intn(len(s.c.inflight))
+		}
+		e.Msg = s.c.inflight[i]
+	case EventPartition:
+		// one side of the partition is a non-empty proper subset of the
+		// nodes.
+		k := 1 + s.rand.Intn(len(s.c.nodes)-1)
+		for _, i := range s.rand.Perm(len(s.c.nodes))[:k] {
+			e.Partition = append(e.Partition, s.c.nodes[i].id)
+		}
+	}
+	if et == EventPropose {
+		s.proposals++
+		e.Data = []byte(fmt.Sprintf("p%d", s.proposals))
+	}
+	return e
+}
+
+// Replay replays trace on a new cluster with the given configuration and
+// checks the invariants after every event. Events that have no effect, such
+// as delivering a message that is not in flight, are skipped. It returns the
+// first violation, if any, with the trace of the events that had an effect.
+func Replay(cfg Config, trace []Event) *Violation {
+	cfg = cfg.withDefaults()
+	c := newCluster(&cfg)
+	var effective []Event
+	for _, e := range trace {
+		ok, v := c.apply(e)
+		if !ok && v == nil {
+			continue
+		}
+		effective = append(effective, e)
+		if v == nil {
+			v = c.check()
+		}
+		if v != nil {
+			v.Step = len(effective) - 1
+			v.Trace = effective
+			return v
+		}
+	}
+	return nil
+}
+
+// Shrink removes events from the trace of v as long as the remaining trace
+// still violates the same invariant, and returns the violation of the
+// shortest trace found.
+func Shrink(cfg Config, v *Violation) *Violation {
+	// replaying drops the events that have no effect.
+	if r := Replay(cfg, v.Trace); r != nil && r.Invariant == v.Invariant {
+		v = r
+	}
+	// remove ever smaller chunks of the trace, down to single events.
+	n := 2
+	for len(v.Trace) >= 2 {
+		chunk := (len(v.Trace) + n - 1) / n
+		reduced := false
+		for start := 0; start < len(v.Trace); start += chunk {
+			end := start + chunk
+			if end > len(v.Trace) {
+				end = len(v.Trace)
+			}
+			candidate := append(append([]Event(nil), v.Trace[:start]...), v.Trace[end:]...)
+			if r := Replay(cfg, candidate); r != nil && r.Invariant == v.Invariant {
+				v, reduced = r, true
+				break
+			}
+		}
+		if reduced {
+			if n > 2 {
+				n--
+			}
+			continue
+		}
+		if n >= len(v.Trace) {
+			break
+		}
+		if n *= 2; n > len(v.Trace) {
+			n = len(v.Trace)
+		}
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/raft"
+	pb "github.com/coreos/etcd/raft/raftpb"
+)
+
+// TestSimulationSafe tests that simulations of a correct cluster make
+// progress without violating any invariant.
+func TestSimulationSafe(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		s := New(Config{Nodes: 3, Seed: seed, Steps: 2000})
+		if v := s.Run(); v != nil {
+			for _, e := range v.Trace {
+				t.Log(e)
+			}
+			t.Fatalf("#%d: %v", seed, v)
+		}
+		committed := uint64(0)
+		for _, n := range s.c.nodes {
+			if n.rn != nil && n.rn.Status().Commit > committed {
+				committed = n.rn.Status().Commit
+			}
+		}
+		if committed <= 1 {
+			t.Errorf("#%d: committed = %d, want > 1", seed, committed)
+		}
+	}
+}
+
+// TestSimulationDeterministic tests that simulations with the same seed take
+// the same steps.
+func TestSimulationDeterministic(t *testing.T) {
+	a := New(Config{Seed: 42, Steps: 500})
+	b := New(Config{Seed: 42, Steps: 500})
+	a.Run()
+	b.Run()
+	if !reflect.DeepEqual(a.Trace(), b.Trace()) {
+		t.Errorf("traces of the same seed differ")
+	}
+}
+
+// TestSimulationShrink tests that a violation caused by an injected fault
+// is found and shrunk to a shorter trace that still violates it.
+func TestSimulationShrink(t *testing.T) {
+	cfg := Config{
+		Seed:  1,
+		Steps: 5000,
+		Configure: func(c *raft.Config) {
+			c.Faults = raft.NewFaultInjector(int64(c.ID))
+			c.Faults.Enable(raft.FaultCorruptEntry, raft.FaultSpec{Role: raft.FaultFollower, Probability: 0.2})
+		},
+	}
+	s := New(cfg)
+	for i := 0; i < cfg.Steps; i++ {
+		if _, v := s.Step(); v != nil {
+			shrunk := Shrink(cfg, v)
+			if len(shrunk.Trace) >= len(v.Trace) {
+				t.Errorf("len(shrunk trace) = %d, want < %d", len(shrunk.Trace), len(v.Trace))
+			}
+			if shrunk.Invariant != v.Invariant {
+				t.Errorf("shrunk invariant = %s, want %s", shrunk.Invariant, v.Invariant)
+			}
+			r := Replay(cfg, shrunk.Trace)
+			if r == nil || r.Invariant != v.Invariant || r.Step != len(shrunk.Trace)-1 {
+				t.Errorf("replay = %v, want %s at step %d", r, v.Invariant, len(shrunk.Trace)-1)
+			}
+			return
+		}
+	}
+	t.Fatalf("no violation found")
+}
+
+// TestReplayRestart tests that a node restarted after a crash recovers its
+// log from storage.
+func TestReplayRestart(t *testing.T) {
+	cfg := Config{Nodes: 1}.withDefaults()
+	c := newCluster(&cfg)
+	trace := make([]Event, 0)
+	for i := 0; i < 2*cfg.ElectionTick; i++ {
+		trace = append(trace, Event{Type: EventTick, Node: 1})
+	}
+	trace = append(trace,
+		Event{Type: EventPropose, Node: 1, Data: []byte("a")},
+		Event{Type: EventCrash, Node: 1},
+		Event{Type: EventRestart, Node: 1},
+	)
+	for i, e := range trace {
+		if ok, v := c.apply(e); !ok || v != nil {
+			t.Fatalf("#%d: apply(%s) = %v, %v, want true, nil", i, e, ok, v)
+		}
+		if v := c.check(); v != nil {
+			t.Fatalf("#%d: %v", i, v)
+		}
+	}
+
+	n := c.node(1)
+	st := n.rn.Status()
+	if st.Commit != 3 {
+		t.Errorf("commit = %d, want 3", st.Commit)
+	}
+	ents, err := n.storage.Entries(3, 4, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wents := []pb.Entry{{Term: 1, Index: 3, Data: []byte("a")}}; !reflect.DeepEqual(ents, wents) {
+		t.Errorf("ents = %+v, want %+v", ents, wents)
+	}
+	// the node does not restart twice.
+	if ok, _ := c.apply(Event{Type: EventRestart, Node: 1}); ok {
+		t.Errorf("restart of a running node = true, want false")
+	}
+}
@@ -41,7 +41,9 @@ func getStatus(r *raft) Status {
 	if s.RaftState == StateLeader {
 		s.Progress = make(map[uint64]Progress)
 		for id, p := range r.prs {
-			s.Progress[id] = *p
+			pr := *p
+			pr.ins = p.ins.clone()
+			s.Progress[id] = pr
 		}
 	}
 
@@ -58,7 +60,7 @@ func (s Status) MarshalJSON() ([]byte, error) {
 		j += "}}"
 	} else {
 		for k, v := range s.Progress {
-			subj := fmt.Sprintf(`"%x":{"match":%d,"next":%d,"state":%q},`, k, v.Match, v.Next, v.State)
+			subj := fmt.Sprintf(`"%x":{"match":%d,"next":%d,"state":%q,"inflightBytes":%d},`, k, v.Match, v.Next, v.State, v.InflightBytes())
 			j += subj
 		}
 		// remove the trailing ","
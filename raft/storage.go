@@ -16,7 +16,6 @@ package raft
 
 import (
 	"errors"
-	"math/rand"
 	"sync"
 
 	pb "github.com/coreos/etcd/raft/raftpb"
@@ -257,17 +256,5 @@ func (ms *MemoryStorage) Append(entries []pb.Entry) error {
 		raftLogger.Panicf("missing log entry [last: %d, append at: %d]",
 			ms.lastIndex(), entries[0].Index)
 	}
-	//DB2 Log matching bug
-	if DB2 {
-		//fmt.Println("ENTERING THE BUG")
-		var bug byte
-		if len(ms.ents) > 20 {
-			startbug()
-			bugInt := rand.Int() % 1000
-			bug = byte(uint8(bugInt & 0xff))
-			ms.ents[15].Data[0] = bug
-			//fmt.Printf("Injecting Bug! %d\n", bug)
-		}
-	}
 	return nil
 }
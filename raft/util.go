@@ -16,6 +16,7 @@ package raft
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 
 	pb "github.com/coreos/etcd/raft/raftpb"
@@ -25,6 +26,20 @@ func (st StateType) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%q", st.String())), nil
 }
 
+func (st *StateType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	for i, name := range stmap {
+		if name == s {
+			*st = StateType(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("raft: unknown state %q", s)
+}
+
 // uint64Slice implements sort interface
 type uint64Slice []uint64
 
@@ -48,7 +63,7 @@ func max(a, b uint64) uint64 {
 
 func IsLocalMsg(msgt pb.MessageType) bool {
 	return msgt == pb.MsgHup || msgt == pb.MsgBeat || msgt == pb.MsgUnreachable ||
-		msgt == pb.MsgSnapStatus || msgt == pb.MsgCheckQuorum || msgt == pb.MsgTransferLeader
+		msgt == pb.MsgSnapStatus || msgt == pb.MsgCheckQuorum
 }
 
 func IsResponseMsg(msgt pb.MessageType) bool {
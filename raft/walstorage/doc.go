@@ -0,0 +1,51 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package walstorage provides a raft.Storage that persists the raft state on
+disk, with the write ahead log and the snapshots etcd itself uses.
+
+A Storage keeps its WAL in the wal subdirectory of the given directory and
+its snapshots in the snap subdirectory. Create makes a new one, and Open
+recovers an existing one after a restart or a crash:
+
+	var s *walstorage.Storage
+	if walstorage.Exist(dir) {
+		s, err = walstorage.Open(dir)
+		n = raft.RestartNode(&raft.Config{Storage: s, ...})
+	} else {
+		s, err = walstorage.Create(dir, nil)
+		n = raft.StartNode(&raft.Config{Storage: s, ...}, peers)
+	}
+
+The Storage is both the raft.Storage of the node and where the application
+persists its Ready:
+
+	rd := <-n.Ready()
+	s.SaveSnap(rd.Snapshot)
+	s.Save(rd.HardState, rd.Entries)
+	send(rd.Messages)
+	apply(rd.CommittedEntries)
+	n.Advance()
+
+Once it applied enough entries, the application snapshots its state and
+discards the entries before it:
+
+	snapshot, err := s.CreateSnapshot(applied, &confState, data)
+	s.Compact(applied)
+
+The entries after the last compaction are cached in memory. The WAL files
+made unnecessary by a snapshot are released but not removed.
+*/
+package walstorage
@@ -0,0 +1,287 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walstorage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/coreos/etcd/pkg/fileutil"
+	"github.com/coreos/etcd/raft"
+	pb "github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/snap"
+	"github.com/coreos/etcd/wal"
+	"github.com/coreos/etcd/wal/walpb"
+	"github.com/coreos/pkg/capnslog"
+)
+
+var (
+	plog = capnslog.NewPackageLogger("github.com/coreos/etcd", "raft/walstorage")
+
+	// ErrCompactBeyondSnapshot is returned by Compact when the compact index
+	// is after the last snapshot, which would make entries unavailable to
+	// the followers that are behind.
+	ErrCompactBeyondSnapshot = errors.New("walstorage: compact index is after the last snapshot")
+)
+
+// Storage implements the raft.Storage interface on top of a WAL and a
+// Snapshotter. The entries after the last compaction are cached in memory,
+// so reading the log never touches the disk.
+type Storage struct {
+	// mu serializes the writes; reads are served by the cache, which has
+	// its own lock.
+	mu sync.Mutex
+
+	w        *wal.WAL
+	ss       *snap.Snapshotter
+	metadata []byte
+	// cache holds the persisted state, the latest snapshot and the entries
+	// after the last compaction.
+	cache *raft.MemoryStorage
+}
+
+// Exist returns true if there is a Storage in dir.
+func Exist(dir string) bool { return wal.Exist(walDir(dir)) }
+
+// Create creates a Storage in dir. The given metadata is recorded in the WAL
+// and can be retrieved with Metadata once the Storage is opened again.
+func Create(dir string, metadata []byte) (*Storage, error) {
+	if err := fileutil.TouchDirAll(snapDir(dir)); err != nil {
+		return nil, err
+	}
+	w, err := wal.Create(walDir(dir), metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{
+		w:        w,
+		ss:       snap.New(snapDir(dir)),
+		metadata: metadata,
+		cache:    raft.NewMemoryStorage(),
+	}, nil
+}
+
+// Open opens the Storage in dir and recovers its state: it loads the latest
+// snapshot and replays the WAL written after it. A WAL torn by a crash in the
+// middle of a write is repaired by dropping the partial record, and a
+// snapshot saved right before a crash but never recorded in the WAL is set
+// aside for the previous one.
+func Open(dir string) (*Storage, error) {
+	ss := snap.New(snapDir(dir))
+	var (
+		snapshot *pb.Snapshot
+		walsnap  walpb.Snapshot
+		err      error
+	)
+	for {
+		snapshot, err = ss.Load()
+		if err != nil && err != snap.ErrNoSnapshot {
+			return nil, err
+		}
+		if snapshot == nil {
+			break
+		}
+		walsnap.Index, walsnap.Term = snapshot.Metadata.Index, snapshot.Metadata.Term
+		recorded, err := walHasSnap(walDir(dir), walsnap)
+		if err != nil {
+			return nil, err
+		}
+		if recorded {
+			break
+		}
+		if err = setAsideSnap(dir, walsnap); err != nil {
+			return nil, err
+		}
+		walsnap = walpb.Snapshot{}
+	}
+	w, metadata, st, ents, err := readWAL(walDir(dir), walsnap)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := raft.NewMemoryStorage()
+	if snapshot != nil {
+		cache.ApplySnapshot(*snapshot)
+	}
+	cache.SetHardState(st)
+	cache.Append(ents)
+	return &Storage{w: w, ss: ss, metadata: metadata, cache: cache}, nil
+}
+
+// readWAL opens the WAL at snap and reads all of its records after it.
+func readWAL(dir string, snap walpb.Snapshot) (w *wal.WAL, metadata []byte, st pb.HardState, ents []pb.Entry, err error) {
+	repaired := false
+	for {
+		if w, err = wal.Open(dir, snap); err != nil {
+			return nil, nil, st, nil, err
+		}
+		if metadata, st, ents, err = w.ReadAll(); err != nil {
+			w.Close()
+			// we can only repair ErrUnexpectedEOF and we never repair twice.
+			if repaired || err != io.ErrUnexpectedEOF {
+				return nil, nil, st, nil, err
+			}
+			if !wal.Repair(dir) {
+				return nil, nil, st, nil, err
+			}
+			plog.Infof("repaired the torn WAL in %s", dir)
+			repaired = true
+			continue
+		}
+		return w, metadata, st, ents, nil
+	}
+}
+
+// walHasSnap reports whether the WAL in dir has a record of walsnap. Opened
+// for writing, the WAL does not report a missing snapshot record, so it is
+// read once more for reading.
+func walHasSnap(dir string, walsnap walpb.Snapshot) (bool, error) {
+	w, err := wal.OpenForRead(dir, walsnap)
+	if err != nil {
+		return false, err
+	}
+	defer w.Close()
+	switch _, _, _, err = w.ReadAll(); err {
+	case nil:
+		return true, nil
+	case wal.ErrSnapshotNotFound:
+		return false, nil
+	}
+	return false, err
+}
+
+// setAsideSnap renames the file of the snapshot saved at walsnap, which the
+// WAL has no record of, so it is no longer loaded.
+func setAsideSnap(dir string, walsnap walpb.Snapshot) error {
+	fpath := path.Join(snapDir(dir), fmt.Sprintf("%016x-%016x.snap", walsnap.Term, walsnap.Index))
+	plog.Warningf("setting aside snapshot %s not recorded in the WAL", fpath)
+	return os.Rename(fpath, fpath+".broken")
+}
+
+// Metadata returns the metadata the Storage was created with.
+func (s *Storage) Metadata() []byte { return s.metadata }
+
+// InitialState implements the raft.Storage interface.
+func (s *Storage) InitialState() (pb.HardState, pb.ConfState, error) {
+	return s.cache.InitialState()
+}
+
+// Entries implements the raft.Storage interface.
+func (s *Storage) Entries(lo, hi, maxSize uint64) ([]pb.Entry, error) {
+	return s.cache.Entries(lo, hi, maxSize)
+}
+
+// Term implements the raft.Storage interface.
+func (s *Storage) Term(i uint64) (uint64, error) { return s.cache.Term(i) }
+
+// LastIndex implements the raft.Storage interface.
+func (s *Storage) LastIndex() (uint64, error) { return s.cache.LastIndex() }
+
+// FirstIndex implements the raft.Storage interface.
+func (s *Storage) FirstIndex() (uint64, error) { return s.cache.FirstIndex() }
+
+// Snapshot implements the raft.Storage interface.
+func (s *Storage) Snapshot() (pb.Snapshot, error) { return s.cache.Snapshot() }
+
+// Save persists the HardState and the entries of a Ready. It blocks until
+// they are on stable storage.
+func (s *Storage) Save(st pb.HardState, ents []pb.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.w.Save(st, ents); err != nil {
+		return err
+	}
+	if !raft.IsEmptyHardState(st) {
+		s.cache.SetHardState(st)
+	}
+	return s.cache.Append(ents)
+}
+
+// SaveSnap persists the snapshot of a Ready, which replaces the log up to its
+// index. It must be called before Save of the same Ready.
+func (s *Storage) SaveSnap(snapshot pb.Snapshot) error {
+	if raft.IsEmptySnap(snapshot) {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.saveSnap(snapshot); err != nil {
+		return err
+	}
+	return s.cache.ApplySnapshot(snapshot)
+}
+
+// CreateSnapshot makes and persists a snapshot of the state of the
+// application at index i, which must have been applied. cs is the ConfState
+// at i and data is the state of the application.
+func (s *Storage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (pb.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, err := s.cache.CreateSnapshot(i, cs, data)
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+	return snapshot, s.saveSnap(snapshot)
+}
+
+// saveSnap writes snapshot to the WAL and to the snapshot directory, and
+// releases the WAL files before it.
+func (s *Storage) saveSnap(snapshot pb.Snapshot) error {
+	walsnap := walpb.Snapshot{
+		Index: snapshot.Metadata.Index,
+		Term:  snapshot.Metadata.Term,
+	}
+	// The snapshot file is synced before the WAL refers to it, so a crash
+	// in between leaves at worst a snapshot file the WAL does not know of,
+	// which Open sets aside.
+	if err := s.ss.SaveSnap(snapshot); err != nil {
+		return err
+	}
+	if err := s.w.SaveSnapshot(walsnap); err != nil {
+		return err
+	}
+	return s.w.ReleaseLockTo(snapshot.Metadata.Index)
+}
+
+// Compact discards the cached entries up to compactIndex, which must not be
+// after the last snapshot. The WAL files are released when snapshots are
+// saved; removing the released files is up to the application.
+func (s *Storage) Compact(compactIndex uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, err := s.cache.Snapshot()
+	if err != nil {
+		return err
+	}
+	if compactIndex > snapshot.Metadata.Index {
+		return ErrCompactBeyondSnapshot
+	}
+	return s.cache.Compact(compactIndex)
+}
+
+// Close closes the Storage.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Close()
+}
+
+func walDir(dir string) string { return path.Join(dir, "wal") }
+
+func snapDir(dir string) string { return path.Join(dir, "snap") }
@@ -0,0 +1,269 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/pkg/fileutil"
+	"github.com/coreos/etcd/raft"
+	pb "github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/snap"
+)
+
+func TestStorageReopen(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "walstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if Exist(dir) {
+		t.Fatalf("exist = true, want false")
+	}
+	s, err := Create(dir, []byte("metadata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ents := []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}, {Index: 4, Term: 2}}
+	st := pb.HardState{Term: 2, Vote: 1, Commit: 4}
+	if err = s.Save(st, ents); err != nil {
+		t.Fatal(err)
+	}
+	cs := &pb.ConfState{Nodes: []uint64{1, 2, 3}}
+	if _, err = s.CreateSnapshot(3, cs, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Compact(4); err != ErrCompactBeyondSnapshot {
+		t.Fatalf("err = %v, want %v", err, ErrCompactBeyondSnapshot)
+	}
+	if err = s.Compact(3); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Save(pb.HardState{}, []pb.Entry{{Index: 5, Term: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	if !Exist(dir) {
+		t.Fatalf("exist = false, want true")
+	}
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if g := s.Metadata(); string(g) != "metadata" {
+		t.Errorf("metadata = %q, want %q", g, "metadata")
+	}
+	gst, gcs, err := s.InitialState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gst, st) {
+		t.Errorf("hardstate = %+v, want %+v", gst, st)
+	}
+	if !reflect.DeepEqual(gcs, *cs) {
+		t.Errorf("confstate = %+v, want %+v", gcs, *cs)
+	}
+	snap, err := s.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Metadata.Index != 3 || snap.Metadata.Term != 2 || string(snap.Data) != "data" {
+		t.Errorf("snapshot = %+v, want index 3, term 2 and data %q", snap, "data")
+	}
+	if fi, _ := s.FirstIndex(); fi != 4 {
+		t.Errorf("firstIndex = %d, want %d", fi, 4)
+	}
+	if li, _ := s.LastIndex(); li != 5 {
+		t.Errorf("lastIndex = %d, want %d", li, 5)
+	}
+	gents, err := s.Entries(4, 6, noLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wents := []pb.Entry{{Index: 4, Term: 2}, {Index: 5, Term: 2}}
+	if !reflect.DeepEqual(gents, wents) {
+		t.Errorf("entries = %+v, want %+v", gents, wents)
+	}
+	if _, err = s.Entries(3, 4, noLimit); err != raft.ErrCompacted {
+		t.Errorf("err = %v, want %v", err, raft.ErrCompacted)
+	}
+}
+
+func TestStorageSaveSnap(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "walstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := Create(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Save(pb.HardState{Term: 1, Commit: 2}, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	// a snapshot from the leader replaces the whole log.
+	snap := pb.Snapshot{
+		Data:     []byte("data"),
+		Metadata: pb.SnapshotMetadata{Index: 10, Term: 3, ConfState: pb.ConfState{Nodes: []uint64{1, 2}}},
+	}
+	if err = s.SaveSnap(pb.Snapshot{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.SaveSnap(snap); err != nil {
+		t.Fatal(err)
+	}
+	st := pb.HardState{Term: 3, Commit: 10}
+	if err = s.Save(st, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	gst, _, err := s.InitialState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gst, st) {
+		t.Errorf("hardstate = %+v, want %+v", gst, st)
+	}
+	if fi, _ := s.FirstIndex(); fi != 11 {
+		t.Errorf("firstIndex = %d, want %d", fi, 11)
+	}
+	if li, _ := s.LastIndex(); li != 10 {
+		t.Errorf("lastIndex = %d, want %d", li, 10)
+	}
+	if term, _ := s.Term(10); term != 3 {
+		t.Errorf("term = %d, want %d", term, 3)
+	}
+}
+
+// TestStorageOpenUnrecordedSnap tests that a snapshot file saved right
+// before a crash, without its WAL record, is set aside on Open.
+func TestStorageOpenUnrecordedSnap(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "walstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := Create(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ents := []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 1}}
+	st := pb.HardState{Term: 1, Commit: 3}
+	if err = s.Save(st, ents); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.CreateSnapshot(2, &pb.ConfState{Nodes: []uint64{1}}, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// crash after syncing the snapshot file at 3 but before its WAL record.
+	unrecorded := pb.Snapshot{Metadata: pb.SnapshotMetadata{Index: 3, Term: 1, ConfState: pb.ConfState{Nodes: []uint64{1}}}}
+	if err = snap.New(snapDir(dir)).SaveSnap(unrecorded); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	gsnap, err := s.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gsnap.Metadata.Index != 2 || string(gsnap.Data) != "data" {
+		t.Errorf("snapshot = %+v, want index 2 and data %q", gsnap, "data")
+	}
+	gents, err := s.Entries(3, 4, noLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gents, ents[2:]) {
+		t.Errorf("entries = %+v, want %+v", gents, ents[2:])
+	}
+}
+
+// TestStorageOpenTornWAL tests that a WAL torn in the middle of its last
+// record is repaired on Open by dropping the partial record.
+func TestStorageOpenTornWAL(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "walstorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := Create(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(1); i <= 5; i++ {
+		if err = s.Save(pb.HardState{}, []pb.Entry{{Index: i, Term: 1, Data: []byte("data")}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Close()
+
+	// tear the last record, which is the entry at 5; the WAL file is
+	// preallocated, so its data ends at the last non-zero byte.
+	names, err := fileutil.ReadDir(walDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fpath := path.Join(walDir(dir), names[len(names)-1])
+	b, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	end := len(b)
+	for end > 0 && b[end-1] == 0 {
+		end--
+	}
+	if err = os.Truncate(fpath, int64(end-4)); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if li, _ := s.LastIndex(); li != 4 {
+		t.Errorf("lastIndex = %d, want %d", li, 4)
+	}
+	// the repaired WAL takes new writes.
+	if err = s.Save(pb.HardState{}, []pb.Entry{{Index: 5, Term: 2}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const noLimit = 1<<64 - 1
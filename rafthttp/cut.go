@@ -0,0 +1,125 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// The consistent cuts of raft require the messages a peer sends to be
+// delivered in order, marker included. Messages to a peer are spread over
+// its streams, so a MsgCutMarker is sent over every working stream with the
+// number of copies in RejectHint. The streams of the receiving peer deliver
+// the marker to raft once, after its copy arrived on every stream, and hold
+// the messages that follow a copy until then. Messages sent over the
+// pipeline, such as snapshots, and proposals are not ordered with markers.
+
+const (
+	// cutMarkerTimeout is how long a stream waits for the copies of a marker
+	// on the other streams before delivering it anyway.
+	cutMarkerTimeout = time.Second
+	// maxCutHistory is the number of delivered markers remembered to drop
+	// their late copies.
+	maxCutHistory = 64
+)
+
+func isMsgCutMarker(m raftpb.Message) bool { return m.Type == raftpb.MsgCutMarker }
+
+type cutMarkerKey struct {
+	initiator uint64
+	id        uint64
+}
+
+type pendingMarker struct {
+	arrived int
+	// donec is closed once the marker is delivered.
+	donec chan struct{}
+}
+
+// cutBarrier gathers the copies of the markers received on the streams of
+// a peer.
+type cutBarrier struct {
+	mu        sync.Mutex
+	pending   map[cutMarkerKey]*pendingMarker
+	delivered map[cutMarkerKey]bool
+	history   []cutMarkerKey
+}
+
+func newCutBarrier() *cutBarrier {
+	return &cutBarrier{
+		pending:   make(map[cutMarkerKey]*pendingMarker),
+		delivered: make(map[cutMarkerKey]bool),
+	}
+}
+
+// wait waits until the copies of the marker m arrived on every stream, the
+// marker timed out or stopc is closed. It returns true to the one caller
+// that must deliver the marker to raft.
+func (b *cutBarrier) wait(m raftpb.Message, stopc <-chan struct{}) bool {
+	k := cutMarkerKey{initiator: m.Commit, id: m.Index}
+	copies := int(m.RejectHint)
+	if copies < 1 {
+		copies = 1
+	}
+
+	b.mu.Lock()
+	if b.delivered[k] {
+		b.mu.Unlock()
+		return false
+	}
+	pm, ok := b.pending[k]
+	if !ok {
+		pm = &pendingMarker{donec: make(chan struct{})}
+		b.pending[k] = pm
+	}
+	pm.arrived++
+	if pm.arrived >= copies {
+		b.deliver(k, pm)
+		b.mu.Unlock()
+		return true
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-pm.donec:
+		return false
+	case <-time.After(cutMarkerTimeout):
+	case <-stopc:
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.delivered[k] {
+		return false
+	}
+	plog.Warningf("delivering cut marker %d of %x after %d of its %d copies", k.id, k.initiator, pm.arrived, copies)
+	b.deliver(k, pm)
+	return true
+}
+
+// deliver marks the marker k as delivered. b.mu MUST be held.
+func (b *cutBarrier) deliver(k cutMarkerKey, pm *pendingMarker) {
+	close(pm.donec)
+	delete(b.pending, k)
+	b.delivered[k] = true
+	b.history = append(b.history, k)
+	if len(b.history) > maxCutHistory {
+		delete(b.delivered, b.history[0])
+		b.history = b.history[1:]
+	}
+}
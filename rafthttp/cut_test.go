@@ -0,0 +1,86 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/stats"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// TestCutBarrier tests that a marker sent over two streams is delivered
+// once, after both copies arrived, and that the first copy is held until
+// then.
+func TestCutBarrier(t *testing.T) {
+	b := newCutBarrier()
+	m := raftpb.Message{Type: raftpb.MsgCutMarker, From: 2, Index: 7, Commit: 3, RejectHint: 2}
+	stopc := make(chan struct{})
+
+	firstc := make(chan bool, 1)
+	go func() { firstc <- b.wait(m, stopc) }()
+	select {
+	case <-firstc:
+		t.Fatalf("first copy returned before the second arrived")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if !b.wait(m, stopc) {
+		t.Errorf("deliver second copy = false, want true")
+	}
+	select {
+	case deliver := <-firstc:
+		if deliver {
+			t.Errorf("deliver first copy = true, want false")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("first copy still held after the second arrived")
+	}
+
+	// late copies of a delivered marker are dropped
+	if b.wait(m, stopc) {
+		t.Errorf("deliver late copy = true, want false")
+	}
+	// a marker sent over one stream is delivered right away
+	if !b.wait(raftpb.Message{Type: raftpb.MsgCutMarker, From: 2, Index: 8, Commit: 3}, stopc) {
+		t.Errorf("deliver single copy = false, want true")
+	}
+}
+
+// TestPeerSendCutMarkerPipeline tests that a marker is sent over the
+// pipeline if no stream works.
+func TestPeerSendCutMarkerPipeline(t *testing.T) {
+	status := newPeerStatus(types.ID(1))
+	p := &peer{
+		id:             types.ID(1),
+		msgAppV2Writer: startStreamWriter(types.ID(1), status, &stats.FollowerStats{}, &fakeRaft{}),
+		writer:         startStreamWriter(types.ID(1), status, &stats.FollowerStats{}, &fakeRaft{}),
+		pipeline:       &pipeline{msgc: make(chan raftpb.Message, 1)},
+	}
+	defer p.msgAppV2Writer.stop()
+	defer p.writer.stop()
+
+	p.send(raftpb.Message{Type: raftpb.MsgCutMarker, To: 1, Index: 7})
+	select {
+	case m := <-p.pipeline.msgc:
+		if m.RejectHint != 1 {
+			t.Errorf("copies = %d, want 1", m.RejectHint)
+		}
+	default:
+		t.Fatalf("marker not sent over the pipeline")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+
+	"bitbucket.org/bestchai/dinv/dinvRT"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// dinvHeader is the header through which peers agree to piggyback dinv
+// vector clocks on the messages they exchange. The dialer of a stream sets it
+// if it can piggyback, and the peer answers with it if both can. Pipeline and
+// snapshot requests set it when their body carries the clocks.
+const dinvHeader = "X-Dinv-Piggyback"
+
+// maxClockSize bounds the size of a piggybacked clock, so a corrupt length
+// does not make the decoder allocate unbounded memory.
+const maxClockSize = 1 << 20
+
+// setCapabilityHeader sets the header key of h if on.
+func setCapabilityHeader(h http.Header, key string, on bool) {
+	if on {
+		h.Set(key, "true")
+	}
+}
+
+func hasCapabilityHeader(h http.Header, key string) bool { return h.Get(key) == "true" }
+
+// A piggybacked message is the length prefixed clock of the sender followed
+// by the message as encoded without the clock.
+//
+// +--------+---------------------------+
+// | offset | bytes | description       |
+// +--------+---------------------------+
+// | 0      | 8     | length of clock   |
+// | 8      | n     | clock             |
+// | 8+n    | m     | encoded message   |
+//
+// The encoded message may piggyback another clock in turn. Link heartbeat
+// messages carry empty clocks, so they do not show up as events in the logs.
+
+// writeClock writes the length prefixed clock to w and returns the number of
+// bytes written.
+func writeClock(w io.Writer, clock []byte) (int, error) {
+	if err := binary.Write(w, binary.BigEndian, uint64(len(clock))); err != nil {
+		return 0, err
+	}
+	n, err := w.Write(clock)
+	return 8 + n, err
+}
+
+// readClock reads a length prefixed clock from r. It returns a nil clock if
+// the clock is empty, and the number of bytes read.
+func readClock(r io.Reader) ([]byte, int, error) {
+	var l uint64
+	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+		return nil, 0, err
+	}
+	if l == 0 {
+		return nil, 8, nil
+	}
+	if l > maxClockSize {
+		return nil, 8, fmt.Errorf("clock size %d exceeds the maximum %d", l, maxClockSize)
+	}
+	clock := make([]byte, int(l))
+	if _, err := io.ReadFull(r, clock); err != nil {
+		return nil, 8, err
+	}
+	return clock, 8 + len(clock), nil
+}
+
+// writeDinvClock writes the current dinv vector clock to w and returns the
+// number of bytes written.
+func writeDinvClock(w io.Writer, heartbeat bool) (int, error) {
+	var clock []byte
+	if !heartbeat {
+		clock = dinvRT.Pack([]byte{})
+	}
+	return writeClock(w, clock)
+}
+
+// readDinvClock reads a dinv vector clock from r, merges it into the local
+// one and returns the number of bytes read.
+func readDinvClock(r io.Reader) (int, error) {
+	clock, n, err := readClock(r)
+	if err != nil || clock == nil {
+		return n, err
+	}
+	var b []byte
+	dinvRT.Unpack(clock, &b)
+	return n, nil
+}
+
+// dinvEncoder piggybacks the vector clock on every message encoded by enc.
+// w MUST be the writer enc writes to.
+type dinvEncoder struct {
+	w    io.Writer
+	enc  encoder
+	path string
+}
+
+func (e *dinvEncoder) encode(m *raftpb.Message) error {
+	n, err := writeDinvClock(e.w, isLinkHeartbeatMessage(m))
+	dinvSentBytes.WithLabelValues(e.path).Add(float64(n))
+	if err != nil {
+		return err
+	}
+	return e.enc.encode(m)
+}
+
+// dinvDecoder reads the vector clock piggybacked on every message decoded by
+// dec. r MUST be the reader dec reads from.
+type dinvDecoder struct {
+	r    io.Reader
+	dec  decoder
+	path string
+}
+
+func (d *dinvDecoder) decode() (raftpb.Message, error) {
+	n, err := readDinvClock(d.r)
+	dinvReceivedBytes.WithLabelValues(d.path).Add(float64(n))
+	if err != nil {
+		return raftpb.Message{}, err
+	}
+	return d.dec.decode()
+}
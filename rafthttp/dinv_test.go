@@ -0,0 +1,251 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/stats"
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/version"
+)
+
+func TestDinvCodec(t *testing.T) {
+	msgs := []raftpb.Message{
+		{Type: raftpb.MsgApp, From: 1, To: 2, Term: 1, LogTerm: 1, Index: 3, Entries: []raftpb.Entry{{Term: 1, Index: 4}}},
+		{Type: raftpb.MsgHeartbeat, From: 1, To: 2, Term: 1, Commit: 4},
+		linkHeartbeatMessage,
+	}
+	codecs := []struct {
+		enc func(io.Writer) encoder
+		dec func(io.Reader) decoder
+	}{
+		{
+			func(w io.Writer) encoder { return &messageEncoder{w: w} },
+			func(r io.Reader) decoder { return &messageDecoder{r: r} },
+		},
+		{
+			func(w io.Writer) encoder { return newMsgAppV2Encoder(w, &stats.FollowerStats{}) },
+			func(r io.Reader) decoder { return newMsgAppV2Decoder(r, types.ID(2), types.ID(1)) },
+		},
+	}
+	for i, c := range codecs {
+		b := &bytes.Buffer{}
+		enc := &dinvEncoder{w: b, enc: c.enc(b), path: "test"}
+		dec := &dinvDecoder{r: b, dec: c.dec(b), path: "test"}
+		for j, tt := range msgs {
+			if err := enc.encode(&tt); err != nil {
+				t.Fatalf("#%d.%d: unexpected encode message error: %v", i, j, err)
+			}
+			m, err := dec.decode()
+			if err != nil {
+				t.Fatalf("#%d.%d: unexpected decode message error: %v", i, j, err)
+			}
+			if !reflect.DeepEqual(m, tt) {
+				t.Errorf("#%d.%d: message = %+v, want %+v", i, j, m, tt)
+			}
+		}
+		if b.Len() != 0 {
+			t.Errorf("#%d: %d bytes left unread", i, b.Len())
+		}
+	}
+}
+
+func TestReadDinvClock(t *testing.T) {
+	tests := []struct {
+		clock []byte
+		l     uint64
+
+		wn   int
+		werr bool
+	}{
+		{nil, 0, 8, false},
+		{[]byte("clock"), 5, 13, false},
+		{[]byte("clock"), 10, 8, true},
+		{nil, maxClockSize + 1, 8, true},
+	}
+	for i, tt := range tests {
+		b := &bytes.Buffer{}
+		binary.Write(b, binary.BigEndian, tt.l)
+		b.Write(tt.clock)
+		b.WriteString("rest")
+		n, err := readDinvClock(b)
+		if n != tt.wn {
+			t.Errorf("#%d: n = %d, want %d", i, n, tt.wn)
+		}
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if !tt.werr && b.String() != "rest" {
+			t.Errorf("#%d: rest = %q, want %q", i, b.String(), "rest")
+		}
+	}
+}
+
+// TestServeRaftStreamPrefixDinv tests that the stream handler agrees to
+// piggyback dinv vector clocks only if both sides enable it.
+func TestServeRaftStreamPrefixDinv(t *testing.T) {
+	tests := []struct {
+		local, remote bool
+
+		w bool
+	}{
+		{false, false, false},
+		{false, true, false},
+		{true, false, false},
+		{true, true, true},
+	}
+	for i, tt := range tests {
+		req, err := http.NewRequest("GET", "http://localhost:2380"+RaftStreamPrefix+"/message/1", nil)
+		if err != nil {
+			t.Fatalf("#%d: could not create request: %#v", i, err)
+		}
+		req.Header.Set("X-Etcd-Cluster-ID", "1")
+		req.Header.Set("X-Server-Version", version.Version)
+		req.Header.Set("X-Raft-To", "2")
+		setCapabilityHeader(req.Header, dinvHeader, tt.remote)
+
+		peer := newFakePeer()
+		peerGetter := &fakePeerGetter{peers: map[types.ID]Peer{types.ID(1): peer}}
+		h := newStreamHandler(&Transport{Dinv: tt.local}, peerGetter, &fakeRaft{}, types.ID(2), types.ID(1))
+
+		rw := httptest.NewRecorder()
+		go h.ServeHTTP(rw, req)
+
+		var conn *outgoingConn
+		select {
+		case conn = <-peer.connc:
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: failed to attach outgoingConn", i)
+		}
+		if g := hasCapabilityHeader(rw.Header(), dinvHeader); g != tt.w {
+			t.Errorf("#%d: response piggyback = %v, want %v", i, g, tt.w)
+		}
+		if conn.dinv != tt.w {
+			t.Errorf("#%d: conn piggyback = %v, want %v", i, conn.dinv, tt.w)
+		}
+		conn.Close()
+	}
+}
+
+func TestStreamReaderDialDinv(t *testing.T) {
+	tests := []struct {
+		local, remote bool
+
+		w bool
+	}{
+		{false, false, false},
+		{false, true, false},
+		{true, false, false},
+		{true, true, true},
+	}
+	for i, tt := range tests {
+		h := http.Header{}
+		h.Add("X-Server-Version", version.Version)
+		setCapabilityHeader(h, dinvHeader, tt.remote)
+		tr := &respRoundTripper{code: http.StatusOK, header: h}
+		sr := &streamReader{
+			peerID: types.ID(2),
+			tr:     &Transport{streamRt: tr, ClusterID: types.ID(1), Dinv: tt.local},
+			picker: mustNewURLPicker(t, []string{"http://localhost:2380"}),
+		}
+		if _, err := sr.dial(streamTypeMessage); err != nil {
+			t.Fatalf("#%d: unexpected dial error: %v", i, err)
+		}
+		if sr.dinv != tt.w {
+			t.Errorf("#%d: piggyback = %v, want %v", i, sr.dinv, tt.w)
+		}
+	}
+}
+
+func TestPipelinePostDinv(t *testing.T) {
+	tests := []struct {
+		local, negotiated bool
+
+		w bool
+	}{
+		{false, false, false},
+		{false, true, false},
+		{true, false, false},
+		{true, true, true},
+	}
+	data := pbutil.MustMarshal(&raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 2})
+	for i, tt := range tests {
+		tr := &roundTripperRecorder{}
+		picker := mustNewURLPicker(t, []string{"http://localhost:2380"})
+		tp := &Transport{ClusterID: types.ID(1), pipelineRt: tr, Dinv: tt.local}
+		p := startTestPipeline(tp, picker)
+		p.status.setDinv(tt.negotiated)
+		if err := p.post(data, nil); err != nil {
+			t.Fatalf("#%d: unexpected post error: %v", i, err)
+		}
+		p.stop()
+
+		req := tr.Request()
+		if g := hasCapabilityHeader(req.Header, dinvHeader); g != tt.w {
+			t.Errorf("#%d: piggyback = %v, want %v", i, g, tt.w)
+		}
+		if tt.w {
+			if _, err := readDinvClock(req.Body); err != nil {
+				t.Fatalf("#%d: unexpected read clock error: %v", i, err)
+			}
+		}
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("#%d: unexpected ReadAll error: %v", i, err)
+		}
+		if !bytes.Equal(b, data) {
+			t.Errorf("#%d: body = %v, want %v", i, b, data)
+		}
+	}
+}
+
+// TestServeRaftPrefixDinv tests that the pipeline handler reads out the
+// piggybacked dinv vector clock before the message.
+func TestServeRaftPrefixDinv(t *testing.T) {
+	wm := raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 2, Term: 1}
+	body := &bytes.Buffer{}
+	writeDinvClock(body, false)
+	body.Write(pbutil.MustMarshal(&wm))
+
+	req, err := http.NewRequest("POST", "foo", body)
+	if err != nil {
+		t.Fatalf("could not create request: %#v", err)
+	}
+	req.Header.Set("X-Etcd-Cluster-ID", "0")
+	req.Header.Set("X-Server-Version", version.Version)
+	setCapabilityHeader(req.Header, dinvHeader, true)
+
+	recvc := make(chan raftpb.Message, 1)
+	rw := httptest.NewRecorder()
+	h := newPipelineHandler(NewNopTransporter(), &fakeRaft{recvc: recvc}, types.ID(0), nil)
+	h.ServeHTTP(rw, req)
+	if rw.Code != http.StatusNoContent {
+		t.Fatalf("code = %d, want %d", rw.Code, http.StatusNoContent)
+	}
+	if m := <-recvc; !reflect.DeepEqual(m, wm) {
+		t.Errorf("message = %+v, want %+v", m, wm)
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	pioutil "github.com/coreos/etcd/pkg/ioutil"
 	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/snap"
 	"github.com/coreos/etcd/version"
@@ -62,6 +63,7 @@ type pipelineHandler struct {
 	tr	Transporter
 	r	Raft
 	cid	types.ID
+	tracer	raft.Tracer
 }
 
 // newPipelineHandler returns a handler for handling raft messages
@@ -69,11 +71,12 @@ type pipelineHandler struct {
 //
 // The handler reads out the raft message from request body,
 // and forwards it to the given raft state machine for processing.
-func newPipelineHandler(tr Transporter, r Raft, cid types.ID) http.Handler {
+func newPipelineHandler(tr Transporter, r Raft, cid types.ID, tracer raft.Tracer) http.Handler {
 	return &pipelineHandler{
 		tr:	tr,
 		r:	r,
 		cid:	cid,
+		tracer:	tracer,
 	}
 }
 
@@ -100,6 +103,24 @@ func (h *pipelineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Limit the data size that could be read from the request body, which ensures that read from
 	// connection will not time out accidentally due to possible blocking in underlying implementation.
 	limitedr := pioutil.NewLimitedBufferReader(r.Body, connReadLimitByte)
+	if hasCapabilityHeader(r.Header, dinvHeader) {
+		n, err := readDinvClock(limitedr)
+		dinvReceivedBytes.WithLabelValues(pipelineMsg).Add(float64(n))
+		if err != nil {
+			plog.Errorf("failed to read dinv vector clock (%v)", err)
+			http.Error(w, "error reading dinv vector clock", http.StatusBadRequest)
+			return
+		}
+	}
+	var clock []byte
+	if hasCapabilityHeader(r.Header, traceHeader) {
+		var err error
+		if clock, _, err = readClock(limitedr); err != nil {
+			plog.Errorf("failed to read trace vector clock (%v)", err)
+			http.Error(w, "error reading trace vector clock", http.StatusBadRequest)
+			return
+		}
+	}
 	b, err := ioutil.ReadAll(limitedr)
 	if err != nil {
 		plog.Errorf("failed to read raft message (%v)", err)
@@ -115,6 +136,9 @@ func (h *pipelineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	receivedBytes.WithLabelValues(types.ID(m.From).String()).Add(float64(len(b)))
+	if h.tracer != nil {
+		traceReceive(h.tracer, &m, clock)
+	}
 
 	if err := h.r.Process(context.TODO(), m); err != nil {
 		switch v := err.(type) {
@@ -137,14 +161,16 @@ type snapshotHandler struct {
 	r		Raft
 	snapshotter	*snap.Snapshotter
 	cid		types.ID
+	tracer		raft.Tracer
 }
 
-func newSnapshotHandler(tr Transporter, r Raft, snapshotter *snap.Snapshotter, cid types.ID) http.Handler {
+func newSnapshotHandler(tr Transporter, r Raft, snapshotter *snap.Snapshotter, cid types.ID, tracer raft.Tracer) http.Handler {
 	return &snapshotHandler{
 		tr:		tr,
 		r:		r,
 		snapshotter:	snapshotter,
 		cid:		cid,
+		tracer:		tracer,
 	}
 }
 
@@ -177,7 +203,13 @@ func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	dec := &messageDecoder{r: r.Body}
+	var dec decoder = &messageDecoder{r: r.Body}
+	if trace := hasCapabilityHeader(r.Header, traceHeader); trace || h.tracer != nil {
+		dec = &traceDecoder{r: r.Body, dec: dec, tracer: h.tracer, piggyback: trace}
+	}
+	if hasCapabilityHeader(r.Header, dinvHeader) {
+		dec = &dinvDecoder{r: r.Body, dec: dec, path: sendSnap}
+	}
 	m, err := dec.decode()
 	if err != nil {
 		msg := fmt.Sprintf("failed to decode raft message (%v)", err)
@@ -303,12 +335,24 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// piggyback vector clocks only if both sides enable them, so the
+	// dialer learns from the response whether to expect them.
+	piggyback := h.tr.Dinv && hasCapabilityHeader(r.Header, dinvHeader)
+	setCapabilityHeader(w.Header(), dinvHeader, piggyback)
+	trace := h.tr.Tracer != nil && hasCapabilityHeader(r.Header, traceHeader)
+	setCapabilityHeader(w.Header(), traceHeader, trace)
+	setCapabilityHeader(w.Header(), preVoteHeader, true)
+
 	w.WriteHeader(http.StatusOK)
 	w.(http.Flusher).Flush()
 
 	c := newCloseNotifier()
 	conn := &outgoingConn{
 		t:		t,
+		dinv:		piggyback,
+		tracer:		h.tr.Tracer,
+		trace:		trace,
+		preVote:	hasCapabilityHeader(r.Header, preVoteHeader),
 		Writer:		w,
 		Flusher:	w.(http.Flusher),
 		Closer:		c,
@@ -45,10 +45,30 @@ var (
 	},
 		[]string{"To"},
 	)
+
+	dinvSentBytes	= prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:	"etcd",
+		Subsystem:	"network",
+		Name:		"peer_dinv_sent_bytes_total",
+		Help:		"The total number of dinv vector clock bytes piggybacked on messages sent to peers.",
+	},
+		[]string{"Path"},
+	)
+
+	dinvReceivedBytes	= prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:	"etcd",
+		Subsystem:	"network",
+		Name:		"peer_dinv_received_bytes_total",
+		Help:		"The total number of dinv vector clock bytes piggybacked on messages received from peers.",
+	},
+		[]string{"Path"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(sentBytes)
 	prometheus.MustRegister(receivedBytes)
 	prometheus.MustRegister(rtts)
+	prometheus.MustRegister(dinvSentBytes)
+	prometheus.MustRegister(dinvReceivedBytes)
 }
@@ -18,7 +18,6 @@ import (
 	"encoding/binary"
 	"io"
 
-	"bitbucket.org/bestchai/dinv/dinvRT"
 	"github.com/coreos/etcd/pkg/pbutil"
 	"github.com/coreos/etcd/raft/raftpb"
 )
@@ -29,24 +28,12 @@ type messageEncoder struct {
 	w io.Writer
 }
 
-var dinv = true
-
 func (enc *messageEncoder) encode(m *raftpb.Message) error {
-	if dinv {
-		buf := pbutil.MustMarshal(m)
-		ibuf := dinvRT.Pack(buf)
-		if err := binary.Write(enc.w, binary.BigEndian, uint64(len(ibuf))); err != nil {
-			return err
-		}
-		_, err := enc.w.Write(ibuf)
-		return err
-	} else {
-		if err := binary.Write(enc.w, binary.BigEndian, uint64(m.Size())); err != nil {
-			return err
-		}
-		_, err := enc.w.Write(pbutil.MustMarshal(m))
+	if err := binary.Write(enc.w, binary.BigEndian, uint64(m.Size())); err != nil {
 		return err
 	}
+	_, err := enc.w.Write(pbutil.MustMarshal(m))
+	return err
 }
 
 // messageDecoder is a decoder that can decode all kinds of messages.
@@ -66,11 +53,5 @@ func (dec *messageDecoder) decode() (raftpb.Message, error) {
 	if _, err := io.ReadFull(dec.r, buf); err != nil {
 		return m, err
 	}
-	if dinv {
-		var ibuf []byte
-		dinvRT.Unpack(buf, &ibuf)
-		return m, m.Unmarshal(ibuf)
-	} else {
-		return m, m.Unmarshal(buf)
-	}
+	return m, m.Unmarshal(buf)
 }
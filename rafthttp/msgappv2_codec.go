@@ -90,7 +90,7 @@ func (enc *msgAppV2Encoder) encode(m *raftpb.Message) error {
 		if _, err := enc.w.Write(enc.uint8buf); err != nil {
 			return err
 		}
-	case enc.index == m.Index && enc.term == m.LogTerm && m.LogTerm == m.Term:
+	case isMsgApp(*m) && enc.index == m.Index && enc.term == m.LogTerm && m.LogTerm == m.Term:
 		enc.uint8buf[0] = byte(msgTypeAppEntries)
 		if _, err := enc.w.Write(enc.uint8buf); err != nil {
 			return err
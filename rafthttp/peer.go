@@ -106,6 +106,8 @@ type peer struct {
 	snapSender	*snapshotSender	// snapshot sender to send v3 snapshot messages
 	msgAppV2Reader	*streamReader
 	msgAppReader	*streamReader
+	// cuts gathers the copies of the cut markers received on the streams.
+	cuts	*cutBarrier
 
 	sendc	chan raftpb.Message
 	recvc	chan raftpb.Message
@@ -149,6 +151,7 @@ func startPeer(transport *Transport, urls types.URLs, peerID types.ID, fs *stats
 		sendc:		make(chan raftpb.Message),
 		recvc:		make(chan raftpb.Message, recvBufSize),
 		propc:		make(chan raftpb.Message, maxPendingProposals),
+		cuts:		newCutBarrier(),
 		stopc:		make(chan struct{}),
 	}
 
@@ -193,6 +196,7 @@ func startPeer(transport *Transport, urls types.URLs, peerID types.ID, fs *stats
 		status:	status,
 		recvc:	p.recvc,
 		propc:	p.propc,
+		cuts:	p.cuts,
 	}
 	p.msgAppReader = &streamReader{
 		peerID:	peerID,
@@ -202,6 +206,7 @@ func startPeer(transport *Transport, urls types.URLs, peerID types.ID, fs *stats
 		status:	status,
 		recvc:	p.recvc,
 		propc:	p.propc,
+		cuts:	p.cuts,
 	}
 	p.msgAppV2Reader.start()
 	p.msgAppReader.start()
@@ -219,6 +224,20 @@ func (p *peer) send(m raftpb.Message) {
 	}
 	
 
+	if isMsgCutMarker(m) {
+		p.sendCutMarker(m)
+		return
+	}
+	if m.Type == raftpb.MsgPreVote && !p.status.sendPreVote() {
+		// the peer does not understand MsgPreVote; see preVoteHeader.
+		select {
+		case p.recvc <- preVoteGrant(m):
+		default:
+			plog.Debugf("dropped %s to %s since the receiving buffer is full", m.Type, p.id)
+		}
+		return
+	}
+
 	writec, name := p.pick(m)
 	select {
 	case writec <- m:
@@ -234,6 +253,28 @@ func (p *peer) send(m raftpb.Message) {
 	}
 }
 
+// sendCutMarker sends a copy of the cut marker m over every working stream,
+// or over the pipeline if none works.
+func (p *peer) sendCutMarker(m raftpb.Message) {
+	var writecs []chan<- raftpb.Message
+	for _, w := range []*streamWriter{p.msgAppV2Writer, p.writer} {
+		if writec, ok := w.writec(); ok {
+			writecs = append(writecs, writec)
+		}
+	}
+	if len(writecs) == 0 {
+		writecs = append(writecs, p.pipeline.msgc)
+	}
+	m.RejectHint = uint64(len(writecs))
+	for _, writec := range writecs {
+		select {
+		case writec <- m:
+		default:
+			plog.Debugf("dropped %s to %s since the sending buffer is full", m.Type, p.id)
+		}
+	}
+}
+
 func (p *peer) sendSnap(m snap.Message) {
 	go p.snapSender.send(m)
 }
@@ -32,6 +32,15 @@ type peerStatus struct {
 	mu	sync.Mutex	// protect variables below
 	active	bool
 	since	time.Time
+	// piggyback is whether the peer agreed to piggyback dinv vector
+	// clocks in the last stream handshake with it.
+	piggyback	bool
+	// tracing is whether the peer agreed to piggyback trace vector
+	// clocks in the last stream handshake with it.
+	tracing	bool
+	// preVote is whether the peer understands MsgPreVote, as told in the
+	// last stream handshake with it.
+	preVote	bool
 }
 
 func newPeerStatus(id types.ID) *peerStatus {
@@ -75,3 +84,46 @@ func (s *peerStatus) activeSince() time.Time {
 	defer s.mu.Unlock()
 	return s.since
 }
+
+func (s *peerStatus) setDinv(piggyback bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.piggyback = piggyback
+}
+
+// dinv returns whether messages sent to the peer may piggyback dinv vector
+// clocks.
+func (s *peerStatus) dinv() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.piggyback
+}
+
+func (s *peerStatus) setTrace(tracing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracing = tracing
+}
+
+// trace returns whether messages sent to the peer may piggyback trace vector
+// clocks.
+func (s *peerStatus) trace() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tracing
+}
+
+func (s *peerStatus) setPreVote(preVote bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preVote = preVote
+}
+
+// sendPreVote returns whether MsgPreVote may be sent to the peer: it is
+// inactive, so whether it understands MsgPreVote is unknown, or it told it
+// does. See preVoteHeader.
+func (s *peerStatus) sendPreVote() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.active || s.preVote
+}
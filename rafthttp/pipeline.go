@@ -80,7 +80,11 @@ func (p *pipeline) handle() {
 		select {
 		case m := <-p.msgc:
 			start := time.Now()
-			err := p.post(pbutil.MustMarshal(&m))
+			var clock []byte
+			if p.tr.Tracer != nil {
+				clock = traceSend(p.tr.Tracer, &m)
+			}
+			err := p.post(pbutil.MustMarshal(&m), clock)
 			end := time.Now()
 
 			if err != nil {
@@ -112,9 +116,24 @@ func (p *pipeline) handle() {
 
 // post POSTs a data payload to a url. Returns nil if the POST succeeds,
 // error on any failure.
-func (p *pipeline) post(data []byte) (err error) {
+// post sends data to the peer, piggybacking the trace vector clock if it is
+// not nil and the peer agreed to.
+func (p *pipeline) post(data, clock []byte) (err error) {
 	u := p.picker.pick()
-	req := createPostRequest(u, RaftPrefix, bytes.NewBuffer(data), "application/protobuf", p.tr.URLs, p.tr.ID, p.tr.ClusterID)
+	body := bytes.NewBuffer(nil)
+	piggyback := p.tr.Dinv && p.status.dinv()
+	if piggyback {
+		n, _ := writeDinvClock(body, false)
+		dinvSentBytes.WithLabelValues(pipelineMsg).Add(float64(n))
+	}
+	trace := clock != nil && p.status.trace()
+	if trace {
+		writeClock(body, clock)
+	}
+	body.Write(data)
+	req := createPostRequest(u, RaftPrefix, body, "application/protobuf", p.tr.URLs, p.tr.ID, p.tr.ClusterID)
+	setCapabilityHeader(req.Header, dinvHeader, piggyback)
+	setCapabilityHeader(req.Header, traceHeader, trace)
 
 	done := make(chan struct{}, 1)
 	cancel := httputil.RequestCanceler(nil, req)
@@ -130,7 +130,7 @@ func TestPipelinePost(t *testing.T) {
 	picker := mustNewURLPicker(t, []string{"http://localhost:2380"})
 	tp := &Transport{ClusterID: types.ID(1), pipelineRt: tr}
 	p := startTestPipeline(tp, picker)
-	if err := p.post([]byte("some data")); err != nil {
+	if err := p.post([]byte("some data"), nil); err != nil {
 		t.Fatalf("unexpected post error: %v", err)
 	}
 	p.stop()
@@ -178,7 +178,7 @@ func TestPipelinePostBad(t *testing.T) {
 		picker := mustNewURLPicker(t, []string{tt.u})
 		tp := &Transport{pipelineRt: newRespRoundTripper(tt.code, tt.err)}
 		p := startTestPipeline(tp, picker)
-		err := p.post([]byte("some data"))
+		err := p.post([]byte("some data"), nil)
 		p.stop()
 
 		if err == nil {
@@ -199,7 +199,7 @@ func TestPipelinePostErrorc(t *testing.T) {
 		picker := mustNewURLPicker(t, []string{tt.u})
 		tp := &Transport{pipelineRt: newRespRoundTripper(tt.code, tt.err)}
 		p := startTestPipeline(tp, picker)
-		p.post([]byte("some data"))
+		p.post([]byte("some data"), nil)
 		p.stop()
 		select {
 		case <-p.errorc:
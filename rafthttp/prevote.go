@@ -0,0 +1,33 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import "github.com/coreos/etcd/raft/raftpb"
+
+// preVoteHeader is the header through which peers tell each other in the
+// stream handshake that they understand MsgPreVote. A member without
+// pre-vote steps a MsgPreVote like any message with a higher term: it
+// increments its term, which disrupts the cluster, and never answers. So
+// MsgPreVote is not sent to an active peer that did not set the header.
+// Instead the peer grants it right away, and votes in the election that
+// follows as it would without pre-vote.
+const preVoteHeader = "X-Raft-PreVote"
+
+// preVoteGrant returns the response of a peer granting the pre-vote m.
+// Pre-votes only prevent disruptive elections, so granting one on behalf of
+// a peer does not affect the safety of raft.
+func preVoteGrant(m raftpb.Message) raftpb.Message {
+	return raftpb.Message{Type: raftpb.MsgPreVoteResp, From: m.To, To: m.From, Term: m.Term}
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/stats"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/version"
+)
+
+// TestPeerSendPreVote tests that MsgPreVote is granted on behalf of an
+// active peer that does not understand it, and sent otherwise.
+func TestPeerSendPreVote(t *testing.T) {
+	tests := []struct {
+		active, preVote bool
+
+		wsent bool
+	}{
+		{false, false, true},
+		{true, true, true},
+		{true, false, false},
+	}
+	for i, tt := range tests {
+		status := newPeerStatus(types.ID(2))
+		status.setPreVote(tt.preVote)
+		if tt.active {
+			status.activate()
+		}
+		p := &peer{
+			id:             types.ID(2),
+			status:         status,
+			msgAppV2Writer: startStreamWriter(types.ID(2), status, &stats.FollowerStats{}, &fakeRaft{}),
+			writer:         startStreamWriter(types.ID(2), status, &stats.FollowerStats{}, &fakeRaft{}),
+			pipeline:       &pipeline{msgc: make(chan raftpb.Message, 1)},
+			recvc:          make(chan raftpb.Message, 1),
+		}
+
+		m := raftpb.Message{Type: raftpb.MsgPreVote, From: 1, To: 2, Term: 3, Index: 5, LogTerm: 2}
+		p.send(m)
+		select {
+		case g := <-p.pipeline.msgc:
+			if !tt.wsent {
+				t.Errorf("#%d: sent %s, want granted", i, g.Type)
+			}
+		case g := <-p.recvc:
+			w := raftpb.Message{Type: raftpb.MsgPreVoteResp, From: 2, To: 1, Term: 3}
+			if tt.wsent {
+				t.Errorf("#%d: granted, want sent", i)
+			} else if !reflect.DeepEqual(g, w) {
+				t.Errorf("#%d: grant = %+v, want %+v", i, g, w)
+			}
+		default:
+			t.Errorf("#%d: neither sent nor granted", i)
+		}
+		p.msgAppV2Writer.stop()
+		p.writer.stop()
+	}
+}
+
+// TestStreamPreVoteHandshake tests that both sides of a stream learn
+// whether the other understands MsgPreVote.
+func TestStreamPreVoteHandshake(t *testing.T) {
+	for i, remote := range []bool{false, true} {
+		req, err := http.NewRequest("GET", "http://localhost:2380"+RaftStreamPrefix+"/message/1", nil)
+		if err != nil {
+			t.Fatalf("#%d: could not create request: %#v", i, err)
+		}
+		req.Header.Set("X-Etcd-Cluster-ID", "1")
+		req.Header.Set("X-Server-Version", version.Version)
+		req.Header.Set("X-Raft-To", "2")
+		setCapabilityHeader(req.Header, preVoteHeader, remote)
+
+		peer := newFakePeer()
+		peerGetter := &fakePeerGetter{peers: map[types.ID]Peer{types.ID(1): peer}}
+		h := newStreamHandler(&Transport{}, peerGetter, &fakeRaft{}, types.ID(2), types.ID(1))
+
+		rw := httptest.NewRecorder()
+		go h.ServeHTTP(rw, req)
+
+		var conn *outgoingConn
+		select {
+		case conn = <-peer.connc:
+		case <-time.After(time.Second):
+			t.Fatalf("#%d: failed to attach outgoingConn", i)
+		}
+		if !hasCapabilityHeader(rw.Header(), preVoteHeader) {
+			t.Errorf("#%d: response pre-vote = false, want true", i)
+		}
+		if conn.preVote != remote {
+			t.Errorf("#%d: conn pre-vote = %v, want %v", i, conn.preVote, remote)
+		}
+		conn.Close()
+
+		rh := http.Header{}
+		rh.Add("X-Server-Version", version.Version)
+		setCapabilityHeader(rh, preVoteHeader, remote)
+		sr := &streamReader{
+			peerID: types.ID(2),
+			tr:     &Transport{streamRt: &respRoundTripper{code: http.StatusOK, header: rh}, ClusterID: types.ID(1)},
+			picker: mustNewURLPicker(t, []string{"http://localhost:2380"}),
+		}
+		if _, err := sr.dial(streamTypeMessage); err != nil {
+			t.Fatalf("#%d: unexpected dial error: %v", i, err)
+		}
+		if sr.preVote != remote {
+			t.Errorf("#%d: reader pre-vote = %v, want %v", i, sr.preVote, remote)
+		}
+	}
+}
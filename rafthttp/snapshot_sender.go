@@ -65,11 +65,15 @@ func (s *snapshotSender) stop() { close(s.stopc) }
 func (s *snapshotSender) send(merged snap.Message) {
 	m := merged.Message
 
-	body := createSnapBody(merged)
+	piggyback := s.tr.Dinv && s.status.dinv()
+	trace := s.tr.Tracer != nil && s.status.trace()
+	body := createSnapBody(merged, piggyback, s.tr.Tracer, trace)
 	defer body.Close()
 
 	u := s.picker.pick()
 	req := createPostRequest(u, RaftSnapshotPrefix, body, "application/octet-stream", s.tr.URLs, s.from, s.cid)
+	setCapabilityHeader(req.Header, dinvHeader, piggyback)
+	setCapabilityHeader(req.Header, traceHeader, trace)
 
 	plog.Infof("start to send database snapshot [index: %d, to %s]...", m.Snapshot.Metadata.Index, types.ID(m.To))
 
@@ -139,9 +143,15 @@ func (s *snapshotSender) post(req *http.Request) (err error) {
 	}
 }
 
-func createSnapBody(merged snap.Message) io.ReadCloser {
+func createSnapBody(merged snap.Message, piggyback bool, tracer raft.Tracer, trace bool) io.ReadCloser {
 	buf := new(bytes.Buffer)
-	enc := &messageEncoder{w: buf}
+	var enc encoder = &messageEncoder{w: buf}
+	if tracer != nil {
+		enc = &traceEncoder{w: buf, enc: enc, tracer: tracer, piggyback: trace}
+	}
+	if piggyback {
+		enc = &dinvEncoder{w: buf, enc: enc, path: sendSnap}
+	}
 	// encode raft message
 	if err := enc.encode(&merged.Message); err != nil {
 		plog.Panicf("encode message error (%v)", err)
@@ -28,6 +28,7 @@ import (
 	"github.com/coreos/etcd/etcdserver/stats"
 	"github.com/coreos/etcd/pkg/httputil"
 	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/version"
 	"github.com/coreos/go-semver/semver"
@@ -90,6 +91,15 @@ func isLinkHeartbeatMessage(m *raftpb.Message) bool {
 
 type outgoingConn struct {
 	t streamType
+	// dinv is whether messages written to the connection piggyback
+	// dinv vector clocks.
+	dinv bool
+	// tracer records the messages written to the connection, if not
+	// nil. trace is whether they piggyback its vector clocks.
+	tracer raft.Tracer
+	trace  bool
+	// preVote is whether the peer understands MsgPreVote.
+	preVote bool
 	io.Writer
 	http.Flusher
 	io.Closer
@@ -198,6 +208,15 @@ func (cw *streamWriter) run() {
 			default:
 				plog.Panicf("unhandled stream type %s", conn.t)
 			}
+			if conn.tracer != nil {
+				enc = &traceEncoder{w: conn.Writer, enc: enc, tracer: conn.tracer, piggyback: conn.trace}
+			}
+			if conn.dinv {
+				enc = &dinvEncoder{w: conn.Writer, enc: enc, path: t.String()}
+			}
+			cw.status.setDinv(conn.dinv)
+			cw.status.setTrace(conn.trace)
+			cw.status.setPreVote(conn.preVote)
 			flusher = conn.Flusher
 			unflushed = 0
 			cw.mu.Lock()
@@ -271,6 +290,17 @@ type streamReader struct {
 	paused bool
 	cancel func()
 	closer io.Closer
+	// dinv is whether the messages read from the current connection
+	// piggyback dinv vector clocks.
+	dinv bool
+	// trace is whether they piggyback trace vector clocks.
+	trace bool
+	// preVote is whether the peer understands MsgPreVote.
+	preVote bool
+	// cuts holds the messages read after a cut marker until its copies
+	// arrived on the other streams of the peer. If nil, markers are
+	// delivered as they are read.
+	cuts *cutBarrier
 
 	stopc chan struct{}
 	done  chan struct{}
@@ -296,6 +326,11 @@ func (cr *streamReader) run() {
 				cr.status.deactivate(failureType{source: t.String(), action: "dial"}, err.Error())
 			}
 		} else {
+			cr.mu.Lock()
+			cr.status.setDinv(cr.dinv)
+			cr.status.setTrace(cr.trace)
+			cr.status.setPreVote(cr.preVote)
+			cr.mu.Unlock()
 			cr.status.activate()
 			plog.Infof("established a TCP streaming connection with peer %s (%s reader)", cr.peerID, cr.typ)
 			err := cr.decodeLoop(rc, t)
@@ -332,6 +367,12 @@ func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 	default:
 		plog.Panicf("unhandled stream type %s", t)
 	}
+	if cr.tr.Tracer != nil {
+		dec = &traceDecoder{r: rc, dec: dec, tracer: cr.tr.Tracer, piggyback: cr.trace}
+	}
+	if cr.dinv {
+		dec = &dinvDecoder{r: rc, dec: dec, path: t.String()}
+	}
 	cr.closer = rc
 	cr.mu.Unlock()
 
@@ -361,6 +402,10 @@ func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 			continue
 		}
 
+		if isMsgCutMarker(m) && cr.cuts != nil && !cr.cuts.wait(m, cr.stopc) {
+			continue
+		}
+
 		recvc := cr.recvc
 		if m.Type == raftpb.MsgProp {
 			recvc = cr.propc
@@ -403,6 +448,9 @@ func (cr *streamReader) dial(t streamType) (io.ReadCloser, error) {
 	req.Header.Set("X-Min-Cluster-Version", version.MinClusterVersion)
 	req.Header.Set("X-Etcd-Cluster-ID", cr.tr.ClusterID.String())
 	req.Header.Set("X-Raft-To", cr.peerID.String())
+	setCapabilityHeader(req.Header, dinvHeader, cr.tr.Dinv)
+	setCapabilityHeader(req.Header, traceHeader, cr.tr.Tracer != nil)
+	setCapabilityHeader(req.Header, preVoteHeader, true)
 
 	setPeerURLsHeader(req, cr.tr.URLs)
 
@@ -441,6 +489,11 @@ func (cr *streamReader) dial(t streamType) (io.ReadCloser, error) {
 		}
 		return nil, err
 	case http.StatusOK:
+		cr.mu.Lock()
+		cr.dinv = cr.tr.Dinv && hasCapabilityHeader(resp.Header, dinvHeader)
+		cr.trace = cr.tr.Tracer != nil && hasCapabilityHeader(resp.Header, traceHeader)
+		cr.preVote = hasCapabilityHeader(resp.Header, preVoteHeader)
+		cr.mu.Unlock()
 		return resp.Body, nil
 	case http.StatusNotFound:
 		httputil.GracefulClose(resp)
@@ -0,0 +1,102 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+)
+
+// traceHeader is the header through which peers agree to piggyback the
+// vector clocks of their raft.Tracer on the messages they exchange. It is
+// negotiated the same way as dinvHeader. The trace clock follows the dinv
+// clock if both are piggybacked.
+const traceHeader = "X-Raft-Trace"
+
+func messageEvent(verb string, m *raftpb.Message) string {
+	return fmt.Sprintf("%s %s from %x to %x term=%d logterm=%d index=%d commit=%d entries=%d",
+		verb, m.Type, m.From, m.To, m.Term, m.LogTerm, m.Index, m.Commit, len(m.Entries))
+}
+
+// traceSend records the sending of m with t and returns the clock to send
+// with it.
+func traceSend(t raft.Tracer, m *raftpb.Message) []byte {
+	clock, err := t.PrepareSend(messageEvent("send", m))
+	if err != nil {
+		plog.Warningf("failed to trace sending %s to %x (%v)", m.Type, m.To, err)
+	}
+	return clock
+}
+
+// traceReceive records the receipt of m with the clock sent with it with t.
+func traceReceive(t raft.Tracer, m *raftpb.Message, clock []byte) {
+	if err := t.UnpackReceive(messageEvent("receive", m), clock); err != nil {
+		plog.Warningf("failed to trace receiving %s from %x (%v)", m.Type, m.From, err)
+	}
+}
+
+// traceEncoder records the sending of every message encoded by enc with
+// tracer, and piggybacks the vector clock on it if the peer agreed to.
+// w MUST be the writer enc writes to.
+type traceEncoder struct {
+	w         io.Writer
+	enc       encoder
+	tracer    raft.Tracer
+	piggyback bool
+}
+
+func (e *traceEncoder) encode(m *raftpb.Message) error {
+	var clock []byte
+	if !isLinkHeartbeatMessage(m) {
+		clock = traceSend(e.tracer, m)
+	}
+	if e.piggyback {
+		if _, err := writeClock(e.w, clock); err != nil {
+			return err
+		}
+	}
+	return e.enc.encode(m)
+}
+
+// traceDecoder records the receipt of every message decoded by dec with
+// tracer, if not nil, merging the vector clock piggybacked on it if the peer
+// agreed to. r MUST be the reader dec reads from.
+type traceDecoder struct {
+	r         io.Reader
+	dec       decoder
+	tracer    raft.Tracer
+	piggyback bool
+}
+
+func (d *traceDecoder) decode() (raftpb.Message, error) {
+	var clock []byte
+	if d.piggyback {
+		var err error
+		if clock, _, err = readClock(d.r); err != nil {
+			return raftpb.Message{}, err
+		}
+	}
+	m, err := d.dec.decode()
+	if err != nil {
+		return m, err
+	}
+	if d.tracer != nil && !isLinkHeartbeatMessage(&m) {
+		traceReceive(d.tracer, &m, clock)
+	}
+	return m, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/coreos/etcd/pkg/pbutil"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/coreos/etcd/version"
+)
+
+type traceEvent struct {
+	event string
+	clock string
+}
+
+// traceRecorder records traced events. It sends its name as the clock.
+type traceRecorder struct {
+	name string
+
+	mu     sync.Mutex
+	events []traceEvent
+}
+
+func (tr *traceRecorder) LogLocal(event string) error {
+	return tr.record(event, "")
+}
+
+func (tr *traceRecorder) PrepareSend(event string) ([]byte, error) {
+	return []byte(tr.name), tr.record(event, "")
+}
+
+func (tr *traceRecorder) UnpackReceive(event string, clock []byte) error {
+	return tr.record(event, string(clock))
+}
+
+func (tr *traceRecorder) record(event, clock string) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.events = append(tr.events, traceEvent{event, clock})
+	return nil
+}
+
+func (tr *traceRecorder) Events() []traceEvent {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.events
+}
+
+func TestTraceCodec(t *testing.T) {
+	m := raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 2, Term: 1, Index: 3, Entries: []raftpb.Entry{{Term: 1, Index: 4}}}
+	tests := []struct {
+		piggyback bool
+
+		wclock string
+	}{
+		{false, ""},
+		{true, "a"},
+	}
+	for i, tt := range tests {
+		a, b := &traceRecorder{name: "a"}, &traceRecorder{name: "b"}
+		buf := &bytes.Buffer{}
+		enc := &traceEncoder{w: buf, enc: &messageEncoder{w: buf}, tracer: a, piggyback: tt.piggyback}
+		dec := &traceDecoder{r: buf, dec: &messageDecoder{r: buf}, tracer: b, piggyback: tt.piggyback}
+		for _, msg := range []raftpb.Message{m, linkHeartbeatMessage} {
+			if err := enc.encode(&msg); err != nil {
+				t.Fatalf("#%d: unexpected encode message error: %v", i, err)
+			}
+			g, err := dec.decode()
+			if err != nil {
+				t.Fatalf("#%d: unexpected decode message error: %v", i, err)
+			}
+			if !reflect.DeepEqual(g, msg) {
+				t.Errorf("#%d: message = %+v, want %+v", i, g, msg)
+			}
+		}
+
+		wa := []traceEvent{{messageEvent("send", &m), ""}}
+		if g := a.Events(); !reflect.DeepEqual(g, wa) {
+			t.Errorf("#%d: sent events = %v, want %v", i, g, wa)
+		}
+		wb := []traceEvent{{messageEvent("receive", &m), tt.wclock}}
+		if g := b.Events(); !reflect.DeepEqual(g, wb) {
+			t.Errorf("#%d: received events = %v, want %v", i, g, wb)
+		}
+	}
+}
+
+// TestPipelineTrace tests that the trace vector clock sent by a pipeline
+// is received by the pipeline handler of the peer.
+func TestPipelineTrace(t *testing.T) {
+	tests := []struct {
+		negotiated bool
+
+		wclock string
+	}{
+		{false, ""},
+		{true, "a"},
+	}
+	m := raftpb.Message{Type: raftpb.MsgApp, From: 1, To: 2, Term: 1}
+	for i, tt := range tests {
+		a, b := &traceRecorder{name: "a"}, &traceRecorder{name: "b"}
+		rt := &roundTripperRecorder{}
+		tp := &Transport{ClusterID: types.ID(1), pipelineRt: rt, Tracer: a}
+		p := startTestPipeline(tp, mustNewURLPicker(t, []string{"http://localhost:2380"}))
+		p.status.setTrace(tt.negotiated)
+		if err := p.post(pbutil.MustMarshal(&m), traceSend(a, &m)); err != nil {
+			t.Fatalf("#%d: unexpected post error: %v", i, err)
+		}
+		p.stop()
+
+		req := rt.Request()
+		if g := hasCapabilityHeader(req.Header, traceHeader); g != tt.negotiated {
+			t.Errorf("#%d: piggyback = %v, want %v", i, g, tt.negotiated)
+		}
+		req.Header.Set("X-Server-Version", version.Version)
+		rw := httptest.NewRecorder()
+		h := newPipelineHandler(NewNopTransporter(), &fakeRaft{}, types.ID(1), b)
+		h.ServeHTTP(rw, req)
+		if rw.Code != http.StatusNoContent {
+			t.Fatalf("#%d: code = %d, want %d", i, rw.Code, http.StatusNoContent)
+		}
+		wb := []traceEvent{{messageEvent("receive", &m), tt.wclock}}
+		if g := b.Events(); !reflect.DeepEqual(g, wb) {
+			t.Errorf("#%d: received events = %v, want %v", i, g, wb)
+		}
+	}
+}
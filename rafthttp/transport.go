@@ -111,6 +111,15 @@ type Transport struct {
 	// When an error is received from ErrorC, user should stop raft state
 	// machine and thus stop the Transport.
 	ErrorC	chan error
+	// Dinv enables piggybacking dinv vector clocks on the messages
+	// exchanged with peers that enable it as well. Peers agree on it
+	// in the stream handshake, so members with and without it can be
+	// mixed in a cluster.
+	Dinv	bool
+	// Tracer records the messages sent to and received from peers with
+	// a vector clock, which is piggybacked on the messages exchanged
+	// with peers that trace as well. If nil, messages are not traced.
+	Tracer	raft.Tracer
 
 	streamRt	http.RoundTripper	// roundTripper used by streams
 	pipelineRt	http.RoundTripper	// roundTripper used by pipelines
@@ -139,9 +148,9 @@ func (t *Transport) Start() error {
 }
 
 func (t *Transport) Handler() http.Handler {
-	pipelineHandler := newPipelineHandler(t, t.Raft, t.ClusterID)
+	pipelineHandler := newPipelineHandler(t, t.Raft, t.ClusterID, t.Tracer)
 	streamHandler := newStreamHandler(t, t, t.Raft, t.ID, t.ClusterID)
-	snapHandler := newSnapshotHandler(t, t.Raft, t.Snapshotter, t.ClusterID)
+	snapHandler := newSnapshotHandler(t, t.Raft, t.Snapshotter, t.ClusterID, t.Tracer)
 	mux := http.NewServeMux()
 	mux.Handle(RaftPrefix, pipelineHandler)
 	mux.Handle(RaftStreamPrefix+"/", streamHandler)
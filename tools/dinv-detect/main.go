@@ -0,0 +1,163 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/coreos/etcd/raft"
+)
+
+func main() {
+	merged := flag.Bool("merged", false, "Print the merged event stream instead of the detection report.")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <bug log>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var logs [][]raft.BugEvent
+	for _, p := range flag.Args() {
+		es, err := readBugLog(p)
+		if err != nil {
+			log.Fatalf("Failed reading bug log %s: %v", p, err)
+		}
+		logs = append(logs, es)
+	}
+	events := merge(logs)
+
+	if *merged {
+		for _, e := range events {
+			fmt.Printf("%s\t%x\t%s\tterm=%d index=%d commit=%d invariant=%s fault=%s\n",
+				e.Time.Format(time.RFC3339Nano), e.Node, e.Type, e.Term, e.Index, e.Commit, e.Invariant, e.Fault)
+		}
+		return
+	}
+	report(detect(events))
+}
+
+func readBugLog(p string) ([]raft.BugEvent, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return raft.ReadBugEvents(f)
+}
+
+// merge merges the bug logs of the nodes into a single stream sorted by
+// wall clock time. Events with the same time keep the order of the logs.
+func merge(logs [][]raft.BugEvent) []raft.BugEvent {
+	var events []raft.BugEvent
+	for _, es := range logs {
+		events = append(events, es...)
+	}
+	sort.Stable(eventsByTime(events))
+	return events
+}
+
+// detection is an injected bug and the first catch of the invariant it
+// violates that followed it.
+type detection struct {
+	start raft.BugEvent
+	catch *raft.BugEvent
+	// latency is the time from start to catch.
+	latency time.Duration
+}
+
+// detect pairs each bug start of events, sorted by time, with the first
+// catch that follows it of the invariant the bug violates. Starts of faults
+// that are not paired with an invariant are paired with the first catch of
+// any invariant.
+func detect(events []raft.BugEvent) []detection {
+	var ds []detection
+	for _, s := range events {
+		if s.Type != raft.BugStart {
+			continue
+		}
+		d := detection{start: s}
+		for j := range events {
+			c := &events[j]
+			if c.Type != raft.BugCatch || (s.Invariant != "" && c.Invariant != s.Invariant) {
+				continue
+			}
+			l, ok := latency(s, *c)
+			if !ok {
+				continue
+			}
+			d.catch, d.latency = c, l
+			break
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// latency returns the time from s to c and whether c did not happen before
+// s. Events of the same node compare by their monotonic timestamps, others
+// by their wall clock time.
+func latency(s, c raft.BugEvent) (time.Duration, bool) {
+	l := c.Time.Sub(s.Time)
+	if c.Node == s.Node && c.Mono >= s.Mono {
+		l = time.Duration(c.Mono - s.Mono)
+	}
+	return l, l >= 0
+}
+
+func report(ds []detection) {
+	fmt.Printf("%-24s\t%-20s\t%8s\t%6s\t%10s\t%8s\t%s\n",
+		"fault", "invariant", "node", "term", "index", "caught by", "latency")
+	var (
+		n        int
+		total    time.Duration
+		min, max time.Duration
+	)
+	for _, d := range ds {
+		by, l := "-", "undetected"
+		if d.catch != nil {
+			by, l = fmt.Sprintf("%x", d.catch.Node), d.latency.String()
+			if n == 0 || d.latency < min {
+				min = d.latency
+			}
+			if d.latency > max {
+				max = d.latency
+			}
+			total += d.latency
+			n++
+		}
+		fmt.Printf("%-24s\t%-20s\t%8x\t%6d\t%10d\t%8s\t%s\n",
+			d.start.Fault, d.start.Invariant, d.start.Node, d.start.Term, d.start.Index, by, l)
+	}
+	fmt.Printf("\n%d bugs injected, %d detected", len(ds), n)
+	if n > 0 {
+		fmt.Printf(", latency min %v mean %v max %v", min, total/time.Duration(n), max)
+	}
+	fmt.Println()
+}
+
+type eventsByTime []raft.BugEvent
+
+func (s eventsByTime) Len() int           { return len(s) }
+func (s eventsByTime) Less(i, j int) bool { return s[i].Time.Before(s[j].Time) }
+func (s eventsByTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
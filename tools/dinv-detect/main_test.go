@@ -0,0 +1,174 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/raft"
+)
+
+var t0 = time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func event(typ string, node uint64, inv string, mono int64, wall time.Duration) raft.BugEvent {
+	return raft.BugEvent{Type: typ, Node: node, Invariant: inv, Mono: mono, Time: t0.Add(wall)}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		logs [][]raft.BugEvent
+		// wnodes are the nodes of the merged events, in order.
+		wnodes []uint64
+	}{
+		{nil, nil},
+		// a single log stays as it is.
+		{
+			[][]raft.BugEvent{{
+				event(raft.BugStart, 1, "", 1, 1),
+				event(raft.BugCatch, 1, "", 2, 2),
+			}},
+			[]uint64{1, 1},
+		},
+		// interleaved nodes are ordered by wall clock time.
+		{
+			[][]raft.BugEvent{
+				{event(raft.BugStart, 1, "", 1, 1), event(raft.BugCatch, 1, "", 3, 3)},
+				{event(raft.BugStart, 2, "", 1, 2), event(raft.BugCatch, 2, "", 3, 4)},
+				{event(raft.BugCatch, 3, "", 1, 0)},
+			},
+			[]uint64{3, 1, 2, 1, 2},
+		},
+		// events at the same time keep the order of the logs.
+		{
+			[][]raft.BugEvent{
+				{event(raft.BugCatch, 2, "", 1, 1)},
+				{event(raft.BugStart, 1, "", 1, 1)},
+			},
+			[]uint64{2, 1},
+		},
+	}
+	for i, tt := range tests {
+		var nodes []uint64
+		for _, e := range merge(tt.logs) {
+			nodes = append(nodes, e.Node)
+		}
+		if !reflect.DeepEqual(nodes, tt.wnodes) {
+			t.Errorf("#%d: nodes = %v, want %v", i, nodes, tt.wnodes)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		events []raft.BugEvent
+		// wcatches are the indexes in events of the catch of each start, or
+		// -1 if it is undetected.
+		wcatches   []int
+		wlatencies []time.Duration
+	}{
+		// a start seen only once is undetected.
+		{
+			[]raft.BugEvent{event(raft.BugStart, 1, "leader", 1, 1)},
+			[]int{-1},
+			[]time.Duration{0},
+		},
+		// a catch that happened before the start does not detect it.
+		{
+			[]raft.BugEvent{
+				event(raft.BugCatch, 1, "leader", 1, 1),
+				event(raft.BugStart, 1, "leader", 2, 2),
+			},
+			[]int{-1},
+			[]time.Duration{0},
+		},
+		// a start is caught by the first following catch of its invariant.
+		{
+			[]raft.BugEvent{
+				event(raft.BugStart, 1, "leader", 10, 10),
+				event(raft.BugCatch, 1, "log", 20, 20),
+				event(raft.BugCatch, 2, "leader", 5, 30),
+				event(raft.BugCatch, 1, "leader", 40, 40),
+			},
+			[]int{2},
+			[]time.Duration{20},
+		},
+		// a fault without an invariant is caught by any invariant.
+		{
+			[]raft.BugEvent{
+				event(raft.BugStart, 1, "", 10, 10),
+				event(raft.BugCatch, 1, "log", 25, 20),
+			},
+			[]int{1},
+			[]time.Duration{15},
+		},
+		// interleaved starts of several nodes.
+		{
+			[]raft.BugEvent{
+				event(raft.BugStart, 1, "leader", 10, 10),
+				event(raft.BugStart, 2, "log", 10, 15),
+				event(raft.BugCatch, 3, "log", 10, 20),
+				event(raft.BugCatch, 1, "leader", 40, 25),
+			},
+			[]int{3, 2},
+			[]time.Duration{30, 5},
+		},
+	}
+	for i, tt := range tests {
+		ds := detect(tt.events)
+		if len(ds) != len(tt.wcatches) {
+			t.Fatalf("#%d: len(detections) = %d, want %d", i, len(ds), len(tt.wcatches))
+		}
+		for j, d := range ds {
+			var wcatch *raft.BugEvent
+			if tt.wcatches[j] >= 0 {
+				wcatch = &tt.events[tt.wcatches[j]]
+			}
+			if d.catch != wcatch {
+				t.Errorf("#%d.%d: catch = %+v, want %+v", i, j, d.catch, wcatch)
+			}
+			if d.latency != tt.wlatencies[j] {
+				t.Errorf("#%d.%d: latency = %v, want %v", i, j, d.latency, tt.wlatencies[j])
+			}
+		}
+	}
+}
+
+func TestLatency(t *testing.T) {
+	tests := []struct {
+		s, c raft.BugEvent
+
+		wlatency time.Duration
+		wok      bool
+	}{
+		// events of the same node compare by their monotonic timestamps.
+		{event(raft.BugStart, 1, "", 10, 10), event(raft.BugCatch, 1, "", 15, 30), 5, true},
+		{event(raft.BugStart, 1, "", 10, 10), event(raft.BugCatch, 1, "", 10, 30), 0, true},
+		// events of different nodes compare by their wall clock time.
+		{event(raft.BugStart, 1, "", 10, 10), event(raft.BugCatch, 2, "", 15, 30), 20, true},
+		{event(raft.BugStart, 1, "", 10, 30), event(raft.BugCatch, 2, "", 15, 10), -20, false},
+		// a monotonic timestamp that went backwards, e.g. as the node
+		// restarted, falls back to the wall clock time.
+		{event(raft.BugStart, 1, "", 10, 10), event(raft.BugCatch, 1, "", 5, 30), 20, true},
+		{event(raft.BugStart, 1, "", 10, 30), event(raft.BugCatch, 1, "", 5, 10), -20, false},
+	}
+	for i, tt := range tests {
+		l, ok := latency(tt.s, tt.c)
+		if l != tt.wlatency || ok != tt.wok {
+			t.Errorf("#%d: latency = %v, %t, want %v, %t", i, l, ok, tt.wlatency, tt.wok)
+		}
+	}
+}
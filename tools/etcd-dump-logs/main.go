@@ -127,6 +127,22 @@ func main() {
 			} else {
 				msg = fmt.Sprintf("%s\tmethod=%s id=%s", msg, r.Type, types.ID(r.NodeID))
 			}
+		case raftpb.EntryConfChangeV2:
+			msg = fmt.Sprintf("%s\tconf", msg)
+			var r raftpb.ConfChangeV2
+			if err := r.Unmarshal(e.Data); err != nil {
+				msg = fmt.Sprintf("%s\t???", msg)
+				break
+			}
+			if r.LeaveJoint() {
+				msg = fmt.Sprintf("%s\tmethod=LeaveJoint", msg)
+			}
+			for _, c := range r.Changes {
+				msg = fmt.Sprintf("%s\tmethod=%s id=%s", msg, c.Type, types.ID(c.NodeID))
+			}
+		default:
+			// application entry types are opaque to etcd.
+			msg = fmt.Sprintf("%s\tapp\ttype=%d data=%s", msg, e.Type, excerpt(string(e.Data), 64, 64))
 		}
 		fmt.Println(msg)
 	}
@@ -39,6 +39,9 @@ type cluster struct {
 	stressQPS            int
 	stressKeySize        int
 	stressKeySuffixRange int
+	// raftFaultSeed seeds the raft fault points of the members. Negative
+	// values leave the fault points disabled.
+	raftFaultSeed int64
 
 	Size      int
 	Stressers []Stresser
@@ -51,13 +54,14 @@ type ClusterStatus struct {
 }
 
 // newCluster starts and returns a new cluster. The caller should call Terminate when finished, to shut it down.
-func newCluster(agentEndpoints []string, datadir string, stressQPS, stressKeySize, stressKeySuffixRange int, isV2Only bool) (*cluster, error) {
+func newCluster(agentEndpoints []string, datadir string, stressQPS, stressKeySize, stressKeySuffixRange int, isV2Only bool, raftFaultSeed int64) (*cluster, error) {
 	c := &cluster{
 		v2Only:               isV2Only,
 		datadir:              datadir,
 		stressQPS:            stressQPS,
 		stressKeySize:        stressKeySize,
 		stressKeySuffixRange: stressKeySuffixRange,
+		raftFaultSeed:        raftFaultSeed,
 	}
 	if err := c.bootstrap(agentEndpoints); err != nil {
 		return nil, err
@@ -86,6 +90,7 @@ func (c *cluster) bootstrap(agentEndpoints []string) error {
 			ClientURL:    fmt.Sprintf("http://%s:2379", host),
 			PeerURL:      fmt.Sprintf("http://%s:%d", host, peerURLPort),
 			FailpointURL: fmt.Sprintf("http://%s:%d", host, failpointPort),
+			RaftFaultURL: fmt.Sprintf("http://%s:2379/debug/raftfaults", host),
 		}
 		memberNameURLs[i] = members[i].ClusterEntry()
 	}
@@ -98,6 +103,12 @@ func (c *cluster) bootstrap(agentEndpoints []string) error {
 			"--data-dir", c.datadir,
 			"--initial-cluster-token", token,
 			"--initial-cluster", clusterStr)
+		if c.raftFaultSeed >= 0 {
+			// seed each member differently so they do not make the same choices
+			flags = append(flags,
+				"--dinv-enable-faults",
+				"--dinv-fault-seed", fmt.Sprint(c.raftFaultSeed+int64(i)))
+		}
 
 		if _, err := m.Agent.Start(flags...); err != nil {
 			// cleanup
@@ -36,10 +36,11 @@ func main() {
 	schedCases := flag.String("schedule-cases", "", "test case schedule")
 	consistencyCheck := flag.Bool("consistency-check", true, "true to check consistency (revision, hash)")
 	isV2Only := flag.Bool("v2-only", false, "'true' to run V2 only tester.")
+	raftFaultSeed := flag.Int64("raft-fault-seed", -1, "seed of the raft fault points of the members (-1 to not inject raft faults).")
 	flag.Parse()
 
 	endpoints := strings.Split(*endpointStr, ",")
-	c, err := newCluster(endpoints, *datadir, *stressQPS, *stressKeySize, *stressKeySuffixRange, *isV2Only)
+	c, err := newCluster(endpoints, *datadir, *stressQPS, *stressKeySize, *stressKeySuffixRange, *isV2Only, *raftFaultSeed)
 	if err != nil {
 		plog.Fatal(err)
 	}
@@ -67,6 +68,14 @@ func main() {
 	}
 	failures = append(failures, fpFailures...)
 
+	if *raftFaultSeed >= 0 {
+		rfFailures, rferr := raftFaultFailures(c)
+		if rferr != nil {
+			plog.Fatalf("failed to list raft faults (%v)", rferr)
+		}
+		failures = append(failures, rfFailures...)
+	}
+
 	schedule := failures
 	if schedCases != nil && *schedCases != "" {
 		cases := strings.Split(*schedCases, " ")
@@ -35,6 +35,7 @@ type member struct {
 	ClientURL    string
 	PeerURL      string
 	FailpointURL string
+	RaftFaultURL string
 }
 
 func (m *member) ClusterEntry() string { return m.Name + "=" + m.PeerURL }
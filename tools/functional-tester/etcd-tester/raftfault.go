@@ -0,0 +1,76 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// raftFaultFailures creates failures that enable each raft fault point
+// served by the members, aimed at the leader or at the followers. Every
+// fault fires once per member, on the first hit by a node in the aimed role.
+func raftFaultFailures(c *cluster) (ret []failure, err error) {
+	var rfs []string
+	rfs, err = raftFaultNames(c.Members[0].RaftFaultURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, rf := range rfs {
+		for _, role := range []string{"leader", "follower"} {
+			ret = append(ret, &failureDelay{
+				&failureAll{
+					description:   description("raft fault " + rf + " on " + role),
+					injectMember:  makeInjectRaftFault(rf, "role="+role+",trigger=1"),
+					recoverMember: makeRecoverRaftFault(rf),
+				},
+				3 * time.Second,
+			})
+		}
+	}
+	return ret, nil
+}
+
+func raftFaultNames(endpoint string) ([]string, error) {
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var rfs []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rfs); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rfs))
+	for i, rf := range rfs {
+		names[i] = rf.Name
+	}
+	return names, nil
+}
+
+func makeInjectRaftFault(rf, spec string) injectMemberFunc {
+	return func(m *member) error {
+		return putFailpoint(m.RaftFaultURL, rf, spec)
+	}
+}
+
+func makeRecoverRaftFault(rf string) recoverMemberFunc {
+	return func(m *member) error {
+		return delFailpoint(m.RaftFaultURL, rf)
+	}
+}
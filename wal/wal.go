@@ -509,9 +509,30 @@ func (w *WAL) Save(st raftpb.HardState, ents []raftpb.Entry) error {
 	if raft.IsEmptyHardState(st) && len(ents) == 0 {
 		return nil
 	}
+	return w.save(st, ents, mustSync(st, w.state, len(ents)))
+}
+
+// SaveNoSync writes st and ents like Save, but does not wait for them to
+// reach stable storage. They are durable once a following Sync returns.
+func (w *WAL) SaveNoSync(st raftpb.HardState, ents []raftpb.Entry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	mustSync := mustSync(st, w.state, len(ents))
+	if raft.IsEmptyHardState(st) && len(ents) == 0 {
+		return nil
+	}
+	return w.save(st, ents, false)
+}
+
+// Sync blocks until all the records written to the WAL are on stable
+// storage.
+func (w *WAL) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sync()
+}
 
+func (w *WAL) save(st raftpb.HardState, ents []raftpb.Entry, mustSync bool) error {
 	// TODO(xiangli): no more reference operator
 	for i := range ents {
 		if err := w.saveEntry(&ents[i]); err != nil {
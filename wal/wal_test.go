@@ -475,6 +475,45 @@ func TestSaveEmpty(t *testing.T) {
 	}
 }
 
+func TestSaveNoSync(t *testing.T) {
+	p, err := ioutil.TempDir(os.TempDir(), "waltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(p)
+	dir := path.Join(p, "wal")
+
+	w, err := Create(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := raftpb.HardState{Term: 1, Vote: 1, Commit: 2}
+	ents := []raftpb.Entry{{Index: 1, Term: 1, Data: []byte{1}}, {Index: 2, Term: 1, Data: []byte{2}}}
+	if err = w.SaveNoSync(st, ents); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	w, err = Open(dir, walpb.Snapshot{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	_, gst, gents, err := w.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gst, st) {
+		t.Errorf("state = %+v, want %+v", gst, st)
+	}
+	if !reflect.DeepEqual(gents, ents) {
+		t.Errorf("ents = %+v, want %+v", gents, ents)
+	}
+}
+
 func TestReleaseLockTo(t *testing.T) {
 	p, err := ioutil.TempDir(os.TempDir(), "waltest")
 	if err != nil {